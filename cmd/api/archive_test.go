@@ -0,0 +1,174 @@
+//go:build integration
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// requestArchive posts an archive request and returns the response
+func requestArchive(ta *TestApp, namespace string, body any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/ns/"+namespace+"/documents/archive",
+		bytes.NewReader(payload),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	return w
+}
+
+// readArchive unpacks a ZIP response into its manifest and file contents keyed by path
+func readArchive(
+	t *testing.T,
+	w *httptest.ResponseRecorder,
+) (services.ArchiveManifest, map[string]string) {
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+
+	var manifest services.ArchiveManifest
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		_ = rc.Close()
+
+		if f.Name == "manifest.json" {
+			require.NoError(t, json.Unmarshal(data, &manifest))
+			continue
+		}
+		files[f.Name] = string(data)
+	}
+	return manifest, files
+}
+
+func TestDownloadArchive(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	for _, ns := range []string{"archive-test", "archive-other"} {
+		_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+			Name: ns,
+		})
+		require.NoError(t, err)
+	}
+	_, err := ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "archive-test",
+		Name:      "audit",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:  "archive-test",
+		Name:       "2024",
+		ParentPath: stringPtr("/audit"),
+	})
+	require.NoError(t, err)
+
+	top := uploadTestDocument(t, ta, "archive-test", "ledger.txt", "text/plain", []byte("ledger"))
+	nested := uploadTestDocument(
+		t,
+		ta,
+		"archive-test",
+		"ledger.txt",
+		"text/plain",
+		[]byte("ledger 2024"),
+	)
+	untagged := uploadTestDocument(t, ta, "archive-test", "memo.txt", "text/plain", []byte("memo"))
+	foreign := uploadTestDocument(t, ta, "archive-other", "secret.txt", "text/plain", []byte("x"))
+
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "archive-test",
+		DocumentId: top.ID,
+		TagPath:    "/audit",
+	})
+	require.NoError(t, err)
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "archive-test",
+		DocumentId: nested.ID,
+		TagPath:    "/audit/2024",
+	})
+	require.NoError(t, err)
+
+	// === By tag path, without descendants ===
+	manifest, files := readArchive(t, requestArchive(ta, "archive-test", map[string]any{
+		"tag_path": "/audit",
+	}))
+	require.Equal(t, "archive-test", manifest.Namespace)
+	require.Equal(t, "/audit", manifest.TagPath)
+	require.Len(t, manifest.Documents, 1)
+	require.Equal(t, top.ID, manifest.Documents[0].ID)
+	require.Equal(t, "/audit", manifest.Documents[0].Tags[0].Path)
+	require.Equal(t, map[string]string{"documents/ledger.txt": "ledger"}, files)
+
+	// === By tag path, including descendants; duplicate names are disambiguated ===
+	manifest, files = readArchive(t, requestArchive(ta, "archive-test", map[string]any{
+		"tag_path":            "/audit",
+		"include_descendants": true,
+	}))
+	require.Len(t, manifest.Documents, 2)
+	require.Equal(t, map[string]string{
+		"documents/ledger.txt":     "ledger",
+		"documents/ledger (2).txt": "ledger 2024",
+	}, files)
+	for _, entry := range manifest.Documents {
+		require.Equal(t, files[entry.Path], map[string]string{
+			top.ID:    "ledger",
+			nested.ID: "ledger 2024",
+		}[entry.ID])
+	}
+
+	// === By document IDs ===
+	manifest, files = readArchive(t, requestArchive(ta, "archive-test", map[string]any{
+		"document_ids": []string{untagged.ID, top.ID},
+	}))
+	require.Len(t, manifest.Documents, 2)
+	require.Len(t, files, 2)
+	require.Contains(t, files, "documents/memo.txt")
+
+	// === Documents from another namespace are not found ===
+	w := requestArchive(ta, "archive-test", map[string]any{
+		"document_ids": []string{top.ID, foreign.ID},
+	})
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// === Invalid requests ===
+	w = requestArchive(ta, "archive-test", map[string]any{})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = requestArchive(ta, "archive-test", map[string]any{
+		"document_ids": []string{top.ID},
+		"tag_path":     "/audit",
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = requestArchive(ta, "archive-test", map[string]any{"document_ids": []string{"not-a-uuid"}})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = requestArchive(ta, "archive-test", map[string]any{"tag_path": "/missing"})
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = requestArchive(ta, "no-such-namespace", map[string]any{"tag_path": "/audit"})
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// ArchiveInput selects the documents to bundle into a ZIP archive
+type ArchiveInput struct {
+	Namespace string `path:"namespace" maxLength:"255" doc:"Namespace name"`
+	Body      struct {
+		DocumentIDs        []string `json:"document_ids,omitempty"        doc:"Documents to include"                                 maxItems:"10000"`
+		TagPath            string   `json:"tag_path,omitempty"            doc:"Include every document with this tag, e.g. /invoices"`
+		IncludeDescendants bool     `json:"include_descendants,omitempty" doc:"Also include documents tagged with descendants of tag_path"`
+	}
+}
+
+// registerArchiveRoutes registers the bulk download endpoint
+func registerArchiveRoutes(api huma.API, app *App) {
+	huma.Register(api, huma.Operation{
+		OperationID: "download-archive",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/ns/{namespace}/documents/archive",
+		Summary:     "Download documents as a ZIP archive",
+		Description: "Stream a ZIP of the selected documents, either by ID or by tag path, " +
+			"with a manifest.json describing each document's metadata, tags and attributes",
+		Tags: []string{"documents"},
+	}, func(ctx context.Context, input *ArchiveInput) (*huma.StreamResponse, error) {
+		archive, err := app.DocumentService.PrepareArchive(
+			ctx,
+			input.Namespace,
+			services.ArchiveRequest{
+				DocumentIDs:        input.Body.DocumentIDs,
+				TagPath:            input.Body.TagPath,
+				IncludeDescendants: input.Body.IncludeDescendants,
+			},
+		)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrInvalidArchiveRequest):
+				return nil, huma.Error400BadRequest(err.Error())
+			case errors.Is(err, services.ErrNamespaceNotFound):
+				return nil, huma.Error404NotFound("Namespace not found")
+			case errors.Is(err, services.ErrTagNotFound):
+				return nil, huma.Error404NotFound("Tag not found")
+			case errors.Is(err, services.ErrDocumentNotInNamespace):
+				return nil, huma.Error404NotFound("One or more documents not found")
+			}
+			app.Logger.Error(
+				"Failed to prepare archive",
+				"error", err,
+				"namespace", input.Namespace,
+			)
+			return nil, huma.Error500InternalServerError("Error preparing the archive")
+		}
+
+		return &huma.StreamResponse{
+			Body: func(ctx huma.Context) {
+				// Archives can take far longer than a single download to stream
+				_, w := humachi.Unwrap(ctx)
+				_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+				ctx.SetHeader("Content-Type", "application/zip")
+				ctx.SetHeader(
+					"Content-Disposition",
+					fmt.Sprintf("attachment; filename=%q", archive.Namespace+"-documents.zip"),
+				)
+				ctx.SetHeader("X-Content-Type-Options", "nosniff")
+				ctx.SetStatus(http.StatusOK)
+
+				if err := app.DocumentService.WriteArchive(ctx.Context(), ctx.BodyWriter(), archive); err != nil {
+					// Headers are already sent, so the client sees a truncated archive
+					app.Logger.Error(
+						"Failed to stream archive",
+						"error", err,
+						"namespace", archive.Namespace,
+					)
+				}
+			},
+		}, nil
+	})
+}
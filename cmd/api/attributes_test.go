@@ -0,0 +1,940 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// getAttributes returns a document's attributes, global if tagPath is nil, and their
+// provenance
+func getAttributes(
+	t *testing.T,
+	ta *TestApp,
+	namespace string,
+	documentID string,
+	tagPath *string,
+) (string, services.DocumentTagMetadata) {
+	resp, err := ta.ConnectClient.GetDocumentAttributes(
+		context.Background(),
+		&documentsv1.GetDocumentAttributesRequest{
+			Namespace:  namespace,
+			DocumentId: documentID,
+			TagPath:    tagPath,
+		},
+	)
+	require.NoError(t, err)
+	var metadata services.DocumentTagMetadata
+	require.NoError(t, json.Unmarshal([]byte(resp.GetMetadata()), &metadata))
+	return resp.GetAttributes(), metadata
+}
+
+func TestPatchDocumentAttributes(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "patch-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "patch-test",
+		Name:      "invoices",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"total": {"type": "number"},
+				"vendor": {"type": "string"},
+				"notes": {"type": "string"}
+			}
+		}`),
+	})
+	require.NoError(t, err)
+
+	doc := uploadTestDocument(
+		t, ta, "patch-test", "invoice.txt", "text/plain", []byte("invoice"),
+	)
+	confidence := 0.7
+	_, err = ta.App.DocumentService.AddTagToDocument(
+		ctx,
+		"patch-test",
+		doc.ID,
+		"/invoices",
+		stringPtr(`{"total": 10, "vendor": "Acme", "notes": "paid"}`),
+		services.ExtractionMethodAutomatic,
+		"invoice-reader",
+		&confidence,
+		false,
+		nil,
+	)
+	require.NoError(t, err)
+
+	update := func(
+		tagPath *string,
+		attributes string,
+		mode documentsv1.AttributeUpdateMode,
+	) error {
+		_, err := ta.ConnectClient.UpdateDocumentAttributes(
+			ctx,
+			&documentsv1.UpdateDocumentAttributesRequest{
+				Namespace:  "patch-test",
+				DocumentId: doc.ID,
+				TagPath:    tagPath,
+				Attributes: attributes,
+				Mode:       mode,
+			},
+		)
+		return err
+	}
+	tagPath := stringPtr("/invoices")
+
+	// === Merge patches change only the given fields ===
+	err = update(
+		tagPath,
+		`{"total": 12, "notes": null}`,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+	)
+	require.NoError(t, err)
+
+	attrs, metadata := getAttributes(t, ta, "patch-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"total": 12, "vendor": "Acme"}`, attrs, "merged attributes")
+	require.Equal(t, services.ExtractionMethodAutomatic, metadata.Tag.Method)
+	require.Equal(t, services.ExtractionMethodManual, metadata.Attributes["total"].Method)
+	require.Equal(t, services.ExtractionMethodAutomatic, metadata.Attributes["vendor"].Method)
+	require.Equal(t, "invoice-reader", metadata.Attributes["vendor"].ExtractedBy)
+	require.NotContains(t, metadata.Attributes, "notes")
+
+	// === JSON patches apply their operations in order ===
+	err = update(
+		tagPath,
+		`[{"op": "test", "path": "/total", "value": 12}, {"op": "add", "path": "/notes", "value": "late"}]`,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_JSON_PATCH,
+	)
+	require.NoError(t, err)
+
+	attrs, metadata = getAttributes(t, ta, "patch-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"total": 12, "vendor": "Acme", "notes": "late"}`, attrs, "patched")
+	require.Equal(t, services.ExtractionMethodManual, metadata.Attributes["notes"].Method)
+	require.Equal(t, services.ExtractionMethodAutomatic, metadata.Attributes["vendor"].Method)
+
+	// A failed test leaves the attributes as they were
+	err = update(
+		tagPath,
+		`[{"op": "test", "path": "/total", "value": 10}, {"op": "remove", "path": "/vendor"}]`,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_JSON_PATCH,
+	)
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	err = update(
+		tagPath,
+		`[{"op": "remove", "path": "/due"}]`,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_JSON_PATCH,
+	)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// The merged result is validated against the schema
+	err = update(
+		tagPath,
+		`{"total": "twelve"}`,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+	)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	attrs, _ = getAttributes(t, ta, "patch-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"total": 12, "vendor": "Acme", "notes": "late"}`, attrs, "unchanged")
+
+	// === Global attributes keep the provenance of untouched fields ===
+	err = update(
+		nil,
+		`{"project": "apollo"}`,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+	)
+	require.NoError(t, err)
+	_, metadata = getAttributes(t, ta, "patch-test", doc.ID, nil)
+	projectInfo := metadata.Attributes["project"]
+
+	err = update(
+		nil,
+		`{"owner": "kim"}`,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+	)
+	require.NoError(t, err)
+
+	attrs, metadata = getAttributes(t, ta, "patch-test", doc.ID, nil)
+	AssertJSONEqual(t, `{"project": "apollo", "owner": "kim"}`, attrs, "global attributes")
+	require.True(t, projectInfo.ExtractedAt.Equal(metadata.Attributes["project"].ExtractedAt))
+	require.Contains(t, metadata.Attributes, "owner")
+
+	// Replacing drops the provenance of fields that are gone
+	err = update(
+		nil,
+		`{"owner": "kim"}`,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_REPLACE,
+	)
+	require.NoError(t, err)
+	_, metadata = getAttributes(t, ta, "patch-test", doc.ID, nil)
+	require.NotContains(t, metadata.Attributes, "project")
+	require.Contains(t, metadata.Attributes, "owner")
+}
+
+func TestAttributeHistory(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "history-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "history-test",
+		Name:      "invoices",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"total": {"type": "number"},
+				"vendor": {"type": "string"}
+			}
+		}`),
+	})
+	require.NoError(t, err)
+
+	doc := uploadTestDocument(
+		t, ta, "history-test", "invoice.txt", "text/plain", []byte("invoice"),
+	)
+	confidence := 0.9
+	_, err = ta.App.DocumentService.AddTagToDocument(
+		events.WithActor(ctx, "extractor:invoice-reader"),
+		"history-test",
+		doc.ID,
+		"/invoices",
+		stringPtr(`{"total": 10, "vendor": "Acme"}`),
+		services.ExtractionMethodAutomatic,
+		"invoice-reader",
+		&confidence,
+		false,
+		nil,
+	)
+	require.NoError(t, err)
+
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "history-test",
+			DocumentId: doc.ID,
+			TagPath:    stringPtr("/invoices"),
+			Attributes: `{"total": 12}`,
+			Mode:       documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+		},
+	)
+	require.NoError(t, err)
+
+	history := func(tagPath *string, field *string) []*documentsv1.AttributeChange {
+		resp, err := ta.ConnectClient.GetAttributeHistory(
+			ctx,
+			&documentsv1.GetAttributeHistoryRequest{
+				Namespace:  "history-test",
+				DocumentId: doc.ID,
+				TagPath:    tagPath,
+				Field:      field,
+			},
+		)
+		require.NoError(t, err)
+		return resp.GetChanges()
+	}
+
+	// === Every change to a field is recorded, newest first ===
+	changes := history(stringPtr("/invoices"), stringPtr("total"))
+	require.Len(t, changes, 2)
+
+	require.Equal(t, "10", changes[0].GetOldValue())
+	require.Equal(t, "12", changes[0].GetNewValue())
+	require.Equal(t, "api", changes[0].GetActor())
+	require.Equal(t, "manual", changes[0].GetExtractionMethod())
+	require.Equal(t, "api-user", changes[0].GetExtractedBy())
+	require.Equal(t, int64(1), changes[0].GetSchemaVersion())
+
+	require.Nil(t, changes[1].OldValue)
+	require.Equal(t, "10", changes[1].GetNewValue())
+	require.Equal(t, "extractor:invoice-reader", changes[1].GetActor())
+	require.Equal(t, "automatic", changes[1].GetExtractionMethod())
+	require.Equal(t, "invoice-reader", changes[1].GetExtractedBy())
+
+	// Unchanged fields are only recorded when they were set
+	require.Len(t, history(stringPtr("/invoices"), nil), 3)
+
+	// === Removing the tag records the removed values ===
+	_, err = ta.ConnectClient.RemoveTagFromDocument(ctx, &documentsv1.RemoveTagFromDocumentRequest{
+		Namespace:  "history-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+	})
+	require.NoError(t, err)
+
+	changes = history(stringPtr("/invoices"), stringPtr("vendor"))
+	require.Len(t, changes, 2)
+	require.Equal(t, `"Acme"`, changes[0].GetOldValue())
+	require.Nil(t, changes[0].NewValue)
+
+	// === Global attributes have their own history ===
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "history-test",
+			DocumentId: doc.ID,
+			Attributes: `{"project": "apollo"}`,
+		},
+	)
+	require.NoError(t, err)
+
+	changes = history(nil, nil)
+	require.Len(t, changes, 1)
+	require.Equal(t, "project", changes[0].GetField())
+	require.Equal(t, `"apollo"`, changes[0].GetNewValue())
+	require.Nil(t, changes[0].SchemaVersion)
+
+	// Unknown tags are not found
+	_, err = ta.ConnectClient.GetAttributeHistory(ctx, &documentsv1.GetAttributeHistoryRequest{
+		Namespace:  "history-test",
+		DocumentId: doc.ID,
+		TagPath:    stringPtr("/receipts"),
+	})
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestExtractedAttributePrecedence(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "precedence-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "precedence-test",
+		Name:      "invoices",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"total": {"type": "number"},
+				"vendor": {"type": "string"}
+			}
+		}`),
+	})
+	require.NoError(t, err)
+
+	doc := uploadTestDocument(
+		t, ta, "precedence-test", "invoice.txt", "text/plain", []byte("invoice"),
+	)
+	extract := func(
+		attributes string,
+		confidence float64,
+		force bool,
+	) *documentsv1.AddTagToDocumentResponse {
+		resp, err := ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+			Namespace:  "precedence-test",
+			DocumentId: doc.ID,
+			TagPath:    "/invoices",
+			Attributes: &attributes,
+			Extractor:  stringPtr("invoice-reader"),
+			Confidence: &confidence,
+			Force:      force,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	tagPath := stringPtr("/invoices")
+
+	resp := extract(`{"total": 10, "vendor": "Acme"}`, 0.9, false)
+	require.Empty(t, resp.GetSkippedAttributes())
+
+	// A human corrects the total
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "precedence-test",
+			DocumentId: doc.ID,
+			TagPath:    tagPath,
+			Attributes: `{"total": 12}`,
+			Mode:       documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+		},
+	)
+	require.NoError(t, err)
+
+	// === Re-extracting keeps manual and more confident values ===
+	resp = extract(`{"total": 10, "vendor": "ACME Corp"}`, 0.5, false)
+	skipped := resp.GetSkippedAttributes()
+	require.Len(t, skipped, 2)
+	require.Equal(t, "total", skipped[0].GetField())
+	require.Equal(t, documentsv1.SkipReason_SKIP_REASON_MANUAL, skipped[0].GetReason())
+	require.Equal(t, "vendor", skipped[1].GetField())
+	require.Equal(
+		t,
+		documentsv1.SkipReason_SKIP_REASON_HIGHER_CONFIDENCE,
+		skipped[1].GetReason(),
+	)
+
+	attrs, metadata := getAttributes(t, ta, "precedence-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"total": 12, "vendor": "Acme"}`, attrs, "kept attributes")
+	require.Equal(t, services.ExtractionMethodManual, metadata.Attributes["total"].Method)
+	require.InDelta(t, 0.9, *metadata.Attributes["vendor"].Confidence, 1e-9)
+
+	// More confident extractions replace automatic values only
+	resp = extract(`{"total": 10, "vendor": "ACME Corp"}`, 0.95, false)
+	require.Len(t, resp.GetSkippedAttributes(), 1)
+	attrs, _ = getAttributes(t, ta, "precedence-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"total": 12, "vendor": "ACME Corp"}`, attrs, "more confident")
+
+	// === Forcing replaces everything ===
+	resp = extract(`{"total": 10}`, 0.5, true)
+	require.Empty(t, resp.GetSkippedAttributes())
+	attrs, metadata = getAttributes(t, ta, "precedence-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"total": 10}`, attrs, "forced")
+	require.Equal(t, services.ExtractionMethodAutomatic, metadata.Attributes["total"].Method)
+
+	// === Manual writes always replace ===
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "precedence-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: stringPtr(`{"vendor": "Acme"}`),
+	})
+	require.NoError(t, err)
+	attrs, _ = getAttributes(t, ta, "precedence-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"vendor": "Acme"}`, attrs, "manual")
+
+	// Retagging automatically without attributes, as rules and imports do, keeps them
+	skippedAttributes, err := ta.App.DocumentService.AddTagToDocument(
+		ctx,
+		"precedence-test",
+		doc.ID,
+		"/invoices",
+		nil,
+		services.ExtractionMethodAutomatic,
+		"invoice-rule",
+		nil,
+		false,
+		nil,
+	)
+	require.NoError(t, err)
+	require.Empty(t, skippedAttributes)
+	attrs, metadata = getAttributes(t, ta, "precedence-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"vendor": "Acme"}`, attrs, "retagged")
+	require.Equal(t, services.ExtractionMethodManual, metadata.Attributes["vendor"].Method)
+
+	// A confidence needs an extractor
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "precedence-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Confidence: float64Ptr(0.5),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestDerivedAttributes(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "derived-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "derived-test",
+		Name:      "invoices",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"net": {"type": "number"},
+				"tax": {"type": "number", "default": 0},
+				"total": {"type": "number", "x-computed": "net + tax"},
+				"document_date": {"type": "string", "format": "date"},
+				"year": {"type": "integer", "x-computed": "year(document_date)"}
+			},
+			"required": ["net", "tax"]
+		}`),
+	})
+	require.NoError(t, err)
+
+	// Invalid expressions are rejected with the schema
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "derived-test",
+		Name:      "broken",
+		JsonSchema: stringPtr(
+			`{"type": "object", "properties": {"a": {"type": "number", "x-computed": "b +"}}}`,
+		),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	doc := uploadTestDocument(
+		t, ta, "derived-test", "invoice.txt", "text/plain", []byte("invoice"),
+	)
+	tagPath := stringPtr("/invoices")
+
+	// === Defaults satisfy required fields and computed attributes are derived ===
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "derived-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: stringPtr(`{"net": 100.1, "total": 1, "document_date": "2024-03-15"}`),
+		Extractor:  stringPtr("invoice-reader"),
+		Confidence: float64Ptr(0.8),
+	})
+	require.NoError(t, err)
+	attrs, metadata := getAttributes(t, ta, "derived-test", doc.ID, tagPath)
+	AssertJSONEqual(
+		t,
+		`{"net": 100.1, "tax": 0, "total": 100.1, "document_date": "2024-03-15", "year": 2024}`,
+		attrs,
+		"derived attributes",
+	)
+	require.Equal(t, services.ExtractionMethodAutomatic, metadata.Attributes["net"].Method)
+	for field, source := range map[string]string{
+		"tax": "default", "total": "computed", "year": "computed",
+	} {
+		require.Equal(t, services.ExtractionMethodDerived, metadata.Attributes[field].Method, field)
+		require.Equal(t, source, metadata.Attributes[field].Source, field)
+	}
+
+	// === Computed attributes follow every write ===
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "derived-test",
+			DocumentId: doc.ID,
+			TagPath:    tagPath,
+			Attributes: `{"tax": 20.2, "document_date": null}`,
+			Mode:       documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+		},
+	)
+	require.NoError(t, err)
+	attrs, metadata = getAttributes(t, ta, "derived-test", doc.ID, tagPath)
+	AssertJSONEqual(t, `{"net": 100.1, "tax": 20.2, "total": 120.3}`, attrs, "recomputed")
+	require.Equal(t, services.ExtractionMethodManual, metadata.Attributes["tax"].Method)
+	require.NotContains(t, metadata.Attributes, "year")
+
+	// Expressions that can't be evaluated reject the write
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "derived-test",
+			DocumentId: doc.ID,
+			TagPath:    tagPath,
+			Attributes: `{"document_date": "someday"}`,
+			Mode:       documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+		},
+	)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// === Expressions can refer to the document's own fields ===
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "derived-test",
+		Name:      "filed",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"year": {"type": "integer", "x-computed": "year($document.document_date)"},
+				"source": {"type": "string", "x-computed": "$document.file_name"}
+			}
+		}`),
+	})
+	require.NoError(t, err)
+	_, err = ta.Pool.Exec(
+		ctx,
+		"UPDATE documents SET document_date = '2023-11-05' WHERE id = $1",
+		doc.ID,
+	)
+	require.NoError(t, err)
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "derived-test",
+		DocumentId: doc.ID,
+		TagPath:    "/filed",
+		Attributes: stringPtr(`{}`),
+	})
+	require.NoError(t, err)
+	attrs, _ = getAttributes(t, ta, "derived-test", doc.ID, stringPtr("/filed"))
+	AssertJSONEqual(t, `{"year": 2023, "source": "invoice.txt"}`, attrs, "document fields")
+}
+
+func TestAttributeCoercion(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "coercion-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "coercion-test",
+		Name:      "invoices",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"total": {"type": "number"},
+				"paid": {"type": "boolean"},
+				"document_date": {"type": "string", "format": "date"}
+			}
+		}`),
+	})
+	require.NoError(t, err)
+
+	doc := uploadTestDocument(
+		t, ta, "coercion-test", "invoice.txt", "text/plain", []byte("invoice"),
+	)
+	tagPath := stringPtr("/invoices")
+	attributes := `{"total": "1.234,50", "paid": "ja", "document_date": "15.03.2024"}`
+
+	// Without coercion the strings fail validation
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "coercion-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: &attributes,
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// A locale needs coercion, and must be a language tag
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "coercion-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: &attributes,
+		Locale:     stringPtr("de-DE"),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "coercion-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: &attributes,
+		Coerce:     true,
+		Locale:     stringPtr("german"),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Values that can't be converted still fail validation
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "coercion-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: &attributes,
+		Coerce:     true,
+		Locale:     stringPtr("de-DE"),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// === Values are converted to their declared types and the conversions recorded ===
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "coercion-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: stringPtr(
+			`{"total": "1.234,50", "paid": "yes", "document_date": "15.03.2024"}`,
+		),
+		Coerce: true,
+		Locale: stringPtr("de-DE"),
+	})
+	require.NoError(t, err)
+	attrs, metadata := getAttributes(t, ta, "coercion-test", doc.ID, tagPath)
+	AssertJSONEqual(
+		t,
+		`{"total": 1234.5, "paid": true, "document_date": "2024-03-15"}`,
+		attrs,
+		"coerced attributes",
+	)
+	require.Equal(t, services.ExtractionMethodManual, metadata.Attributes["total"].Method)
+	require.Equal(t, []services.AttributeCoercion{
+		{Path: "total", From: "1.234,50", To: 1234.5},
+	}, metadata.Attributes["total"].Coercions)
+	require.Equal(t, []services.AttributeCoercion{
+		{Path: "document_date", From: "15.03.2024", To: "2024-03-15"},
+	}, metadata.Attributes["document_date"].Coercions)
+
+	// === Updates coerce with the locale's date order ===
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "coercion-test",
+			DocumentId: doc.ID,
+			TagPath:    tagPath,
+			Attributes: `{"document_date": "04/03/2024"}`,
+			Mode:       documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+			Coerce:     true,
+			Locale:     stringPtr("en-US"),
+		},
+	)
+	require.NoError(t, err)
+	attrs, metadata = getAttributes(t, ta, "coercion-test", doc.ID, tagPath)
+	AssertJSONEqual(
+		t,
+		`{"total": 1234.5, "paid": true, "document_date": "2024-04-03"}`,
+		attrs,
+		"coerced update",
+	)
+	require.Equal(t, []services.AttributeCoercion{
+		{Path: "document_date", From: "04/03/2024", To: "2024-04-03"},
+	}, metadata.Attributes["document_date"].Coercions)
+	require.Len(t, metadata.Attributes["total"].Coercions, 1)
+
+	// Global attributes have no schema to coerce to
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "coercion-test",
+			DocumentId: doc.ID,
+			Attributes: `{"total": "12"}`,
+			Coerce:     true,
+		},
+	)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestValidationErrorDetails(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "details-test",
+	})
+	require.NoError(t, err)
+
+	// violations returns the validation details of an error
+	violations := func(err error) []*tagsv1.ValidationViolation {
+		t.Helper()
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), err)
+		var connectErr *connect.Error
+		require.ErrorAs(t, err, &connectErr)
+		for _, detail := range connectErr.Details() {
+			value, err := detail.Value()
+			require.NoError(t, err)
+			if details, ok := value.(*tagsv1.ValidationErrorDetails); ok {
+				return details.Violations
+			}
+		}
+		require.Fail(t, "no validation details", err.Error())
+		return nil
+	}
+
+	// === Tag schemas are rejected with the failing keyword ===
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "details-test",
+		Name:      "broken",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {"total": {"type": "number", "x-computed": "net +"}}
+		}`),
+	})
+	schemaViolations := violations(err)
+	require.Len(t, schemaViolations, 1)
+	require.Equal(t, "/properties/total/x-computed", schemaViolations[0].Pointer)
+	require.Equal(t, "x-computed", schemaViolations[0].Keyword)
+
+	// === Attributes are rejected with a violation per failing value ===
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "details-test",
+		Name:      "invoices",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"total": {"type": "number"},
+				"status": {"type": "string", "enum": ["open", "paid"]}
+			},
+			"required": ["total"]
+		}`),
+	})
+	require.NoError(t, err)
+	doc := uploadTestDocument(
+		t, ta, "details-test", "invoice.txt", "text/plain", []byte("invoice"),
+	)
+
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "details-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: stringPtr(`{"status": "void"}`),
+	})
+	attributeViolations := violations(err)
+	require.Len(t, attributeViolations, 2)
+	byPointer := make(map[string]*tagsv1.ValidationViolation)
+	for _, violation := range attributeViolations {
+		byPointer[violation.Pointer] = violation
+	}
+	require.Equal(t, "required", byPointer["/total"].GetKeyword())
+	require.Nil(t, byPointer["/total"].Actual)
+	require.Equal(t, "enum", byPointer["/status"].GetKeyword())
+	require.JSONEq(t, `["open", "paid"]`, byPointer["/status"].GetExpected())
+	require.JSONEq(t, `"void"`, byPointer["/status"].GetActual())
+
+	// Updates report their violations the same way
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "details-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoices",
+		Attributes: stringPtr(`{"total": 10}`),
+	})
+	require.NoError(t, err)
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "details-test",
+			DocumentId: doc.ID,
+			TagPath:    stringPtr("/invoices"),
+			Attributes: `{"total": "ten"}`,
+			Mode:       documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH,
+		},
+	)
+	updateViolations := violations(err)
+	require.Len(t, updateViolations, 1)
+	require.Equal(t, "/total", updateViolations[0].Pointer)
+	require.Equal(t, "type", updateViolations[0].Keyword)
+	require.JSONEq(t, `"number"`, updateViolations[0].GetExpected())
+	require.JSONEq(t, `"string"`, updateViolations[0].GetActual())
+}
+
+func TestSearchStructuredAttributes(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "search-test",
+	})
+	require.NoError(t, err)
+
+	// Arrays and nested objects need the schema to opt in
+	schema := `{
+		"type": "object",
+		%s
+		"properties": {
+			"total": {"type": "number"},
+			"parties": {"type": "array", "items": {"type": "string"}},
+			"vendor": {
+				"type": "object",
+				"properties": {"name": {"type": "string"}},
+				"required": ["name"]
+			}
+		}
+	}`
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:  "search-test",
+		Name:       "contracts",
+		JsonSchema: stringPtr(fmt.Sprintf(schema, "")),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:  "search-test",
+		Name:       "contracts",
+		JsonSchema: stringPtr(fmt.Sprintf(schema, `"x-structured-attributes": true,`)),
+	})
+	require.NoError(t, err)
+
+	tag := func(filename string, attributes string) string {
+		doc := uploadTestDocument(
+			t, ta, "search-test", filename, "text/plain", []byte(filename),
+		)
+		_, err := ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+			Namespace:  "search-test",
+			DocumentId: doc.ID,
+			TagPath:    "/contracts",
+			Attributes: &attributes,
+		})
+		require.NoError(t, err)
+		return doc.ID
+	}
+	acme := tag(
+		"acme.txt",
+		`{"total": 100, "parties": ["Acme", "Globex"], "vendor": {"name": "Acme"}}`,
+	)
+	initech := tag(
+		"initech.txt",
+		`{"total": 250, "parties": ["Initech", "Globex"], "vendor": {"name": "Initech"}}`,
+	)
+
+	// Nested attributes are validated against their own constraints
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "search-test",
+		DocumentId: acme,
+		TagPath:    "/contracts",
+		Attributes: stringPtr(`{"vendor": {}}`),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	search := func(filters ...*documentsv1.AttributeFilter) []string {
+		resp, err := ta.ConnectClient.SearchDocuments(ctx, &documentsv1.SearchDocumentsRequest{
+			Namespace: "search-test",
+			TagPath:   stringPtr("/contracts"),
+			Filters:   filters,
+		})
+		require.NoError(t, err)
+		var ids []string
+		for _, document := range resp.GetDocuments() {
+			ids = append(ids, document.GetDocumentId())
+		}
+		return ids
+	}
+	contains := documentsv1.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_CONTAINS
+	equals := documentsv1.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_EQUALS
+
+	// === Without filters every document with the tag matches ===
+	require.Equal(t, []string{acme, initech}, search())
+
+	// === Array membership ===
+	require.Equal(t, []string{acme, initech}, search(&documentsv1.AttributeFilter{
+		Field: "parties", Operator: contains, Value: `"Globex"`,
+	}))
+	require.Equal(t, []string{initech}, search(&documentsv1.AttributeFilter{
+		Field: "parties", Operator: contains, Value: `"Initech"`,
+	}))
+
+	// === Nested fields ===
+	require.Equal(t, []string{acme}, search(&documentsv1.AttributeFilter{
+		Field: "vendor.name", Operator: equals, Value: `"Acme"`,
+	}))
+
+	// === Filters combine ===
+	require.Equal(t, []string{initech}, search(
+		&documentsv1.AttributeFilter{Field: "parties", Operator: contains, Value: `"Globex"`},
+		&documentsv1.AttributeFilter{Field: "total", Value: `250`},
+	))
+	require.Empty(t, search(
+		&documentsv1.AttributeFilter{Field: "vendor.name", Operator: equals, Value: `"Acme"`},
+		&documentsv1.AttributeFilter{Field: "total", Value: `250`},
+	))
+	require.Equal(t, []string{initech}, search(
+		&documentsv1.AttributeFilter{Field: "parties", Operator: contains, Value: `"Globex"`},
+		&documentsv1.AttributeFilter{Field: "parties", Operator: contains, Value: `"Initech"`},
+	))
+	require.Empty(t, search(
+		&documentsv1.AttributeFilter{Field: "total", Value: `250`},
+		&documentsv1.AttributeFilter{Field: "total", Value: `100`},
+	))
+
+	// Filters compare to primitives only
+	_, err = ta.ConnectClient.SearchDocuments(ctx, &documentsv1.SearchDocumentsRequest{
+		Namespace: "search-test",
+		TagPath:   stringPtr("/contracts"),
+		Filters: []*documentsv1.AttributeFilter{
+			{Field: "vendor", Operator: equals, Value: `{"name": "Acme"}`},
+		},
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
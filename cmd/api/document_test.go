@@ -1093,6 +1093,89 @@ func TestUploadDocumentWithTags(t *testing.T) {
 	require.Equal(t, "2026-02-07", attrs["date"])
 }
 
+func TestUploadDocumentWithInvalidTagAttributes(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "upload-invalid-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "upload-invalid-test",
+		Name:      "invoice",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {"amount": {"type": "number", "minimum": 0}},
+			"required": ["amount"]
+		}`),
+	})
+	require.NoError(t, err)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "invoice.txt")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("invalid invoice"))
+	require.NoError(t, err)
+	err = writer.WriteField(
+		"tags",
+		`[{"tag_path": "/invoice", "attributes": {"amount": "ten"}},
+		  {"tag_path": "/invoice", "attributes": {"amount": -5}}]`,
+	)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/ns/upload-invalid-test/documents",
+		body,
+	)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+
+	// The document is stored, and the response is a problem detailing each violation
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	require.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	var problem ValidationProblem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	require.NotEmpty(t, problem.DocumentID)
+	AssertJSONEqual(t, `{
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "Tag attributes failed validation; the document was stored without those tags",
+		"document_id": "`+problem.DocumentID+`",
+		"errors": [
+			{
+				"location": "body.tags[0].attributes",
+				"pointer": "/amount",
+				"keyword": "type",
+				"expected": "number",
+				"actual": "string",
+				"message": "expected number, but got string"
+			},
+			{
+				"location": "body.tags[1].attributes",
+				"pointer": "/amount",
+				"keyword": "minimum",
+				"expected": 0,
+				"actual": -5,
+				"message": "must be >= 0 but found -5"
+			}
+		]
+	}`, w.Body.String(), "validation problem")
+
+	// Neither tag was added
+	tagsResp, err := ta.ConnectClient.ListDocumentTags(ctx, &documentsv1.ListDocumentTagsRequest{
+		Namespace:  "upload-invalid-test",
+		DocumentId: problem.DocumentID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, tagsResp.Tags)
+}
+
 func TestCrossNamespaceTagProtection(t *testing.T) {
 	ta := SetupTestApp(t)
 	defer ta.Cleanup(t)
@@ -0,0 +1,252 @@
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+)
+
+// postDocument uploads content through the multipart endpoint and returns the response
+func postDocument(
+	t *testing.T,
+	ta *TestApp,
+	namespace string,
+	filename string,
+	mimeType string,
+	content []byte,
+) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	h := make(map[string][]string)
+	h["Content-Disposition"] = []string{`form-data; name="file"; filename="` + filename + `"`}
+	h["Content-Type"] = []string{mimeType}
+	part, err := writer.CreatePart(h)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/ns/"+namespace+"/documents", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	return w
+}
+
+// uploadTestDocument uploads content through the multipart endpoint and returns the
+// created document
+func uploadTestDocument(
+	t *testing.T,
+	ta *TestApp,
+	namespace string,
+	filename string,
+	mimeType string,
+	content []byte,
+) DocumentResponse {
+	w := postDocument(t, ta, namespace, filename, mimeType, content)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp DocumentResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	return resp
+}
+
+func TestDownloadRangesAndConditionalGet(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "range-test",
+	})
+	require.NoError(t, err)
+
+	content := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	doc := uploadTestDocument(t, ta, "range-test", "alphabet.txt", "text/plain", content)
+	path := "/api/v1/ns/range-test/documents/" + doc.ID
+
+	get := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		ta.Router.ServeHTTP(w, req)
+		return w
+	}
+
+	// === Full download advertises validators ===
+	w := get(nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, content, w.Body.Bytes())
+	require.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+	require.Equal(t, strconv.Itoa(len(content)), w.Header().Get("Content-Length"))
+	etag := w.Header().Get("ETag")
+	lastModified := w.Header().Get("Last-Modified")
+	require.Equal(t, `"`+doc.ChecksumSHA+`"`, etag)
+	require.NotEmpty(t, lastModified)
+
+	// === Single range ===
+	w = get(map[string]string{"Range": "bytes=10-15"})
+	require.Equal(t, http.StatusPartialContent, w.Code)
+	require.Equal(t, "abcdef", w.Body.String())
+	require.Equal(t, "bytes 10-15/36", w.Header().Get("Content-Range"))
+	require.Equal(t, "6", w.Header().Get("Content-Length"))
+
+	// Suffix and open-ended ranges
+	w = get(map[string]string{"Range": "bytes=-4"})
+	require.Equal(t, http.StatusPartialContent, w.Code)
+	require.Equal(t, "wxyz", w.Body.String())
+
+	w = get(map[string]string{"Range": "bytes=30-"})
+	require.Equal(t, http.StatusPartialContent, w.Code)
+	require.Equal(t, "uvwxyz", w.Body.String())
+
+	// === Multiple ranges ===
+	w = get(map[string]string{"Range": "bytes=0-2,33-35"})
+	require.Equal(t, http.StatusPartialContent, w.Code)
+	mediaType, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/byteranges", mediaType)
+
+	reader := multipart.NewReader(w.Body, params["boundary"])
+	expected := []struct{ contentRange, data string }{
+		{"bytes 0-2/36", "012"},
+		{"bytes 33-35/36", "xyz"},
+	}
+	for _, exp := range expected {
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		require.Equal(t, exp.contentRange, part.Header.Get("Content-Range"))
+		data, err := io.ReadAll(part)
+		require.NoError(t, err)
+		require.Equal(t, exp.data, string(data))
+	}
+	_, err = reader.NextPart()
+	require.ErrorIs(t, err, io.EOF)
+
+	// === Unsatisfiable and malformed ranges ===
+	w = get(map[string]string{"Range": "bytes=100-200"})
+	require.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+	require.Equal(t, "bytes */36", w.Header().Get("Content-Range"))
+
+	w = get(map[string]string{"Range": "pages=1-2"})
+	require.Equal(t, http.StatusOK, w.Code, "malformed ranges should be ignored")
+	require.Equal(t, content, w.Body.Bytes())
+
+	// === Conditional GET ===
+	w = get(map[string]string{"If-None-Match": etag})
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Empty(t, w.Body.Bytes())
+	require.Equal(t, etag, w.Header().Get("ETag"))
+
+	w = get(map[string]string{"If-None-Match": `W/` + etag})
+	require.Equal(t, http.StatusNotModified, w.Code, "If-None-Match uses weak comparison")
+
+	w = get(map[string]string{"If-None-Match": `"something-else"`})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = get(map[string]string{"If-Modified-Since": lastModified})
+	require.Equal(t, http.StatusNotModified, w.Code)
+
+	earlier := time.Now().Add(-24 * time.Hour).UTC().Format(http.TimeFormat)
+	w = get(map[string]string{"If-Modified-Since": earlier})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// === If-Range ===
+	w = get(map[string]string{"Range": "bytes=0-3", "If-Range": etag})
+	require.Equal(t, http.StatusPartialContent, w.Code)
+	require.Equal(t, "0123", w.Body.String())
+
+	w = get(map[string]string{"Range": "bytes=0-3", "If-Range": lastModified})
+	require.Equal(t, http.StatusPartialContent, w.Code)
+
+	w = get(map[string]string{"Range": "bytes=0-3", "If-Range": `"stale"`})
+	require.Equal(t, http.StatusOK, w.Code, "a stale If-Range should return the full file")
+	require.Equal(t, content, w.Body.Bytes())
+}
+
+func TestDownloadDisposition(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "preview-test",
+	})
+	require.NoError(t, err)
+
+	// The declared type is recorded alongside the sniffed one
+	text := uploadTestDocument(
+		t,
+		ta,
+		"preview-test",
+		"notes.md",
+		"text/markdown",
+		[]byte("# Notes"),
+	)
+	require.Equal(t, "text/markdown", text.MimeType)
+	require.Equal(t, "text/plain; charset=utf-8", text.DetectedMimeType)
+
+	// HTML disguised as an image
+	html := []byte("<!DOCTYPE html><html><body><script>alert(1)</script></body></html>")
+	disguised := uploadTestDocument(t, ta, "preview-test", "cat.png", "image/png", html)
+	require.Equal(t, "text/html; charset=utf-8", disguised.DetectedMimeType)
+
+	download := func(documentID, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(
+			http.MethodGet,
+			"/api/v1/ns/preview-test/documents/"+documentID+query,
+			nil,
+		)
+		w := httptest.NewRecorder()
+		ta.Router.ServeHTTP(w, req)
+		return w
+	}
+
+	// === Attachment is the default and serves the sniffed type ===
+	w := download(text.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `attachment; filename="notes.md"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	require.Empty(t, w.Header().Get("Content-Security-Policy"))
+
+	// === Inline serves the sniffed type with a locked-down policy ===
+	w = download(text.ID, "?disposition=inline")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, `inline; filename="notes.md"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	require.Contains(t, w.Header().Get("Content-Security-Policy"), "default-src 'none'")
+	require.Contains(t, w.Header().Get("Content-Security-Policy"), "sandbox")
+	require.Equal(t, "# Notes", w.Body.String())
+
+	// === Active content can't be rendered inline, whatever its declared type ===
+	w = download(disguised.ID, "?disposition=inline")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = download(disguised.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `attachment; filename="cat.png"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"),
+		"a mislabelled upload isn't served with the type the client claimed")
+
+	// === Unknown dispositions are rejected ===
+	w = download(text.ID, "?disposition=render")
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/storage"
+)
+
+// inlineMimeTypes lists the sniffed content types that may be rendered inline by a browser.
+// Anything that can carry active content (HTML, SVG, XML, scripts) is deliberately absent.
+var inlineMimeTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"image/bmp":       true,
+	"text/plain":      true,
+	"audio/mpeg":      true,
+	"audio/wave":      true,
+	"video/mp4":       true,
+	"video/webm":      true,
+}
+
+// inlineContentSecurityPolicy locks down inline responses so that content rendered by the
+// browser can't run scripts, load remote resources or act with the API's origin
+const inlineContentSecurityPolicy = "default-src 'none'; img-src 'self' data:; media-src 'self'; " +
+	"style-src 'unsafe-inline'; sandbox"
+
+// inlineContentType returns the content type to serve for an inline response. Only the type
+// sniffed at upload is trusted; documents without one or outside the allowlist can't be inlined.
+func inlineContentType(doc *sqlc.Document) (string, bool) {
+	if doc.DetectedMimeType == nil {
+		return "", false
+	}
+	mediaType, _, err := mime.ParseMediaType(*doc.DetectedMimeType)
+	if err != nil || !inlineMimeTypes[mediaType] {
+		return "", false
+	}
+	return *doc.DetectedMimeType, true
+}
+
+// attachmentContentType returns the content type to serve for a download. The type sniffed
+// at upload is preferred over the client-supplied one, which can't be trusted.
+func attachmentContentType(doc *sqlc.Document) string {
+	if doc.DetectedMimeType != nil {
+		return *doc.DetectedMimeType
+	}
+	return doc.MimeType
+}
+
+// documentETag returns the strong ETag of a document's content, derived from its checksum
+func documentETag(doc *sqlc.Document) string {
+	return `"` + doc.ChecksumSha256 + `"`
+}
+
+// documentLastModified returns the Last-Modified time of a document's content.
+// Content never changes after upload, so this is the creation time at HTTP date precision.
+func documentLastModified(doc *sqlc.Document) time.Time {
+	return doc.CreatedAt.Time.UTC().Truncate(time.Second)
+}
+
+// opaqueTag strips the weakness indicator from an entity tag for weak comparison
+func opaqueTag(tag string) string {
+	return strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+}
+
+// notModified evaluates If-None-Match and If-Modified-Since for a GET request.
+// If-Modified-Since is only considered when If-None-Match is absent (RFC 9110 section 13.2.2).
+func notModified(ifNoneMatch, ifModifiedSince, etag string, lastModified time.Time) bool {
+	if ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || opaqueTag(candidate) == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			// Invalid dates are ignored
+			return false
+		}
+		return !lastModified.After(since)
+	}
+
+	return false
+}
+
+// rangeApplies evaluates If-Range: the Range header is honored only if the
+// representation still matches the supplied strong ETag or exact date
+func rangeApplies(ifRange, etag string, lastModified time.Time) bool {
+	ifRange = strings.TrimSpace(ifRange)
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) {
+		return ifRange == etag
+	}
+	if strings.HasPrefix(ifRange, "W/") {
+		// Weak tags never match for If-Range
+		return false
+	}
+
+	date, err := http.ParseTime(ifRange)
+	if err != nil {
+		return false
+	}
+	return date.Equal(lastModified)
+}
+
+// rangeNotSatisfiable builds the 416 response for content of the given size
+func rangeNotSatisfiable(size int64) error {
+	return huma.ErrorWithHeaders(
+		huma.Error416RequestedRangeNotSatisfiable("Requested range not satisfiable"),
+		http.Header{"Content-Range": {fmt.Sprintf("bytes */%d", size)}},
+	)
+}
+
+// streamByteRanges writes a multipart/byteranges body, opening each part only when it is reached
+func streamByteRanges(
+	ctx huma.Context,
+	app *App,
+	doc *sqlc.Document,
+	contentType string,
+	ranges []storage.ByteRange,
+) {
+	writer := multipart.NewWriter(ctx.BodyWriter())
+	ctx.SetHeader("Content-Type", "multipart/byteranges; boundary="+writer.Boundary())
+	ctx.SetStatus(http.StatusPartialContent)
+
+	for _, r := range ranges {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":  {contentType},
+			"Content-Range": {r.ContentRange(doc.FileSize)},
+		})
+		if err != nil {
+			app.Logger.Error("Failed to write range part header", "error", err)
+			return
+		}
+
+		reader, err := app.DocumentService.DownloadDocumentRange(ctx.Context(), doc, r)
+		if err != nil {
+			app.Logger.Error("Failed to open document range", "error", err)
+			return
+		}
+		_, err = io.Copy(part, reader)
+		_ = reader.Close()
+		if err != nil {
+			app.Logger.Error("Failed to stream document range", "error", err)
+			return
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		app.Logger.Error("Failed to finish multipart response", "error", err)
+	}
+}
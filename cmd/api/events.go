@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+// eventStreamKeepAlive is how often an idle event stream sends a comment so proxies
+// don't close the connection
+const eventStreamKeepAlive = 15 * time.Second
+
+// WatchEventsInput selects the namespace to stream events from
+type WatchEventsInput struct {
+	Namespace   string `path:"namespace" maxLength:"255" doc:"Namespace name"`
+	LastEventID string `                                 doc:"Resume after this event ID" header:"Last-Event-ID"`
+}
+
+// withoutWriteDeadline lifts the server write timeout for long-lived streaming responses
+func withoutWriteDeadline(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		next.ServeHTTP(w, r)
+	})
+}
+
+// registerEventRoutes registers the server-sent events stream of namespace activity
+func registerEventRoutes(api huma.API, app *App) {
+	huma.Register(api, huma.Operation{
+		OperationID: "watch-events",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/ns/{namespace}/events",
+		Summary:     "Stream namespace events",
+		Description: "Stream the namespace's events as server-sent events. Each event's `event` " +
+			"field is its type, e.g. documents.uploaded, and its `id` is its position in the " +
+			"event stream, so reconnecting with Last-Event-ID resumes without missing events.",
+		Tags: []string{"events"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Server-sent events",
+				Content: map[string]*huma.MediaType{
+					"text/event-stream": {Schema: &huma.Schema{Type: huma.TypeString}},
+				},
+			},
+		},
+	}, func(ctx context.Context, input *WatchEventsInput) (*huma.StreamResponse, error) {
+		var afterSeq uint64
+		if input.LastEventID != "" {
+			seq, err := strconv.ParseUint(input.LastEventID, 10, 64)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid Last-Event-ID")
+			}
+			afterSeq = seq
+		}
+
+		if _, err := app.NamespaceService.GetNamespace(ctx, input.Namespace); err != nil {
+			return nil, huma.Error404NotFound("Namespace not found")
+		}
+
+		return &huma.StreamResponse{
+			Body: func(ctx huma.Context) {
+				_, w := humachi.Unwrap(ctx)
+				rc := http.NewResponseController(w)
+				_ = rc.SetWriteDeadline(time.Time{})
+
+				watched, err := app.EventWatcher.Watch(ctx.Context(), input.Namespace, afterSeq)
+				if err != nil {
+					app.Logger.Error(
+						"Failed to watch events",
+						"error", err,
+						"namespace", input.Namespace,
+					)
+					ctx.SetStatus(http.StatusServiceUnavailable)
+					return
+				}
+
+				ctx.SetHeader("Content-Type", "text/event-stream")
+				ctx.SetHeader("Cache-Control", "no-cache")
+				ctx.SetStatus(http.StatusOK)
+				if err := writeEventStream(ctx, rc, watched); err != nil {
+					app.Logger.Debug(
+						"Event stream closed",
+						"error", err,
+						"namespace", input.Namespace,
+					)
+				}
+			},
+		}, nil
+	})
+}
+
+// writeEventStream writes events as server-sent events until the stream ends or the
+// client goes away
+func writeEventStream(
+	ctx huma.Context,
+	rc *http.ResponseController,
+	watched <-chan events.StreamedEvent,
+) error {
+	w := ctx.BodyWriter()
+
+	// Send the headers right away so clients know the stream is open
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, ok := <-watched:
+			if !ok {
+				return nil
+			}
+			data, err := event.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(
+				w,
+				"id: %d\nevent: %s\ndata: %s\n\n",
+				event.Sequence,
+				event.Type,
+				data,
+			); err != nil {
+				return err
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+		case <-ctx.Context().Done():
+			return ctx.Context().Err()
+		}
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,151 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+// takeOutboxEvents returns the subjects and payloads of unsent outbox events in order and
+// marks them sent, so each step of a test only sees its own events
+func takeOutboxEvents(t *testing.T, ta *TestApp) ([]string, [][]byte) {
+	subjects, payloads := unsentOutboxMessages(t, ta)
+	_, err := ta.Pool.Exec(
+		context.Background(),
+		"UPDATE event_outbox SET sent_at = NOW() WHERE sent_at IS NULL",
+	)
+	require.NoError(t, err)
+	return subjects, payloads
+}
+
+func TestLifecycleEvents(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+
+	// === Namespace created ===
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "events-test",
+	})
+	require.NoError(t, err)
+	subjects, payloads := takeOutboxEvents(t, ta)
+	require.Equal(t, []string{"wayfile.events-test.namespace.created"}, subjects)
+
+	var nsCreated eventsv1.NamespaceCreatedEvent
+	require.NoError(t, proto.Unmarshal(payloads[0], &nsCreated))
+	require.Equal(t, "events-test", nsCreated.Namespace)
+	require.NotEmpty(t, nsCreated.NamespaceId)
+	require.NotEmpty(t, nsCreated.Context.GetEventId())
+	require.NotNil(t, nsCreated.Context.GetOccurredAt())
+	require.Equal(t, events.DefaultActor, nsCreated.Context.GetActor())
+
+	// === Tag created, tagged, attributes updated, untagged ===
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "events-test",
+		Name:      "invoice",
+	})
+	require.NoError(t, err)
+	doc := uploadTestDocument(t, ta, "events-test", "a.txt", "text/plain", []byte("events"))
+	_, err = ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+		Namespace:  "events-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoice",
+	})
+	require.NoError(t, err)
+	_, err = ta.ConnectClient.UpdateDocumentAttributes(
+		ctx,
+		&documentsv1.UpdateDocumentAttributesRequest{
+			Namespace:  "events-test",
+			DocumentId: doc.ID,
+			TagPath:    stringPtr("/invoice"),
+			Attributes: `{"total": 10}`,
+		},
+	)
+	require.NoError(t, err)
+	_, err = ta.ConnectClient.RemoveTagFromDocument(ctx, &documentsv1.RemoveTagFromDocumentRequest{
+		Namespace:  "events-test",
+		DocumentId: doc.ID,
+		TagPath:    "/invoice",
+	})
+	require.NoError(t, err)
+
+	subjects, payloads = takeOutboxEvents(t, ta)
+	require.Equal(t, []string{
+		"wayfile.events-test.tags.invoice.created",
+		"wayfile.events-test.documents.uploaded",
+		"wayfile.events-test.tags.invoice.extracted",
+		"wayfile.events-test.tags.invoice.attributes_updated",
+		"wayfile.events-test.tags.invoice.removed",
+	}, subjects)
+
+	var attrs eventsv1.AttributesUpdatedEvent
+	require.NoError(t, proto.Unmarshal(payloads[3], &attrs))
+	require.Equal(t, doc.ID, attrs.DocumentId)
+	require.Equal(t, "/invoice", attrs.TagPath)
+	require.JSONEq(t, `{"total": 10}`, attrs.Attributes)
+
+	// === Tag renamed and deleted ===
+	_, err = ta.TagClient.UpdateTag(ctx, &tagsv1.UpdateTagRequest{
+		Namespace: "events-test",
+		Path:      "/invoice",
+		NewName:   stringPtr("bill"),
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.DeleteTag(ctx, &tagsv1.DeleteTagRequest{
+		Namespace: "events-test",
+		Path:      "/bill",
+	})
+	require.NoError(t, err)
+
+	subjects, payloads = takeOutboxEvents(t, ta)
+	require.Equal(t, []string{
+		"wayfile.events-test.tags.invoice.updated",
+		"wayfile.events-test.tags.bill.deleted",
+	}, subjects)
+
+	var renamed eventsv1.TagUpdatedEvent
+	require.NoError(t, proto.Unmarshal(payloads[0], &renamed))
+	require.Equal(t, "/invoice", renamed.OldTagPath)
+	require.Equal(t, "/bill", renamed.TagPath)
+
+	// === Document and namespace deleted ===
+	_, err = ta.ConnectClient.DeleteDocument(ctx, &documentsv1.DeleteDocumentRequest{
+		Namespace:  "events-test",
+		DocumentId: doc.ID,
+	})
+	require.NoError(t, err)
+	_, err = ta.NamespaceClient.DeleteNamespace(ctx, &namespacesv1.DeleteNamespaceRequest{
+		Name: "events-test",
+	})
+	require.NoError(t, err)
+
+	subjects, payloads = takeOutboxEvents(t, ta)
+	require.Equal(t, []string{
+		"wayfile.events-test.documents.deleted",
+		"wayfile.events-test.namespace.deleted",
+	}, subjects)
+
+	var deleted eventsv1.DocumentDeletedEvent
+	require.NoError(t, proto.Unmarshal(payloads[0], &deleted))
+	require.Equal(t, doc.ID, deleted.DocumentId)
+	require.Equal(t, "a.txt", deleted.Filename)
+
+	// Deleting a namespace that no longer exists emits nothing
+	_, err = ta.NamespaceClient.DeleteNamespace(ctx, &namespacesv1.DeleteNamespaceRequest{
+		Name: "events-test",
+	})
+	require.NoError(t, err)
+	subjects, _ = takeOutboxEvents(t, ta)
+	require.Empty(t, subjects)
+}
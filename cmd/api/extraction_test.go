@@ -0,0 +1,156 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// testExtractor tags text documents with what a function finds in their content
+type testExtractor struct {
+	name    string
+	extract func(content string) ([]services.ExtractedTag, error)
+}
+
+func (e *testExtractor) Name() string { return e.name }
+
+func (e *testExtractor) Accepts(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "text/")
+}
+
+func (e *testExtractor) Extract(
+	_ context.Context,
+	input *services.ExtractionInput,
+) ([]services.ExtractedTag, error) {
+	content, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, err
+	}
+	return e.extract(string(content))
+}
+
+// uploadedEvent returns the documents.uploaded event queued in the outbox by an upload
+func uploadedEvent(t *testing.T, ta *TestApp) (string, []byte) {
+	subjects, payloads := takeOutboxEvents(t, ta)
+	for i, subject := range subjects {
+		if strings.HasSuffix(subject, ".documents.uploaded") {
+			return subject, payloads[i]
+		}
+	}
+	t.Fatalf("no documents.uploaded event in %v", subjects)
+	return "", nil
+}
+
+func TestExtraction(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "extraction-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:  "extraction-test",
+		Name:       "invoices",
+		JsonSchema: stringPtr(`{"type": "object", "properties": {"total": {"type": "number"}}}`),
+	})
+	require.NoError(t, err)
+
+	ta.Extraction.Register(&testExtractor{
+		name: "invoice-reader",
+		extract: func(content string) ([]services.ExtractedTag, error) {
+			if !strings.Contains(content, "INVOICE") {
+				return nil, nil
+			}
+			return []services.ExtractedTag{
+				{TagPath: "/invoices", Attributes: map[string]any{"total": 42.5}, Confidence: 0.8},
+				// Unknown tags and attributes the schema rejects are skipped
+				{TagPath: "/receipts", Confidence: 0.9},
+				{TagPath: "/invoices", Attributes: map[string]any{"total": "n/a"}, Confidence: 1},
+			}, nil
+		},
+	})
+
+	// === Extracted tags are applied with automatic provenance ===
+	doc := uploadTestDocument(
+		t, ta, "extraction-test", "invoice.txt", "text/plain", []byte("INVOICE total 42.50"),
+	)
+	subject, payload := uploadedEvent(t, ta)
+	require.NoError(t, ta.Extraction.HandleEvent(ctx, subject, payload))
+
+	attrs, err := ta.ConnectClient.GetDocumentAttributes(
+		ctx,
+		&documentsv1.GetDocumentAttributesRequest{
+			Namespace:  "extraction-test",
+			DocumentId: doc.ID,
+			TagPath:    stringPtr("/invoices"),
+		},
+	)
+	require.NoError(t, err)
+	AssertJSONEqual(t, `{"total": 42.5}`, attrs.GetAttributes(), "extracted attributes")
+
+	var metadata services.DocumentTagMetadata
+	require.NoError(t, json.Unmarshal([]byte(attrs.GetMetadata()), &metadata))
+	require.Equal(t, services.ExtractionMethodAutomatic, metadata.Tag.Method)
+	require.Equal(t, "invoice-reader", metadata.Tag.ExtractedBy)
+	require.NotNil(t, metadata.Tag.Confidence)
+	require.InDelta(t, 0.8, *metadata.Tag.Confidence, 1e-9)
+	require.NotNil(t, metadata.Attributes["total"].Confidence)
+	require.InDelta(t, 0.8, *metadata.Attributes["total"].Confidence, 1e-9)
+
+	// The tag extracted event is attributed to the extractor
+	subjects, payloads := takeOutboxEvents(t, ta)
+	require.Len(t, subjects, 1)
+	event, err := events.DecodeEvent(subjects[0], payloads[0])
+	require.NoError(t, err)
+	require.Equal(t, events.TagExtracted, event.Type)
+	require.Equal(t, "extractor:invoice-reader", event.Context().GetActor())
+
+	// === Documents the extractor finds nothing in are left untagged ===
+	plain := uploadTestDocument(
+		t, ta, "extraction-test", "notes.txt", "text/plain", []byte("meeting notes"),
+	)
+	plainSubject, plainPayload := uploadedEvent(t, ta)
+	require.NoError(t, ta.Extraction.HandleEvent(ctx, plainSubject, plainPayload))
+
+	tags, err := ta.ConnectClient.ListDocumentTags(ctx, &documentsv1.ListDocumentTagsRequest{
+		Namespace:  "extraction-test",
+		DocumentId: plain.ID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, tags.Tags)
+
+	// === Extractor failures are returned so the document is retried ===
+	ta.Extraction.Register(&testExtractor{
+		name: "flaky",
+		extract: func(string) ([]services.ExtractedTag, error) {
+			return nil, errors.New("temporarily unavailable")
+		},
+	})
+	uploadTestDocument(t, ta, "extraction-test", "retry.txt", "text/plain", []byte("retry"))
+	subject, payload = uploadedEvent(t, ta)
+	err = ta.Extraction.HandleEvent(ctx, subject, payload)
+	require.ErrorContains(t, err, "extractor flaky")
+
+	// === Documents deleted before extraction are skipped ===
+	_, err = ta.ConnectClient.DeleteDocument(ctx, &documentsv1.DeleteDocumentRequest{
+		Namespace:  "extraction-test",
+		DocumentId: plain.ID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ta.Extraction.HandleEvent(ctx, plainSubject, plainPayload))
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/RynoXLI/Wayfile/internal/auth"
@@ -38,12 +40,14 @@ type DocumentUploadOutput struct {
 
 // DocumentResponse represents the response for document operations
 type DocumentResponse struct {
-	ID          string    `json:"id"              example:"123e4567-e89b-12d3-a456-426614174000"                                                                              doc:"Document UUID"`
-	FileName    string    `json:"file_name"       example:"document.pdf"                                                                                                      doc:"Original filename"`
-	Title       string    `json:"title"           example:"document.pdf"                                                                                                      doc:"Document title"`
-	ChecksumSHA string    `json:"checksum_sha256" example:"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"                                                  doc:"SHA-256 checksum"`
-	DownloadURL string    `json:"download_url"    example:"http://localhost:8080/api/v1/ns/my-namespace/documents/123e4567-e89b-12d3-a456-426614174000?token=abc.def.123.sig" doc:"Pre-signed download URL"`
-	CreatedAt   time.Time `json:"created_at"      example:"2024-01-15T10:00:00Z"                                                                                              doc:"Creation timestamp"`
+	ID               string    `json:"id"                           example:"123e4567-e89b-12d3-a456-426614174000"                                                                              doc:"Document UUID"`
+	FileName         string    `json:"file_name"                    example:"document.pdf"                                                                                                      doc:"Original filename"`
+	Title            string    `json:"title"                        example:"document.pdf"                                                                                                      doc:"Document title"`
+	MimeType         string    `json:"mime_type"                    example:"application/pdf"                                                                                                   doc:"MIME type declared by the uploader"`
+	DetectedMimeType string    `json:"detected_mime_type,omitempty" example:"application/pdf"                                                                                                   doc:"MIME type sniffed from the file content"`
+	ChecksumSHA      string    `json:"checksum_sha256"              example:"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"                                                  doc:"SHA-256 checksum"`
+	DownloadURL      string    `json:"download_url"                 example:"http://localhost:8080/api/v1/ns/my-namespace/documents/123e4567-e89b-12d3-a456-426614174000?token=abc.def.123.sig" doc:"Pre-signed download URL"`
+	CreatedAt        time.Time `json:"created_at"                   example:"2024-01-15T10:00:00Z"                                                                                              doc:"Creation timestamp"`
 }
 
 // DocumentDownloadInput handles download requests
@@ -51,6 +55,138 @@ type DocumentDownloadInput struct {
 	Namespace  string `path:"namespace"  maxLength:"255" doc:"Namespace name"`
 	DocumentID string `path:"documentID"                 doc:"Document UUID"                       format:"uuid"`
 	Token      string `                                  doc:"Pre-signed token for authentication"               query:"token" required:"false"`
+
+	Disposition string `query:"disposition" enum:"attachment,inline" default:"attachment" doc:"Whether the browser should save the file or render it inline; inline is limited to safe content types"`
+
+	Range           string `header:"Range"             doc:"Byte ranges to return, e.g. bytes=0-1023"`
+	IfRange         string `header:"If-Range"          doc:"Only honor Range if the ETag or Last-Modified date still matches"`
+	IfNoneMatch     string `header:"If-None-Match"     doc:"Return 304 if the document's ETag matches"`
+	IfModifiedSince string `header:"If-Modified-Since" doc:"Return 304 if the document has not changed since this date"`
+}
+
+// ValidationProblem is an RFC 9457 problem detailing why attributes failed validation
+// against their tag's schema
+type ValidationProblem struct {
+	Title      string                `json:"title"                 doc:"Short summary of the problem"`
+	Status     int                   `json:"status"                doc:"HTTP status code"`
+	Detail     string                `json:"detail"                doc:"Explanation of the problem"`
+	DocumentID string                `json:"document_id,omitempty" doc:"UUID of the uploaded document, which is stored without the failing tags"`
+	Errors     []*AttributeViolation `json:"errors"                doc:"Each reason the attributes failed validation"`
+}
+
+// AttributeViolation is one reason attributes failed validation against their tag's schema
+type AttributeViolation struct {
+	Location string      `json:"location"           doc:"Where the attributes are in the request, e.g. body.tags[0].attributes"`
+	Pointer  string      `json:"pointer"            doc:"JSON pointer to the failing value within the attributes"`
+	Keyword  string      `json:"keyword"            doc:"JSON schema keyword the value failed, e.g. type"`
+	Expected interface{} `json:"expected,omitempty" doc:"Value of the keyword in the schema"`
+	Actual   interface{} `json:"actual,omitempty"   doc:"Failing value, or its type for the type keyword"`
+	Message  string      `json:"message"            doc:"Description of the failure"`
+}
+
+func (p *ValidationProblem) Error() string {
+	return p.Detail
+}
+
+// GetStatus returns the problem's HTTP status code
+func (p *ValidationProblem) GetStatus() int {
+	return p.Status
+}
+
+// ContentType serves the problem as problem+json
+func (p *ValidationProblem) ContentType(ct string) string {
+	if ct == "application/json" {
+		return "application/problem+json"
+	}
+	return ct
+}
+
+// applyUploadTags adds the tags described by an upload's JSON tag list to a document.
+// Individual tag failures are logged rather than failing the whole upload. If any tags
+// failed attribute validation, a ValidationProblem is returned with the violations
+// located under the request field holding the tag list.
+func applyUploadTags(
+	ctx context.Context,
+	app *App,
+	namespace string,
+	documentID string,
+	tagsJSON string,
+	location string,
+) error {
+	if tagsJSON == "" {
+		return nil
+	}
+
+	var tagInputs []struct {
+		TagPath    string                 `json:"tag_path"`
+		Attributes map[string]interface{} `json:"attributes,omitempty"`
+	}
+
+	if err := json.Unmarshal([]byte(tagsJSON), &tagInputs); err != nil {
+		app.Logger.Error("Invalid tags JSON", "error", err, "tags", tagsJSON)
+		return huma.Error400BadRequest("Invalid tags JSON")
+	}
+
+	problem := &ValidationProblem{
+		Title:      http.StatusText(http.StatusUnprocessableEntity),
+		Status:     http.StatusUnprocessableEntity,
+		Detail:     "Tag attributes failed validation; the document was stored without those tags",
+		DocumentID: documentID,
+	}
+	for i, tagInput := range tagInputs {
+		var attributesJSON *string
+		if len(tagInput.Attributes) > 0 {
+			attrBytes, err := json.Marshal(tagInput.Attributes)
+			if err != nil {
+				app.Logger.Error("Failed to marshal tag attributes", "error", err)
+				return huma.Error400BadRequest("Invalid tag attributes")
+			}
+			attrStr := string(attrBytes)
+			attributesJSON = &attrStr
+		}
+
+		// Add tag to document via service
+		_, err := app.DocumentService.AddTagToDocument(
+			ctx,
+			namespace,
+			documentID,
+			tagInput.TagPath,
+			attributesJSON,
+			services.ExtractionMethodManual,
+			"api-upload",
+			nil,
+			false,
+			nil,
+		)
+		if err != nil {
+			app.Logger.Error(
+				"Failed to add tag to document",
+				"error", err,
+				"document_id", documentID,
+				"tag_path", tagInput.TagPath,
+			)
+			// Continue with other tags rather than failing the entire upload
+			var validationErr *services.ValidationError
+			if !errors.As(err, &validationErr) {
+				continue
+			}
+			for _, violation := range validationErr.Violations {
+				problem.Errors = append(problem.Errors, &AttributeViolation{
+					Location: fmt.Sprintf("%s[%d].attributes", location, i),
+					Pointer:  violation.Pointer,
+					Keyword:  violation.Keyword,
+					Expected: violation.Expected,
+					Actual:   violation.Actual,
+					Message:  violation.Message,
+				})
+			}
+		}
+	}
+
+	if len(problem.Errors) > 0 {
+		return problem
+	}
+	return nil
 }
 
 // RegisterRoutes registers all Huma operations
@@ -86,11 +222,15 @@ func RegisterRoutes(api huma.API, app *App) {
 
 	// Upload document
 	huma.Register(api, huma.Operation{
-		OperationID:   "upload-document",
-		Method:        "POST",
-		Path:          "/api/v1/ns/{namespace}/documents",
-		Summary:       "Upload a document",
-		Description:   "Upload a file to the specified namespace",
+		OperationID: "upload-document",
+		Method:      "POST",
+		Path:        "/api/v1/ns/{namespace}/documents",
+		Summary:     "Upload a document",
+		Description: "Upload a file to the specified namespace. The document is stored even " +
+			"if some of its tags have attributes that fail validation, so the upload needn't " +
+			"be repeated: those tags aren't added, and the response is a 422 " +
+			"application/problem+json whose errors list each violation and whose " +
+			"document_id identifies the stored document.",
 		Tags:          []string{"documents"},
 		DefaultStatus: 201,
 	}, func(ctx context.Context, input *DocumentUploadInput) (*DocumentUploadOutput, error) {
@@ -128,50 +268,10 @@ func RegisterRoutes(api huma.API, app *App) {
 		}
 
 		// Process tags if provided
-		if formData.Tags != "" {
-			var tagInputs []struct {
-				TagPath    string                 `json:"tag_path"`
-				Attributes map[string]interface{} `json:"attributes,omitempty"`
-			}
-
-			if err := json.Unmarshal([]byte(formData.Tags), &tagInputs); err != nil {
-				app.Logger.Error("Invalid tags JSON", "error", err, "tags", formData.Tags)
-				return nil, huma.Error400BadRequest("Invalid tags JSON")
-			}
-
-			documentID := result.Document.ID.String()
-			for _, tagInput := range tagInputs {
-				var attributesJSON *string
-				if len(tagInput.Attributes) > 0 {
-					attrBytes, err := json.Marshal(tagInput.Attributes)
-					if err != nil {
-						app.Logger.Error("Failed to marshal tag attributes", "error", err)
-						return nil, huma.Error400BadRequest("Invalid tag attributes")
-					}
-					attrStr := string(attrBytes)
-					attributesJSON = &attrStr
-				}
-
-				// Add tag to document via service
-				err := app.DocumentService.AddTagToDocument(
-					ctx,
-					input.Namespace,
-					documentID,
-					tagInput.TagPath,
-					attributesJSON,
-					services.ExtractionMethodManual,
-					"api-upload",
-				)
-				if err != nil {
-					app.Logger.Error(
-						"Failed to add tag to document",
-						"error", err,
-						"document_id", documentID,
-						"tag_path", tagInput.TagPath,
-					)
-					// Continue with other tags rather than failing the entire upload
-				}
-			}
+		documentID := result.Document.ID.String()
+		err = applyUploadTags(ctx, app, input.Namespace, documentID, formData.Tags, "body.tags")
+		if err != nil {
+			return nil, err
 		}
 
 		// Create response with download URL
@@ -180,21 +280,37 @@ func RegisterRoutes(api huma.API, app *App) {
 			ID:          result.Document.ID.String(),
 			FileName:    result.Document.FileName,
 			Title:       result.Document.Title,
+			MimeType:    result.Document.MimeType,
 			ChecksumSHA: result.Document.ChecksumSha256,
 			DownloadURL: result.DownloadURL,
 			CreatedAt:   result.Document.CreatedAt.Time,
 		}
+		if result.Document.DetectedMimeType != nil {
+			resp.Body.DetectedMimeType = *result.Document.DetectedMimeType
+		}
 
 		return resp, nil
 	})
 
+	// Resumable uploads (tus protocol)
+	registerUploadRoutes(api, app)
+
+	// Bulk download as a ZIP archive
+	registerArchiveRoutes(api, app)
+
+	// Archive imports
+	registerImportRoutes(api, app)
+
+	// Namespace event stream
+	registerEventRoutes(api, app)
+
 	// Download document
 	huma.Register(api, huma.Operation{
 		OperationID: "download-document",
 		Method:      "GET",
 		Path:        "/api/v1/ns/{namespace}/documents/{documentID}",
 		Summary:     "Download a document",
-		Description: "Download a file from the specified namespace",
+		Description: "Download a file from the specified namespace. Supports byte range requests, conditional GET via ETag and Last-Modified, and inline previews of safe content types.",
 		Tags:        []string{"documents"},
 	}, func(ctx context.Context, input *DocumentDownloadInput) (*huma.StreamResponse, error) {
 		// Validate UUID
@@ -202,12 +318,8 @@ func RegisterRoutes(api huma.API, app *App) {
 			return nil, huma.Error404NotFound("Invalid document ID")
 		}
 
-		// Download the file
-		file, doc, err := app.DocumentService.DownloadDocument(
-			ctx,
-			input.Namespace,
-			input.DocumentID,
-		)
+		// Resolve the document; content is opened once the response shape is known
+		doc, err := app.DocumentService.GetDocument(ctx, input.Namespace, input.DocumentID)
 		if err != nil {
 			if errors.Is(err, storage.ErrNotFound) {
 				return nil, huma.Error404NotFound("File not found")
@@ -220,7 +332,6 @@ func RegisterRoutes(api huma.API, app *App) {
 		if input.Token != "" {
 			tokenNsUUID, tokenDocID, err := app.Signer.VerifyToken(input.Token)
 			if err != nil {
-				_ = file.Close()
 				if errors.Is(err, auth.ErrTokenExpired) {
 					return nil, huma.Error401Unauthorized("Token expired")
 				}
@@ -229,20 +340,96 @@ func RegisterRoutes(api huma.API, app *App) {
 
 			// Verify token is for the correct resource using document's namespace_id
 			if tokenNsUUID != doc.NamespaceID.String() || tokenDocID != input.DocumentID {
-				_ = file.Close()
 				return nil, huma.Error401Unauthorized("Token not valid for this resource")
 			}
 		}
 
+		// Downloads are served with the sniffed type; inline rendering is only allowed
+		// for safe ones
+		disposition := "attachment"
+		contentType := attachmentContentType(doc)
+		if input.Disposition == "inline" {
+			inlineType, ok := inlineContentType(doc)
+			if !ok {
+				return nil, huma.Error400BadRequest(
+					"Inline disposition is not allowed for this content type",
+				)
+			}
+			disposition = "inline"
+			contentType = inlineType
+		}
+
+		etag := documentETag(doc)
+		lastModified := documentLastModified(doc)
+
+		// Conditional GET
+		if notModified(input.IfNoneMatch, input.IfModifiedSince, etag, lastModified) {
+			return nil, huma.ErrorWithHeaders(huma.Status304NotModified(), http.Header{
+				"ETag":          {etag},
+				"Last-Modified": {lastModified.Format(http.TimeFormat)},
+			})
+		}
+
+		// Range requests; malformed Range headers are ignored and the full file is served
+		var ranges []storage.ByteRange
+		if input.Range != "" && rangeApplies(input.IfRange, etag, lastModified) {
+			ranges, err = storage.ParseRange(input.Range, doc.FileSize)
+			if errors.Is(err, storage.ErrRangeNotSatisfiable) {
+				return nil, rangeNotSatisfiable(doc.FileSize)
+			}
+		}
+
+		setHeaders := func(ctx huma.Context) {
+			ctx.SetHeader(
+				"Content-Disposition",
+				fmt.Sprintf("%s; filename=%q", disposition, doc.FileName),
+			)
+			ctx.SetHeader("X-Content-Type-Options", "nosniff")
+			if disposition == "inline" {
+				ctx.SetHeader("Content-Security-Policy", inlineContentSecurityPolicy)
+				ctx.SetHeader("Referrer-Policy", "no-referrer")
+			}
+			ctx.SetHeader("Accept-Ranges", "bytes")
+			ctx.SetHeader("ETag", etag)
+			ctx.SetHeader("Last-Modified", lastModified.Format(http.TimeFormat))
+		}
+
+		if len(ranges) > 1 {
+			return &huma.StreamResponse{
+				Body: func(ctx huma.Context) {
+					setHeaders(ctx)
+					streamByteRanges(ctx, app, doc, contentType, ranges)
+				},
+			}, nil
+		}
+
+		status := http.StatusOK
+		span := storage.ByteRange{Start: 0, Length: doc.FileSize}
+		if len(ranges) == 1 {
+			status = http.StatusPartialContent
+			span = ranges[0]
+		}
+
+		file, err := app.DocumentService.DownloadDocumentRange(ctx, doc, span)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, huma.Error404NotFound("File not found")
+			}
+			app.Logger.Error("Failed to download file", "error", err)
+			return nil, huma.Error500InternalServerError("Error downloading the file")
+		}
+
 		// Return streaming response
 		return &huma.StreamResponse{
 			Body: func(ctx huma.Context) {
 				defer func() { _ = file.Close() }()
-				ctx.SetHeader(
-					"Content-Disposition",
-					fmt.Sprintf("attachment; filename=%q", doc.FileName),
-				)
-				ctx.SetHeader("Content-Type", doc.MimeType)
+				setHeaders(ctx)
+				ctx.SetHeader("Content-Type", contentType)
+				ctx.SetHeader("Content-Length", strconv.FormatInt(span.Length, 10))
+				if status == http.StatusPartialContent {
+					ctx.SetHeader("Content-Range", span.ContentRange(doc.FileSize))
+				}
+				ctx.SetStatus(status)
 				if _, err := io.Copy(ctx.BodyWriter(), file); err != nil {
 					app.Logger.Error("Failed to stream file", "error", err)
 				}
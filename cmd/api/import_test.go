@@ -0,0 +1,256 @@
+//go:build integration
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
+	importsv1 "github.com/RynoXLI/Wayfile/gen/go/imports/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// importResponse mirrors the create-import response body
+type importResponse struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	Format       string `json:"format"`
+	ArchiveSize  int64  `json:"archive_size"`
+	TotalEntries *int32 `json:"total_entries"`
+}
+
+// postImport uploads an archive for import and returns the response
+func postImport(
+	ta *TestApp,
+	namespace string,
+	query url.Values,
+	archive []byte,
+) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/ns/"+namespace+"/imports?"+query.Encode(),
+		bytes.NewReader(archive),
+	)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	w := httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	return w
+}
+
+// buildZip creates a ZIP archive from a map of entry names to contents
+func buildZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// buildTarGz creates a gzipped tar archive from a map of entry names to contents
+func buildTarGz(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+// runImport queues an archive and processes it synchronously
+func runImport(
+	t *testing.T,
+	ta *TestApp,
+	namespace string,
+	query url.Values,
+	archive []byte,
+) importResponse {
+	w := postImport(ta, namespace, query, archive)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	var resp importResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, services.ImportStatusPending, resp.Status)
+
+	processed, err := ta.App.ImportService.ProcessNext(context.Background())
+	require.NoError(t, err)
+	require.True(t, processed)
+	return resp
+}
+
+func TestImportArchive(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "import-test",
+	})
+	require.NoError(t, err)
+
+	existing := uploadTestDocument(
+		t,
+		ta,
+		"import-test",
+		"existing.txt",
+		"text/plain",
+		[]byte("already here"),
+	)
+
+	// === ZIP with nested directories, a duplicate and OS metadata ===
+	archive := buildZip(t, map[string]string{
+		"Tax Returns/2019/return.txt": "return 2019",
+		"Tax Returns/notes.txt":       "notes",
+		"readme.txt":                  "readme",
+		"copy-of-existing.txt":        "already here",
+		"__MACOSX/._readme.txt":       "resource fork",
+		"Tax Returns/.DS_Store":       "finder",
+	})
+	created := runImport(t, ta, "import-test", url.Values{"file_name": {"taxes.zip"}}, archive)
+	require.Equal(t, services.ArchiveFormatZip, created.Format)
+	require.Equal(t, int64(len(archive)), created.ArchiveSize)
+	require.NotNil(t, created.TotalEntries)
+	require.Equal(t, int32(6), *created.TotalEntries)
+
+	job, err := ta.ImportClient.GetImport(ctx, &importsv1.GetImportRequest{
+		Namespace: "import-test",
+		ImportId:  created.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, services.ImportStatusCompleted, job.Import.Status)
+	require.Equal(t, "taxes.zip", job.Import.FileName)
+	require.Equal(t, int32(6), job.Import.ProcessedEntries)
+	require.Equal(t, int32(3), job.Import.CreatedCount)
+	require.Equal(t, int32(1), job.Import.DuplicateCount)
+	require.Equal(t, int32(2), job.Import.SkippedCount)
+	require.Equal(t, int32(0), job.Import.FailedCount)
+	require.NotNil(t, job.Import.CompletedAt)
+
+	entries, err := ta.ImportClient.ListImportEntries(ctx, &importsv1.ListImportEntriesRequest{
+		Namespace: "import-test",
+		ImportId:  created.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 6)
+
+	byPath := make(map[string]*importsv1.ImportEntry, len(entries.Entries))
+	for _, entry := range entries.Entries {
+		byPath[entry.Path] = entry
+	}
+	require.Equal(t, services.ImportEntryDuplicate, byPath["copy-of-existing.txt"].Status)
+	require.Equal(t, existing.ID, byPath["copy-of-existing.txt"].GetDocumentId())
+	require.Equal(t, services.ImportEntrySkipped, byPath["__MACOSX/._readme.txt"].Status)
+
+	nested := byPath["Tax Returns/2019/return.txt"]
+	require.Equal(t, services.ImportEntryCreated, nested.Status)
+	require.Equal(t, "/Tax-Returns/2019", nested.GetTagPath())
+
+	// Directory tags were created and applied to the imported document
+	_, err = ta.TagClient.GetTag(ctx, &tagsv1.GetTagRequest{
+		Namespace: "import-test",
+		Path:      "/Tax-Returns/2019",
+	})
+	require.NoError(t, err)
+
+	tags, err := ta.ConnectClient.ListDocumentTags(ctx, &documentsv1.ListDocumentTagsRequest{
+		Namespace:  "import-test",
+		DocumentId: nested.GetDocumentId(),
+	})
+	require.NoError(t, err)
+	require.Len(t, tags.Tags, 1)
+	require.Equal(t, "/Tax-Returns/2019", tags.Tags[0].TagPath)
+
+	// Files at the archive root aren't tagged
+	require.Nil(t, byPath["readme.txt"].TagPath)
+
+	// === Gzipped tar nested under an existing tag ===
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "import-test",
+		Name:      "scans",
+	})
+	require.NoError(t, err)
+
+	created = runImport(
+		t,
+		ta,
+		"import-test",
+		url.Values{"file_name": {"scans.tar.gz"}, "tag_path": {"/scans"}},
+		buildTarGz(t, map[string]string{
+			"receipts/march.txt": "march",
+			"cover.txt":          "cover",
+		}),
+	)
+	require.Equal(t, services.ArchiveFormatTarGz, created.Format)
+	require.Nil(t, created.TotalEntries)
+
+	job, err = ta.ImportClient.GetImport(ctx, &importsv1.GetImportRequest{
+		Namespace: "import-test",
+		ImportId:  created.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, services.ImportStatusCompleted, job.Import.Status)
+	require.Equal(t, int32(2), job.Import.CreatedCount)
+	require.Equal(t, int32(2), job.Import.GetTotalEntries())
+
+	entries, err = ta.ImportClient.ListImportEntries(ctx, &importsv1.ListImportEntriesRequest{
+		Namespace: "import-test",
+		ImportId:  created.ID,
+	})
+	require.NoError(t, err)
+	for _, entry := range entries.Entries {
+		switch entry.Path {
+		case "receipts/march.txt":
+			require.Equal(t, "/scans/receipts", entry.GetTagPath())
+		case "cover.txt":
+			require.Equal(t, "/scans", entry.GetTagPath())
+		}
+	}
+
+	// === Invalid requests ===
+	w := postImport(ta, "import-test", url.Values{}, []byte("definitely not an archive"))
+	require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	w = postImport(ta, "import-test", url.Values{"tag_path": {"/missing"}}, archive)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = postImport(ta, "no-such-namespace", url.Values{}, archive)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	_, err = ta.ImportClient.GetImport(ctx, &importsv1.GetImportRequest{
+		Namespace: "import-test",
+		ImportId:  "00000000-0000-0000-0000-000000000000",
+	})
+	require.Error(t, err)
+
+	// Nothing is left queued after the rejected uploads
+	processed, err := ta.App.ImportService.ProcessNext(ctx)
+	require.NoError(t, err)
+	require.False(t, processed)
+}
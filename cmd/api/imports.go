@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// CreateImportInput uploads a ZIP or tar archive to import. The request body is
+// streamed straight to storage rather than buffered.
+type CreateImportInput struct {
+	Namespace string `path:"namespace" maxLength:"255" doc:"Namespace name"`
+	FileName  string `                 maxLength:"255" doc:"Original archive file name"                                    query:"file_name"`
+	TagPath   string `                                 doc:"Tag to nest the archive's directory tags under, e.g. /imports" query:"tag_path"`
+
+	body io.Reader
+}
+
+// Resolve captures the raw request body so it can be streamed. Archives can take far
+// longer than the server read timeout to arrive, so the deadline is lifted.
+func (i *CreateImportInput) Resolve(ctx huma.Context) []error {
+	_, w := humachi.Unwrap(ctx)
+	_ = http.NewResponseController(w).SetReadDeadline(time.Time{})
+	i.body = ctx.BodyReader()
+	return nil
+}
+
+// CreateImportOutput describes a queued import
+type CreateImportOutput struct {
+	Body struct {
+		ID           string `json:"id"                      doc:"Import UUID"`
+		Status       string `json:"status"                  doc:"Import status"               enum:"pending,running,completed,failed"`
+		Format       string `json:"format"                  doc:"Detected archive format"     enum:"zip,tar,tar.gz"`
+		ArchiveSize  int64  `json:"archive_size"            doc:"Archive size in bytes"`
+		TotalEntries *int32 `json:"total_entries,omitempty" doc:"Number of files in the archive, when known up front"`
+	}
+}
+
+// isImportRequest reports whether r uploads an archive for import. Imports enforce their
+// own, larger size limit.
+func isImportRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost &&
+		strings.HasPrefix(r.URL.Path, "/api/v1/ns/") &&
+		strings.HasSuffix(r.URL.Path, "/imports")
+}
+
+// registerImportRoutes registers the archive import endpoint
+func registerImportRoutes(api huma.API, app *App) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-import",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/ns/{namespace}/imports",
+		Summary:     "Import an archive",
+		Description: "Upload a ZIP, tar or gzipped tar archive whose files are imported as individual " +
+			"documents in the background. Directories become tags; progress is available " +
+			"through the ImportService RPC.",
+		Tags:          []string{"imports"},
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *CreateImportInput) (*CreateImportOutput, error) {
+		fileName := input.FileName
+		if fileName == "" {
+			fileName = "archive"
+		}
+
+		job, err := app.ImportService.CreateImport(
+			ctx,
+			input.Namespace,
+			fileName,
+			input.TagPath,
+			input.body,
+		)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrUnsupportedArchive):
+				return nil, huma.Error415UnsupportedMediaType(err.Error())
+			case errors.Is(err, services.ErrImportTooLarge):
+				return nil, huma.Error413RequestEntityTooLarge(err.Error())
+			case errors.Is(err, services.ErrNamespaceNotFound):
+				return nil, huma.Error404NotFound("Namespace not found")
+			case errors.Is(err, services.ErrTagNotFound):
+				return nil, huma.Error404NotFound("Tag not found")
+			}
+			app.Logger.Error(
+				"Failed to create import",
+				"error", err,
+				"namespace", input.Namespace,
+			)
+			return nil, huma.Error500InternalServerError("Error creating the import")
+		}
+
+		resp := &CreateImportOutput{}
+		resp.Body.ID = job.ID.String()
+		resp.Body.Status = job.Status
+		resp.Body.Format = job.Format
+		resp.Body.ArchiveSize = job.ArchiveSize
+		resp.Body.TotalEntries = job.TotalEntries
+		return resp, nil
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/danielgtaylor/huma/v2"
@@ -31,8 +32,13 @@ import (
 
 	"github.com/RynoXLI/Wayfile/cmd/api/rpc"
 	"github.com/RynoXLI/Wayfile/gen/go/documents/v1/documentsv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/events/v1/eventsv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/imports/v1/importsv1connect"
 	"github.com/RynoXLI/Wayfile/gen/go/namespaces/v1/namespacesv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/reviews/v1/reviewsv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/rules/v1/rulesv1connect"
 	"github.com/RynoXLI/Wayfile/gen/go/tags/v1/tagsv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/webhooks/v1/webhooksv1connect"
 	"github.com/RynoXLI/Wayfile/internal/auth"
 	"github.com/RynoXLI/Wayfile/internal/config"
 	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
@@ -160,10 +166,17 @@ type TestApp struct {
 	Router          http.Handler
 	Pool            *pgxpool.Pool
 	NC              *nats.Conn
+	Relay           *events.Relay
+	Extraction      *services.ExtractionService
 	TmpDir          string
 	ConnectClient   documentsv1connect.DocumentServiceClient
 	NamespaceClient namespacesv1connect.NamespaceServiceClient
 	TagClient       tagsv1connect.TagServiceClient
+	ImportClient    importsv1connect.ImportServiceClient
+	WebhookClient   webhooksv1connect.WebhookServiceClient
+	RuleClient      rulesv1connect.RuleServiceClient
+	ReviewClient    reviewsv1connect.ReviewServiceClient
+	EventClient     eventsv1connect.EventServiceClient
 	TestServer      *httptest.Server
 }
 
@@ -226,37 +239,88 @@ func SetupTestApp(t *testing.T) *TestApp {
 	localClient, err := storage.NewLocalStorage(tmpDir, logger)
 	require.NoError(t, err)
 
-	// Initialize event publisher and storage
-	publisher := events.NewPublisher(js)
+	// Initialize the event outbox and storage
 	queries := sqlc.New(pool)
+	outbox := events.NewOutbox(queries, events.SubjectModeNamespaced)
 	storageService := storage.NewStorage(localClient, queries, logger)
 
+	// Tests drive the relay with RelayBatch
+	relay := events.NewRelay(pool, queries, events.NewPublisher(js), 100, 5, time.Hour)
+
 	// Initialize tag service (needed by document service)
-	tagService := services.NewTagService(queries, publisher)
+	tagService := services.NewTagService(pool, queries, outbox)
 
 	// Initialize document service
 	signer := auth.NewSigner("test-secret")
 	baseURL := "http://localhost:8080"
 	documentService := services.NewDocumentService(
 		storageService,
-		publisher,
+		outbox,
 		signer,
 		baseURL,
+		pool,
 		queries,
 		tagService,
 	)
 
 	// Initialize namespace service
-	namespaceService := services.NewNamespaceService(queries)
+	namespaceService := services.NewNamespaceService(pool, queries, outbox)
+
+	// Initialize resumable upload service
+	uploadService := services.NewUploadService(
+		storageService,
+		documentService,
+		queries,
+		1073741824, // 1 GB
+		time.Hour,
+	)
+
+	// Initialize archive import service; tests drive the worker with ProcessNext
+	importService := services.NewImportService(
+		storageService,
+		documentService,
+		tagService,
+		queries,
+		1073741824, // 1 GB
+		1073741824, // 1 GB
+	)
+
+	// Initialize webhook service; tests queue events with HandleEvent and drive the
+	// worker with DeliverDue
+	webhookService := services.NewWebhookService(
+		queries,
+		&http.Client{Timeout: 5 * time.Second},
+		3,
+		time.Minute,
+		time.Hour,
+	)
+
+	// Extraction runs in cmd/worker; tests register further extractors and call HandleEvent
+	ruleService := services.NewRuleService(queries, storageService, tagService)
+	reviewService := services.NewReviewService(queries, documentService, tagService)
+	extractionService := services.NewExtractionService(
+		storageService,
+		documentService,
+		reviewService,
+	)
+	extractionService.Register(ruleService)
+
+	// Event feeds read from the stream tests create with createEventStream
+	eventWatcher := events.NewWatcher(js, testEventStream)
 
 	// Initialize app (need to export fields in main.go App struct)
 	app := &App{
-		DocumentService: documentService,
-		Logger:          logger,
-		Signer:          signer,
-		BaseURL:         baseURL,
-		Pool:            pool,
-		NC:              nc,
+		DocumentService:  documentService,
+		UploadService:    uploadService,
+		ImportService:    importService,
+		WebhookService:   webhookService,
+		NamespaceService: namespaceService,
+		EventWatcher:     eventWatcher,
+		Logger:           logger,
+		Signer:           signer,
+		BaseURL:          baseURL,
+		Pool:             pool,
+		NC:               nc,
 	}
 
 	// Create test config
@@ -279,7 +343,7 @@ func SetupTestApp(t *testing.T) *TestApp {
 	// Apply max upload size to POST routes only
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.Method == http.MethodPost {
+			if r.Method == http.MethodPost && !isImportRequest(r) {
 				r.Body = http.MaxBytesReader(w, r.Body, testCfg.Server.MaxUploadSize)
 			}
 			next.ServeHTTP(w, r)
@@ -320,6 +384,46 @@ func SetupTestApp(t *testing.T) *TestApp {
 	)
 	router.Mount(tagPath, tagHandler)
 
+	// Mount Import RPC handlers
+	importRPCService := rpc.NewImportServiceServer(importService)
+	importPath, importHandler := importsv1connect.NewImportServiceHandler(
+		importRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(importPath, importHandler)
+
+	// Mount Webhook RPC handlers
+	webhookRPCService := rpc.NewWebhookServiceServer(webhookService)
+	webhookPath, webhookHandler := webhooksv1connect.NewWebhookServiceHandler(
+		webhookRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(webhookPath, webhookHandler)
+
+	// Mount Rule RPC handlers
+	ruleRPCService := rpc.NewRuleServiceServer(ruleService)
+	rulePath, ruleHandler := rulesv1connect.NewRuleServiceHandler(
+		ruleRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(rulePath, ruleHandler)
+
+	// Mount Review RPC handlers
+	reviewRPCService := rpc.NewReviewServiceServer(reviewService)
+	reviewPath, reviewHandler := reviewsv1connect.NewReviewServiceHandler(
+		reviewRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(reviewPath, reviewHandler)
+
+	// Mount Event RPC handlers
+	eventRPCService := rpc.NewEventServiceServer(eventWatcher, namespaceService)
+	eventPath, eventHandler := eventsv1connect.NewEventServiceHandler(
+		eventRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(eventPath, eventHandler)
+
 	// Wrap with h2c for HTTP/2
 	h2cHandler := h2c.NewHandler(router, &http2.Server{})
 
@@ -339,16 +443,43 @@ func SetupTestApp(t *testing.T) *TestApp {
 		http.DefaultClient,
 		testServer.URL,
 	)
+	importClient := importsv1connect.NewImportServiceClient(
+		http.DefaultClient,
+		testServer.URL,
+	)
+	webhookClient := webhooksv1connect.NewWebhookServiceClient(
+		http.DefaultClient,
+		testServer.URL,
+	)
+	ruleClient := rulesv1connect.NewRuleServiceClient(
+		http.DefaultClient,
+		testServer.URL,
+	)
+	reviewClient := reviewsv1connect.NewReviewServiceClient(
+		http.DefaultClient,
+		testServer.URL,
+	)
+	eventClient := eventsv1connect.NewEventServiceClient(
+		http.DefaultClient,
+		testServer.URL,
+	)
 
 	return &TestApp{
 		App:             app,
 		Router:          h2cHandler,
 		Pool:            pool,
 		NC:              nc,
+		Relay:           relay,
+		Extraction:      extractionService,
 		TmpDir:          tmpDir,
 		ConnectClient:   connectClient,
 		NamespaceClient: namespaceClient,
 		TagClient:       tagClient,
+		ImportClient:    importClient,
+		WebhookClient:   webhookClient,
+		RuleClient:      ruleClient,
+		ReviewClient:    reviewClient,
+		EventClient:     eventClient,
 		TestServer:      testServer,
 	}
 }
@@ -3,11 +3,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"connectrpc.com/connect"
@@ -22,8 +25,13 @@ import (
 
 	"github.com/RynoXLI/Wayfile/cmd/api/rpc"
 	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1/documentsv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/events/v1/eventsv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/imports/v1/importsv1connect"
 	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1/namespacesv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/reviews/v1/reviewsv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/rules/v1/rulesv1connect"
 	"github.com/RynoXLI/Wayfile/gen/go/tags/v1/tagsv1connect"
+	"github.com/RynoXLI/Wayfile/gen/go/webhooks/v1/webhooksv1connect"
 	"github.com/RynoXLI/Wayfile/internal/auth"
 	"github.com/RynoXLI/Wayfile/internal/config"
 	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
@@ -50,7 +58,9 @@ func main() {
 	logger := slog.New(handler)
 	logger.Info("Starting API server...")
 
-	ctx := context.Background()
+	// Cancelled on SIGINT/SIGTERM to begin a graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Parse connection string from config into pool config
 	poolConfig, err := pgxpool.ParseConfig(cfg.Database.URL)
@@ -74,13 +84,22 @@ func main() {
 	}
 	defer nc.Close()
 
-	js, err := nc.JetStream()
+	js, err := nc.JetStream(nats.PublishAsyncMaxPending(cfg.NATS.MaxPendingPublishes))
 	if err != nil {
 		log.Fatal("Unable to create JetStream context:", err)
 	}
 
 	logger.Info("Connected to NATS with JetStream")
 
+	// Ensure the event streams and built-in consumers exist before anything publishes
+	if err := events.Provision(js, cfg.NATS.Streams, cfg.NATS.Consumers); err != nil {
+		log.Fatal("Unable to provision JetStream:", err)
+	}
+	logger.Info("JetStream provisioned",
+		"streams", len(cfg.NATS.Streams),
+		"consumers", len(cfg.NATS.Consumers),
+	)
+
 	// Initialize storage client
 	localClient, err := storage.NewLocalStorage(cfg.Storage.Local.Path, logger)
 	if err != nil {
@@ -92,36 +111,145 @@ func main() {
 	}
 	logger.Info("Storage initialized", "type", cfg.Storage.Type, "path", cfg.Storage.Local.Path)
 
-	// Initialize event publisher and storage
-	publisher := events.NewPublisher(js)
+	// Initialize the event outbox and storage
+	subjectMode, err := events.ParseSubjectMode(cfg.NATS.SubjectMode)
+	if err != nil {
+		log.Fatal("Invalid NATS configuration:", err)
+	}
 	queries := sqlc.New(pool)
+	outbox := events.NewOutbox(queries, subjectMode)
 	storageService := storage.NewStorage(localClient, queries, logger)
 
+	// Relay committed events from the outbox to JetStream in the background
+	publisher := events.NewPublisher(
+		js,
+		events.WithAckTimeout(time.Duration(cfg.NATS.PublishAckTimeout)*time.Second),
+		events.WithFailureHandler(func(subject string, msgID string, err error) {
+			logger.Error("Failed to publish event",
+				"subject", subject,
+				"msg_id", msgID,
+				"error", err,
+			)
+		}),
+	)
+	relay := events.NewRelay(
+		pool,
+		queries,
+		publisher,
+		int32(cfg.NATS.OutboxBatchSize),
+		int32(cfg.NATS.OutboxMaxAttempts),
+		time.Duration(cfg.NATS.OutboxRetention)*time.Second,
+	)
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(ctx, time.Duration(cfg.NATS.OutboxPollInterval)*time.Second)
+	}()
+
 	// Initialize tag service (needed by document service)
-	tagService := services.NewTagService(queries, publisher)
+	tagService := services.NewTagService(pool, queries, outbox)
 
 	// Initialize document service
 	signer := auth.NewSigner(cfg.Server.SigningSecret)
 	documentService := services.NewDocumentService(
 		storageService,
-		publisher,
+		outbox,
 		signer,
 		cfg.Server.BaseURL,
+		pool,
 		queries,
 		tagService,
 	)
 
 	// Initialize namespace service
-	namespaceService := services.NewNamespaceService(queries)
+	namespaceService := services.NewNamespaceService(pool, queries, outbox)
+
+	// Initialize resumable upload service and sweep abandoned uploads in the background
+	uploadService := services.NewUploadService(
+		storageService,
+		documentService,
+		queries,
+		cfg.Server.MaxResumableUploadSize,
+		time.Duration(cfg.Server.UploadExpiry)*time.Second,
+	)
+	go uploadService.RunExpirySweeper(
+		ctx,
+		time.Duration(cfg.Server.UploadCleanupInterval)*time.Second,
+	)
+
+	// Initialize archive import service and process queued imports in the background
+	importService := services.NewImportService(
+		storageService,
+		documentService,
+		tagService,
+		queries,
+		cfg.Server.MaxImportSize,
+		cfg.Server.MaxResumableUploadSize,
+	)
+	go importService.RunWorker(ctx, time.Duration(cfg.Server.ImportPollInterval)*time.Second)
+
+	// Initialize webhook service, queue deliveries from JetStream and send them in the
+	// background
+	webhookService := services.NewWebhookService(
+		queries,
+		&http.Client{Timeout: time.Duration(cfg.Webhooks.Timeout) * time.Second},
+		int32(cfg.Webhooks.MaxAttempts),
+		time.Duration(cfg.Webhooks.InitialBackoff)*time.Second,
+		time.Duration(cfg.Webhooks.MaxBackoff)*time.Second,
+	)
+	go func() {
+		err := events.Consume(
+			ctx,
+			js,
+			cfg.Webhooks.Stream,
+			cfg.Webhooks.Consumer,
+			10, // events per fetch
+			webhookService.HandleEvent,
+		)
+		if err != nil {
+			logger.Error("Webhook consumer stopped", "error", err)
+		}
+	}()
+	go webhookService.RunWorker(ctx, time.Duration(cfg.Webhooks.PollInterval)*time.Second)
+
+	// Initialize tagging rule service; the extractor worker applies the rules
+	ruleService := services.NewRuleService(queries, storageService, tagService)
+
+	// Initialize review queue service; the extractor worker queues uncertain extractions
+	reviewService := services.NewReviewService(queries, documentService, tagService)
+
+	// Namespace event feeds read back from the event stream
+	eventWatcher := events.NewWatcher(js, cfg.NATS.EventStream)
+
+	// Other replicas' schema changes invalidate this one's compiled schemas
+	err = tagService.FollowSchemaChanges(
+		ctx,
+		eventWatcher,
+		events.SchemaInvalidationSubjects(subjectMode),
+	)
+	if err != nil {
+		log.Fatal("Unable to follow schema changes:", err)
+	}
+	if cfg.Logging.StatsInterval > 0 {
+		go tagService.ReportSchemaCacheStats(
+			ctx,
+			time.Duration(cfg.Logging.StatsInterval)*time.Second,
+		)
+	}
 
 	// Initialize app
 	app := &App{
-		DocumentService: documentService,
-		Logger:          logger,
-		Signer:          signer,
-		BaseURL:         cfg.Server.BaseURL,
-		Pool:            pool,
-		NC:              nc,
+		DocumentService:  documentService,
+		UploadService:    uploadService,
+		ImportService:    importService,
+		WebhookService:   webhookService,
+		NamespaceService: namespaceService,
+		EventWatcher:     eventWatcher,
+		Logger:           logger,
+		Signer:           signer,
+		BaseURL:          cfg.Server.BaseURL,
+		Pool:             pool,
+		NC:               nc,
 	}
 
 	// Setup router with Huma
@@ -134,10 +262,10 @@ func main() {
 	router.Use(chimiddleware.SetHeader("X-Content-Type-Options", "nosniff"))
 	router.Use(middleware.RateLimiter(cfg.Server.RateLimitRPS, cfg.Server.RateLimitBurst))
 
-	// Apply max upload size to POST routes only
+	// Apply max upload size to POST routes only; archive imports enforce their own limit
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.Method == http.MethodPost {
+			if r.Method == http.MethodPost && !isImportRequest(r) {
 				r.Body = http.MaxBytesReader(w, r.Body, cfg.Server.MaxUploadSize)
 			}
 			next.ServeHTTP(w, r)
@@ -188,6 +316,46 @@ func main() {
 	)
 	router.Mount(tagPath, tagHandler)
 
+	// Mount Import RPC handlers
+	importRPCService := rpc.NewImportServiceServer(importService)
+	importPath, importHandler := importsv1connect.NewImportServiceHandler(
+		importRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(importPath, importHandler)
+
+	// Mount Webhook RPC handlers
+	webhookRPCService := rpc.NewWebhookServiceServer(webhookService)
+	webhookPath, webhookHandler := webhooksv1connect.NewWebhookServiceHandler(
+		webhookRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(webhookPath, webhookHandler)
+
+	// Mount Rule RPC handlers
+	ruleRPCService := rpc.NewRuleServiceServer(ruleService)
+	rulePath, ruleHandler := rulesv1connect.NewRuleServiceHandler(
+		ruleRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(rulePath, ruleHandler)
+
+	// Mount Review RPC handlers
+	reviewRPCService := rpc.NewReviewServiceServer(reviewService)
+	reviewPath, reviewHandler := reviewsv1connect.NewReviewServiceHandler(
+		reviewRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(reviewPath, reviewHandler)
+
+	// Mount Event RPC handlers; event streams outlive the server write timeout
+	eventRPCService := rpc.NewEventServiceServer(eventWatcher, namespaceService)
+	eventPath, eventHandler := eventsv1connect.NewEventServiceHandler(
+		eventRPCService,
+		connect.WithInterceptors(),
+	)
+	router.Mount(eventPath, withoutWriteDeadline(eventHandler))
+
 	// Add endpoint for OpenAPI 3.0.3 (downgraded for oapi-codegen)
 	router.Get("/openapi-3.0.yaml", func(w http.ResponseWriter, _ *http.Request) {
 		b, err := api.OpenAPI().DowngradeYAML()
@@ -223,16 +391,56 @@ func main() {
 	if cfg.Server.EnableDocs {
 		logger.Info("OpenAPI docs available at", "url", cfg.Server.BaseURL+"/docs")
 	}
-	if err := srv.ListenAndServe(); err != nil {
-		log.Fatal("Server failed:", err)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Server failed:", err)
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Info("Shutting down...")
+
+	shutdownCtx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(cfg.Server.ShutdownTimeout)*time.Second,
+	)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to shut down server", "error", err)
+	}
+
+	// Let the relay finish its current batch, then wait for outstanding acknowledgements
+	select {
+	case <-relayDone:
+	case <-shutdownCtx.Done():
 	}
+	if err := publisher.Drain(shutdownCtx); err != nil {
+		logger.Error("Failed to drain event publisher", "error", err)
+	}
+	stats := publisher.Stats()
+	logger.Info("Event publisher drained",
+		"published", stats.Published,
+		"failed", stats.Failed,
+	)
+	schemaStats := tagService.SchemaCacheStats()
+	logger.Info("Compiled schema cache",
+		"hit_rate", schemaStats.HitRate(),
+		"compiles", schemaStats.Compiles,
+		"compile_time", schemaStats.CompileTime,
+	)
 }
 
 type App struct {
-	DocumentService *services.DocumentService
-	Logger          *slog.Logger
-	Signer          *auth.Signer
-	BaseURL         string
-	Pool            *pgxpool.Pool
-	NC              *nats.Conn
+	DocumentService  *services.DocumentService
+	UploadService    *services.UploadService
+	ImportService    *services.ImportService
+	WebhookService   *services.WebhookService
+	NamespaceService *services.NamespaceService
+	EventWatcher     *events.Watcher
+	Logger           *slog.Logger
+	Signer           *auth.Signer
+	BaseURL          string
+	Pool             *pgxpool.Pool
+	NC               *nats.Conn
 }
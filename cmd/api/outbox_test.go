@@ -0,0 +1,237 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+// unsentOutboxMessages returns the subjects and payloads of events waiting in the outbox,
+// oldest first
+func unsentOutboxMessages(t *testing.T, ta *TestApp) ([]string, [][]byte) {
+	rows, err := ta.Pool.Query(
+		context.Background(),
+		"SELECT subject, payload FROM event_outbox WHERE sent_at IS NULL ORDER BY id",
+	)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	subjects := []string{}
+	var payloads [][]byte
+	for rows.Next() {
+		var subject string
+		var payload []byte
+		require.NoError(t, rows.Scan(&subject, &payload))
+		subjects = append(subjects, subject)
+		payloads = append(payloads, payload)
+	}
+	require.NoError(t, rows.Err())
+	return subjects, payloads
+}
+
+// unsentOutboxEvents returns the subjects of events waiting in the outbox, oldest first
+func unsentOutboxEvents(t *testing.T, ta *TestApp) []string {
+	subjects, _ := unsentOutboxMessages(t, ta)
+	return subjects
+}
+
+// makeOutboxEventsDue skips the backoff of every event waiting to be retried
+func makeOutboxEventsDue(t *testing.T, ta *TestApp) {
+	_, err := ta.Pool.Exec(
+		context.Background(),
+		"UPDATE event_outbox SET next_attempt_at = NOW() WHERE sent_at IS NULL",
+	)
+	require.NoError(t, err)
+}
+
+// failingSender publishes through sender, except on subjects it can never deliver
+type failingSender struct {
+	sender      events.Sender
+	undelivered string
+}
+
+func (s *failingSender) PublishAsync(
+	subject string,
+	msgID string,
+	data []byte,
+) (events.PendingAck, error) {
+	if subject == s.undelivered {
+		return nil, errors.New("no stream matches subject")
+	}
+	return s.sender.PublishAsync(subject, msgID, data)
+}
+
+func TestEventOutbox(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "outbox-test",
+	})
+	require.NoError(t, err)
+
+	// === Events are written with the change they describe ===
+	doc := uploadTestDocument(t, ta, "outbox-test", "a.txt", "text/plain", []byte("outbox"))
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:  "outbox-test",
+		Name:       "invoice",
+		JsonSchema: stringPtr(`{"type": "object"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"wayfile.outbox-test.namespace.created",
+		"wayfile.outbox-test.documents.uploaded",
+		"wayfile.outbox-test.tags.invoice.created",
+		"wayfile.outbox-test.tags.invoice.schema_changed",
+	}, unsentOutboxEvents(t, ta))
+
+	// === Rolled back changes emit nothing ===
+	w := postDocument(t, ta, "outbox-test", "copy.txt", "text/plain", []byte("outbox"))
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Len(t, unsentOutboxEvents(t, ta), 4)
+
+	// === Events stay queued while JetStream can't accept them ===
+	sent, err := ta.Relay.RelayBatch(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, sent)
+
+	var attempts int32
+	var lastError *string
+	var nextAttemptAt time.Time
+	require.NoError(t, ta.Pool.QueryRow(
+		ctx,
+		"SELECT attempts, last_error, next_attempt_at FROM event_outbox ORDER BY id LIMIT 1",
+	).Scan(&attempts, &lastError, &nextAttemptAt))
+	require.Equal(t, int32(1), attempts)
+	require.NotNil(t, lastError)
+	require.True(t, nextAttemptAt.After(time.Now()), "retry should be backed off")
+	require.Len(t, unsentOutboxEvents(t, ta), 4)
+
+	// Failed events aren't retried until their backoff has passed
+	sent, err = ta.Relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, sent)
+	makeOutboxEventsDue(t, ta)
+
+	// === Once a stream exists, events are delivered in order and marked sent ===
+	js, err := ta.NC.JetStream()
+	require.NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     "WAYFILE_TEST",
+		Subjects: []string{"wayfile.>"},
+	})
+	require.NoError(t, err)
+
+	sub, err := js.SubscribeSync("wayfile.outbox-test.documents.*", nats.DeliverAll())
+	require.NoError(t, err)
+	defer func() { _ = sub.Unsubscribe() }()
+
+	sent, err = ta.Relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, sent)
+	require.Empty(t, unsentOutboxEvents(t, ta))
+
+	msg, err := sub.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	var uploaded eventsv1.DocumentUploadedEvent
+	require.NoError(t, proto.Unmarshal(msg.Data, &uploaded))
+	require.Equal(t, doc.ID, uploaded.DocumentId)
+	require.Equal(t, "outbox-test", uploaded.Namespace)
+	require.NotEmpty(t, uploaded.Context.GetEventId())
+	require.Equal(t, events.DefaultActor, uploaded.Context.GetActor())
+
+	// Nothing is left to relay
+	sent, err = ta.Relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, sent)
+
+	// === Events published again, e.g. after a failed commit, are deduplicated ===
+	_, err = ta.Pool.Exec(ctx, "UPDATE event_outbox SET sent_at = NULL")
+	require.NoError(t, err)
+	sent, err = ta.Relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, sent)
+
+	info, err := js.StreamInfo("WAYFILE_TEST")
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), info.State.Msgs)
+}
+
+func TestEventOutboxParksUndeliverableEvents(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	js, err := ta.NC.JetStream()
+	require.NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     "WAYFILE_TEST",
+		Subjects: []string{"wayfile.>"},
+	})
+	require.NoError(t, err)
+
+	// A relay whose batches hold a single event, so an undeliverable event at the head of
+	// the outbox would block everything behind it
+	_, err = ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "parked-test",
+	})
+	require.NoError(t, err)
+	uploadTestDocument(t, ta, "parked-test", "a.txt", "text/plain", []byte("parked"))
+	relay := events.NewRelay(ta.Pool, sqlc.New(ta.Pool), &failingSender{
+		sender:      events.NewPublisher(js),
+		undelivered: "wayfile.parked-test.namespace.created",
+	}, 1, 3, time.Hour)
+
+	// === Events behind a failing event are still delivered ===
+	sent, err := relay.RelayBatch(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, sent)
+	sent, err = relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+	require.Equal(t, []string{"wayfile.parked-test.namespace.created"}, unsentOutboxEvents(t, ta))
+
+	// === The failing event is parked once its attempts run out ===
+	for range 2 {
+		makeOutboxEventsDue(t, ta)
+		sent, err = relay.RelayBatch(ctx)
+		require.Error(t, err)
+		require.Equal(t, 0, sent)
+	}
+
+	var attempts int32
+	var parkedAt *time.Time
+	require.NoError(t, ta.Pool.QueryRow(
+		ctx,
+		"SELECT attempts, parked_at FROM event_outbox WHERE subject = $1",
+		"wayfile.parked-test.namespace.created",
+	).Scan(&attempts, &parkedAt))
+	require.Equal(t, int32(3), attempts)
+	require.NotNil(t, parkedAt)
+
+	// Parked events are never claimed again, so new events flow freely
+	makeOutboxEventsDue(t, ta)
+	sent, err = relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, sent)
+
+	uploadTestDocument(t, ta, "parked-test", "b.txt", "text/plain", []byte("flowing"))
+	sent, err = relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+}
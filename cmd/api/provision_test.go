@@ -0,0 +1,65 @@
+//go:build integration
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/RynoXLI/Wayfile/internal/config"
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+func TestProvisionJetStream(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	js, err := ta.NC.JetStream()
+	require.NoError(t, err)
+
+	streams := []config.StreamConfig{{
+		Name:     "PROVISION_TEST",
+		Subjects: []string{"provision.>"},
+		MaxAge:   3600,
+		Storage:  "memory",
+	}}
+	consumers := []config.ConsumerConfig{{
+		Stream:         "PROVISION_TEST",
+		Durable:        "worker",
+		FilterSubjects: []string{"provision.uploaded"},
+		AckWait:        30,
+		MaxDeliver:     5,
+	}}
+
+	// === Creates missing streams and consumers ===
+	require.NoError(t, events.Provision(js, streams, consumers))
+
+	stream, err := js.StreamInfo("PROVISION_TEST")
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, stream.Config.MaxAge)
+
+	consumer, err := js.ConsumerInfo("PROVISION_TEST", "worker")
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, consumer.Config.AckWait)
+
+	// === Running again is a no-op, and changed settings are applied ===
+	require.NoError(t, events.Provision(js, streams, consumers))
+
+	streams[0].MaxAge = 7200
+	consumers[0].MaxDeliver = 10
+	require.NoError(t, events.Provision(js, streams, consumers))
+
+	stream, err = js.StreamInfo("PROVISION_TEST")
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Hour, stream.Config.MaxAge)
+
+	consumer, err = js.ConsumerInfo("PROVISION_TEST", "worker")
+	require.NoError(t, err)
+	require.Equal(t, 10, consumer.Config.MaxDeliver)
+
+	// === Invalid definitions are rejected ===
+	streams[0].Retention = "forever"
+	require.Error(t, events.Provision(js, streams, nil))
+}
@@ -0,0 +1,311 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	reviewsv1 "github.com/RynoXLI/Wayfile/gen/go/reviews/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+func TestReviewQueue(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "review-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:  "review-test",
+		Name:       "invoices",
+		JsonSchema: stringPtr(`{"type": "object", "properties": {"total": {"type": "number"}}}`),
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "review-test",
+		Name:      "receipts",
+	})
+	require.NoError(t, err)
+
+	ta.Extraction.Register(&testExtractor{
+		name: "invoice-reader",
+		extract: func(content string) ([]services.ExtractedTag, error) {
+			var tags []services.ExtractedTag
+			if strings.Contains(content, "INVOICE") {
+				tags = append(tags, services.ExtractedTag{
+					TagPath:    "/invoices",
+					Attributes: map[string]any{"total": 42.5},
+					Confidence: 0.6,
+				})
+			}
+			if strings.Contains(content, "RECEIPT") {
+				tags = append(tags, services.ExtractedTag{TagPath: "/receipts", Confidence: 0.95})
+			}
+			return tags, nil
+		},
+	})
+
+	// extract uploads a document and runs the extractors over it
+	extract := func(filename string, content string) string {
+		doc := uploadTestDocument(
+			t, ta, "review-test", filename, "text/plain", []byte(content),
+		)
+		subject, payload := uploadedEvent(t, ta)
+		require.NoError(t, ta.Extraction.HandleEvent(ctx, subject, payload))
+		return doc.ID
+	}
+	listTags := func(documentID string) []string {
+		resp, err := ta.ConnectClient.ListDocumentTags(ctx, &documentsv1.ListDocumentTagsRequest{
+			Namespace:  "review-test",
+			DocumentId: documentID,
+		})
+		require.NoError(t, err)
+		var paths []string
+		for _, tag := range resp.GetTags() {
+			paths = append(paths, tag.GetTagPath())
+		}
+		return paths
+	}
+	pendingItems := func() []*reviewsv1.ReviewItem {
+		resp, err := ta.ReviewClient.ListReviewItems(ctx, &reviewsv1.ListReviewItemsRequest{
+			Namespace: "review-test",
+		})
+		require.NoError(t, err)
+		return resp.GetItems()
+	}
+
+	// === Threshold ===
+	set, err := ta.ReviewClient.SetReviewThreshold(ctx, &reviewsv1.SetReviewThresholdRequest{
+		Namespace: "review-test",
+		Threshold: float64Ptr(0.9),
+	})
+	require.NoError(t, err)
+	require.InDelta(t, 0.9, set.GetThreshold(), 1e-9)
+
+	ns, err := ta.NamespaceClient.GetNamespace(ctx, &namespacesv1.GetNamespaceRequest{
+		Name: "review-test",
+	})
+	require.NoError(t, err)
+	require.InDelta(t, 0.9, ns.GetNamespace().GetReviewThreshold(), 1e-9)
+
+	_, err = ta.ReviewClient.SetReviewThreshold(ctx, &reviewsv1.SetReviewThresholdRequest{
+		Namespace: "review-test",
+		Threshold: float64Ptr(1.5),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = ta.ReviewClient.SetReviewThreshold(ctx, &reviewsv1.SetReviewThresholdRequest{
+		Namespace: "missing-namespace",
+		Threshold: float64Ptr(0.5),
+	})
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// === Extractions below the threshold are queued, the rest applied ===
+	first := uploadTestDocument(
+		t, ta, "review-test", "first.txt", "text/plain", []byte("INVOICE and RECEIPT 1"),
+	).ID
+	subject, payload := uploadedEvent(t, ta)
+	require.NoError(t, ta.Extraction.HandleEvent(ctx, subject, payload))
+	require.Equal(t, []string{"/receipts"}, listTags(first))
+
+	items := pendingItems()
+	require.Len(t, items, 1)
+	require.Equal(t, first, items[0].GetDocumentId())
+	require.Equal(t, "/invoices", items[0].GetTagPath())
+	AssertJSONEqual(t, `{"total": 42.5}`, items[0].GetAttributes(), "queued attributes")
+	require.InDelta(t, 0.6, items[0].GetConfidence(), 1e-9)
+	require.Equal(t, "invoice-reader", items[0].GetExtractedBy())
+
+	// Extracting the document again replaces its pending item
+	require.NoError(t, ta.Extraction.HandleEvent(ctx, subject, payload))
+	require.Len(t, pendingItems(), 1)
+
+	// === Accepting applies the extraction as set by the reviewer ===
+	takeOutboxEvents(t, ta)
+	_, err = ta.ReviewClient.AcceptReviewItem(ctx, &reviewsv1.AcceptReviewItemRequest{
+		Namespace: "review-test",
+		ItemId:    items[0].GetId(),
+		Reviewer:  "alice",
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"/invoices", "/receipts"}, listTags(first))
+	require.Empty(t, pendingItems())
+
+	attrs, err := ta.ConnectClient.GetDocumentAttributes(
+		ctx,
+		&documentsv1.GetDocumentAttributesRequest{
+			Namespace:  "review-test",
+			DocumentId: first,
+			TagPath:    stringPtr("/invoices"),
+		},
+	)
+	require.NoError(t, err)
+	AssertJSONEqual(t, `{"total": 42.5}`, attrs.GetAttributes(), "accepted attributes")
+
+	var metadata services.DocumentTagMetadata
+	require.NoError(t, json.Unmarshal([]byte(attrs.GetMetadata()), &metadata))
+	require.Equal(t, services.ExtractionMethodManual, metadata.Tag.Method)
+	require.Equal(t, "alice", metadata.Tag.ExtractedBy)
+	require.Nil(t, metadata.Tag.Confidence)
+	require.Equal(t, services.ExtractionMethodManual, metadata.Attributes["total"].Method)
+	require.Equal(t, "alice", metadata.Attributes["total"].ExtractedBy)
+
+	subjects, payloads := takeOutboxEvents(t, ta)
+	require.Len(t, subjects, 1)
+	event, err := events.DecodeEvent(subjects[0], payloads[0])
+	require.NoError(t, err)
+	require.Equal(t, events.TagExtracted, event.Type)
+	require.Equal(t, "reviewer:alice", event.Context().GetActor())
+
+	// Resolved items can't be reviewed again
+	_, err = ta.ReviewClient.RejectReviewItem(ctx, &reviewsv1.RejectReviewItemRequest{
+		Namespace: "review-test",
+		ItemId:    items[0].GetId(),
+		Reviewer:  "bob",
+	})
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	// === Accepting with edits replaces the extracted attributes ===
+	second := extract("second.txt", "INVOICE 2")
+	items = pendingItems()
+	require.Len(t, items, 1)
+
+	_, err = ta.ReviewClient.AcceptReviewItem(ctx, &reviewsv1.AcceptReviewItemRequest{
+		Namespace:  "review-test",
+		ItemId:     items[0].GetId(),
+		Reviewer:   "alice",
+		Attributes: stringPtr(`{"total": "forty"}`),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.Len(t, pendingItems(), 1, "a failed accept leaves the item pending")
+
+	_, err = ta.ReviewClient.AcceptReviewItem(ctx, &reviewsv1.AcceptReviewItemRequest{
+		Namespace:  "review-test",
+		ItemId:     items[0].GetId(),
+		Reviewer:   "alice",
+		Attributes: stringPtr(`{"total": 40}`),
+	})
+	require.NoError(t, err)
+
+	attrs, err = ta.ConnectClient.GetDocumentAttributes(
+		ctx,
+		&documentsv1.GetDocumentAttributesRequest{
+			Namespace:  "review-test",
+			DocumentId: second,
+			TagPath:    stringPtr("/invoices"),
+		},
+	)
+	require.NoError(t, err)
+	AssertJSONEqual(t, `{"total": 40}`, attrs.GetAttributes(), "edited attributes")
+
+	// === Rejecting discards the extraction ===
+	third := extract("third.txt", "INVOICE 3")
+	items = pendingItems()
+	require.Len(t, items, 1)
+
+	_, err = ta.ReviewClient.RejectReviewItem(ctx, &reviewsv1.RejectReviewItemRequest{
+		Namespace: "review-test",
+		ItemId:    items[0].GetId(),
+		Reviewer:  "bob",
+	})
+	require.NoError(t, err)
+	require.Empty(t, listTags(third))
+	require.Empty(t, pendingItems())
+
+	_, err = ta.ReviewClient.AcceptReviewItem(ctx, &reviewsv1.AcceptReviewItemRequest{
+		Namespace: "review-test",
+		ItemId:    "00000000-0000-0000-0000-000000000000",
+		Reviewer:  "alice",
+	})
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// === Concurrent reviews resolve an item exactly once ===
+	for i := range 5 {
+		document := extract(fmt.Sprintf("race-%d.txt", i), fmt.Sprintf("INVOICE race %d", i))
+		items = pendingItems()
+		require.Len(t, items, 1)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 3)
+		for j, total := range []string{`{"total": 1}`, `{"total": 2}`} {
+			wg.Go(func() {
+				_, errs[j] = ta.ReviewClient.AcceptReviewItem(
+					ctx,
+					&reviewsv1.AcceptReviewItemRequest{
+						Namespace:  "review-test",
+						ItemId:     items[0].GetId(),
+						Reviewer:   "alice",
+						Attributes: stringPtr(total),
+					},
+				)
+			})
+		}
+		wg.Go(func() {
+			_, errs[2] = ta.ReviewClient.RejectReviewItem(ctx, &reviewsv1.RejectReviewItemRequest{
+				Namespace: "review-test",
+				ItemId:    items[0].GetId(),
+				Reviewer:  "bob",
+			})
+		})
+		wg.Wait()
+
+		winner := -1
+		for j, err := range errs {
+			if err == nil {
+				require.Equal(t, -1, winner, "only one review may resolve the item")
+				winner = j
+				continue
+			}
+			require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		}
+		require.NotEqual(t, -1, winner)
+
+		// The document reflects the review that won, and only that one
+		if winner == 2 {
+			require.Empty(t, listTags(document))
+			continue
+		}
+		attrs, err := ta.ConnectClient.GetDocumentAttributes(
+			ctx,
+			&documentsv1.GetDocumentAttributesRequest{
+				Namespace:  "review-test",
+				DocumentId: document,
+				TagPath:    stringPtr("/invoices"),
+			},
+		)
+		require.NoError(t, err)
+		AssertJSONEqual(t, fmt.Sprintf(`{"total": %d}`, winner+1), attrs.GetAttributes(),
+			"winning review's attributes")
+	}
+	require.Empty(t, pendingItems())
+
+	// === Clearing the threshold applies every extraction ===
+	set, err = ta.ReviewClient.SetReviewThreshold(ctx, &reviewsv1.SetReviewThresholdRequest{
+		Namespace: "review-test",
+	})
+	require.NoError(t, err)
+	require.Nil(t, set.Threshold)
+
+	fourth := extract("fourth.txt", "INVOICE 4")
+	require.Equal(t, []string{"/invoices"}, listTags(fourth))
+	require.Empty(t, pendingItems())
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
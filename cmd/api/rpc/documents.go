@@ -4,6 +4,7 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
@@ -101,15 +102,42 @@ func (s *DocumentsServiceServer) AddTagToDocument(
 		)
 	}
 
+	// Tags from an extractor are automatic and follow the precedence rules
+	extractionMethod := services.ExtractionMethodManual
+	extractedBy := "api-user" // Could be enhanced to get actual user info from context
+	if req.GetExtractor() != "" {
+		extractionMethod = services.ExtractionMethodAutomatic
+		extractedBy = req.GetExtractor()
+	} else if req.Confidence != nil {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("confidence requires an extractor"),
+		)
+	}
+	if req.Confidence != nil && (req.GetConfidence() < 0 || req.GetConfidence() > 1) {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("confidence must be between 0 and 1"),
+		)
+	}
+
+	coerce, err := coercionOptions(req.Coerce, req.Locale)
+	if err != nil {
+		return nil, err
+	}
+
 	// Add the tag to the document
-	err := s.documentService.AddTagToDocument(
+	skipped, err := s.documentService.AddTagToDocument(
 		ctx,
 		req.Namespace,
 		req.DocumentId,
 		req.TagPath,
 		req.Attributes,
-		services.ExtractionMethodManual,
-		"api-user", // Could be enhanced to get actual user info from context
+		extractionMethod,
+		extractedBy,
+		req.Confidence,
+		req.Force,
+		coerce,
 	)
 	if err != nil {
 		if errors.Is(err, services.ErrDocumentNotInNamespace) {
@@ -118,10 +146,45 @@ func (s *DocumentsServiceServer) AddTagToDocument(
 		if errors.Is(err, services.ErrTagNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, err)
 		}
+		if errors.Is(err, services.ErrAttributeValidationFailed) {
+			return nil, validationError(err)
+		}
 		return nil, err
 	}
 
-	return &documentsv1.AddTagToDocumentResponse{}, nil
+	skippedAttributes := make([]*documentsv1.SkippedAttribute, len(skipped))
+	for i, attribute := range skipped {
+		reason := documentsv1.SkipReason_SKIP_REASON_MANUAL
+		if attribute.Reason == services.SkipReasonHigherConfidence {
+			reason = documentsv1.SkipReason_SKIP_REASON_HIGHER_CONFIDENCE
+		}
+		skippedAttributes[i] = &documentsv1.SkippedAttribute{
+			Field:  attribute.Field,
+			Reason: reason,
+		}
+	}
+	return &documentsv1.AddTagToDocumentResponse{SkippedAttributes: skippedAttributes}, nil
+}
+
+// coercionOptions converts a request's coercion fields, nil if coercion is off
+func coercionOptions(coerce bool, locale *string) (*services.CoercionOptions, error) {
+	if !coerce {
+		if locale != nil {
+			return nil, connect.NewError(
+				connect.CodeInvalidArgument,
+				errors.New("locale requires coerce"),
+			)
+		}
+		return nil, nil
+	}
+	options := &services.CoercionOptions{}
+	if locale != nil {
+		options.Locale = *locale
+	}
+	if err := options.Validate(); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return options, nil
 }
 
 // RemoveTagFromDocument handles removing a tag from a document via Connect RPC
@@ -327,13 +390,42 @@ func (s *DocumentsServiceServer) UpdateDocumentAttributes(
 		tagPath = *req.TagPath
 	}
 
+	// Global attributes have no schema to coerce to
+	if req.Coerce && tagPath == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("coerce requires tag_path"),
+		)
+	}
+	coerce, err := coercionOptions(req.Coerce, req.Locale)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update the attributes
-	err := s.documentService.UpdateDocumentAttributes(
+	var mode services.AttributeUpdateMode
+	switch req.Mode {
+	case documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_UNSPECIFIED,
+		documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_REPLACE:
+		mode = services.AttributeUpdateReplace
+	case documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH:
+		mode = services.AttributeUpdateMergePatch
+	case documentsv1.AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_JSON_PATCH:
+		mode = services.AttributeUpdateJSONPatch
+	default:
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			fmt.Errorf("unknown mode %v", req.Mode),
+		)
+	}
+	err = s.documentService.UpdateDocumentAttributes(
 		ctx,
 		req.Namespace,
 		req.DocumentId,
 		tagPath,
 		req.Attributes,
+		mode,
+		coerce,
 	)
 	if err != nil {
 		if errors.Is(err, services.ErrDocumentNotInNamespace) {
@@ -342,8 +434,142 @@ func (s *DocumentsServiceServer) UpdateDocumentAttributes(
 		if errors.Is(err, services.ErrTagNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, err)
 		}
+		if errors.Is(err, services.ErrAttributeValidationFailed) {
+			return nil, validationError(err)
+		}
 		return nil, err
 	}
 
 	return &documentsv1.UpdateDocumentAttributesResponse{}, nil
 }
+
+// GetAttributeHistory handles listing the changes to a document's attributes via Connect RPC
+func (s *DocumentsServiceServer) GetAttributeHistory(
+	ctx context.Context,
+	req *documentsv1.GetAttributeHistoryRequest,
+) (*documentsv1.GetAttributeHistoryResponse, error) {
+	// Validate required fields
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+	if req.DocumentId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("document_id is required"),
+		)
+	}
+
+	// Get the history, of the document global attributes if no tag_path is given
+	history, err := s.documentService.GetAttributeHistory(
+		ctx,
+		req.Namespace,
+		req.DocumentId,
+		req.GetTagPath(),
+		req.GetField(),
+	)
+	if err != nil {
+		if errors.Is(err, services.ErrDocumentNotInNamespace) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		if errors.Is(err, services.ErrTagNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+
+	// Convert to protobuf response
+	changes := make([]*documentsv1.AttributeChange, len(history))
+	for i, entry := range history {
+		change := &documentsv1.AttributeChange{
+			Field:            entry.Field,
+			SchemaVersion:    entry.SchemaVersion,
+			Actor:            entry.Actor,
+			ExtractionMethod: entry.ExtractionMethod,
+			ExtractedBy:      entry.ExtractedBy,
+			ChangedAt:        timestamppb.New(entry.ChangedAt.Time),
+		}
+		if entry.OldValue != nil {
+			oldValue := string(entry.OldValue)
+			change.OldValue = &oldValue
+		}
+		if entry.NewValue != nil {
+			newValue := string(entry.NewValue)
+			change.NewValue = &newValue
+		}
+		changes[i] = change
+	}
+
+	return &documentsv1.GetAttributeHistoryResponse{Changes: changes}, nil
+}
+
+// SearchDocuments handles searching documents by their attributes via Connect RPC
+func (s *DocumentsServiceServer) SearchDocuments(
+	ctx context.Context,
+	req *documentsv1.SearchDocumentsRequest,
+) (*documentsv1.SearchDocumentsResponse, error) {
+	// Validate required fields
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+
+	filters := make([]services.AttributeFilter, len(req.Filters))
+	for i, filter := range req.Filters {
+		var operator services.AttributeFilterOperator
+		switch filter.GetOperator() {
+		case documentsv1.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED,
+			documentsv1.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_EQUALS:
+			operator = services.AttributeFilterEquals
+		case documentsv1.AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_CONTAINS:
+			operator = services.AttributeFilterContains
+		default:
+			return nil, connect.NewError(
+				connect.CodeInvalidArgument,
+				fmt.Errorf("unknown operator %v", filter.GetOperator()),
+			)
+		}
+		filters[i] = services.AttributeFilter{
+			Field:    filter.GetField(),
+			Operator: operator,
+			Value:    filter.GetValue(),
+		}
+	}
+
+	// Search global attributes if no tag_path is given
+	matches, err := s.documentService.SearchDocuments(
+		ctx,
+		req.Namespace,
+		req.GetTagPath(),
+		filters,
+	)
+	if err != nil {
+		if errors.Is(err, services.ErrTagNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+
+	// Convert to protobuf response
+	documents := make([]*documentsv1.DocumentMatch, len(matches))
+	for i, match := range matches {
+		document := &documentsv1.DocumentMatch{
+			DocumentId: match.ID.String(),
+			FileName:   match.FileName,
+			Title:      match.Title,
+			MimeType:   match.MimeType,
+			CreatedAt:  timestamppb.New(match.CreatedAt.Time),
+		}
+		if len(match.Attributes) > 0 {
+			attributes := string(match.Attributes)
+			document.Attributes = &attributes
+		}
+		documents[i] = document
+	}
+
+	return &documentsv1.SearchDocumentsResponse{Documents: documents}, nil
+}
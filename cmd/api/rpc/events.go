@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// EventServiceServer implements the Connect RPC EventService
+type EventServiceServer struct {
+	watcher          *events.Watcher
+	namespaceService *services.NamespaceService
+}
+
+// NewEventServiceServer creates a new Connect RPC service for watching events
+func NewEventServiceServer(
+	watcher *events.Watcher,
+	namespaceService *services.NamespaceService,
+) *EventServiceServer {
+	return &EventServiceServer{
+		watcher:          watcher,
+		namespaceService: namespaceService,
+	}
+}
+
+// WatchEvents streams a namespace's events via Connect RPC until the client disconnects
+func (s *EventServiceServer) WatchEvents(
+	ctx context.Context,
+	req *eventsv1.WatchEventsRequest,
+	stream *connect.ServerStream[eventsv1.WatchEventsResponse],
+) error {
+	if req.Namespace == "" {
+		return connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+	if _, err := s.namespaceService.GetNamespace(ctx, req.Namespace); err != nil {
+		return connect.NewError(connect.CodeNotFound, services.ErrNamespaceNotFound)
+	}
+
+	watched, err := s.watcher.Watch(ctx, req.Namespace, req.AfterSequence)
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+	for event := range watched {
+		if err := stream.Send(convertEventToProto(event)); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// convertEventToProto wraps a streamed event in a WatchEventsResponse
+func convertEventToProto(event events.StreamedEvent) *eventsv1.WatchEventsResponse {
+	resp := &eventsv1.WatchEventsResponse{
+		Sequence:  event.Sequence,
+		EventType: event.Type,
+	}
+	switch m := event.Message.(type) {
+	case *eventsv1.DocumentUploadedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_DocumentUploaded{DocumentUploaded: m}
+	case *eventsv1.DocumentUpdatedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_DocumentUpdated{DocumentUpdated: m}
+	case *eventsv1.DocumentDeletedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_DocumentDeleted{DocumentDeleted: m}
+	case *eventsv1.AttributesUpdatedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_AttributesUpdated{AttributesUpdated: m}
+	case *eventsv1.SchemaChangedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_SchemaChanged{SchemaChanged: m}
+	case *eventsv1.TagExtractedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_TagExtracted{TagExtracted: m}
+	case *eventsv1.TagRemovedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_TagRemoved{TagRemoved: m}
+	case *eventsv1.TagCreatedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_TagCreated{TagCreated: m}
+	case *eventsv1.TagUpdatedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_TagUpdated{TagUpdated: m}
+	case *eventsv1.TagDeletedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_TagDeleted{TagDeleted: m}
+	case *eventsv1.NamespaceCreatedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_NamespaceCreated{NamespaceCreated: m}
+	case *eventsv1.NamespaceDeletedEvent:
+		resp.Event = &eventsv1.WatchEventsResponse_NamespaceDeleted{NamespaceDeleted: m}
+	}
+	return resp
+}
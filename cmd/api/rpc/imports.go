@@ -0,0 +1,164 @@
+// Package rpc implements Connect RPC service handlers
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	importsv1 "github.com/RynoXLI/Wayfile/gen/go/imports/v1"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// Import entry page sizes
+const (
+	defaultImportEntriesLimit = 100
+	maxImportEntriesLimit     = 1000
+)
+
+// ImportServiceServer implements the Connect RPC ImportService
+type ImportServiceServer struct {
+	service *services.ImportService
+}
+
+// NewImportServiceServer creates a new Connect RPC service for imports
+func NewImportServiceServer(service *services.ImportService) *ImportServiceServer {
+	return &ImportServiceServer{
+		service: service,
+	}
+}
+
+// GetImport retrieves the status and progress of an import via Connect RPC
+func (s *ImportServiceServer) GetImport(
+	ctx context.Context,
+	req *importsv1.GetImportRequest,
+) (*importsv1.GetImportResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+	if req.ImportId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("import_id is required"),
+		)
+	}
+
+	job, err := s.service.GetImport(ctx, req.Namespace, req.ImportId)
+	if err != nil {
+		return nil, importError(err)
+	}
+
+	return &importsv1.GetImportResponse{
+		Import: convertImportToProto(job),
+	}, nil
+}
+
+// ListImportEntries retrieves the per-entry results of an import via Connect RPC
+func (s *ImportServiceServer) ListImportEntries(
+	ctx context.Context,
+	req *importsv1.ListImportEntriesRequest,
+) (*importsv1.ListImportEntriesResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+	if req.ImportId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("import_id is required"),
+		)
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("limit and offset must not be negative"),
+		)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultImportEntriesLimit
+	}
+	limit = min(limit, maxImportEntriesLimit)
+
+	entries, err := s.service.ListImportEntries(
+		ctx,
+		req.Namespace,
+		req.ImportId,
+		limit,
+		req.Offset,
+	)
+	if err != nil {
+		return nil, importError(err)
+	}
+
+	protoEntries := make([]*importsv1.ImportEntry, 0, len(entries))
+	for _, entry := range entries {
+		protoEntries = append(protoEntries, &importsv1.ImportEntry{
+			Path:       entry.Path,
+			Status:     entry.Status,
+			DocumentId: optionalUUID(entry.DocumentID),
+			TagPath:    entry.TagPath,
+			Error:      entry.Error,
+		})
+	}
+
+	return &importsv1.ListImportEntriesResponse{
+		Entries: protoEntries,
+	}, nil
+}
+
+// importError maps import service errors to Connect errors
+func importError(err error) error {
+	if errors.Is(err, services.ErrNamespaceNotFound) ||
+		errors.Is(err, services.ErrImportNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// convertImportToProto converts an import job to its protobuf representation
+func convertImportToProto(job *sqlc.ImportJob) *importsv1.Import {
+	result := &importsv1.Import{
+		Id:               job.ID.String(),
+		FileName:         job.FileName,
+		Format:           job.Format,
+		TagPath:          job.TagPath,
+		Status:           job.Status,
+		ArchiveSize:      job.ArchiveSize,
+		BytesProcessed:   job.BytesProcessed,
+		TotalEntries:     job.TotalEntries,
+		ProcessedEntries: job.ProcessedEntries,
+		CreatedCount:     job.CreatedCount,
+		DuplicateCount:   job.DuplicateCount,
+		SkippedCount:     job.SkippedCount,
+		FailedCount:      job.FailedCount,
+		Error:            job.Error,
+		CreatedAt:        timestamppb.New(job.CreatedAt.Time),
+	}
+	if job.StartedAt.Valid {
+		result.StartedAt = timestamppb.New(job.StartedAt.Time)
+	}
+	if job.CompletedAt.Valid {
+		result.CompletedAt = timestamppb.New(job.CompletedAt.Time)
+	}
+	return result
+}
+
+// optionalUUID returns the string form of a nullable UUID
+func optionalUUID(id pgtype.UUID) *string {
+	if !id.Valid {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
@@ -46,10 +46,11 @@ func (s *NamespaceServiceServer) CreateNamespace(
 
 	return &namespacesv1.CreateNamespaceResponse{
 		Namespace: &namespacesv1.Namespace{
-			Id:         namespace.ID.String(),
-			Name:       namespace.Name,
-			CreatedAt:  timestamppb.New(namespace.CreatedAt.Time),
-			ModifiedAt: timestamppb.New(namespace.ModifiedAt.Time),
+			Id:              namespace.ID.String(),
+			Name:            namespace.Name,
+			CreatedAt:       timestamppb.New(namespace.CreatedAt.Time),
+			ModifiedAt:      timestamppb.New(namespace.ModifiedAt.Time),
+			ReviewThreshold: namespace.ReviewThreshold,
 		},
 	}, nil
 }
@@ -69,10 +70,11 @@ func (s *NamespaceServiceServer) ListNamespaces(
 	pbNamespaces := make([]*namespacesv1.Namespace, len(namespaces))
 	for i, ns := range namespaces {
 		pbNamespaces[i] = &namespacesv1.Namespace{
-			Id:         ns.ID.String(),
-			Name:       ns.Name,
-			CreatedAt:  timestamppb.New(ns.CreatedAt.Time),
-			ModifiedAt: timestamppb.New(ns.ModifiedAt.Time),
+			Id:              ns.ID.String(),
+			Name:            ns.Name,
+			CreatedAt:       timestamppb.New(ns.CreatedAt.Time),
+			ModifiedAt:      timestamppb.New(ns.ModifiedAt.Time),
+			ReviewThreshold: ns.ReviewThreshold,
 		}
 	}
 
@@ -105,10 +107,11 @@ func (s *NamespaceServiceServer) GetNamespace(
 
 	return &namespacesv1.GetNamespaceResponse{
 		Namespace: &namespacesv1.Namespace{
-			Id:         namespace.ID.String(),
-			Name:       namespace.Name,
-			CreatedAt:  timestamppb.New(namespace.CreatedAt.Time),
-			ModifiedAt: timestamppb.New(namespace.ModifiedAt.Time),
+			Id:              namespace.ID.String(),
+			Name:            namespace.Name,
+			CreatedAt:       timestamppb.New(namespace.CreatedAt.Time),
+			ModifiedAt:      timestamppb.New(namespace.ModifiedAt.Time),
+			ReviewThreshold: namespace.ReviewThreshold,
 		},
 	}, nil
 }
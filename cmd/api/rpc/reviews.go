@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	reviewsv1 "github.com/RynoXLI/Wayfile/gen/go/reviews/v1"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// ReviewServiceServer implements the Connect RPC ReviewService
+type ReviewServiceServer struct {
+	service *services.ReviewService
+}
+
+// NewReviewServiceServer creates a new Connect RPC service for the review queue
+func NewReviewServiceServer(service *services.ReviewService) *ReviewServiceServer {
+	return &ReviewServiceServer{
+		service: service,
+	}
+}
+
+// SetReviewThreshold sets a namespace's review threshold via Connect RPC
+func (s *ReviewServiceServer) SetReviewThreshold(
+	ctx context.Context,
+	req *reviewsv1.SetReviewThresholdRequest,
+) (*reviewsv1.SetReviewThresholdResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+
+	threshold, err := s.service.SetReviewThreshold(ctx, req.Namespace, req.Threshold)
+	if err != nil {
+		return nil, reviewError(err)
+	}
+
+	return &reviewsv1.SetReviewThresholdResponse{
+		Threshold: threshold,
+	}, nil
+}
+
+// ListReviewItems retrieves a namespace's pending review items via Connect RPC
+func (s *ReviewServiceServer) ListReviewItems(
+	ctx context.Context,
+	req *reviewsv1.ListReviewItemsRequest,
+) (*reviewsv1.ListReviewItemsResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+
+	items, err := s.service.ListReviewItems(ctx, req.Namespace)
+	if err != nil {
+		return nil, reviewError(err)
+	}
+
+	protoItems := make([]*reviewsv1.ReviewItem, 0, len(items))
+	for _, item := range items {
+		protoItem := &reviewsv1.ReviewItem{
+			Id:          item.ID.String(),
+			DocumentId:  item.DocumentID.String(),
+			TagPath:     item.TagPath,
+			Confidence:  item.Confidence,
+			ExtractedBy: item.ExtractedBy,
+			CreatedAt:   timestamppb.New(item.CreatedAt.Time),
+		}
+		if len(item.Attributes) > 0 {
+			attributes := string(item.Attributes)
+			protoItem.Attributes = &attributes
+		}
+		protoItems = append(protoItems, protoItem)
+	}
+
+	return &reviewsv1.ListReviewItemsResponse{
+		Items: protoItems,
+	}, nil
+}
+
+// AcceptReviewItem applies a pending extraction via Connect RPC
+func (s *ReviewServiceServer) AcceptReviewItem(
+	ctx context.Context,
+	req *reviewsv1.AcceptReviewItemRequest,
+) (*reviewsv1.AcceptReviewItemResponse, error) {
+	if req.Namespace == "" || req.ItemId == "" || req.Reviewer == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace, item_id and reviewer are required"),
+		)
+	}
+
+	err := s.service.AcceptReviewItem(
+		ctx,
+		req.Namespace,
+		req.ItemId,
+		req.Reviewer,
+		req.Attributes,
+	)
+	if err != nil {
+		return nil, reviewError(err)
+	}
+
+	return &reviewsv1.AcceptReviewItemResponse{}, nil
+}
+
+// RejectReviewItem discards a pending extraction via Connect RPC
+func (s *ReviewServiceServer) RejectReviewItem(
+	ctx context.Context,
+	req *reviewsv1.RejectReviewItemRequest,
+) (*reviewsv1.RejectReviewItemResponse, error) {
+	if req.Namespace == "" || req.ItemId == "" || req.Reviewer == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace, item_id and reviewer are required"),
+		)
+	}
+
+	err := s.service.RejectReviewItem(ctx, req.Namespace, req.ItemId, req.Reviewer)
+	if err != nil {
+		return nil, reviewError(err)
+	}
+
+	return &reviewsv1.RejectReviewItemResponse{}, nil
+}
+
+// reviewError maps review queue service errors to Connect errors. Errors from applying an
+// accepted extraction are already gRPC status errors, which Connect understands.
+func reviewError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrAttributeValidationFailed):
+		return validationError(err)
+	case errors.Is(err, services.ErrNamespaceNotFound),
+		errors.Is(err, services.ErrReviewItemNotFound),
+		errors.Is(err, services.ErrTagNotFound),
+		errors.Is(err, services.ErrDocumentNotInNamespace):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, services.ErrInvalidReviewThreshold):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, services.ErrReviewItemResolved):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return err
+}
@@ -0,0 +1,315 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	rulesv1 "github.com/RynoXLI/Wayfile/gen/go/rules/v1"
+	"github.com/RynoXLI/Wayfile/internal/services"
+	"github.com/RynoXLI/Wayfile/internal/storage"
+)
+
+// RuleServiceServer implements the Connect RPC RuleService
+type RuleServiceServer struct {
+	service *services.RuleService
+}
+
+// NewRuleServiceServer creates a new Connect RPC service for tagging rules
+func NewRuleServiceServer(service *services.RuleService) *RuleServiceServer {
+	return &RuleServiceServer{
+		service: service,
+	}
+}
+
+// CreateRule handles tagging rule creation via Connect RPC
+func (s *RuleServiceServer) CreateRule(
+	ctx context.Context,
+	req *rulesv1.CreateRuleRequest,
+) (*rulesv1.CreateRuleResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	input, err := convertRuleFromProto(
+		req.Name,
+		enabled,
+		req.Priority,
+		req.Conditions,
+		req.Action,
+	)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	rule, err := s.service.CreateRule(ctx, req.Namespace, input)
+	if err != nil {
+		return nil, ruleError(err)
+	}
+
+	return &rulesv1.CreateRuleResponse{
+		Rule: convertRuleToProto(rule),
+	}, nil
+}
+
+// GetRule retrieves a tagging rule via Connect RPC
+func (s *RuleServiceServer) GetRule(
+	ctx context.Context,
+	req *rulesv1.GetRuleRequest,
+) (*rulesv1.GetRuleResponse, error) {
+	if req.Namespace == "" || req.RuleId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace and rule_id are required"),
+		)
+	}
+
+	rule, err := s.service.GetRule(ctx, req.Namespace, req.RuleId)
+	if err != nil {
+		return nil, ruleError(err)
+	}
+
+	return &rulesv1.GetRuleResponse{
+		Rule: convertRuleToProto(rule),
+	}, nil
+}
+
+// ListRules retrieves the tagging rules in a namespace via Connect RPC
+func (s *RuleServiceServer) ListRules(
+	ctx context.Context,
+	req *rulesv1.ListRulesRequest,
+) (*rulesv1.ListRulesResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+
+	rules, err := s.service.ListRules(ctx, req.Namespace)
+	if err != nil {
+		return nil, ruleError(err)
+	}
+
+	protoRules := make([]*rulesv1.Rule, 0, len(rules))
+	for _, rule := range rules {
+		protoRules = append(protoRules, convertRuleToProto(rule))
+	}
+
+	return &rulesv1.ListRulesResponse{
+		Rules: protoRules,
+	}, nil
+}
+
+// UpdateRule replaces a tagging rule's definition via Connect RPC
+func (s *RuleServiceServer) UpdateRule(
+	ctx context.Context,
+	req *rulesv1.UpdateRuleRequest,
+) (*rulesv1.UpdateRuleResponse, error) {
+	if req.Namespace == "" || req.RuleId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace and rule_id are required"),
+		)
+	}
+
+	input, err := convertRuleFromProto(
+		req.Name,
+		req.Enabled,
+		req.Priority,
+		req.Conditions,
+		req.Action,
+	)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	rule, err := s.service.UpdateRule(ctx, req.Namespace, req.RuleId, input)
+	if err != nil {
+		return nil, ruleError(err)
+	}
+
+	return &rulesv1.UpdateRuleResponse{
+		Rule: convertRuleToProto(rule),
+	}, nil
+}
+
+// DeleteRule handles tagging rule deletion via Connect RPC
+func (s *RuleServiceServer) DeleteRule(
+	ctx context.Context,
+	req *rulesv1.DeleteRuleRequest,
+) (*rulesv1.DeleteRuleResponse, error) {
+	if req.Namespace == "" || req.RuleId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace and rule_id are required"),
+		)
+	}
+
+	if err := s.service.DeleteRule(ctx, req.Namespace, req.RuleId); err != nil {
+		return nil, ruleError(err)
+	}
+
+	return &rulesv1.DeleteRuleResponse{}, nil
+}
+
+// TestRule evaluates a tagging rule against a document via Connect RPC
+func (s *RuleServiceServer) TestRule(
+	ctx context.Context,
+	req *rulesv1.TestRuleRequest,
+) (*rulesv1.TestRuleResponse, error) {
+	if req.Namespace == "" || req.RuleId == "" || req.DocumentId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace, rule_id and document_id are required"),
+		)
+	}
+	if _, err := uuid.Parse(req.DocumentId); err != nil {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("invalid document_id format"),
+		)
+	}
+
+	match, err := s.service.TestRule(ctx, req.Namespace, req.RuleId, req.DocumentId)
+	if err != nil {
+		return nil, ruleError(err)
+	}
+
+	resp := &rulesv1.TestRuleResponse{
+		Matched: match.Matched,
+		Unmet:   match.Unmet,
+		TagPath: match.TagPath,
+	}
+	if match.Attributes != nil {
+		attributes, err := json.Marshal(match.Attributes)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		attributesStr := string(attributes)
+		resp.Attributes = &attributesStr
+	}
+	return resp, nil
+}
+
+// ruleError maps tagging rule service errors to Connect errors
+func ruleError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrNamespaceNotFound),
+		errors.Is(err, services.ErrRuleNotFound),
+		errors.Is(err, storage.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, services.ErrInvalidRule):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, services.ErrRuleAlreadyExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// convertRuleFromProto converts a rule definition from its protobuf representation
+func convertRuleFromProto(
+	name string,
+	enabled bool,
+	priority int32,
+	conditions *rulesv1.RuleConditions,
+	action *rulesv1.RuleAction,
+) (*services.RuleInput, error) {
+	if conditions == nil {
+		return nil, errors.New("conditions are required")
+	}
+	if action == nil {
+		return nil, errors.New("action is required")
+	}
+
+	input := &services.RuleInput{
+		Name:     name,
+		Enabled:  enabled,
+		Priority: priority,
+		Conditions: services.RuleConditions{
+			FilenameGlob:  conditions.FilenameGlob,
+			FilenameRegex: conditions.FilenameRegex,
+			MimeType:      conditions.MimeType,
+			MinSize:       conditions.MinSize,
+			MaxSize:       conditions.MaxSize,
+			TextRegex:     conditions.TextRegex,
+		},
+		Action: services.RuleAction{
+			TagPath:  action.TagPath,
+			Captures: action.Captures,
+		},
+	}
+	for _, condition := range conditions.Attributes {
+		attributeCondition := services.AttributeCondition{
+			Key:     condition.Key,
+			Matches: condition.Matches,
+		}
+		if condition.Equals != nil {
+			attributeCondition.Equals = json.RawMessage(*condition.Equals)
+		}
+		input.Conditions.Attributes = append(input.Conditions.Attributes, attributeCondition)
+	}
+	if action.Attributes != nil {
+		if err := json.Unmarshal([]byte(*action.Attributes), &input.Action.Attributes); err != nil {
+			return nil, fmt.Errorf("action attributes must be a JSON object: %w", err)
+		}
+	}
+	return input, nil
+}
+
+// convertRuleToProto converts a tagging rule to its protobuf representation
+func convertRuleToProto(rule *services.TaggingRule) *rulesv1.Rule {
+	conditions := &rulesv1.RuleConditions{
+		FilenameGlob:  rule.Conditions.FilenameGlob,
+		FilenameRegex: rule.Conditions.FilenameRegex,
+		MimeType:      rule.Conditions.MimeType,
+		MinSize:       rule.Conditions.MinSize,
+		MaxSize:       rule.Conditions.MaxSize,
+		TextRegex:     rule.Conditions.TextRegex,
+	}
+	for _, condition := range rule.Conditions.Attributes {
+		attributeCondition := &rulesv1.AttributeCondition{
+			Key:     condition.Key,
+			Matches: condition.Matches,
+		}
+		if len(condition.Equals) > 0 {
+			equals := string(condition.Equals)
+			attributeCondition.Equals = &equals
+		}
+		conditions.Attributes = append(conditions.Attributes, attributeCondition)
+	}
+
+	action := &rulesv1.RuleAction{
+		TagPath:  rule.Action.TagPath,
+		Captures: rule.Action.Captures,
+	}
+	if len(rule.Action.Attributes) > 0 {
+		if attributes, err := json.Marshal(rule.Action.Attributes); err == nil {
+			attributesStr := string(attributes)
+			action.Attributes = &attributesStr
+		}
+	}
+
+	return &rulesv1.Rule{
+		Id:         rule.ID.String(),
+		Name:       rule.Name,
+		Enabled:    rule.Enabled,
+		Priority:   rule.Priority,
+		Conditions: conditions,
+		Action:     action,
+		CreatedAt:  timestamppb.New(rule.CreatedAt),
+		ModifiedAt: timestamppb.New(rule.ModifiedAt),
+	}
+}
@@ -3,13 +3,14 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
-	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
 	"github.com/RynoXLI/Wayfile/internal/services"
 )
 
@@ -46,6 +47,15 @@ func (s *TagServiceServer) CreateTag(
 		)
 	}
 
+	compatibility, err := schemaCompatibilityFromProto(req.SchemaCompatibility)
+	if err != nil {
+		return nil, err
+	}
+	var createCompatibility services.SchemaCompatibility
+	if compatibility != nil {
+		createCompatibility = *compatibility
+	}
+
 	// Create the tag via service
 	result, err := s.service.CreateTag(
 		ctx,
@@ -55,6 +65,8 @@ func (s *TagServiceServer) CreateTag(
 		req.ParentPath,
 		req.Color,
 		req.JsonSchema,
+		req.InheritSchema,
+		createCompatibility,
 	)
 	if err != nil {
 		if errors.Is(err, services.ErrNamespaceNotFound) {
@@ -70,14 +82,17 @@ func (s *TagServiceServer) CreateTag(
 			errors.Is(err, services.ErrInvalidTagName) ||
 			errors.Is(err, services.ErrInvalidParentName) ||
 			errors.Is(err, services.ErrInvalidColor) ||
-			errors.Is(err, services.ErrInvalidJSONSchema) {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			errors.Is(err, services.ErrInvalidJSONSchema) ||
+			errors.Is(err, services.ErrNestedTypesNotAllowed) ||
+			errors.Is(err, services.ErrSchemaInheritanceConflict) ||
+			errors.Is(err, services.ErrInvalidSchemaCompatibility) {
+			return nil, validationError(err)
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
 	return &tagsv1.CreateTagResponse{
-		Tag: s.convertTagToProto(ctx, result.Tag, result.Schema, req.Namespace),
+		Tag: s.convertTagToProto(ctx, result, req.Namespace),
 	}, nil
 }
 
@@ -115,7 +130,7 @@ func (s *TagServiceServer) GetTag(
 	}
 
 	return &tagsv1.GetTagResponse{
-		Tag: s.convertTagToProto(ctx, result.Tag, result.Schema, req.Namespace),
+		Tag: s.convertTagToProto(ctx, result, req.Namespace),
 	}, nil
 }
 
@@ -144,7 +159,7 @@ func (s *TagServiceServer) ListTags(
 	// Convert to protobuf format
 	pbTags := make([]*tagsv1.Tag, len(results))
 	for i, result := range results {
-		pbTags[i] = s.convertTagToProto(ctx, result.Tag, result.Schema, req.Namespace)
+		pbTags[i] = s.convertTagToProto(ctx, result, req.Namespace)
 	}
 
 	return &tagsv1.ListTagsResponse{
@@ -173,6 +188,11 @@ func (s *TagServiceServer) UpdateTag(
 		)
 	}
 
+	compatibility, err := schemaCompatibilityFromProto(req.SchemaCompatibility)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update the tag via service
 	result, err := s.service.UpdateTag(
 		ctx,
@@ -183,6 +203,8 @@ func (s *TagServiceServer) UpdateTag(
 		req.ParentPath,
 		req.Color,
 		req.JsonSchema,
+		req.InheritSchema,
+		compatibility,
 	)
 	if err != nil {
 		if errors.Is(err, services.ErrNamespaceNotFound) {
@@ -197,18 +219,24 @@ func (s *TagServiceServer) UpdateTag(
 		if errors.Is(err, services.ErrTagAlreadyExists) {
 			return nil, connect.NewError(connect.CodeAlreadyExists, err)
 		}
+		if errors.Is(err, services.ErrIncompatibleSchema) {
+			return nil, incompatibleSchemaError(err)
+		}
 		if errors.Is(err, services.ErrInvalidParentReference) ||
 			errors.Is(err, services.ErrInvalidTagName) ||
 			errors.Is(err, services.ErrInvalidParentName) ||
 			errors.Is(err, services.ErrInvalidColor) ||
-			errors.Is(err, services.ErrInvalidJSONSchema) {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			errors.Is(err, services.ErrInvalidJSONSchema) ||
+			errors.Is(err, services.ErrNestedTypesNotAllowed) ||
+			errors.Is(err, services.ErrSchemaInheritanceConflict) ||
+			errors.Is(err, services.ErrInvalidSchemaCompatibility) {
+			return nil, validationError(err)
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
 	return &tagsv1.UpdateTagResponse{
-		Tag: s.convertTagToProto(ctx, result.Tag, result.Schema, req.Namespace),
+		Tag: s.convertTagToProto(ctx, result, req.Namespace),
 	}, nil
 }
 
@@ -248,18 +276,22 @@ func (s *TagServiceServer) DeleteTag(
 	return &tagsv1.DeleteTagResponse{}, nil
 }
 
-// convertTagToProto converts a sqlc Tag to a protobuf Tag
+// convertTagToProto converts a tag with its schemas to a protobuf Tag
 func (s *TagServiceServer) convertTagToProto(
 	_ context.Context,
-	tag sqlc.Tag,
-	schema *sqlc.AttributeSchema,
+	result *services.TagWithSchema,
 	_ string,
 ) *tagsv1.Tag {
+	tag, schema := result.Tag, result.Schema
 	pbTag := &tagsv1.Tag{
-		Name:       tag.Name,
-		Path:       tag.Path,
-		CreatedAt:  timestamppb.New(tag.CreatedAt.Time),
-		ModifiedAt: timestamppb.New(tag.ModifiedAt.Time),
+		Name:          tag.Name,
+		Path:          tag.Path,
+		CreatedAt:     timestamppb.New(tag.CreatedAt.Time),
+		ModifiedAt:    timestamppb.New(tag.ModifiedAt.Time),
+		InheritSchema: tag.InheritSchema,
+		SchemaCompatibility: schemaCompatibilityToProto(
+			services.SchemaCompatibility(tag.SchemaCompatibility),
+		),
 	}
 
 	if tag.Description != nil {
@@ -276,6 +308,117 @@ func (s *TagServiceServer) convertTagToProto(
 		schemaStr := string(schema.JsonSchema)
 		pbTag.JsonSchema = &schemaStr
 	}
+	if result.EffectiveSchema != nil {
+		effectiveSchemaStr := string(result.EffectiveSchema)
+		pbTag.EffectiveJsonSchema = &effectiveSchemaStr
+	}
 
 	return pbTag
 }
+
+// schemaCompatibilityFromProto converts a protobuf compatibility mode, nil if unspecified
+func schemaCompatibilityFromProto(
+	compatibility tagsv1.SchemaCompatibility,
+) (*services.SchemaCompatibility, error) {
+	var mode services.SchemaCompatibility
+	switch compatibility {
+	case tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_UNSPECIFIED:
+		return nil, nil
+	case tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_NONE:
+		mode = services.SchemaCompatibilityNone
+	case tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_BACKWARD:
+		mode = services.SchemaCompatibilityBackward
+	case tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_FORWARD:
+		mode = services.SchemaCompatibilityForward
+	case tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_FULL:
+		mode = services.SchemaCompatibilityFull
+	default:
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			fmt.Errorf("unknown schema compatibility %v", compatibility),
+		)
+	}
+	return &mode, nil
+}
+
+// schemaCompatibilityToProto converts a compatibility mode to protobuf
+func schemaCompatibilityToProto(mode services.SchemaCompatibility) tagsv1.SchemaCompatibility {
+	switch mode {
+	case services.SchemaCompatibilityBackward:
+		return tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_BACKWARD
+	case services.SchemaCompatibilityForward:
+		return tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_FORWARD
+	case services.SchemaCompatibilityFull:
+		return tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_FULL
+	default:
+		return tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_NONE
+	}
+}
+
+// validationError maps a failed validation to an InvalidArgument error, detailing its
+// violations if the service reported them
+func validationError(err error) error {
+	connectErr := connect.NewError(connect.CodeInvalidArgument, err)
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		return connectErr
+	}
+
+	details := &tagsv1.ValidationErrorDetails{
+		Violations: make([]*tagsv1.ValidationViolation, len(validationErr.Violations)),
+	}
+	for i, violation := range validationErr.Violations {
+		details.Violations[i] = &tagsv1.ValidationViolation{
+			Pointer:  violation.Pointer,
+			Keyword:  violation.Keyword,
+			Expected: encodeViolationValue(violation.Expected),
+			Actual:   encodeViolationValue(violation.Actual),
+			Message:  violation.Message,
+		}
+	}
+	if detail, detailErr := connect.NewErrorDetail(details); detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// incompatibleSchemaError maps a rejected schema version to a FailedPrecondition error,
+// detailing the changes that break compatibility
+func incompatibleSchemaError(err error) error {
+	connectErr := connect.NewError(connect.CodeFailedPrecondition, err)
+	var incompatibleErr *services.IncompatibleSchemaError
+	if !errors.As(err, &incompatibleErr) {
+		return connectErr
+	}
+
+	details := &tagsv1.SchemaCompatibilityErrorDetails{
+		Violations: make(
+			[]*tagsv1.SchemaCompatibilityViolation,
+			len(incompatibleErr.Violations),
+		),
+	}
+	for i, violation := range incompatibleErr.Violations {
+		details.Violations[i] = &tagsv1.SchemaCompatibilityViolation{
+			Field:         violation.Field,
+			Compatibility: schemaCompatibilityToProto(violation.Compatibility),
+			Message:       violation.Message,
+		}
+	}
+	if detail, detailErr := connect.NewErrorDetail(details); detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// encodeViolationValue encodes a violation's expected or actual value as JSON, nil if unset
+func encodeViolationValue(value interface{}) *string {
+	if value == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	s := string(encoded)
+	return &s
+}
@@ -0,0 +1,247 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	webhooksv1 "github.com/RynoXLI/Wayfile/gen/go/webhooks/v1"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// Webhook delivery page sizes
+const (
+	defaultWebhookDeliveriesLimit = 100
+	maxWebhookDeliveriesLimit     = 1000
+)
+
+// WebhookServiceServer implements the Connect RPC WebhookService
+type WebhookServiceServer struct {
+	service *services.WebhookService
+}
+
+// NewWebhookServiceServer creates a new Connect RPC service for webhooks
+func NewWebhookServiceServer(service *services.WebhookService) *WebhookServiceServer {
+	return &WebhookServiceServer{
+		service: service,
+	}
+}
+
+// CreateWebhook handles webhook creation via Connect RPC
+func (s *WebhookServiceServer) CreateWebhook(
+	ctx context.Context,
+	req *webhooksv1.CreateWebhookRequest,
+) (*webhooksv1.CreateWebhookResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+	if req.Url == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("url is required"),
+		)
+	}
+
+	webhook, err := s.service.CreateWebhook(
+		ctx,
+		req.Namespace,
+		req.Url,
+		req.EventTypes,
+		req.GetSecret(),
+	)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	return &webhooksv1.CreateWebhookResponse{
+		Webhook: convertWebhookToProto(webhook),
+		Secret:  webhook.Secret,
+	}, nil
+}
+
+// ListWebhooks retrieves the webhooks in a namespace via Connect RPC
+func (s *WebhookServiceServer) ListWebhooks(
+	ctx context.Context,
+	req *webhooksv1.ListWebhooksRequest,
+) (*webhooksv1.ListWebhooksResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+
+	webhooks, err := s.service.ListWebhooks(ctx, req.Namespace)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	protoWebhooks := make([]*webhooksv1.Webhook, 0, len(webhooks))
+	for i := range webhooks {
+		protoWebhooks = append(protoWebhooks, convertWebhookToProto(&webhooks[i]))
+	}
+
+	return &webhooksv1.ListWebhooksResponse{
+		Webhooks: protoWebhooks,
+	}, nil
+}
+
+// DeleteWebhook handles webhook deletion via Connect RPC
+func (s *WebhookServiceServer) DeleteWebhook(
+	ctx context.Context,
+	req *webhooksv1.DeleteWebhookRequest,
+) (*webhooksv1.DeleteWebhookResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+	if req.WebhookId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("webhook_id is required"),
+		)
+	}
+
+	if err := s.service.DeleteWebhook(ctx, req.Namespace, req.WebhookId); err != nil {
+		return nil, webhookError(err)
+	}
+
+	return &webhooksv1.DeleteWebhookResponse{}, nil
+}
+
+// ListWebhookDeliveries retrieves the delivery log of a webhook via Connect RPC
+func (s *WebhookServiceServer) ListWebhookDeliveries(
+	ctx context.Context,
+	req *webhooksv1.ListWebhookDeliveriesRequest,
+) (*webhooksv1.ListWebhookDeliveriesResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+	if req.WebhookId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("webhook_id is required"),
+		)
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("limit and offset must not be negative"),
+		)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultWebhookDeliveriesLimit
+	}
+	limit = min(limit, maxWebhookDeliveriesLimit)
+
+	deliveries, err := s.service.ListDeliveries(
+		ctx,
+		req.Namespace,
+		req.WebhookId,
+		req.Status,
+		limit,
+		req.Offset,
+	)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	protoDeliveries := make([]*webhooksv1.WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		protoDeliveries = append(protoDeliveries, convertWebhookDeliveryToProto(&deliveries[i]))
+	}
+
+	return &webhooksv1.ListWebhookDeliveriesResponse{
+		Deliveries: protoDeliveries,
+	}, nil
+}
+
+// RetryWebhookDelivery requeues a dead-lettered delivery via Connect RPC
+func (s *WebhookServiceServer) RetryWebhookDelivery(
+	ctx context.Context,
+	req *webhooksv1.RetryWebhookDeliveryRequest,
+) (*webhooksv1.RetryWebhookDeliveryResponse, error) {
+	if req.Namespace == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("namespace is required"),
+		)
+	}
+	if req.DeliveryId == "" {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("delivery_id is required"),
+		)
+	}
+
+	delivery, err := s.service.RetryDelivery(ctx, req.Namespace, req.DeliveryId)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+
+	return &webhooksv1.RetryWebhookDeliveryResponse{
+		Delivery: convertWebhookDeliveryToProto(delivery),
+	}, nil
+}
+
+// webhookError maps webhook service errors to Connect errors
+func webhookError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrNamespaceNotFound),
+		errors.Is(err, services.ErrWebhookNotFound),
+		errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, services.ErrInvalidWebhook):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, services.ErrDeliveryNotDead):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// convertWebhookToProto converts a webhook to its protobuf representation. The secret is
+// never included.
+func convertWebhookToProto(webhook *sqlc.Webhook) *webhooksv1.Webhook {
+	return &webhooksv1.Webhook{
+		Id:         webhook.ID.String(),
+		Url:        webhook.Url,
+		EventTypes: webhook.EventTypes,
+		CreatedAt:  timestamppb.New(webhook.CreatedAt.Time),
+	}
+}
+
+// convertWebhookDeliveryToProto converts a delivery to its protobuf representation
+func convertWebhookDeliveryToProto(delivery *sqlc.WebhookDelivery) *webhooksv1.WebhookDelivery {
+	result := &webhooksv1.WebhookDelivery{
+		Id:             delivery.ID.String(),
+		WebhookId:      delivery.WebhookID.String(),
+		EventId:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.LastError,
+		Payload:        string(delivery.Payload),
+		CreatedAt:      timestamppb.New(delivery.CreatedAt.Time),
+	}
+	if delivery.Status == services.WebhookDeliveryPending {
+		result.NextAttemptAt = timestamppb.New(delivery.NextAttemptAt.Time)
+	}
+	if delivery.DeliveredAt.Valid {
+		result.DeliveredAt = timestamppb.New(delivery.DeliveredAt.Time)
+	}
+	return result
+}
@@ -0,0 +1,236 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	rulesv1 "github.com/RynoXLI/Wayfile/gen/go/rules/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+func TestTaggingRules(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "rules-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "rules-test",
+		Name:      "invoices",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"number": {"type": "integer"},
+				"vendor": {"type": "string"}
+			}
+		}`),
+	})
+	require.NoError(t, err)
+
+	invoiceRule := func(name string) *rulesv1.CreateRuleRequest {
+		return &rulesv1.CreateRuleRequest{
+			Namespace: "rules-test",
+			Name:      name,
+			Conditions: &rulesv1.RuleConditions{
+				FilenameGlob: stringPtr("invoice-*"),
+				MimeType:     stringPtr("text/*"),
+				TextRegex:    stringPtr(`Invoice #(?P<number>\d+)`),
+			},
+			Action: &rulesv1.RuleAction{
+				TagPath:    "/invoices",
+				Attributes: stringPtr(`{"vendor": "Acme"}`),
+				Captures:   map[string]string{"number": "number"},
+			},
+		}
+	}
+
+	// === Create, get and list ===
+	created, err := ta.RuleClient.CreateRule(ctx, invoiceRule("acme-invoices"))
+	require.NoError(t, err)
+	rule := created.GetRule()
+	require.NotEmpty(t, rule.GetId())
+	require.True(t, rule.GetEnabled(), "rules are enabled by default")
+
+	got, err := ta.RuleClient.GetRule(ctx, &rulesv1.GetRuleRequest{
+		Namespace: "rules-test",
+		RuleId:    rule.GetId(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "invoice-*", got.GetRule().GetConditions().GetFilenameGlob())
+	require.Equal(t, map[string]string{"number": "number"}, got.GetRule().GetAction().GetCaptures())
+
+	list, err := ta.RuleClient.ListRules(ctx, &rulesv1.ListRulesRequest{Namespace: "rules-test"})
+	require.NoError(t, err)
+	require.Len(t, list.GetRules(), 1)
+
+	// === Invalid rules are rejected ===
+	_, err = ta.RuleClient.CreateRule(ctx, invoiceRule("acme-invoices"))
+	require.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+	unknownTag := invoiceRule("unknown-tag")
+	unknownTag.Action.TagPath = "/receipts"
+	_, err = ta.RuleClient.CreateRule(ctx, unknownTag)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	badRegex := invoiceRule("bad-regex")
+	badRegex.Conditions.TextRegex = stringPtr(`Invoice #(\d+`)
+	_, err = ta.RuleClient.CreateRule(ctx, badRegex)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	missingGroup := invoiceRule("missing-group")
+	missingGroup.Action.Captures = map[string]string{"number": "invoice_number"}
+	_, err = ta.RuleClient.CreateRule(ctx, missingGroup)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = ta.RuleClient.CreateRule(ctx, &rulesv1.CreateRuleRequest{
+		Namespace: "missing-namespace",
+		Name:      "rule",
+		Conditions: &rulesv1.RuleConditions{
+			MimeType: stringPtr("text/plain"),
+		},
+		Action: &rulesv1.RuleAction{TagPath: "/invoices"},
+	})
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// === Dry runs report the outcome without tagging ===
+	invoice := uploadTestDocument(
+		t, ta, "rules-test", "invoice-0042.txt", "text/plain", []byte("Invoice #42 from Acme"),
+	)
+	tested, err := ta.RuleClient.TestRule(ctx, &rulesv1.TestRuleRequest{
+		Namespace:  "rules-test",
+		RuleId:     rule.GetId(),
+		DocumentId: invoice.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, tested.GetMatched())
+	require.Equal(t, "/invoices", tested.GetTagPath())
+	AssertJSONEqual(
+		t,
+		`{"number": 42, "vendor": "Acme"}`,
+		tested.GetAttributes(),
+		"test attributes",
+	)
+
+	memo := uploadTestDocument(
+		t, ta, "rules-test", "memo.txt", "text/plain", []byte("Quarterly memo"),
+	)
+	tested, err = ta.RuleClient.TestRule(ctx, &rulesv1.TestRuleRequest{
+		Namespace:  "rules-test",
+		RuleId:     rule.GetId(),
+		DocumentId: memo.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, tested.GetMatched())
+	require.ElementsMatch(t, []string{"filename_glob", "text_regex"}, tested.GetUnmet())
+	require.Nil(t, tested.Attributes)
+
+	tags, err := ta.ConnectClient.ListDocumentTags(ctx, &documentsv1.ListDocumentTagsRequest{
+		Namespace:  "rules-test",
+		DocumentId: invoice.ID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, tags.GetTags(), "dry run must not tag the document")
+
+	// === Captures take the types of inherited attributes ===
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:     "rules-test",
+		Name:          "utilities",
+		ParentPath:    stringPtr("/invoices"),
+		InheritSchema: true,
+	})
+	require.NoError(t, err)
+	utilities := invoiceRule("utility-invoices")
+	utilities.Action.TagPath = "/invoices/utilities"
+	utilities.Enabled = boolPtr(false)
+	inherited, err := ta.RuleClient.CreateRule(ctx, utilities)
+	require.NoError(t, err)
+	tested, err = ta.RuleClient.TestRule(ctx, &rulesv1.TestRuleRequest{
+		Namespace:  "rules-test",
+		RuleId:     inherited.GetRule().GetId(),
+		DocumentId: invoice.ID,
+	})
+	require.NoError(t, err)
+	AssertJSONEqual(
+		t,
+		`{"number": 42, "vendor": "Acme"}`,
+		tested.GetAttributes(),
+		"inherited attribute types",
+	)
+
+	// === Enabled rules tag uploaded documents ===
+	takeOutboxEvents(t, ta)
+	upload := uploadTestDocument(
+		t, ta, "rules-test", "invoice-0043.txt", "text/plain", []byte("Invoice #43"),
+	)
+	subject, payload := uploadedEvent(t, ta)
+	require.NoError(t, ta.Extraction.HandleEvent(ctx, subject, payload))
+
+	attrs, err := ta.ConnectClient.GetDocumentAttributes(
+		ctx,
+		&documentsv1.GetDocumentAttributesRequest{
+			Namespace:  "rules-test",
+			DocumentId: upload.ID,
+			TagPath:    stringPtr("/invoices"),
+		},
+	)
+	require.NoError(t, err)
+	AssertJSONEqual(t, `{"number": 43, "vendor": "Acme"}`, attrs.GetAttributes(), "rule attributes")
+
+	var metadata services.DocumentTagMetadata
+	require.NoError(t, json.Unmarshal([]byte(attrs.GetMetadata()), &metadata))
+	require.Equal(t, services.ExtractionMethodAutomatic, metadata.Tag.Method)
+	require.Equal(t, rule.GetId(), metadata.Tag.ExtractedBy)
+	require.NotNil(t, metadata.Tag.Confidence)
+	require.InDelta(t, 1.0, *metadata.Tag.Confidence, 1e-9)
+
+	// === Disabled rules are skipped ===
+	updated, err := ta.RuleClient.UpdateRule(ctx, &rulesv1.UpdateRuleRequest{
+		Namespace:  "rules-test",
+		RuleId:     rule.GetId(),
+		Name:       "acme-invoices",
+		Enabled:    false,
+		Conditions: rule.GetConditions(),
+		Action:     rule.GetAction(),
+	})
+	require.NoError(t, err)
+	require.False(t, updated.GetRule().GetEnabled())
+
+	takeOutboxEvents(t, ta)
+	skipped := uploadTestDocument(
+		t, ta, "rules-test", "invoice-0044.txt", "text/plain", []byte("Invoice #44"),
+	)
+	subject, payload = uploadedEvent(t, ta)
+	require.NoError(t, ta.Extraction.HandleEvent(ctx, subject, payload))
+
+	tags, err = ta.ConnectClient.ListDocumentTags(ctx, &documentsv1.ListDocumentTagsRequest{
+		Namespace:  "rules-test",
+		DocumentId: skipped.ID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, tags.GetTags())
+
+	// === Delete ===
+	_, err = ta.RuleClient.DeleteRule(ctx, &rulesv1.DeleteRuleRequest{
+		Namespace: "rules-test",
+		RuleId:    rule.GetId(),
+	})
+	require.NoError(t, err)
+
+	_, err = ta.RuleClient.GetRule(ctx, &rulesv1.GetRuleRequest{
+		Namespace: "rules-test",
+		RuleId:    rule.GetId(),
+	})
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
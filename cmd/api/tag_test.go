@@ -13,11 +13,15 @@ import (
 	"testing"
 
 	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 
 	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
 	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
 	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/services"
 )
 
 // TestTagCRUD tests the tag CRUD operations via Connect RPC
@@ -987,3 +991,263 @@ func TestTagPathNormalization(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, listResp.Tags, 3) // parent, child1, child2
 }
+
+// TestTagSchemaInheritance tests composing schemas down the tag hierarchy
+func TestTagSchemaInheritance(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "inheritance-test",
+	})
+	require.NoError(t, err)
+
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "inheritance-test",
+		Name:      "invoice",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {
+				"total": {"type": "number"},
+				"vendor": {"type": "string"}
+			},
+			"required": ["total"],
+			"additionalProperties": false
+		}`),
+	})
+	require.NoError(t, err)
+
+	// === Children compose their schema with the inherited one ===
+	utilitySchema := `{
+		"type": "object",
+		"properties": {"meter": {"type": "string"}},
+		"required": ["meter"]
+	}`
+	resp, err := ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:     "inheritance-test",
+		Name:          "utility",
+		ParentPath:    stringPtr("/invoice"),
+		JsonSchema:    stringPtr(utilitySchema),
+		InheritSchema: true,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Tag.GetInheritSchema())
+	AssertJSONEqual(t, utilitySchema, resp.Tag.GetJsonSchema(), "own schema")
+	AssertJSONEqual(t, `{
+		"type": "object",
+		"properties": {
+			"total": {"type": "number"},
+			"vendor": {"type": "string"},
+			"meter": {"type": "string"}
+		},
+		"required": ["total", "meter"],
+		"additionalProperties": false
+	}`, resp.Tag.GetEffectiveJsonSchema(), "effective schema")
+
+	// Grandchildren without a schema of their own inherit the whole chain
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:     "inheritance-test",
+		Name:          "water",
+		ParentPath:    stringPtr("/invoice/utility"),
+		InheritSchema: true,
+	})
+	require.NoError(t, err)
+	getResp, err := ta.TagClient.GetTag(ctx, &tagsv1.GetTagRequest{
+		Namespace: "inheritance-test",
+		Path:      "/invoice/utility/water",
+	})
+	require.NoError(t, err)
+	require.Nil(t, getResp.Tag.JsonSchema)
+	AssertJSONEqual(
+		t,
+		resp.Tag.GetEffectiveJsonSchema(),
+		getResp.Tag.GetEffectiveJsonSchema(),
+		"inherited schema",
+	)
+
+	// === Attributes are validated against the effective schema ===
+	doc := uploadTestDocument(
+		t, ta, "inheritance-test", "bill.txt", "text/plain", []byte("bill"),
+	)
+	addTag := func(attributes string) error {
+		_, err := ta.ConnectClient.AddTagToDocument(ctx, &documentsv1.AddTagToDocumentRequest{
+			Namespace:  "inheritance-test",
+			DocumentId: doc.ID,
+			TagPath:    "/invoice/utility/water",
+			Attributes: &attributes,
+		})
+		return err
+	}
+	require.NoError(t, addTag(`{"total": 40, "meter": "W-1"}`))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(addTag(`{"meter": "W-1"}`)))
+	require.Equal(
+		t,
+		connect.CodeInvalidArgument,
+		connect.CodeOf(addTag(`{"total": 40, "meter": "W-1", "color": "blue"}`)),
+	)
+
+	// === Incompatible overrides are rejected ===
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:     "inheritance-test",
+		Name:          "foreign",
+		ParentPath:    stringPtr("/invoice"),
+		JsonSchema:    stringPtr(`{"type": "object", "properties": {"total": {"type": "string"}}}`),
+		InheritSchema: true,
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Including when an ancestor changes under an inheriting descendant
+	_, err = ta.TagClient.UpdateTag(ctx, &tagsv1.UpdateTagRequest{
+		Namespace:  "inheritance-test",
+		Path:       "/invoice",
+		JsonSchema: stringPtr(`{"type": "object", "properties": {"meter": {"type": "integer"}}}`),
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Another replica compiles and caches the effective schema, and isn't told of changes
+	replica := services.NewTagService(
+		ta.Pool,
+		sqlc.New(ta.Pool),
+		events.NewOutbox(sqlc.New(ta.Pool), events.SubjectModeNamespaced),
+	)
+	var waterID pgtype.UUID
+	require.NoError(t, ta.Pool.QueryRow(
+		ctx,
+		"SELECT id FROM tags WHERE path = $1",
+		"/invoice/utility/water",
+	).Scan(&waterID))
+	_, err = replica.PrepareAttributes(
+		ctx,
+		waterID,
+		nil,
+		map[string]interface{}{"meter": "W-1"},
+		nil,
+	)
+	require.Error(t, err, "total is required through /invoice")
+
+	// Tags stop inheriting when told to
+	updateResp, err := ta.TagClient.UpdateTag(ctx, &tagsv1.UpdateTagRequest{
+		Namespace:     "inheritance-test",
+		Path:          "/invoice/utility",
+		InheritSchema: boolPtr(false),
+	})
+	require.NoError(t, err)
+	require.False(t, updateResp.Tag.GetInheritSchema())
+	AssertJSONEqual(t, utilitySchema, updateResp.Tag.GetEffectiveJsonSchema(), "own schema only")
+
+	// The replica's cached schema is for the old version, so it's never served again
+	_, err = replica.PrepareAttributes(
+		ctx,
+		waterID,
+		nil,
+		map[string]interface{}{"meter": "W-1"},
+		nil,
+	)
+	require.NoError(t, err)
+}
+
+// TestTagSchemaCompatibility tests rejecting schema versions that break a tag's compatibility
+func TestTagSchemaCompatibility(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "compatibility-test",
+	})
+	require.NoError(t, err)
+
+	createResp, err := ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "compatibility-test",
+		Name:      "invoice",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {"total": {"type": "number"}},
+			"required": ["total"]
+		}`),
+		SchemaCompatibility: tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_BACKWARD,
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_BACKWARD,
+		createResp.Tag.GetSchemaCompatibility(),
+	)
+
+	// === Breaking changes are rejected with every violation ===
+	_, err = ta.TagClient.UpdateTag(ctx, &tagsv1.UpdateTagRequest{
+		Namespace: "compatibility-test",
+		Path:      "/invoice",
+		JsonSchema: stringPtr(`{
+			"type": "object",
+			"properties": {"total": {"type": "string"}, "vendor": {"type": "string"}},
+			"required": ["total", "vendor"]
+		}`),
+	})
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	var violations []*tagsv1.SchemaCompatibilityViolation
+	for _, detail := range connectErr.Details() {
+		value, err := detail.Value()
+		require.NoError(t, err)
+		if details, ok := value.(*tagsv1.SchemaCompatibilityErrorDetails); ok {
+			violations = details.Violations
+		}
+	}
+	type violation struct {
+		Field         string
+		Compatibility tagsv1.SchemaCompatibility
+		Message       string
+	}
+	got := make([]violation, len(violations))
+	for i, v := range violations {
+		got[i] = violation{v.GetField(), v.GetCompatibility(), v.GetMessage()}
+	}
+	require.ElementsMatch(t, []violation{
+		{
+			"total",
+			tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_BACKWARD,
+			"type changed from number to string",
+		},
+		{
+			"vendor",
+			tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_BACKWARD,
+			"required by the new schema but not the old one",
+		},
+	}, got)
+
+	// === Compatible changes create a new version ===
+	compatibleSchema := `{
+		"type": "object",
+		"properties": {"total": {"type": "number"}, "vendor": {"type": "string"}}
+	}`
+	updateResp, err := ta.TagClient.UpdateTag(ctx, &tagsv1.UpdateTagRequest{
+		Namespace:  "compatibility-test",
+		Path:       "/invoice",
+		JsonSchema: stringPtr(compatibleSchema),
+	})
+	require.NoError(t, err)
+	AssertJSONEqual(t, compatibleSchema, updateResp.Tag.GetJsonSchema(), "compatible schema")
+
+	// === Relaxing the mode allows the breaking change ===
+	updateResp, err = ta.TagClient.UpdateTag(ctx, &tagsv1.UpdateTagRequest{
+		Namespace: "compatibility-test",
+		Path:      "/invoice",
+		JsonSchema: stringPtr(
+			`{"type": "object", "properties": {"total": {"type": "string"}}}`,
+		),
+		SchemaCompatibility: tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_NONE,
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		tagsv1.SchemaCompatibility_SCHEMA_COMPATIBILITY_NONE,
+		updateResp.Tag.GetSchemaCompatibility(),
+	)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
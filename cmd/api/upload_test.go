@@ -0,0 +1,296 @@
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	documentsv1 "github.com/RynoXLI/Wayfile/gen/go/documents/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+)
+
+// tusRequest builds a request carrying the tus protocol version header
+func tusRequest(method, target string, body []byte) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	req.Header.Set("Tus-Resumable", tusVersion)
+	return req
+}
+
+// createTusUpload creates a resumable upload and returns its path
+func createTusUpload(
+	t *testing.T,
+	ta *TestApp,
+	namespace string,
+	length int,
+	metadata string,
+) string {
+	req := tusRequest(http.MethodPost, "/api/v1/ns/"+namespace+"/uploads", nil)
+	req.Header.Set("Upload-Length", strconv.Itoa(length))
+	req.Header.Set("Upload-Metadata", metadata)
+	w := httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.Equal(t, tusVersion, w.Header().Get("Tus-Resumable"))
+	require.NotEmpty(t, w.Header().Get("Upload-Expires"))
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	return location.Path
+}
+
+// patchTusChunk sends one chunk of a resumable upload
+func patchTusChunk(ta *TestApp, path string, offset int, chunk []byte) *httptest.ResponseRecorder {
+	req := tusRequest(http.MethodPatch, path, chunk)
+	req.Header.Set("Content-Type", tusChunkContentType)
+	req.Header.Set("Upload-Offset", strconv.Itoa(offset))
+	w := httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	return w
+}
+
+func b64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func TestResumableUpload(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "tus-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "tus-test",
+		Name:      "scans",
+	})
+	require.NoError(t, err)
+
+	// === Capability discovery ===
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/ns/tus-test/uploads", nil)
+	w := httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, tusVersion, w.Header().Get("Tus-Version"))
+	require.Contains(t, w.Header().Get("Tus-Extension"), "creation")
+
+	// === Missing Tus-Resumable header is rejected ===
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/ns/tus-test/uploads", nil)
+	req.Header.Set("Upload-Length", "10")
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	// === Upload a file in two chunks ===
+	content := []byte("first half|second half of a large scanned document")
+	split := 11
+	metadata := "filename " + b64("scan.txt") +
+		",filetype " + b64("text/plain") +
+		",tags " + b64(`[{"tag_path":"/scans"}]`)
+	path := createTusUpload(t, ta, "tus-test", len(content), metadata)
+
+	req = tusRequest(http.MethodHead, path, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "0", w.Header().Get("Upload-Offset"))
+	require.Equal(t, strconv.Itoa(len(content)), w.Header().Get("Upload-Length"))
+	require.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	w = patchTusChunk(ta, path, 0, content[:split])
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	require.Equal(t, strconv.Itoa(split), w.Header().Get("Upload-Offset"))
+	require.Empty(t, w.Header().Get("Wayfile-Document-Id"))
+
+	// A chunk at the wrong offset is a conflict
+	w = patchTusChunk(ta, path, 0, content[split:])
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	// A chunk without the tus content type is rejected
+	req = tusRequest(http.MethodPatch, path, content[split:])
+	req.Header.Set("Upload-Offset", strconv.Itoa(split))
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	// Resume from the reported offset
+	req = tusRequest(http.MethodHead, path, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, strconv.Itoa(split), w.Header().Get("Upload-Offset"))
+
+	w = patchTusChunk(ta, path, split, content[split:])
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	require.Equal(t, strconv.Itoa(len(content)), w.Header().Get("Upload-Offset"))
+	documentID := w.Header().Get("Wayfile-Document-Id")
+	require.NotEmpty(t, documentID, "final chunk should finalize the document")
+
+	// Further data is rejected once the upload is complete
+	w = patchTusChunk(ta, path, len(content), []byte("x"))
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	// The finalized document downloads with the concatenated content
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/ns/tus-test/documents/"+documentID, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, content, w.Body.Bytes())
+
+	// Tags from the upload metadata were applied
+	listResp, err := ta.ConnectClient.ListDocumentTags(ctx, &documentsv1.ListDocumentTagsRequest{
+		Namespace:  "tus-test",
+		DocumentId: documentID,
+	})
+	require.NoError(t, err)
+	require.Len(t, listResp.Tags, 1)
+	require.Equal(t, "/scans", listResp.Tags[0].TagPath)
+
+	// === Uploading the same content again is detected as a duplicate ===
+	path = createTusUpload(t, ta, "tus-test", len(content), "filename "+b64("copy.txt"))
+	w = patchTusChunk(ta, path, 0, content)
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	req = tusRequest(http.MethodHead, path, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code, "duplicate uploads should be discarded")
+
+	// === Chunks larger than the declared length are rejected ===
+	path = createTusUpload(t, ta, "tus-test", 4, "filename "+b64("small.txt"))
+	w = patchTusChunk(ta, path, 0, []byte("too long"))
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	// === Termination discards the upload ===
+	req = tusRequest(http.MethodDelete, path, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	req = tusRequest(http.MethodHead, path, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// === Filename is required ===
+	req = tusRequest(http.MethodPost, "/api/v1/ns/tus-test/uploads", nil)
+	req.Header.Set("Upload-Length", "10")
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// === Filenames can't escape the document's storage directory ===
+	for _, filename := range []string{"../../x", "nested/x", `..\x`, ".."} {
+		req = tusRequest(http.MethodPost, "/api/v1/ns/tus-test/uploads", nil)
+		req.Header.Set("Upload-Length", "0")
+		req.Header.Set("Upload-Metadata", "filename "+b64(filename))
+		w = httptest.NewRecorder()
+		ta.Router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, filename)
+	}
+}
+
+func TestResumableUploadWithInvalidTagAttributes(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "tus-invalid-test",
+	})
+	require.NoError(t, err)
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace:  "tus-invalid-test",
+		Name:       "invoice",
+		JsonSchema: stringPtr(`{"type": "object", "properties": {"amount": {"type": "number"}}}`),
+	})
+	require.NoError(t, err)
+
+	content := []byte("invalid invoice")
+	metadata := "filename " + b64("invoice.txt") +
+		",tags " + b64(`[{"tag_path":"/invoice","attributes":{"amount":"ten"}}]`)
+	path := createTusUpload(t, ta, "tus-invalid-test", len(content), metadata)
+
+	// The final chunk stores the document and reports the violation as a problem
+	w := patchTusChunk(ta, path, 0, content)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	require.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	require.Equal(t, tusVersion, w.Header().Get("Tus-Resumable"))
+	require.Equal(t, strconv.Itoa(len(content)), w.Header().Get("Upload-Offset"))
+	documentID := w.Header().Get("Wayfile-Document-Id")
+	require.NotEmpty(t, documentID)
+	AssertJSONEqual(t, `{
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "Tag attributes failed validation; the document was stored without those tags",
+		"document_id": "`+documentID+`",
+		"errors": [{
+			"location": "header.Upload-Metadata.tags[0].attributes",
+			"pointer": "/amount",
+			"keyword": "type",
+			"expected": "number",
+			"actual": "string",
+			"message": "expected number, but got string"
+		}]
+	}`, w.Body.String(), "validation problem")
+
+	// The upload is complete, and the document has no tags
+	req := tusRequest(http.MethodHead, path, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, documentID, w.Header().Get("Wayfile-Document-Id"))
+
+	listResp, err := ta.ConnectClient.ListDocumentTags(ctx, &documentsv1.ListDocumentTagsRequest{
+		Namespace:  "tus-invalid-test",
+		DocumentId: documentID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, listResp.Tags)
+}
+
+func TestResumableUploadExpiry(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "tus-expiry-test",
+	})
+	require.NoError(t, err)
+
+	path := createTusUpload(t, ta, "tus-expiry-test", 8, "filename "+b64("abandoned.txt"))
+	w := patchTusChunk(ta, path, 0, []byte("half"))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	// Age the session past its expiry
+	_, err = ta.Pool.Exec(
+		ctx,
+		"UPDATE upload_sessions SET expires_at = NOW() - INTERVAL '1 minute'",
+	)
+	require.NoError(t, err)
+
+	req := tusRequest(http.MethodHead, path, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusGone, w.Code)
+
+	expired, err := ta.App.UploadService.ExpireUploads(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, expired)
+
+	req = tusRequest(http.MethodHead, path, nil)
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
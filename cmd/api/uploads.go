@@ -0,0 +1,403 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/services"
+	"github.com/RynoXLI/Wayfile/internal/storage"
+)
+
+// tus protocol constants
+const (
+	tusVersion          = "1.0.0"
+	tusExtensions       = "creation,expiration,termination"
+	tusChunkContentType = "application/offset+octet-stream"
+)
+
+// UploadCapabilitiesInput handles tus capability discovery
+type UploadCapabilitiesInput struct {
+	Namespace string `path:"namespace" maxLength:"255" doc:"Namespace name"`
+}
+
+// UploadCapabilitiesOutput advertises the supported tus version and extensions
+type UploadCapabilitiesOutput struct {
+	TusResumable string `header:"Tus-Resumable" doc:"tus protocol version in use"`
+	TusVersion   string `header:"Tus-Version"   doc:"Supported tus protocol versions"`
+	TusExtension string `header:"Tus-Extension" doc:"Supported tus extensions"`
+	TusMaxSize   int64  `header:"Tus-Max-Size"  doc:"Maximum upload length in bytes"`
+}
+
+// UploadCreateInput handles creation of a resumable upload
+type UploadCreateInput struct {
+	Namespace      string `path:"namespace" maxLength:"255" doc:"Namespace name"`
+	TusResumable   string `                                 doc:"tus protocol version"                                                              header:"Tus-Resumable"`
+	UploadLength   int64  `                                 doc:"Total size of the upload in bytes"                                                 header:"Upload-Length"   required:"true" minimum:"0"`
+	UploadMetadata string `                                 doc:"Comma separated key/base64-value pairs: filename, filetype and optional tags JSON" header:"Upload-Metadata"`
+}
+
+// UploadCreateOutput returns the location of a new resumable upload
+type UploadCreateOutput struct {
+	TusResumable  string    `header:"Tus-Resumable"`
+	Location      string    `header:"Location"            doc:"URL of the created upload"`
+	UploadExpires time.Time `header:"Upload-Expires"      doc:"Time after which an unfinished upload is discarded"`
+	DocumentID    string    `header:"Wayfile-Document-Id" doc:"Document UUID, set once the upload has been finalized"`
+}
+
+// UploadStatusInput identifies an existing resumable upload
+type UploadStatusInput struct {
+	Namespace    string `path:"namespace" maxLength:"255" doc:"Namespace name"`
+	UploadID     string `path:"uploadID"                  doc:"Upload UUID"          format:"uuid"`
+	TusResumable string `                                 doc:"tus protocol version"               header:"Tus-Resumable"`
+}
+
+// UploadStatusOutput reports the progress of a resumable upload
+type UploadStatusOutput struct {
+	TusResumable   string    `header:"Tus-Resumable"`
+	CacheControl   string    `header:"Cache-Control"`
+	UploadOffset   int64     `header:"Upload-Offset"       doc:"Number of bytes received so far"`
+	UploadLength   int64     `header:"Upload-Length"       doc:"Total size of the upload in bytes"`
+	UploadMetadata string    `header:"Upload-Metadata"     doc:"Metadata supplied at creation"`
+	UploadExpires  time.Time `header:"Upload-Expires"      doc:"Time after which an unfinished upload is discarded"`
+	DocumentID     string    `header:"Wayfile-Document-Id" doc:"Document UUID, set once the upload has been finalized"`
+}
+
+// UploadChunkInput handles a chunk of a resumable upload. The request body is
+// streamed straight to storage rather than buffered.
+type UploadChunkInput struct {
+	Namespace    string `path:"namespace" maxLength:"255" doc:"Namespace name"`
+	UploadID     string `path:"uploadID"                  doc:"Upload UUID"                             format:"uuid"`
+	TusResumable string `                                 doc:"tus protocol version"                                  header:"Tus-Resumable"`
+	UploadOffset int64  `                                 doc:"Offset at which this chunk starts"                     header:"Upload-Offset" required:"true" minimum:"0"`
+	ContentType  string `                                 doc:"Must be application/offset+octet-stream"               header:"Content-Type"`
+
+	body io.Reader
+}
+
+// Resolve captures the raw request body so it can be streamed
+func (i *UploadChunkInput) Resolve(ctx huma.Context) []error {
+	i.body = ctx.BodyReader()
+	return nil
+}
+
+// UploadChunkOutput reports the new offset after a chunk has been stored
+type UploadChunkOutput struct {
+	TusResumable  string    `header:"Tus-Resumable"`
+	UploadOffset  int64     `header:"Upload-Offset"       doc:"Number of bytes received so far"`
+	UploadExpires time.Time `header:"Upload-Expires"      doc:"Time after which an unfinished upload is discarded"`
+	DocumentID    string    `header:"Wayfile-Document-Id" doc:"Document UUID, set once the upload has been finalized"`
+}
+
+// UploadTerminateOutput confirms termination of a resumable upload
+type UploadTerminateOutput struct {
+	TusResumable string `header:"Tus-Resumable"`
+}
+
+// checkTusResumable rejects requests for a tus version other than the one supported
+func checkTusResumable(version string) error {
+	if version == tusVersion {
+		return nil
+	}
+	return huma.ErrorWithHeaders(
+		huma.Error412PreconditionFailed("Unsupported tus version"),
+		http.Header{"Tus-Version": {tusVersion}, "Tus-Resumable": {tusVersion}},
+	)
+}
+
+// parseUploadMetadata decodes a tus Upload-Metadata header into a map.
+// Pairs are comma separated, each a key and an optional base64 encoded value.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		switch len(fields) {
+		case 1:
+			metadata[fields[0]] = ""
+		case 2:
+			value, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("metadata value for %q is not valid base64", fields[0])
+			}
+			metadata[fields[0]] = string(value)
+		default:
+			return nil, fmt.Errorf("malformed metadata pair %q", strings.TrimSpace(pair))
+		}
+	}
+
+	return metadata, nil
+}
+
+// encodeUploadMetadata renders stored upload metadata back into Upload-Metadata form
+func encodeUploadMetadata(raw []byte) string {
+	var metadata map[string]string
+	if err := json.Unmarshal(raw, &metadata); err != nil {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(metadata))
+	for key, value := range metadata {
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// uploadError maps upload service errors to tus HTTP responses
+func uploadError(app *App, err error, uploadID string) error {
+	var statusErr error
+	switch {
+	case errors.Is(err, services.ErrNamespaceNotFound):
+		statusErr = huma.Error404NotFound("Namespace not found")
+	case errors.Is(err, services.ErrUploadNotFound):
+		statusErr = huma.Error404NotFound("Upload not found")
+	case errors.Is(err, services.ErrUploadExpired):
+		statusErr = huma.Error410Gone("Upload expired")
+	case errors.Is(err, services.ErrUploadOffsetMismatch):
+		statusErr = huma.Error409Conflict("Upload-Offset does not match the current offset")
+	case errors.Is(err, services.ErrUploadComplete):
+		statusErr = huma.Error409Conflict("Upload already complete")
+	case errors.Is(err, storage.ErrDuplicateFile):
+		statusErr = huma.Error409Conflict("File with this content already exists")
+	case errors.Is(err, services.ErrUploadTooLarge),
+		errors.Is(err, services.ErrUploadLengthExceeded):
+		statusErr = huma.Error413RequestEntityTooLarge(err.Error())
+	case errors.Is(err, services.ErrInvalidUploadMetadata):
+		statusErr = huma.Error400BadRequest(err.Error())
+	default:
+		app.Logger.Error("Resumable upload failed", "error", err, "upload_id", uploadID)
+		statusErr = huma.Error500InternalServerError("Error processing the upload")
+	}
+	return huma.ErrorWithHeaders(statusErr, http.Header{"Tus-Resumable": {tusVersion}})
+}
+
+// uploadLocation builds the URL of a resumable upload
+func uploadLocation(app *App, namespace string, session *sqlc.UploadSession) string {
+	return fmt.Sprintf("%s/api/v1/ns/%s/uploads/%s", app.BaseURL, namespace, session.ID.String())
+}
+
+// finalizedDocumentID returns the document ID of a finalized upload, or "" if still in progress
+func finalizedDocumentID(session *sqlc.UploadSession) string {
+	if !session.DocumentID.Valid {
+		return ""
+	}
+	return session.DocumentID.String()
+}
+
+// completeUpload applies tags supplied in the upload metadata once a document exists.
+// The upload stays finalized when tags fail attribute validation; the violations are
+// returned as a ValidationProblem naming the stored document.
+func completeUpload(
+	ctx context.Context,
+	app *App,
+	namespace string,
+	progress *services.UploadProgress,
+) error {
+	if progress.Document == nil {
+		return nil
+	}
+
+	var metadata map[string]string
+	if err := json.Unmarshal(progress.Session.Metadata, &metadata); err != nil {
+		return nil
+	}
+	documentID := progress.Document.Document.ID.String()
+	err := applyUploadTags(
+		ctx,
+		app,
+		namespace,
+		documentID,
+		metadata["tags"],
+		"header.Upload-Metadata.tags",
+	)
+	if err != nil {
+		return huma.ErrorWithHeaders(err, http.Header{
+			"Tus-Resumable":       {tusVersion},
+			"Upload-Offset":       {strconv.FormatInt(progress.Session.UploadOffset, 10)},
+			"Wayfile-Document-Id": {documentID},
+		})
+	}
+	return nil
+}
+
+// registerUploadRoutes registers the tus 1.0 resumable upload operations
+func registerUploadRoutes(api huma.API, app *App) {
+	// Discover tus capabilities
+	huma.Register(api, huma.Operation{
+		OperationID:   "get-upload-capabilities",
+		Method:        http.MethodOptions,
+		Path:          "/api/v1/ns/{namespace}/uploads",
+		Summary:       "Discover resumable upload capabilities",
+		Description:   "Report the supported tus protocol version, extensions and maximum size",
+		Tags:          []string{"uploads"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(_ context.Context, _ *UploadCapabilitiesInput) (*UploadCapabilitiesOutput, error) {
+		return &UploadCapabilitiesOutput{
+			TusResumable: tusVersion,
+			TusVersion:   tusVersion,
+			TusExtension: tusExtensions,
+			TusMaxSize:   app.UploadService.MaxSize(),
+		}, nil
+	})
+
+	// Create a resumable upload
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-upload",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/ns/{namespace}/uploads",
+		Summary:       "Create a resumable upload",
+		Description:   "Start a tus resumable upload; chunks are then sent with PATCH. See upload-chunk for how tags failing validation are reported once the upload completes.",
+		Tags:          []string{"uploads"},
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *UploadCreateInput) (*UploadCreateOutput, error) {
+		if err := checkTusResumable(input.TusResumable); err != nil {
+			return nil, err
+		}
+
+		metadata, err := parseUploadMetadata(input.UploadMetadata)
+		if err != nil {
+			return nil, uploadError(
+				app,
+				fmt.Errorf("%w: %v", services.ErrInvalidUploadMetadata, err),
+				"",
+			)
+		}
+
+		progress, err := app.UploadService.CreateUpload(
+			ctx,
+			input.Namespace,
+			firstNonEmpty(metadata["filename"], metadata["name"]),
+			firstNonEmpty(metadata["filetype"], metadata["type"]),
+			input.UploadLength,
+			metadata,
+		)
+		if err != nil {
+			return nil, uploadError(app, err, "")
+		}
+		if err := completeUpload(ctx, app, input.Namespace, progress); err != nil {
+			return nil, err
+		}
+
+		return &UploadCreateOutput{
+			TusResumable:  tusVersion,
+			Location:      uploadLocation(app, input.Namespace, progress.Session),
+			UploadExpires: progress.Session.ExpiresAt.Time.UTC(),
+			DocumentID:    finalizedDocumentID(progress.Session),
+		}, nil
+	})
+
+	// Get upload offset
+	huma.Register(api, huma.Operation{
+		OperationID: "get-upload-offset",
+		Method:      http.MethodHead,
+		Path:        "/api/v1/ns/{namespace}/uploads/{uploadID}",
+		Summary:     "Get resumable upload offset",
+		Description: "Report how many bytes of a resumable upload have been received",
+		Tags:        []string{"uploads"},
+	}, func(ctx context.Context, input *UploadStatusInput) (*UploadStatusOutput, error) {
+		if err := checkTusResumable(input.TusResumable); err != nil {
+			return nil, err
+		}
+
+		session, err := app.UploadService.GetUpload(ctx, input.Namespace, input.UploadID)
+		if err != nil {
+			return nil, uploadError(app, err, input.UploadID)
+		}
+
+		return &UploadStatusOutput{
+			TusResumable:   tusVersion,
+			CacheControl:   "no-store",
+			UploadOffset:   session.UploadOffset,
+			UploadLength:   session.UploadLength,
+			UploadMetadata: encodeUploadMetadata(session.Metadata),
+			UploadExpires:  session.ExpiresAt.Time.UTC(),
+			DocumentID:     finalizedDocumentID(session),
+		}, nil
+	})
+
+	// Upload a chunk
+	huma.Register(api, huma.Operation{
+		OperationID:   "upload-chunk",
+		Method:        http.MethodPatch,
+		Path:          "/api/v1/ns/{namespace}/uploads/{uploadID}",
+		Summary:       "Upload a chunk",
+		Description:   "Append bytes to a resumable upload at the given offset; the document is created once the final byte arrives. If tags from the upload metadata then have attributes that fail validation, the document is still stored without them and the response is a 422 application/problem+json whose errors list each violation, with the document's UUID in document_id and Wayfile-Document-Id.",
+		Tags:          []string{"uploads"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *UploadChunkInput) (*UploadChunkOutput, error) {
+		if err := checkTusResumable(input.TusResumable); err != nil {
+			return nil, err
+		}
+		if input.ContentType != tusChunkContentType {
+			return nil, huma.ErrorWithHeaders(
+				huma.Error415UnsupportedMediaType("Content-Type must be "+tusChunkContentType),
+				http.Header{"Tus-Resumable": {tusVersion}},
+			)
+		}
+
+		progress, err := app.UploadService.AppendChunk(
+			ctx,
+			input.Namespace,
+			input.UploadID,
+			input.UploadOffset,
+			input.body,
+		)
+		if err != nil {
+			return nil, uploadError(app, err, input.UploadID)
+		}
+		if err := completeUpload(ctx, app, input.Namespace, progress); err != nil {
+			return nil, err
+		}
+
+		return &UploadChunkOutput{
+			TusResumable:  tusVersion,
+			UploadOffset:  progress.Session.UploadOffset,
+			UploadExpires: progress.Session.ExpiresAt.Time.UTC(),
+			DocumentID:    finalizedDocumentID(progress.Session),
+		}, nil
+	})
+
+	// Terminate an upload
+	huma.Register(api, huma.Operation{
+		OperationID:   "terminate-upload",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/ns/{namespace}/uploads/{uploadID}",
+		Summary:       "Terminate a resumable upload",
+		Description:   "Cancel a resumable upload and discard any staged chunks",
+		Tags:          []string{"uploads"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *UploadStatusInput) (*UploadTerminateOutput, error) {
+		if err := checkTusResumable(input.TusResumable); err != nil {
+			return nil, err
+		}
+
+		if err := app.UploadService.TerminateUpload(ctx, input.Namespace, input.UploadID); err != nil {
+			return nil, uploadError(app, err, input.UploadID)
+		}
+
+		return &UploadTerminateOutput{TusResumable: tusVersion}, nil
+	})
+}
@@ -0,0 +1,181 @@
+//go:build integration
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+// testEventStream is the stream event feeds read from in tests
+const testEventStream = "WAYFILE_EVENTS_TEST"
+
+// createEventStream creates the stream event feeds read from
+func createEventStream(t *testing.T, ta *TestApp) {
+	js, err := ta.NC.JetStream()
+	require.NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     testEventStream,
+		Subjects: []string{"wayfile.>"},
+	})
+	require.NoError(t, err)
+}
+
+// sseEvent is a single server-sent event
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readSSEEvent reads the next event from a server-sent events stream, skipping comments
+func readSSEEvent(t *testing.T, r *bufio.Reader) sseEvent {
+	var event sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && event.Event != "":
+			return event
+		case strings.HasPrefix(line, "id: "):
+			event.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+// openEventStream connects to a namespace's server-sent events stream
+func openEventStream(
+	t *testing.T,
+	ctx context.Context,
+	ta *TestApp,
+	namespace string,
+	lastEventID string,
+) *bufio.Reader {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		ta.TestServer.URL+"/api/v1/ns/"+namespace+"/events",
+		nil,
+	)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewReader(resp.Body)
+}
+
+// relayOutbox publishes every unsent outbox event
+func relayOutbox(t *testing.T, ta *TestApp) {
+	_, err := ta.Relay.RelayBatch(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWatchEvents(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+	createEventStream(t, ta)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, name := range []string{"watch-test", "watch-other"} {
+		_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+			Name: name,
+		})
+		require.NoError(t, err)
+	}
+	relayOutbox(t, ta)
+
+	// === Only new events in the namespace are streamed ===
+	stream := openEventStream(t, ctx, ta, "watch-test", "")
+
+	uploadTestDocument(t, ta, "watch-other", "other.txt", "text/plain", []byte("other"))
+	first := uploadTestDocument(t, ta, "watch-test", "a.txt", "text/plain", []byte("first"))
+	relayOutbox(t, ta)
+
+	event := readSSEEvent(t, stream)
+	require.Equal(t, events.DocumentUploaded, event.Event)
+
+	var payload struct {
+		ID        string `json:"id"`
+		Type      string `json:"type"`
+		Namespace string `json:"namespace"`
+		Data      struct {
+			DocumentID string `json:"document_id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &payload))
+	require.NotEmpty(t, payload.ID)
+	require.Equal(t, events.DocumentUploaded, payload.Type)
+	require.Equal(t, "watch-test", payload.Namespace)
+	require.Equal(t, first.ID, payload.Data.DocumentID)
+
+	firstSeq, err := strconv.ParseUint(event.ID, 10, 64)
+	require.NoError(t, err)
+
+	// === Reconnecting with Last-Event-ID resumes after that event ===
+	second := uploadTestDocument(t, ta, "watch-test", "b.txt", "text/plain", []byte("second"))
+	relayOutbox(t, ta)
+
+	resumed := openEventStream(t, ctx, ta, "watch-test", event.ID)
+	event = readSSEEvent(t, resumed)
+	require.Equal(t, events.DocumentUploaded, event.Event)
+	require.NoError(t, json.Unmarshal([]byte(event.Data), &payload))
+	require.Equal(t, second.ID, payload.Data.DocumentID)
+
+	// === The Connect stream resumes from a sequence too ===
+	rpcStream, err := ta.EventClient.WatchEvents(ctx, &eventsv1.WatchEventsRequest{
+		Namespace:     "watch-test",
+		AfterSequence: firstSeq,
+	})
+	require.NoError(t, err)
+	require.True(t, rpcStream.Receive(), rpcStream.Err())
+	msg := rpcStream.Msg()
+	require.Greater(t, msg.Sequence, firstSeq)
+	require.Equal(t, events.DocumentUploaded, msg.EventType)
+	require.Equal(t, second.ID, msg.GetDocumentUploaded().DocumentId)
+	require.NotEmpty(t, msg.GetDocumentUploaded().GetContext().GetEventId())
+
+	// === Invalid requests ===
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ns/no-such-namespace/events", nil)
+	w := httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/ns/watch-test/events", nil)
+	req.Header.Set("Last-Event-ID", "not-a-sequence")
+	w = httptest.NewRecorder()
+	ta.Router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	missing, err := ta.EventClient.WatchEvents(ctx, &eventsv1.WatchEventsRequest{
+		Namespace: "no-such-namespace",
+	})
+	require.NoError(t, err)
+	require.False(t, missing.Receive())
+	require.Error(t, missing.Err())
+}
@@ -0,0 +1,253 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	namespacesv1 "github.com/RynoXLI/Wayfile/gen/go/namespaces/v1"
+	tagsv1 "github.com/RynoXLI/Wayfile/gen/go/tags/v1"
+	webhooksv1 "github.com/RynoXLI/Wayfile/gen/go/webhooks/v1"
+	"github.com/RynoXLI/Wayfile/internal/auth"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/services"
+)
+
+// webhookReceiver is a local webhook endpoint that records what it's sent
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) respondWith(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *webhookReceiver) received() ([]*http.Request, [][]byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests, r.bodies
+}
+
+// dispatchOutboxEvents hands unsent outbox events to the webhook service as if they had
+// been consumed from JetStream
+func dispatchOutboxEvents(t *testing.T, ta *TestApp) {
+	subjects, payloads := takeOutboxEvents(t, ta)
+	for i, subject := range subjects {
+		require.NoError(t, ta.App.WebhookService.HandleEvent(
+			context.Background(),
+			subject,
+			payloads[i],
+		))
+	}
+}
+
+// deliverWebhooks makes every pending delivery due and attempts it
+func deliverWebhooks(t *testing.T, ta *TestApp) int {
+	ctx := context.Background()
+	_, err := ta.Pool.Exec(
+		ctx,
+		"UPDATE webhook_deliveries SET next_attempt_at = NOW() WHERE status = 'pending'",
+	)
+	require.NoError(t, err)
+	delivered, err := ta.App.WebhookService.DeliverDue(ctx)
+	require.NoError(t, err)
+	return delivered
+}
+
+func TestWebhooks(t *testing.T) {
+	ta := SetupTestApp(t)
+	defer ta.Cleanup(t)
+
+	ctx := context.Background()
+	_, err := ta.NamespaceClient.CreateNamespace(ctx, &namespacesv1.CreateNamespaceRequest{
+		Name: "webhook-test",
+	})
+	require.NoError(t, err)
+
+	receiver := &webhookReceiver{status: http.StatusNoContent}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	// === Create a webhook for uploads ===
+	created, err := ta.WebhookClient.CreateWebhook(ctx, &webhooksv1.CreateWebhookRequest{
+		Namespace:  "webhook-test",
+		Url:        server.URL + "/hook",
+		EventTypes: []string{events.DocumentUploaded},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.Secret)
+	webhookID := created.Webhook.Id
+
+	list, err := ta.WebhookClient.ListWebhooks(ctx, &webhooksv1.ListWebhooksRequest{
+		Namespace: "webhook-test",
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Webhooks, 1)
+	require.Equal(t, []string{events.DocumentUploaded}, list.Webhooks[0].EventTypes)
+
+	// === Matching events are delivered, signed ===
+	takeOutboxEvents(t, ta)
+	doc := uploadTestDocument(t, ta, "webhook-test", "a.txt", "text/plain", []byte("hook"))
+	_, err = ta.TagClient.CreateTag(ctx, &tagsv1.CreateTagRequest{
+		Namespace: "webhook-test",
+		Name:      "unrelated",
+	})
+	require.NoError(t, err)
+	dispatchOutboxEvents(t, ta)
+	require.Equal(t, 1, deliverWebhooks(t, ta))
+
+	requests, bodies := receiver.received()
+	require.Len(t, requests, 1)
+	require.Equal(t, "/hook", requests[0].URL.Path)
+	require.Equal(t, "application/json", requests[0].Header.Get("Content-Type"))
+	require.Equal(t, events.DocumentUploaded, requests[0].Header.Get(services.WebhookEventHeader))
+	require.NoError(t, auth.NewSigner(created.Secret).VerifyPayload(
+		requests[0].Header.Get(auth.SignatureHeader),
+		bodies[0],
+		time.Minute,
+	))
+
+	var payload struct {
+		ID        string `json:"id"`
+		Type      string `json:"type"`
+		Namespace string `json:"namespace"`
+		Data      struct {
+			DocumentID string `json:"document_id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(bodies[0], &payload))
+	require.NotEmpty(t, payload.ID)
+	require.Equal(t, events.DocumentUploaded, payload.Type)
+	require.Equal(t, "webhook-test", payload.Namespace)
+	require.Equal(t, doc.ID, payload.Data.DocumentID)
+
+	deliveries, err := ta.WebhookClient.ListWebhookDeliveries(
+		ctx,
+		&webhooksv1.ListWebhookDeliveriesRequest{
+			Namespace: "webhook-test",
+			WebhookId: webhookID,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, deliveries.Deliveries, 1)
+	delivered := deliveries.Deliveries[0]
+	require.Equal(t, services.WebhookDeliverySucceeded, delivered.Status)
+	require.Equal(t, payload.ID, delivered.EventId)
+	require.Equal(t, int32(1), delivered.Attempts)
+	require.Equal(t, int32(http.StatusNoContent), delivered.GetResponseStatus())
+	require.Equal(t, requests[0].Header.Get(services.WebhookDeliveryHeader), delivered.Id)
+	require.NotNil(t, delivered.DeliveredAt)
+
+	// === Failed deliveries are retried, then dead-lettered ===
+	receiver.respondWith(http.StatusInternalServerError)
+	uploadTestDocument(t, ta, "webhook-test", "b.txt", "text/plain", []byte("retry"))
+	subjects, payloads := takeOutboxEvents(t, ta)
+	require.Len(t, subjects, 1)
+
+	// JetStream redelivering the event doesn't queue it twice
+	for range 2 {
+		require.NoError(t, ta.App.WebhookService.HandleEvent(ctx, subjects[0], payloads[0]))
+	}
+
+	for range 3 {
+		require.Equal(t, 1, deliverWebhooks(t, ta))
+	}
+	require.Equal(t, 0, deliverWebhooks(t, ta))
+
+	dead, err := ta.WebhookClient.ListWebhookDeliveries(
+		ctx,
+		&webhooksv1.ListWebhookDeliveriesRequest{
+			Namespace: "webhook-test",
+			WebhookId: webhookID,
+			Status:    stringPtr(services.WebhookDeliveryDead),
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, dead.Deliveries, 1)
+	require.Equal(t, int32(3), dead.Deliveries[0].Attempts)
+	require.Equal(t, int32(http.StatusInternalServerError), dead.Deliveries[0].GetResponseStatus())
+	require.NotEmpty(t, dead.Deliveries[0].GetError())
+
+	// === Dead-lettered deliveries can be retried ===
+	receiver.respondWith(http.StatusOK)
+	retried, err := ta.WebhookClient.RetryWebhookDelivery(
+		ctx,
+		&webhooksv1.RetryWebhookDeliveryRequest{
+			Namespace:  "webhook-test",
+			DeliveryId: dead.Deliveries[0].Id,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, services.WebhookDeliveryPending, retried.Delivery.Status)
+	require.Equal(t, 1, deliverWebhooks(t, ta))
+
+	_, err = ta.WebhookClient.RetryWebhookDelivery(ctx, &webhooksv1.RetryWebhookDeliveryRequest{
+		Namespace:  "webhook-test",
+		DeliveryId: dead.Deliveries[0].Id,
+	})
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	requests, _ = receiver.received()
+	require.Len(t, requests, 5)
+
+	// === Invalid webhooks are rejected ===
+	_, err = ta.WebhookClient.CreateWebhook(ctx, &webhooksv1.CreateWebhookRequest{
+		Namespace: "webhook-test",
+		Url:       "not a url",
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = ta.WebhookClient.CreateWebhook(ctx, &webhooksv1.CreateWebhookRequest{
+		Namespace:  "webhook-test",
+		Url:        server.URL,
+		EventTypes: []string{"documents.exploded"},
+	})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = ta.WebhookClient.CreateWebhook(ctx, &webhooksv1.CreateWebhookRequest{
+		Namespace: "no-such-namespace",
+		Url:       server.URL,
+	})
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// === Deleting a webhook stops deliveries ===
+	_, err = ta.WebhookClient.DeleteWebhook(ctx, &webhooksv1.DeleteWebhookRequest{
+		Namespace: "webhook-test",
+		WebhookId: webhookID,
+	})
+	require.NoError(t, err)
+
+	uploadTestDocument(t, ta, "webhook-test", "c.txt", "text/plain", []byte("gone"))
+	dispatchOutboxEvents(t, ta)
+	require.Equal(t, 0, deliverWebhooks(t, ta))
+
+	_, err = ta.WebhookClient.DeleteWebhook(ctx, &webhooksv1.DeleteWebhookRequest{
+		Namespace: "webhook-test",
+		WebhookId: webhookID,
+	})
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
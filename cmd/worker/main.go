@@ -0,0 +1,155 @@
+// cmd/worker/main.go extractor worker entry point
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nats-io/nats.go"
+
+	"github.com/RynoXLI/Wayfile/internal/auth"
+	"github.com/RynoXLI/Wayfile/internal/config"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/services"
+	"github.com/RynoXLI/Wayfile/internal/storage"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	// Setup logger
+	var handler slog.Handler
+	if cfg.Logging.Format == "json" {
+		handler = slog.NewJSONHandler(os.Stdout, nil)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, nil)
+	}
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+	logger.Info("Starting extractor worker...")
+
+	// Cancelled on SIGINT/SIGTERM to finish in-flight documents and stop
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Connect to PostgreSQL
+	pool, err := pgxpool.New(ctx, cfg.Database.URL)
+	if err != nil {
+		log.Fatal("Unable to connect to database:", err)
+	}
+	defer pool.Close()
+
+	logger.Info("Connected to PostgreSQL")
+
+	// Connect to NATS and create JetStream context
+	nc, err := nats.Connect(cfg.NATS.URL)
+	if err != nil {
+		log.Fatal("Unable to connect to NATS:", err)
+	}
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	if err != nil {
+		log.Fatal("Unable to create JetStream context:", err)
+	}
+
+	logger.Info("Connected to NATS with JetStream")
+
+	// Provisioning is idempotent, so the worker can start before the API server
+	if err := events.Provision(js, cfg.NATS.Streams, cfg.NATS.Consumers); err != nil {
+		log.Fatal("Unable to provision JetStream:", err)
+	}
+
+	// Initialize storage client
+	if cfg.Storage.Type != "local" {
+		log.Fatal("Unsupported storage type:", cfg.Storage.Type)
+	}
+	localClient, err := storage.NewLocalStorage(cfg.Storage.Local.Path, logger)
+	if err != nil {
+		log.Fatal("Unable to initialize storage:", err)
+	}
+
+	// Initialize services. Tags are written through the outbox; the API server's relay
+	// publishes them.
+	subjectMode, err := events.ParseSubjectMode(cfg.NATS.SubjectMode)
+	if err != nil {
+		log.Fatal("Invalid NATS configuration:", err)
+	}
+	queries := sqlc.New(pool)
+	outbox := events.NewOutbox(queries, subjectMode)
+	storageService := storage.NewStorage(localClient, queries, logger)
+	tagService := services.NewTagService(pool, queries, outbox)
+	documentService := services.NewDocumentService(
+		storageService,
+		outbox,
+		auth.NewSigner(cfg.Server.SigningSecret),
+		cfg.Server.BaseURL,
+		pool,
+		queries,
+		tagService,
+	)
+
+	// Extractors run over every uploaded document they accept
+	reviewService := services.NewReviewService(queries, documentService, tagService)
+	extractionService := services.NewExtractionService(
+		storageService,
+		documentService,
+		reviewService,
+	)
+	extractionService.Register(services.NewRuleService(queries, storageService, tagService))
+
+	// Schema changes made elsewhere invalidate the compiled schemas extraction validates with
+	err = tagService.FollowSchemaChanges(
+		ctx,
+		events.NewWatcher(js, cfg.NATS.EventStream),
+		events.SchemaInvalidationSubjects(subjectMode),
+	)
+	if err != nil {
+		log.Fatal("Unable to follow schema changes:", err)
+	}
+	if cfg.Logging.StatsInterval > 0 {
+		go tagService.ReportSchemaCacheStats(
+			ctx,
+			time.Duration(cfg.Logging.StatsInterval)*time.Second,
+		)
+	}
+
+	logger.Info("Consuming uploaded documents",
+		"stream", cfg.Extraction.Stream,
+		"consumer", cfg.Extraction.Consumer,
+		"concurrency", cfg.Extraction.Concurrency,
+	)
+	err = events.Consume(
+		ctx,
+		js,
+		cfg.Extraction.Stream,
+		cfg.Extraction.Consumer,
+		max(cfg.Extraction.Concurrency, 1), // fetch no more than can be worked on at once
+		extractionService.HandleEvent,
+		events.WithConcurrency(cfg.Extraction.Concurrency),
+		events.WithRetryBackoff(
+			time.Duration(cfg.Extraction.InitialBackoff)*time.Second,
+			time.Duration(cfg.Extraction.MaxBackoff)*time.Second,
+		),
+	)
+	if err != nil {
+		log.Fatal("Extractor consumer failed:", err)
+	}
+	schemaStats := tagService.SchemaCacheStats()
+	logger.Info("Extractor worker stopped",
+		"schema_cache_hit_rate", schemaStats.HitRate(),
+		"schema_compiles", schemaStats.Compiles,
+		"schema_compile_time", schemaStats.CompileTime,
+	)
+}
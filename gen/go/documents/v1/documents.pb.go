@@ -22,6 +22,173 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SkipReason is why an attribute's current value took precedence over an extracted one.
+type SkipReason int32
+
+const (
+	// SKIP_REASON_UNSPECIFIED is not used.
+	SkipReason_SKIP_REASON_UNSPECIFIED SkipReason = 0
+	// SKIP_REASON_MANUAL means the current value was set manually.
+	SkipReason_SKIP_REASON_MANUAL SkipReason = 1
+	// SKIP_REASON_HIGHER_CONFIDENCE means the current value was extracted with a higher
+	// confidence.
+	SkipReason_SKIP_REASON_HIGHER_CONFIDENCE SkipReason = 2
+)
+
+// Enum value maps for SkipReason.
+var (
+	SkipReason_name = map[int32]string{
+		0: "SKIP_REASON_UNSPECIFIED",
+		1: "SKIP_REASON_MANUAL",
+		2: "SKIP_REASON_HIGHER_CONFIDENCE",
+	}
+	SkipReason_value = map[string]int32{
+		"SKIP_REASON_UNSPECIFIED":       0,
+		"SKIP_REASON_MANUAL":            1,
+		"SKIP_REASON_HIGHER_CONFIDENCE": 2,
+	}
+)
+
+func (x SkipReason) Enum() *SkipReason {
+	p := new(SkipReason)
+	*p = x
+	return p
+}
+
+func (x SkipReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SkipReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_documents_v1_documents_proto_enumTypes[0].Descriptor()
+}
+
+func (SkipReason) Type() protoreflect.EnumType {
+	return &file_documents_v1_documents_proto_enumTypes[0]
+}
+
+func (x SkipReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SkipReason.Descriptor instead.
+func (SkipReason) EnumDescriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{0}
+}
+
+// AttributeUpdateMode is how an attributes update is applied to the current attributes.
+// Only the provenance of attributes that change is updated.
+type AttributeUpdateMode int32
+
+const (
+	// ATTRIBUTE_UPDATE_MODE_UNSPECIFIED replaces the attributes.
+	AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_UNSPECIFIED AttributeUpdateMode = 0
+	// ATTRIBUTE_UPDATE_MODE_REPLACE replaces the attributes with a new JSON object.
+	AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_REPLACE AttributeUpdateMode = 1
+	// ATTRIBUTE_UPDATE_MODE_MERGE_PATCH applies a JSON Merge Patch (RFC 7396).
+	// Members set to null are removed.
+	AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_MERGE_PATCH AttributeUpdateMode = 2
+	// ATTRIBUTE_UPDATE_MODE_JSON_PATCH applies a JSON Patch (RFC 6902), an array of
+	// operations. A failed test operation fails the update with FAILED_PRECONDITION.
+	AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_JSON_PATCH AttributeUpdateMode = 3
+)
+
+// Enum value maps for AttributeUpdateMode.
+var (
+	AttributeUpdateMode_name = map[int32]string{
+		0: "ATTRIBUTE_UPDATE_MODE_UNSPECIFIED",
+		1: "ATTRIBUTE_UPDATE_MODE_REPLACE",
+		2: "ATTRIBUTE_UPDATE_MODE_MERGE_PATCH",
+		3: "ATTRIBUTE_UPDATE_MODE_JSON_PATCH",
+	}
+	AttributeUpdateMode_value = map[string]int32{
+		"ATTRIBUTE_UPDATE_MODE_UNSPECIFIED": 0,
+		"ATTRIBUTE_UPDATE_MODE_REPLACE":     1,
+		"ATTRIBUTE_UPDATE_MODE_MERGE_PATCH": 2,
+		"ATTRIBUTE_UPDATE_MODE_JSON_PATCH":  3,
+	}
+)
+
+func (x AttributeUpdateMode) Enum() *AttributeUpdateMode {
+	p := new(AttributeUpdateMode)
+	*p = x
+	return p
+}
+
+func (x AttributeUpdateMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttributeUpdateMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_documents_v1_documents_proto_enumTypes[1].Descriptor()
+}
+
+func (AttributeUpdateMode) Type() protoreflect.EnumType {
+	return &file_documents_v1_documents_proto_enumTypes[1]
+}
+
+func (x AttributeUpdateMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttributeUpdateMode.Descriptor instead.
+func (AttributeUpdateMode) EnumDescriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{1}
+}
+
+// AttributeFilterOperator is how an attribute filter compares an attribute to its value.
+type AttributeFilterOperator int32
+
+const (
+	// ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED matches attributes equal to the value.
+	AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED AttributeFilterOperator = 0
+	// ATTRIBUTE_FILTER_OPERATOR_EQUALS matches attributes equal to the value.
+	AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_EQUALS AttributeFilterOperator = 1
+	// ATTRIBUTE_FILTER_OPERATOR_CONTAINS matches array attributes with the value as an element.
+	AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_CONTAINS AttributeFilterOperator = 2
+)
+
+// Enum value maps for AttributeFilterOperator.
+var (
+	AttributeFilterOperator_name = map[int32]string{
+		0: "ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED",
+		1: "ATTRIBUTE_FILTER_OPERATOR_EQUALS",
+		2: "ATTRIBUTE_FILTER_OPERATOR_CONTAINS",
+	}
+	AttributeFilterOperator_value = map[string]int32{
+		"ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED": 0,
+		"ATTRIBUTE_FILTER_OPERATOR_EQUALS":      1,
+		"ATTRIBUTE_FILTER_OPERATOR_CONTAINS":    2,
+	}
+)
+
+func (x AttributeFilterOperator) Enum() *AttributeFilterOperator {
+	p := new(AttributeFilterOperator)
+	*p = x
+	return p
+}
+
+func (x AttributeFilterOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttributeFilterOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_documents_v1_documents_proto_enumTypes[2].Descriptor()
+}
+
+func (AttributeFilterOperator) Type() protoreflect.EnumType {
+	return &file_documents_v1_documents_proto_enumTypes[2]
+}
+
+func (x AttributeFilterOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttributeFilterOperator.Descriptor instead.
+func (AttributeFilterOperator) EnumDescriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{2}
+}
+
 // UpdateDocumentRequest contains the data needed to update a document.
 type UpdateDocumentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -245,7 +412,25 @@ type AddTagToDocumentRequest struct {
 	TagPath string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
 	// attributes is an optional JSON object containing tag-specific attributes.
 	// Must conform to the tag's attribute schema if one exists.
-	Attributes    *string `protobuf:"bytes,4,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"`
+	Attributes *string `protobuf:"bytes,4,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"`
+	// extractor identifies the external extractor that produced the tag (optional - if
+	// empty, the tag is added manually). Manual tags replace the current attributes;
+	// extracted ones keep attributes that were set manually or extracted with a higher
+	// confidence.
+	Extractor *string `protobuf:"bytes,5,opt,name=extractor,proto3,oneof" json:"extractor,omitempty"`
+	// confidence is the extractor's confidence in the tag, between 0 and 1.
+	Confidence *float64 `protobuf:"fixed64,6,opt,name=confidence,proto3,oneof" json:"confidence,omitempty"`
+	// force makes an extracted tag replace the current attributes regardless of how they
+	// were set.
+	Force bool `protobuf:"varint,7,opt,name=force,proto3" json:"force,omitempty"`
+	// coerce converts attribute values to the types the tag's schema declares before
+	// validation, e.g. "1.234,5" to a number or "03/15/2024" to a date. Each conversion is
+	// recorded in the attribute's provenance metadata.
+	Coerce bool `protobuf:"varint,8,opt,name=coerce,proto3" json:"coerce,omitempty"`
+	// locale is the BCP 47 language tag the attributes were written in (e.g. "de-DE"),
+	// deciding the decimal separator of numbers and the order of numeric dates. Requires
+	// coerce; without it, ambiguous values are left unconverted.
+	Locale        *string `protobuf:"bytes,9,opt,name=locale,proto3,oneof" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -308,11 +493,49 @@ func (x *AddTagToDocumentRequest) GetAttributes() string {
 	return ""
 }
 
+func (x *AddTagToDocumentRequest) GetExtractor() string {
+	if x != nil && x.Extractor != nil {
+		return *x.Extractor
+	}
+	return ""
+}
+
+func (x *AddTagToDocumentRequest) GetConfidence() float64 {
+	if x != nil && x.Confidence != nil {
+		return *x.Confidence
+	}
+	return 0
+}
+
+func (x *AddTagToDocumentRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *AddTagToDocumentRequest) GetCoerce() bool {
+	if x != nil {
+		return x.Coerce
+	}
+	return false
+}
+
+func (x *AddTagToDocumentRequest) GetLocale() string {
+	if x != nil && x.Locale != nil {
+		return *x.Locale
+	}
+	return ""
+}
+
 // AddTagToDocumentResponse is returned when a tag is successfully added to a document.
 type AddTagToDocumentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// skipped_attributes are the extracted attributes that were not written because the
+	// current values take precedence.
+	SkippedAttributes []*SkippedAttribute `protobuf:"bytes,1,rep,name=skipped_attributes,json=skippedAttributes,proto3" json:"skipped_attributes,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *AddTagToDocumentResponse) Reset() {
@@ -345,6 +568,68 @@ func (*AddTagToDocumentResponse) Descriptor() ([]byte, []int) {
 	return file_documents_v1_documents_proto_rawDescGZIP(), []int{5}
 }
 
+func (x *AddTagToDocumentResponse) GetSkippedAttributes() []*SkippedAttribute {
+	if x != nil {
+		return x.SkippedAttributes
+	}
+	return nil
+}
+
+// SkippedAttribute is an extracted attribute that was not written.
+type SkippedAttribute struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field is the name of the attribute.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// reason is why the current value was kept.
+	Reason        SkipReason `protobuf:"varint,2,opt,name=reason,proto3,enum=documents.v1.SkipReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkippedAttribute) Reset() {
+	*x = SkippedAttribute{}
+	mi := &file_documents_v1_documents_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkippedAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkippedAttribute) ProtoMessage() {}
+
+func (x *SkippedAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v1_documents_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkippedAttribute.ProtoReflect.Descriptor instead.
+func (*SkippedAttribute) Descriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SkippedAttribute) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SkippedAttribute) GetReason() SkipReason {
+	if x != nil {
+		return x.Reason
+	}
+	return SkipReason_SKIP_REASON_UNSPECIFIED
+}
+
 // RemoveTagFromDocumentRequest contains the information needed to remove a tag from a document.
 type RemoveTagFromDocumentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -361,7 +646,7 @@ type RemoveTagFromDocumentRequest struct {
 
 func (x *RemoveTagFromDocumentRequest) Reset() {
 	*x = RemoveTagFromDocumentRequest{}
-	mi := &file_documents_v1_documents_proto_msgTypes[6]
+	mi := &file_documents_v1_documents_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -373,7 +658,7 @@ func (x *RemoveTagFromDocumentRequest) String() string {
 func (*RemoveTagFromDocumentRequest) ProtoMessage() {}
 
 func (x *RemoveTagFromDocumentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[6]
+	mi := &file_documents_v1_documents_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,7 +671,7 @@ func (x *RemoveTagFromDocumentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveTagFromDocumentRequest.ProtoReflect.Descriptor instead.
 func (*RemoveTagFromDocumentRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{6}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RemoveTagFromDocumentRequest) GetNamespace() string {
@@ -419,7 +704,7 @@ type RemoveTagFromDocumentResponse struct {
 
 func (x *RemoveTagFromDocumentResponse) Reset() {
 	*x = RemoveTagFromDocumentResponse{}
-	mi := &file_documents_v1_documents_proto_msgTypes[7]
+	mi := &file_documents_v1_documents_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -431,7 +716,7 @@ func (x *RemoveTagFromDocumentResponse) String() string {
 func (*RemoveTagFromDocumentResponse) ProtoMessage() {}
 
 func (x *RemoveTagFromDocumentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[7]
+	mi := &file_documents_v1_documents_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +729,7 @@ func (x *RemoveTagFromDocumentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveTagFromDocumentResponse.ProtoReflect.Descriptor instead.
 func (*RemoveTagFromDocumentResponse) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{7}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{8}
 }
 
 // ListDocumentTagsRequest contains the information needed to list tags on a document.
@@ -460,7 +745,7 @@ type ListDocumentTagsRequest struct {
 
 func (x *ListDocumentTagsRequest) Reset() {
 	*x = ListDocumentTagsRequest{}
-	mi := &file_documents_v1_documents_proto_msgTypes[8]
+	mi := &file_documents_v1_documents_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -472,7 +757,7 @@ func (x *ListDocumentTagsRequest) String() string {
 func (*ListDocumentTagsRequest) ProtoMessage() {}
 
 func (x *ListDocumentTagsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[8]
+	mi := &file_documents_v1_documents_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +770,7 @@ func (x *ListDocumentTagsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDocumentTagsRequest.ProtoReflect.Descriptor instead.
 func (*ListDocumentTagsRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{8}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListDocumentTagsRequest) GetNamespace() string {
@@ -521,7 +806,7 @@ type DocumentTag struct {
 
 func (x *DocumentTag) Reset() {
 	*x = DocumentTag{}
-	mi := &file_documents_v1_documents_proto_msgTypes[9]
+	mi := &file_documents_v1_documents_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -533,7 +818,7 @@ func (x *DocumentTag) String() string {
 func (*DocumentTag) ProtoMessage() {}
 
 func (x *DocumentTag) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[9]
+	mi := &file_documents_v1_documents_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -546,7 +831,7 @@ func (x *DocumentTag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DocumentTag.ProtoReflect.Descriptor instead.
 func (*DocumentTag) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{9}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DocumentTag) GetName() string {
@@ -595,7 +880,7 @@ type ListDocumentTagsResponse struct {
 
 func (x *ListDocumentTagsResponse) Reset() {
 	*x = ListDocumentTagsResponse{}
-	mi := &file_documents_v1_documents_proto_msgTypes[10]
+	mi := &file_documents_v1_documents_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -607,7 +892,7 @@ func (x *ListDocumentTagsResponse) String() string {
 func (*ListDocumentTagsResponse) ProtoMessage() {}
 
 func (x *ListDocumentTagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[10]
+	mi := &file_documents_v1_documents_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,7 +905,7 @@ func (x *ListDocumentTagsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDocumentTagsResponse.ProtoReflect.Descriptor instead.
 func (*ListDocumentTagsResponse) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{10}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListDocumentTagsResponse) GetTags() []*DocumentTag {
@@ -646,7 +931,7 @@ type GetDocumentAttributesRequest struct {
 
 func (x *GetDocumentAttributesRequest) Reset() {
 	*x = GetDocumentAttributesRequest{}
-	mi := &file_documents_v1_documents_proto_msgTypes[11]
+	mi := &file_documents_v1_documents_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -658,7 +943,7 @@ func (x *GetDocumentAttributesRequest) String() string {
 func (*GetDocumentAttributesRequest) ProtoMessage() {}
 
 func (x *GetDocumentAttributesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[11]
+	mi := &file_documents_v1_documents_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,7 +956,7 @@ func (x *GetDocumentAttributesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocumentAttributesRequest.ProtoReflect.Descriptor instead.
 func (*GetDocumentAttributesRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{11}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetDocumentAttributesRequest) GetNamespace() string {
@@ -708,7 +993,7 @@ type GetDocumentAttributesResponse struct {
 
 func (x *GetDocumentAttributesResponse) Reset() {
 	*x = GetDocumentAttributesResponse{}
-	mi := &file_documents_v1_documents_proto_msgTypes[12]
+	mi := &file_documents_v1_documents_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -720,7 +1005,7 @@ func (x *GetDocumentAttributesResponse) String() string {
 func (*GetDocumentAttributesResponse) ProtoMessage() {}
 
 func (x *GetDocumentAttributesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[12]
+	mi := &file_documents_v1_documents_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,7 +1018,7 @@ func (x *GetDocumentAttributesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocumentAttributesResponse.ProtoReflect.Descriptor instead.
 func (*GetDocumentAttributesResponse) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{12}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetDocumentAttributesResponse) GetAttributes() string {
@@ -760,15 +1045,25 @@ type UpdateDocumentAttributesRequest struct {
 	// tag_path is the full path of the tag (optional - if empty, updates document global attributes).
 	// Must include a leading slash (e.g., "/backend/api").
 	TagPath *string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3,oneof" json:"tag_path,omitempty"`
-	// attributes is the JSON object containing the new attributes.
-	Attributes    string `protobuf:"bytes,4,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// attributes is the JSON object containing the new attributes, or the patch to apply
+	// to the current attributes in the patch modes.
+	Attributes string `protobuf:"bytes,4,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// mode is how attributes is applied. Defaults to replacing the attributes.
+	Mode AttributeUpdateMode `protobuf:"varint,5,opt,name=mode,proto3,enum=documents.v1.AttributeUpdateMode" json:"mode,omitempty"`
+	// coerce converts the tag's attribute values to the types its schema declares before
+	// validation, recording each conversion in the attribute's provenance metadata.
+	// Requires tag_path.
+	Coerce bool `protobuf:"varint,6,opt,name=coerce,proto3" json:"coerce,omitempty"`
+	// locale is the BCP 47 language tag the attributes were written in (e.g. "de-DE").
+	// Requires coerce.
+	Locale        *string `protobuf:"bytes,7,opt,name=locale,proto3,oneof" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateDocumentAttributesRequest) Reset() {
 	*x = UpdateDocumentAttributesRequest{}
-	mi := &file_documents_v1_documents_proto_msgTypes[13]
+	mi := &file_documents_v1_documents_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -780,7 +1075,7 @@ func (x *UpdateDocumentAttributesRequest) String() string {
 func (*UpdateDocumentAttributesRequest) ProtoMessage() {}
 
 func (x *UpdateDocumentAttributesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[13]
+	mi := &file_documents_v1_documents_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -793,7 +1088,7 @@ func (x *UpdateDocumentAttributesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDocumentAttributesRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDocumentAttributesRequest) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{13}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpdateDocumentAttributesRequest) GetNamespace() string {
@@ -824,6 +1119,27 @@ func (x *UpdateDocumentAttributesRequest) GetAttributes() string {
 	return ""
 }
 
+func (x *UpdateDocumentAttributesRequest) GetMode() AttributeUpdateMode {
+	if x != nil {
+		return x.Mode
+	}
+	return AttributeUpdateMode_ATTRIBUTE_UPDATE_MODE_UNSPECIFIED
+}
+
+func (x *UpdateDocumentAttributesRequest) GetCoerce() bool {
+	if x != nil {
+		return x.Coerce
+	}
+	return false
+}
+
+func (x *UpdateDocumentAttributesRequest) GetLocale() string {
+	if x != nil && x.Locale != nil {
+		return *x.Locale
+	}
+	return ""
+}
+
 // UpdateDocumentAttributesResponse is returned when attributes are successfully updated.
 type UpdateDocumentAttributesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -833,7 +1149,7 @@ type UpdateDocumentAttributesResponse struct {
 
 func (x *UpdateDocumentAttributesResponse) Reset() {
 	*x = UpdateDocumentAttributesResponse{}
-	mi := &file_documents_v1_documents_proto_msgTypes[14]
+	mi := &file_documents_v1_documents_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -845,7 +1161,7 @@ func (x *UpdateDocumentAttributesResponse) String() string {
 func (*UpdateDocumentAttributesResponse) ProtoMessage() {}
 
 func (x *UpdateDocumentAttributesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_documents_v1_documents_proto_msgTypes[14]
+	mi := &file_documents_v1_documents_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,74 +1174,585 @@ func (x *UpdateDocumentAttributesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDocumentAttributesResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDocumentAttributesResponse) Descriptor() ([]byte, []int) {
-	return file_documents_v1_documents_proto_rawDescGZIP(), []int{14}
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{15}
 }
 
-var File_documents_v1_documents_proto protoreflect.FileDescriptor
+// GetAttributeHistoryRequest selects the attribute changes to list.
+type GetAttributeHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace containing the document.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// document_id is the unique identifier of the document.
+	DocumentId string `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// tag_path is the full path of the tag (optional - if empty, lists the history of the
+	// document global attributes). Must include a leading slash (e.g., "/backend/api").
+	TagPath *string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3,oneof" json:"tag_path,omitempty"`
+	// field limits the history to a single attribute (optional - if empty, lists every attribute).
+	Field         *string `protobuf:"bytes,4,opt,name=field,proto3,oneof" json:"field,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_documents_v1_documents_proto_rawDesc = "" +
-	"\n" +
-	"\x1cdocuments/v1/documents.proto\x12\fdocuments.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"R\n" +
-	"\x15UpdateDocumentRequest\x12\x1f\n" +
-	"\vdocument_id\x18\x01 \x01(\tR\n" +
-	"documentId\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"i\n" +
-	"\x16UpdateDocumentResponse\x12\x1f\n" +
-	"\vdocument_id\x18\x01 \x01(\tR\n" +
-	"documentId\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\x12\x14\n" +
-	"\x05title\x18\x03 \x01(\tR\x05title\"V\n" +
-	"\x15DeleteDocumentRequest\x12\x1c\n" +
-	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
-	"\vdocument_id\x18\x02 \x01(\tR\n" +
-	"documentId\"\x18\n" +
-	"\x16DeleteDocumentResponse\"\xa7\x01\n" +
-	"\x17AddTagToDocumentRequest\x12\x1c\n" +
-	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
-	"\vdocument_id\x18\x02 \x01(\tR\n" +
-	"documentId\x12\x19\n" +
-	"\btag_path\x18\x03 \x01(\tR\atagPath\x12#\n" +
-	"\n" +
-	"attributes\x18\x04 \x01(\tH\x00R\n" +
-	"attributes\x88\x01\x01B\r\n" +
-	"\v_attributes\"\x1a\n" +
-	"\x18AddTagToDocumentResponse\"x\n" +
-	"\x1cRemoveTagFromDocumentRequest\x12\x1c\n" +
-	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
-	"\vdocument_id\x18\x02 \x01(\tR\n" +
-	"documentId\x12\x19\n" +
-	"\btag_path\x18\x03 \x01(\tR\atagPath\"\x1f\n" +
-	"\x1dRemoveTagFromDocumentResponse\"X\n" +
-	"\x17ListDocumentTagsRequest\x12\x1c\n" +
-	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
-	"\vdocument_id\x18\x02 \x01(\tR\n" +
-	"documentId\"\xd9\x01\n" +
-	"\vDocumentTag\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
-	"\btag_path\x18\x02 \x01(\tR\atagPath\x12#\n" +
-	"\n" +
-	"attributes\x18\x03 \x01(\tH\x00R\n" +
-	"attributes\x88\x01\x01\x12\x1f\n" +
-	"\bmetadata\x18\x04 \x01(\tH\x01R\bmetadata\x88\x01\x01\x129\n" +
-	"\n" +
-	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\r\n" +
-	"\v_attributesB\v\n" +
-	"\t_metadata\"I\n" +
-	"\x18ListDocumentTagsResponse\x12-\n" +
-	"\x04tags\x18\x01 \x03(\v2\x19.documents.v1.DocumentTagR\x04tags\"\x8a\x01\n" +
-	"\x1cGetDocumentAttributesRequest\x12\x1c\n" +
-	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
-	"\vdocument_id\x18\x02 \x01(\tR\n" +
-	"documentId\x12\x1e\n" +
-	"\btag_path\x18\x03 \x01(\tH\x00R\atagPath\x88\x01\x01B\v\n" +
-	"\t_tag_path\"\x81\x01\n" +
+func (x *GetAttributeHistoryRequest) Reset() {
+	*x = GetAttributeHistoryRequest{}
+	mi := &file_documents_v1_documents_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttributeHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttributeHistoryRequest) ProtoMessage() {}
+
+func (x *GetAttributeHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v1_documents_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttributeHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAttributeHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetAttributeHistoryRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetAttributeHistoryRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *GetAttributeHistoryRequest) GetTagPath() string {
+	if x != nil && x.TagPath != nil {
+		return *x.TagPath
+	}
+	return ""
+}
+
+func (x *GetAttributeHistoryRequest) GetField() string {
+	if x != nil && x.Field != nil {
+		return *x.Field
+	}
+	return ""
+}
+
+// GetAttributeHistoryResponse contains the attribute changes, newest first.
+type GetAttributeHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// changes are the recorded changes.
+	Changes       []*AttributeChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttributeHistoryResponse) Reset() {
+	*x = GetAttributeHistoryResponse{}
+	mi := &file_documents_v1_documents_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttributeHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttributeHistoryResponse) ProtoMessage() {}
+
+func (x *GetAttributeHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v1_documents_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttributeHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetAttributeHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAttributeHistoryResponse) GetChanges() []*AttributeChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// AttributeChange is a recorded change to a single attribute.
+type AttributeChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field is the name of the changed attribute.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// old_value is the JSON value before the change, unset if the attribute was added.
+	OldValue *string `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3,oneof" json:"old_value,omitempty"`
+	// new_value is the JSON value after the change, unset if the attribute was removed.
+	NewValue *string `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3,oneof" json:"new_value,omitempty"`
+	// schema_version is the version of the attributes schema when the change was made.
+	SchemaVersion *int64 `protobuf:"varint,4,opt,name=schema_version,json=schemaVersion,proto3,oneof" json:"schema_version,omitempty"`
+	// actor is who made the change, e.g. "api" or "extractor:invoice-reader".
+	Actor string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	// extraction_method is "manual" or "automatic".
+	ExtractionMethod string `protobuf:"bytes,6,opt,name=extraction_method,json=extractionMethod,proto3" json:"extraction_method,omitempty"`
+	// extracted_by identifies the user or extractor that provided the new value.
+	ExtractedBy string `protobuf:"bytes,7,opt,name=extracted_by,json=extractedBy,proto3" json:"extracted_by,omitempty"`
+	// changed_at is when the change was made.
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeChange) Reset() {
+	*x = AttributeChange{}
+	mi := &file_documents_v1_documents_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeChange) ProtoMessage() {}
+
+func (x *AttributeChange) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v1_documents_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeChange.ProtoReflect.Descriptor instead.
+func (*AttributeChange) Descriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AttributeChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AttributeChange) GetOldValue() string {
+	if x != nil && x.OldValue != nil {
+		return *x.OldValue
+	}
+	return ""
+}
+
+func (x *AttributeChange) GetNewValue() string {
+	if x != nil && x.NewValue != nil {
+		return *x.NewValue
+	}
+	return ""
+}
+
+func (x *AttributeChange) GetSchemaVersion() int64 {
+	if x != nil && x.SchemaVersion != nil {
+		return *x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *AttributeChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AttributeChange) GetExtractionMethod() string {
+	if x != nil {
+		return x.ExtractionMethod
+	}
+	return ""
+}
+
+func (x *AttributeChange) GetExtractedBy() string {
+	if x != nil {
+		return x.ExtractedBy
+	}
+	return ""
+}
+
+func (x *AttributeChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+// SearchDocumentsRequest selects the attributes to search and the filters they must match.
+type SearchDocumentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace to search.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// tag_path is the full path of the tag whose attributes are searched (optional - if
+	// empty, searches document global attributes). Must include a leading slash.
+	TagPath *string `protobuf:"bytes,2,opt,name=tag_path,json=tagPath,proto3,oneof" json:"tag_path,omitempty"`
+	// filters must all match. Without filters every document with the tag matches.
+	Filters       []*AttributeFilter `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchDocumentsRequest) Reset() {
+	*x = SearchDocumentsRequest{}
+	mi := &file_documents_v1_documents_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchDocumentsRequest) ProtoMessage() {}
+
+func (x *SearchDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v1_documents_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*SearchDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchDocumentsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SearchDocumentsRequest) GetTagPath() string {
+	if x != nil && x.TagPath != nil {
+		return *x.TagPath
+	}
+	return ""
+}
+
+func (x *SearchDocumentsRequest) GetFilters() []*AttributeFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+// AttributeFilter compares one attribute to a value.
+type AttributeFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field is the attribute name, or the path to a nested attribute with its parts
+	// separated by dots (e.g., "vendor.name").
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// operator is how the attribute is compared to value.
+	Operator AttributeFilterOperator `protobuf:"varint,2,opt,name=operator,proto3,enum=documents.v1.AttributeFilterOperator" json:"operator,omitempty"`
+	// value is the JSON-encoded string, number, boolean or null to compare to.
+	Value         string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeFilter) Reset() {
+	*x = AttributeFilter{}
+	mi := &file_documents_v1_documents_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeFilter) ProtoMessage() {}
+
+func (x *AttributeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v1_documents_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeFilter.ProtoReflect.Descriptor instead.
+func (*AttributeFilter) Descriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AttributeFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AttributeFilter) GetOperator() AttributeFilterOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return AttributeFilterOperator_ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED
+}
+
+func (x *AttributeFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// SearchDocumentsResponse contains the matching documents, oldest first.
+type SearchDocumentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// documents are the matching documents.
+	Documents     []*DocumentMatch `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchDocumentsResponse) Reset() {
+	*x = SearchDocumentsResponse{}
+	mi := &file_documents_v1_documents_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchDocumentsResponse) ProtoMessage() {}
+
+func (x *SearchDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v1_documents_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*SearchDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchDocumentsResponse) GetDocuments() []*DocumentMatch {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+// DocumentMatch is a document found by a search.
+type DocumentMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// document_id is the unique identifier of the document.
+	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// file_name is the document's file name.
+	FileName string `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// title is the document's title.
+	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// mime_type is the document's MIME type.
+	MimeType string `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// attributes contains the searched attributes as JSON.
+	Attributes *string `protobuf:"bytes,5,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"`
+	// created_at is when the document was uploaded.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentMatch) Reset() {
+	*x = DocumentMatch{}
+	mi := &file_documents_v1_documents_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentMatch) ProtoMessage() {}
+
+func (x *DocumentMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_documents_v1_documents_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentMatch.ProtoReflect.Descriptor instead.
+func (*DocumentMatch) Descriptor() ([]byte, []int) {
+	return file_documents_v1_documents_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DocumentMatch) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DocumentMatch) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DocumentMatch) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DocumentMatch) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *DocumentMatch) GetAttributes() string {
+	if x != nil && x.Attributes != nil {
+		return *x.Attributes
+	}
+	return ""
+}
+
+func (x *DocumentMatch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_documents_v1_documents_proto protoreflect.FileDescriptor
+
+const file_documents_v1_documents_proto_rawDesc = "" +
+	"\n" +
+	"\x1cdocuments/v1/documents.proto\x12\fdocuments.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"R\n" +
+	"\x15UpdateDocumentRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"i\n" +
+	"\x16UpdateDocumentResponse\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\"V\n" +
+	"\x15DeleteDocumentRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\"\x18\n" +
+	"\x16DeleteDocumentResponse\"\xe2\x02\n" +
+	"\x17AddTagToDocumentRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12\x19\n" +
+	"\btag_path\x18\x03 \x01(\tR\atagPath\x12#\n" +
+	"\n" +
+	"attributes\x18\x04 \x01(\tH\x00R\n" +
+	"attributes\x88\x01\x01\x12!\n" +
+	"\textractor\x18\x05 \x01(\tH\x01R\textractor\x88\x01\x01\x12#\n" +
+	"\n" +
+	"confidence\x18\x06 \x01(\x01H\x02R\n" +
+	"confidence\x88\x01\x01\x12\x14\n" +
+	"\x05force\x18\a \x01(\bR\x05force\x12\x16\n" +
+	"\x06coerce\x18\b \x01(\bR\x06coerce\x12\x1b\n" +
+	"\x06locale\x18\t \x01(\tH\x03R\x06locale\x88\x01\x01B\r\n" +
+	"\v_attributesB\f\n" +
+	"\n" +
+	"_extractorB\r\n" +
+	"\v_confidenceB\t\n" +
+	"\a_locale\"i\n" +
+	"\x18AddTagToDocumentResponse\x12M\n" +
+	"\x12skipped_attributes\x18\x01 \x03(\v2\x1e.documents.v1.SkippedAttributeR\x11skippedAttributes\"Z\n" +
+	"\x10SkippedAttribute\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x120\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x18.documents.v1.SkipReasonR\x06reason\"x\n" +
+	"\x1cRemoveTagFromDocumentRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12\x19\n" +
+	"\btag_path\x18\x03 \x01(\tR\atagPath\"\x1f\n" +
+	"\x1dRemoveTagFromDocumentResponse\"X\n" +
+	"\x17ListDocumentTagsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\"\xd9\x01\n" +
+	"\vDocumentTag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\btag_path\x18\x02 \x01(\tR\atagPath\x12#\n" +
+	"\n" +
+	"attributes\x18\x03 \x01(\tH\x00R\n" +
+	"attributes\x88\x01\x01\x12\x1f\n" +
+	"\bmetadata\x18\x04 \x01(\tH\x01R\bmetadata\x88\x01\x01\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\r\n" +
+	"\v_attributesB\v\n" +
+	"\t_metadata\"I\n" +
+	"\x18ListDocumentTagsResponse\x12-\n" +
+	"\x04tags\x18\x01 \x03(\v2\x19.documents.v1.DocumentTagR\x04tags\"\x8a\x01\n" +
+	"\x1cGetDocumentAttributesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12\x1e\n" +
+	"\btag_path\x18\x03 \x01(\tH\x00R\atagPath\x88\x01\x01B\v\n" +
+	"\t_tag_path\"\x81\x01\n" +
 	"\x1dGetDocumentAttributesResponse\x12#\n" +
 	"\n" +
 	"attributes\x18\x01 \x01(\tH\x00R\n" +
 	"attributes\x88\x01\x01\x12\x1f\n" +
 	"\bmetadata\x18\x02 \x01(\tH\x01R\bmetadata\x88\x01\x01B\r\n" +
 	"\v_attributesB\v\n" +
-	"\t_metadata\"\xad\x01\n" +
+	"\t_metadata\"\xa4\x02\n" +
 	"\x1fUpdateDocumentAttributesRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
 	"\vdocument_id\x18\x02 \x01(\tR\n" +
@@ -933,9 +1760,75 @@ const file_documents_v1_documents_proto_rawDesc = "" +
 	"\btag_path\x18\x03 \x01(\tH\x00R\atagPath\x88\x01\x01\x12\x1e\n" +
 	"\n" +
 	"attributes\x18\x04 \x01(\tR\n" +
-	"attributesB\v\n" +
-	"\t_tag_path\"\"\n" +
-	" UpdateDocumentAttributesResponse2\xf0\x05\n" +
+	"attributes\x125\n" +
+	"\x04mode\x18\x05 \x01(\x0e2!.documents.v1.AttributeUpdateModeR\x04mode\x12\x16\n" +
+	"\x06coerce\x18\x06 \x01(\bR\x06coerce\x12\x1b\n" +
+	"\x06locale\x18\a \x01(\tH\x01R\x06locale\x88\x01\x01B\v\n" +
+	"\t_tag_pathB\t\n" +
+	"\a_locale\"\"\n" +
+	" UpdateDocumentAttributesResponse\"\xad\x01\n" +
+	"\x1aGetAttributeHistoryRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12\x1e\n" +
+	"\btag_path\x18\x03 \x01(\tH\x00R\atagPath\x88\x01\x01\x12\x19\n" +
+	"\x05field\x18\x04 \x01(\tH\x01R\x05field\x88\x01\x01B\v\n" +
+	"\t_tag_pathB\b\n" +
+	"\x06_field\"V\n" +
+	"\x1bGetAttributeHistoryResponse\x127\n" +
+	"\achanges\x18\x01 \x03(\v2\x1d.documents.v1.AttributeChangeR\achanges\"\xe7\x02\n" +
+	"\x0fAttributeChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12 \n" +
+	"\told_value\x18\x02 \x01(\tH\x00R\boldValue\x88\x01\x01\x12 \n" +
+	"\tnew_value\x18\x03 \x01(\tH\x01R\bnewValue\x88\x01\x01\x12*\n" +
+	"\x0eschema_version\x18\x04 \x01(\x03H\x02R\rschemaVersion\x88\x01\x01\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12+\n" +
+	"\x11extraction_method\x18\x06 \x01(\tR\x10extractionMethod\x12!\n" +
+	"\fextracted_by\x18\a \x01(\tR\vextractedBy\x129\n" +
+	"\n" +
+	"changed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAtB\f\n" +
+	"\n" +
+	"_old_valueB\f\n" +
+	"\n" +
+	"_new_valueB\x11\n" +
+	"\x0f_schema_version\"\x9c\x01\n" +
+	"\x16SearchDocumentsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1e\n" +
+	"\btag_path\x18\x02 \x01(\tH\x00R\atagPath\x88\x01\x01\x127\n" +
+	"\afilters\x18\x03 \x03(\v2\x1d.documents.v1.AttributeFilterR\afiltersB\v\n" +
+	"\t_tag_path\"\x80\x01\n" +
+	"\x0fAttributeFilter\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12A\n" +
+	"\boperator\x18\x02 \x01(\x0e2%.documents.v1.AttributeFilterOperatorR\boperator\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"T\n" +
+	"\x17SearchDocumentsResponse\x129\n" +
+	"\tdocuments\x18\x01 \x03(\v2\x1b.documents.v1.DocumentMatchR\tdocuments\"\xef\x01\n" +
+	"\rDocumentMatch\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1b\n" +
+	"\tmime_type\x18\x04 \x01(\tR\bmimeType\x12#\n" +
+	"\n" +
+	"attributes\x18\x05 \x01(\tH\x00R\n" +
+	"attributes\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\r\n" +
+	"\v_attributes*d\n" +
+	"\n" +
+	"SkipReason\x12\x1b\n" +
+	"\x17SKIP_REASON_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12SKIP_REASON_MANUAL\x10\x01\x12!\n" +
+	"\x1dSKIP_REASON_HIGHER_CONFIDENCE\x10\x02*\xac\x01\n" +
+	"\x13AttributeUpdateMode\x12%\n" +
+	"!ATTRIBUTE_UPDATE_MODE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dATTRIBUTE_UPDATE_MODE_REPLACE\x10\x01\x12%\n" +
+	"!ATTRIBUTE_UPDATE_MODE_MERGE_PATCH\x10\x02\x12$\n" +
+	" ATTRIBUTE_UPDATE_MODE_JSON_PATCH\x10\x03*\x92\x01\n" +
+	"\x17AttributeFilterOperator\x12)\n" +
+	"%ATTRIBUTE_FILTER_OPERATOR_UNSPECIFIED\x10\x00\x12$\n" +
+	" ATTRIBUTE_FILTER_OPERATOR_EQUALS\x10\x01\x12&\n" +
+	"\"ATTRIBUTE_FILTER_OPERATOR_CONTAINS\x10\x022\xbc\a\n" +
 	"\x0fDocumentService\x12[\n" +
 	"\x0eUpdateDocument\x12#.documents.v1.UpdateDocumentRequest\x1a$.documents.v1.UpdateDocumentResponse\x12[\n" +
 	"\x0eDeleteDocument\x12#.documents.v1.DeleteDocumentRequest\x1a$.documents.v1.DeleteDocumentResponse\x12a\n" +
@@ -943,7 +1836,9 @@ const file_documents_v1_documents_proto_rawDesc = "" +
 	"\x15RemoveTagFromDocument\x12*.documents.v1.RemoveTagFromDocumentRequest\x1a+.documents.v1.RemoveTagFromDocumentResponse\x12a\n" +
 	"\x10ListDocumentTags\x12%.documents.v1.ListDocumentTagsRequest\x1a&.documents.v1.ListDocumentTagsResponse\x12p\n" +
 	"\x15GetDocumentAttributes\x12*.documents.v1.GetDocumentAttributesRequest\x1a+.documents.v1.GetDocumentAttributesResponse\x12y\n" +
-	"\x18UpdateDocumentAttributes\x12-.documents.v1.UpdateDocumentAttributesRequest\x1a..documents.v1.UpdateDocumentAttributesResponseB\xaf\x01\n" +
+	"\x18UpdateDocumentAttributes\x12-.documents.v1.UpdateDocumentAttributesRequest\x1a..documents.v1.UpdateDocumentAttributesResponse\x12j\n" +
+	"\x13GetAttributeHistory\x12(.documents.v1.GetAttributeHistoryRequest\x1a).documents.v1.GetAttributeHistoryResponse\x12^\n" +
+	"\x0fSearchDocuments\x12$.documents.v1.SearchDocumentsRequest\x1a%.documents.v1.SearchDocumentsResponseB\xaf\x01\n" +
 	"\x10com.documents.v1B\x0eDocumentsProtoP\x01Z:github.com/RynoXLI/Wayfile/gen/go/documents/v1;documentsv1\xa2\x02\x03DXX\xaa\x02\fDocuments.V1\xca\x02\fDocuments\\V1\xe2\x02\x18Documents\\V1\\GPBMetadata\xea\x02\rDocuments::V1b\x06proto3"
 
 var (
@@ -958,47 +1853,72 @@ func file_documents_v1_documents_proto_rawDescGZIP() []byte {
 	return file_documents_v1_documents_proto_rawDescData
 }
 
-var file_documents_v1_documents_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_documents_v1_documents_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_documents_v1_documents_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_documents_v1_documents_proto_goTypes = []any{
-	(*UpdateDocumentRequest)(nil),            // 0: documents.v1.UpdateDocumentRequest
-	(*UpdateDocumentResponse)(nil),           // 1: documents.v1.UpdateDocumentResponse
-	(*DeleteDocumentRequest)(nil),            // 2: documents.v1.DeleteDocumentRequest
-	(*DeleteDocumentResponse)(nil),           // 3: documents.v1.DeleteDocumentResponse
-	(*AddTagToDocumentRequest)(nil),          // 4: documents.v1.AddTagToDocumentRequest
-	(*AddTagToDocumentResponse)(nil),         // 5: documents.v1.AddTagToDocumentResponse
-	(*RemoveTagFromDocumentRequest)(nil),     // 6: documents.v1.RemoveTagFromDocumentRequest
-	(*RemoveTagFromDocumentResponse)(nil),    // 7: documents.v1.RemoveTagFromDocumentResponse
-	(*ListDocumentTagsRequest)(nil),          // 8: documents.v1.ListDocumentTagsRequest
-	(*DocumentTag)(nil),                      // 9: documents.v1.DocumentTag
-	(*ListDocumentTagsResponse)(nil),         // 10: documents.v1.ListDocumentTagsResponse
-	(*GetDocumentAttributesRequest)(nil),     // 11: documents.v1.GetDocumentAttributesRequest
-	(*GetDocumentAttributesResponse)(nil),    // 12: documents.v1.GetDocumentAttributesResponse
-	(*UpdateDocumentAttributesRequest)(nil),  // 13: documents.v1.UpdateDocumentAttributesRequest
-	(*UpdateDocumentAttributesResponse)(nil), // 14: documents.v1.UpdateDocumentAttributesResponse
-	(*timestamppb.Timestamp)(nil),            // 15: google.protobuf.Timestamp
+	(SkipReason)(0),                          // 0: documents.v1.SkipReason
+	(AttributeUpdateMode)(0),                 // 1: documents.v1.AttributeUpdateMode
+	(AttributeFilterOperator)(0),             // 2: documents.v1.AttributeFilterOperator
+	(*UpdateDocumentRequest)(nil),            // 3: documents.v1.UpdateDocumentRequest
+	(*UpdateDocumentResponse)(nil),           // 4: documents.v1.UpdateDocumentResponse
+	(*DeleteDocumentRequest)(nil),            // 5: documents.v1.DeleteDocumentRequest
+	(*DeleteDocumentResponse)(nil),           // 6: documents.v1.DeleteDocumentResponse
+	(*AddTagToDocumentRequest)(nil),          // 7: documents.v1.AddTagToDocumentRequest
+	(*AddTagToDocumentResponse)(nil),         // 8: documents.v1.AddTagToDocumentResponse
+	(*SkippedAttribute)(nil),                 // 9: documents.v1.SkippedAttribute
+	(*RemoveTagFromDocumentRequest)(nil),     // 10: documents.v1.RemoveTagFromDocumentRequest
+	(*RemoveTagFromDocumentResponse)(nil),    // 11: documents.v1.RemoveTagFromDocumentResponse
+	(*ListDocumentTagsRequest)(nil),          // 12: documents.v1.ListDocumentTagsRequest
+	(*DocumentTag)(nil),                      // 13: documents.v1.DocumentTag
+	(*ListDocumentTagsResponse)(nil),         // 14: documents.v1.ListDocumentTagsResponse
+	(*GetDocumentAttributesRequest)(nil),     // 15: documents.v1.GetDocumentAttributesRequest
+	(*GetDocumentAttributesResponse)(nil),    // 16: documents.v1.GetDocumentAttributesResponse
+	(*UpdateDocumentAttributesRequest)(nil),  // 17: documents.v1.UpdateDocumentAttributesRequest
+	(*UpdateDocumentAttributesResponse)(nil), // 18: documents.v1.UpdateDocumentAttributesResponse
+	(*GetAttributeHistoryRequest)(nil),       // 19: documents.v1.GetAttributeHistoryRequest
+	(*GetAttributeHistoryResponse)(nil),      // 20: documents.v1.GetAttributeHistoryResponse
+	(*AttributeChange)(nil),                  // 21: documents.v1.AttributeChange
+	(*SearchDocumentsRequest)(nil),           // 22: documents.v1.SearchDocumentsRequest
+	(*AttributeFilter)(nil),                  // 23: documents.v1.AttributeFilter
+	(*SearchDocumentsResponse)(nil),          // 24: documents.v1.SearchDocumentsResponse
+	(*DocumentMatch)(nil),                    // 25: documents.v1.DocumentMatch
+	(*timestamppb.Timestamp)(nil),            // 26: google.protobuf.Timestamp
 }
 var file_documents_v1_documents_proto_depIdxs = []int32{
-	15, // 0: documents.v1.DocumentTag.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 1: documents.v1.ListDocumentTagsResponse.tags:type_name -> documents.v1.DocumentTag
-	0,  // 2: documents.v1.DocumentService.UpdateDocument:input_type -> documents.v1.UpdateDocumentRequest
-	2,  // 3: documents.v1.DocumentService.DeleteDocument:input_type -> documents.v1.DeleteDocumentRequest
-	4,  // 4: documents.v1.DocumentService.AddTagToDocument:input_type -> documents.v1.AddTagToDocumentRequest
-	6,  // 5: documents.v1.DocumentService.RemoveTagFromDocument:input_type -> documents.v1.RemoveTagFromDocumentRequest
-	8,  // 6: documents.v1.DocumentService.ListDocumentTags:input_type -> documents.v1.ListDocumentTagsRequest
-	11, // 7: documents.v1.DocumentService.GetDocumentAttributes:input_type -> documents.v1.GetDocumentAttributesRequest
-	13, // 8: documents.v1.DocumentService.UpdateDocumentAttributes:input_type -> documents.v1.UpdateDocumentAttributesRequest
-	1,  // 9: documents.v1.DocumentService.UpdateDocument:output_type -> documents.v1.UpdateDocumentResponse
-	3,  // 10: documents.v1.DocumentService.DeleteDocument:output_type -> documents.v1.DeleteDocumentResponse
-	5,  // 11: documents.v1.DocumentService.AddTagToDocument:output_type -> documents.v1.AddTagToDocumentResponse
-	7,  // 12: documents.v1.DocumentService.RemoveTagFromDocument:output_type -> documents.v1.RemoveTagFromDocumentResponse
-	10, // 13: documents.v1.DocumentService.ListDocumentTags:output_type -> documents.v1.ListDocumentTagsResponse
-	12, // 14: documents.v1.DocumentService.GetDocumentAttributes:output_type -> documents.v1.GetDocumentAttributesResponse
-	14, // 15: documents.v1.DocumentService.UpdateDocumentAttributes:output_type -> documents.v1.UpdateDocumentAttributesResponse
-	9,  // [9:16] is the sub-list for method output_type
-	2,  // [2:9] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	9,  // 0: documents.v1.AddTagToDocumentResponse.skipped_attributes:type_name -> documents.v1.SkippedAttribute
+	0,  // 1: documents.v1.SkippedAttribute.reason:type_name -> documents.v1.SkipReason
+	26, // 2: documents.v1.DocumentTag.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 3: documents.v1.ListDocumentTagsResponse.tags:type_name -> documents.v1.DocumentTag
+	1,  // 4: documents.v1.UpdateDocumentAttributesRequest.mode:type_name -> documents.v1.AttributeUpdateMode
+	21, // 5: documents.v1.GetAttributeHistoryResponse.changes:type_name -> documents.v1.AttributeChange
+	26, // 6: documents.v1.AttributeChange.changed_at:type_name -> google.protobuf.Timestamp
+	23, // 7: documents.v1.SearchDocumentsRequest.filters:type_name -> documents.v1.AttributeFilter
+	2,  // 8: documents.v1.AttributeFilter.operator:type_name -> documents.v1.AttributeFilterOperator
+	25, // 9: documents.v1.SearchDocumentsResponse.documents:type_name -> documents.v1.DocumentMatch
+	26, // 10: documents.v1.DocumentMatch.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 11: documents.v1.DocumentService.UpdateDocument:input_type -> documents.v1.UpdateDocumentRequest
+	5,  // 12: documents.v1.DocumentService.DeleteDocument:input_type -> documents.v1.DeleteDocumentRequest
+	7,  // 13: documents.v1.DocumentService.AddTagToDocument:input_type -> documents.v1.AddTagToDocumentRequest
+	10, // 14: documents.v1.DocumentService.RemoveTagFromDocument:input_type -> documents.v1.RemoveTagFromDocumentRequest
+	12, // 15: documents.v1.DocumentService.ListDocumentTags:input_type -> documents.v1.ListDocumentTagsRequest
+	15, // 16: documents.v1.DocumentService.GetDocumentAttributes:input_type -> documents.v1.GetDocumentAttributesRequest
+	17, // 17: documents.v1.DocumentService.UpdateDocumentAttributes:input_type -> documents.v1.UpdateDocumentAttributesRequest
+	19, // 18: documents.v1.DocumentService.GetAttributeHistory:input_type -> documents.v1.GetAttributeHistoryRequest
+	22, // 19: documents.v1.DocumentService.SearchDocuments:input_type -> documents.v1.SearchDocumentsRequest
+	4,  // 20: documents.v1.DocumentService.UpdateDocument:output_type -> documents.v1.UpdateDocumentResponse
+	6,  // 21: documents.v1.DocumentService.DeleteDocument:output_type -> documents.v1.DeleteDocumentResponse
+	8,  // 22: documents.v1.DocumentService.AddTagToDocument:output_type -> documents.v1.AddTagToDocumentResponse
+	11, // 23: documents.v1.DocumentService.RemoveTagFromDocument:output_type -> documents.v1.RemoveTagFromDocumentResponse
+	14, // 24: documents.v1.DocumentService.ListDocumentTags:output_type -> documents.v1.ListDocumentTagsResponse
+	16, // 25: documents.v1.DocumentService.GetDocumentAttributes:output_type -> documents.v1.GetDocumentAttributesResponse
+	18, // 26: documents.v1.DocumentService.UpdateDocumentAttributes:output_type -> documents.v1.UpdateDocumentAttributesResponse
+	20, // 27: documents.v1.DocumentService.GetAttributeHistory:output_type -> documents.v1.GetAttributeHistoryResponse
+	24, // 28: documents.v1.DocumentService.SearchDocuments:output_type -> documents.v1.SearchDocumentsResponse
+	20, // [20:29] is the sub-list for method output_type
+	11, // [11:20] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_documents_v1_documents_proto_init() }
@@ -1007,22 +1927,27 @@ func file_documents_v1_documents_proto_init() {
 		return
 	}
 	file_documents_v1_documents_proto_msgTypes[4].OneofWrappers = []any{}
-	file_documents_v1_documents_proto_msgTypes[9].OneofWrappers = []any{}
-	file_documents_v1_documents_proto_msgTypes[11].OneofWrappers = []any{}
+	file_documents_v1_documents_proto_msgTypes[10].OneofWrappers = []any{}
 	file_documents_v1_documents_proto_msgTypes[12].OneofWrappers = []any{}
 	file_documents_v1_documents_proto_msgTypes[13].OneofWrappers = []any{}
+	file_documents_v1_documents_proto_msgTypes[14].OneofWrappers = []any{}
+	file_documents_v1_documents_proto_msgTypes[16].OneofWrappers = []any{}
+	file_documents_v1_documents_proto_msgTypes[18].OneofWrappers = []any{}
+	file_documents_v1_documents_proto_msgTypes[19].OneofWrappers = []any{}
+	file_documents_v1_documents_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_documents_v1_documents_proto_rawDesc), len(file_documents_v1_documents_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   15,
+			NumEnums:      3,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_documents_v1_documents_proto_goTypes,
 		DependencyIndexes: file_documents_v1_documents_proto_depIdxs,
+		EnumInfos:         file_documents_v1_documents_proto_enumTypes,
 		MessageInfos:      file_documents_v1_documents_proto_msgTypes,
 	}.Build()
 	File_documents_v1_documents_proto = out.File
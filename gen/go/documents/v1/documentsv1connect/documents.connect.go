@@ -54,6 +54,12 @@ const (
 	// DocumentServiceUpdateDocumentAttributesProcedure is the fully-qualified name of the
 	// DocumentService's UpdateDocumentAttributes RPC.
 	DocumentServiceUpdateDocumentAttributesProcedure = "/documents.v1.DocumentService/UpdateDocumentAttributes"
+	// DocumentServiceGetAttributeHistoryProcedure is the fully-qualified name of the DocumentService's
+	// GetAttributeHistory RPC.
+	DocumentServiceGetAttributeHistoryProcedure = "/documents.v1.DocumentService/GetAttributeHistory"
+	// DocumentServiceSearchDocumentsProcedure is the fully-qualified name of the DocumentService's
+	// SearchDocuments RPC.
+	DocumentServiceSearchDocumentsProcedure = "/documents.v1.DocumentService/SearchDocuments"
 )
 
 // DocumentServiceClient is a client for the documents.v1.DocumentService service.
@@ -62,7 +68,8 @@ type DocumentServiceClient interface {
 	UpdateDocument(context.Context, *v1.UpdateDocumentRequest) (*v1.UpdateDocumentResponse, error)
 	// DeleteDocument removes a document from a namespace.
 	DeleteDocument(context.Context, *v1.DeleteDocumentRequest) (*v1.DeleteDocumentResponse, error)
-	// AddTagToDocument associates a tag with a document.
+	// AddTagToDocument associates a tag with a document. Attributes failing the tag's schema
+	// are rejected with INVALID_ARGUMENT and tags.v1.ValidationErrorDetails.
 	AddTagToDocument(context.Context, *v1.AddTagToDocumentRequest) (*v1.AddTagToDocumentResponse, error)
 	// RemoveTagFromDocument removes a tag association from a document.
 	RemoveTagFromDocument(context.Context, *v1.RemoveTagFromDocumentRequest) (*v1.RemoveTagFromDocumentResponse, error)
@@ -71,7 +78,13 @@ type DocumentServiceClient interface {
 	// GetDocumentAttributes retrieves attributes for a document (global) or specific tag.
 	GetDocumentAttributes(context.Context, *v1.GetDocumentAttributesRequest) (*v1.GetDocumentAttributesResponse, error)
 	// UpdateDocumentAttributes updates attributes for a document (global) or specific tag.
+	// Attributes failing the tag's schema are rejected with INVALID_ARGUMENT and
+	// tags.v1.ValidationErrorDetails.
 	UpdateDocumentAttributes(context.Context, *v1.UpdateDocumentAttributesRequest) (*v1.UpdateDocumentAttributesResponse, error)
+	// GetAttributeHistory lists the recorded changes to a document's attributes, newest first.
+	GetAttributeHistory(context.Context, *v1.GetAttributeHistoryRequest) (*v1.GetAttributeHistoryResponse, error)
+	// SearchDocuments lists the documents whose attributes match every filter.
+	SearchDocuments(context.Context, *v1.SearchDocumentsRequest) (*v1.SearchDocumentsResponse, error)
 }
 
 // NewDocumentServiceClient constructs a client for the documents.v1.DocumentService service. By
@@ -127,6 +140,18 @@ func NewDocumentServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(documentServiceMethods.ByName("UpdateDocumentAttributes")),
 			connect.WithClientOptions(opts...),
 		),
+		getAttributeHistory: connect.NewClient[v1.GetAttributeHistoryRequest, v1.GetAttributeHistoryResponse](
+			httpClient,
+			baseURL+DocumentServiceGetAttributeHistoryProcedure,
+			connect.WithSchema(documentServiceMethods.ByName("GetAttributeHistory")),
+			connect.WithClientOptions(opts...),
+		),
+		searchDocuments: connect.NewClient[v1.SearchDocumentsRequest, v1.SearchDocumentsResponse](
+			httpClient,
+			baseURL+DocumentServiceSearchDocumentsProcedure,
+			connect.WithSchema(documentServiceMethods.ByName("SearchDocuments")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -139,6 +164,8 @@ type documentServiceClient struct {
 	listDocumentTags         *connect.Client[v1.ListDocumentTagsRequest, v1.ListDocumentTagsResponse]
 	getDocumentAttributes    *connect.Client[v1.GetDocumentAttributesRequest, v1.GetDocumentAttributesResponse]
 	updateDocumentAttributes *connect.Client[v1.UpdateDocumentAttributesRequest, v1.UpdateDocumentAttributesResponse]
+	getAttributeHistory      *connect.Client[v1.GetAttributeHistoryRequest, v1.GetAttributeHistoryResponse]
+	searchDocuments          *connect.Client[v1.SearchDocumentsRequest, v1.SearchDocumentsResponse]
 }
 
 // UpdateDocument calls documents.v1.DocumentService.UpdateDocument.
@@ -204,13 +231,32 @@ func (c *documentServiceClient) UpdateDocumentAttributes(ctx context.Context, re
 	return nil, err
 }
 
+// GetAttributeHistory calls documents.v1.DocumentService.GetAttributeHistory.
+func (c *documentServiceClient) GetAttributeHistory(ctx context.Context, req *v1.GetAttributeHistoryRequest) (*v1.GetAttributeHistoryResponse, error) {
+	response, err := c.getAttributeHistory.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// SearchDocuments calls documents.v1.DocumentService.SearchDocuments.
+func (c *documentServiceClient) SearchDocuments(ctx context.Context, req *v1.SearchDocumentsRequest) (*v1.SearchDocumentsResponse, error) {
+	response, err := c.searchDocuments.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
 // DocumentServiceHandler is an implementation of the documents.v1.DocumentService service.
 type DocumentServiceHandler interface {
 	// UpdateDocument updates an existing document's content.
 	UpdateDocument(context.Context, *v1.UpdateDocumentRequest) (*v1.UpdateDocumentResponse, error)
 	// DeleteDocument removes a document from a namespace.
 	DeleteDocument(context.Context, *v1.DeleteDocumentRequest) (*v1.DeleteDocumentResponse, error)
-	// AddTagToDocument associates a tag with a document.
+	// AddTagToDocument associates a tag with a document. Attributes failing the tag's schema
+	// are rejected with INVALID_ARGUMENT and tags.v1.ValidationErrorDetails.
 	AddTagToDocument(context.Context, *v1.AddTagToDocumentRequest) (*v1.AddTagToDocumentResponse, error)
 	// RemoveTagFromDocument removes a tag association from a document.
 	RemoveTagFromDocument(context.Context, *v1.RemoveTagFromDocumentRequest) (*v1.RemoveTagFromDocumentResponse, error)
@@ -219,7 +265,13 @@ type DocumentServiceHandler interface {
 	// GetDocumentAttributes retrieves attributes for a document (global) or specific tag.
 	GetDocumentAttributes(context.Context, *v1.GetDocumentAttributesRequest) (*v1.GetDocumentAttributesResponse, error)
 	// UpdateDocumentAttributes updates attributes for a document (global) or specific tag.
+	// Attributes failing the tag's schema are rejected with INVALID_ARGUMENT and
+	// tags.v1.ValidationErrorDetails.
 	UpdateDocumentAttributes(context.Context, *v1.UpdateDocumentAttributesRequest) (*v1.UpdateDocumentAttributesResponse, error)
+	// GetAttributeHistory lists the recorded changes to a document's attributes, newest first.
+	GetAttributeHistory(context.Context, *v1.GetAttributeHistoryRequest) (*v1.GetAttributeHistoryResponse, error)
+	// SearchDocuments lists the documents whose attributes match every filter.
+	SearchDocuments(context.Context, *v1.SearchDocumentsRequest) (*v1.SearchDocumentsResponse, error)
 }
 
 // NewDocumentServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -271,6 +323,18 @@ func NewDocumentServiceHandler(svc DocumentServiceHandler, opts ...connect.Handl
 		connect.WithSchema(documentServiceMethods.ByName("UpdateDocumentAttributes")),
 		connect.WithHandlerOptions(opts...),
 	)
+	documentServiceGetAttributeHistoryHandler := connect.NewUnaryHandlerSimple(
+		DocumentServiceGetAttributeHistoryProcedure,
+		svc.GetAttributeHistory,
+		connect.WithSchema(documentServiceMethods.ByName("GetAttributeHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
+	documentServiceSearchDocumentsHandler := connect.NewUnaryHandlerSimple(
+		DocumentServiceSearchDocumentsProcedure,
+		svc.SearchDocuments,
+		connect.WithSchema(documentServiceMethods.ByName("SearchDocuments")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/documents.v1.DocumentService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case DocumentServiceUpdateDocumentProcedure:
@@ -287,6 +351,10 @@ func NewDocumentServiceHandler(svc DocumentServiceHandler, opts ...connect.Handl
 			documentServiceGetDocumentAttributesHandler.ServeHTTP(w, r)
 		case DocumentServiceUpdateDocumentAttributesProcedure:
 			documentServiceUpdateDocumentAttributesHandler.ServeHTTP(w, r)
+		case DocumentServiceGetAttributeHistoryProcedure:
+			documentServiceGetAttributeHistoryHandler.ServeHTTP(w, r)
+		case DocumentServiceSearchDocumentsProcedure:
+			documentServiceSearchDocumentsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -323,3 +391,11 @@ func (UnimplementedDocumentServiceHandler) GetDocumentAttributes(context.Context
 func (UnimplementedDocumentServiceHandler) UpdateDocumentAttributes(context.Context, *v1.UpdateDocumentAttributesRequest) (*v1.UpdateDocumentAttributesResponse, error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("documents.v1.DocumentService.UpdateDocumentAttributes is not implemented"))
 }
+
+func (UnimplementedDocumentServiceHandler) GetAttributeHistory(context.Context, *v1.GetAttributeHistoryRequest) (*v1.GetAttributeHistoryResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("documents.v1.DocumentService.GetAttributeHistory is not implemented"))
+}
+
+func (UnimplementedDocumentServiceHandler) SearchDocuments(context.Context, *v1.SearchDocumentsRequest) (*v1.SearchDocumentsResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("documents.v1.DocumentService.SearchDocuments is not implemented"))
+}
@@ -9,6 +9,7 @@ package eventsv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,36 +22,927 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// WatchEventsRequest contains the namespace to watch and where to start.
+type WatchEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace to watch.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// after_sequence resumes after this stream sequence; 0 starts with new events.
+	AfterSequence uint64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_events_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchEventsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+// WatchEventsResponse is a single event in a namespace.
+type WatchEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sequence is the event's position in the event stream.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// event_type is the type of the event, e.g. "documents.uploaded".
+	EventType string `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// event is the event itself.
+	//
+	// Types that are valid to be assigned to Event:
+	//
+	//	*WatchEventsResponse_DocumentUploaded
+	//	*WatchEventsResponse_DocumentUpdated
+	//	*WatchEventsResponse_DocumentDeleted
+	//	*WatchEventsResponse_AttributesUpdated
+	//	*WatchEventsResponse_SchemaChanged
+	//	*WatchEventsResponse_TagExtracted
+	//	*WatchEventsResponse_TagRemoved
+	//	*WatchEventsResponse_TagCreated
+	//	*WatchEventsResponse_TagUpdated
+	//	*WatchEventsResponse_TagDeleted
+	//	*WatchEventsResponse_NamespaceCreated
+	//	*WatchEventsResponse_NamespaceDeleted
+	Event         isWatchEventsResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsResponse) Reset() {
+	*x = WatchEventsResponse{}
+	mi := &file_events_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsResponse) ProtoMessage() {}
+
+func (x *WatchEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsResponse.ProtoReflect.Descriptor instead.
+func (*WatchEventsResponse) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchEventsResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *WatchEventsResponse) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WatchEventsResponse) GetEvent() isWatchEventsResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetDocumentUploaded() *DocumentUploadedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_DocumentUploaded); ok {
+			return x.DocumentUploaded
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetDocumentUpdated() *DocumentUpdatedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_DocumentUpdated); ok {
+			return x.DocumentUpdated
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetDocumentDeleted() *DocumentDeletedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_DocumentDeleted); ok {
+			return x.DocumentDeleted
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetAttributesUpdated() *AttributesUpdatedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_AttributesUpdated); ok {
+			return x.AttributesUpdated
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetSchemaChanged() *SchemaChangedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_SchemaChanged); ok {
+			return x.SchemaChanged
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetTagExtracted() *TagExtractedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_TagExtracted); ok {
+			return x.TagExtracted
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetTagRemoved() *TagRemovedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_TagRemoved); ok {
+			return x.TagRemoved
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetTagCreated() *TagCreatedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_TagCreated); ok {
+			return x.TagCreated
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetTagUpdated() *TagUpdatedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_TagUpdated); ok {
+			return x.TagUpdated
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetTagDeleted() *TagDeletedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_TagDeleted); ok {
+			return x.TagDeleted
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetNamespaceCreated() *NamespaceCreatedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_NamespaceCreated); ok {
+			return x.NamespaceCreated
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetNamespaceDeleted() *NamespaceDeletedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_NamespaceDeleted); ok {
+			return x.NamespaceDeleted
+		}
+	}
+	return nil
+}
+
+type isWatchEventsResponse_Event interface {
+	isWatchEventsResponse_Event()
+}
+
+type WatchEventsResponse_DocumentUploaded struct {
+	// document_uploaded is set for "documents.uploaded" events.
+	DocumentUploaded *DocumentUploadedEvent `protobuf:"bytes,3,opt,name=document_uploaded,json=documentUploaded,proto3,oneof"`
+}
+
+type WatchEventsResponse_DocumentUpdated struct {
+	// document_updated is set for "documents.updated" events.
+	DocumentUpdated *DocumentUpdatedEvent `protobuf:"bytes,4,opt,name=document_updated,json=documentUpdated,proto3,oneof"`
+}
+
+type WatchEventsResponse_DocumentDeleted struct {
+	// document_deleted is set for "documents.deleted" events.
+	DocumentDeleted *DocumentDeletedEvent `protobuf:"bytes,5,opt,name=document_deleted,json=documentDeleted,proto3,oneof"`
+}
+
+type WatchEventsResponse_AttributesUpdated struct {
+	// attributes_updated is set for "documents.attributes_updated" events.
+	AttributesUpdated *AttributesUpdatedEvent `protobuf:"bytes,6,opt,name=attributes_updated,json=attributesUpdated,proto3,oneof"`
+}
+
+type WatchEventsResponse_SchemaChanged struct {
+	// schema_changed is set for "schema.changed" events.
+	SchemaChanged *SchemaChangedEvent `protobuf:"bytes,7,opt,name=schema_changed,json=schemaChanged,proto3,oneof"`
+}
+
+type WatchEventsResponse_TagExtracted struct {
+	// tag_extracted is set for "tags.extracted" events.
+	TagExtracted *TagExtractedEvent `protobuf:"bytes,8,opt,name=tag_extracted,json=tagExtracted,proto3,oneof"`
+}
+
+type WatchEventsResponse_TagRemoved struct {
+	// tag_removed is set for "tags.removed" events.
+	TagRemoved *TagRemovedEvent `protobuf:"bytes,9,opt,name=tag_removed,json=tagRemoved,proto3,oneof"`
+}
+
+type WatchEventsResponse_TagCreated struct {
+	// tag_created is set for "tags.created" events.
+	TagCreated *TagCreatedEvent `protobuf:"bytes,10,opt,name=tag_created,json=tagCreated,proto3,oneof"`
+}
+
+type WatchEventsResponse_TagUpdated struct {
+	// tag_updated is set for "tags.updated" events.
+	TagUpdated *TagUpdatedEvent `protobuf:"bytes,11,opt,name=tag_updated,json=tagUpdated,proto3,oneof"`
+}
+
+type WatchEventsResponse_TagDeleted struct {
+	// tag_deleted is set for "tags.deleted" events.
+	TagDeleted *TagDeletedEvent `protobuf:"bytes,12,opt,name=tag_deleted,json=tagDeleted,proto3,oneof"`
+}
+
+type WatchEventsResponse_NamespaceCreated struct {
+	// namespace_created is set for "namespaces.created" events.
+	NamespaceCreated *NamespaceCreatedEvent `protobuf:"bytes,13,opt,name=namespace_created,json=namespaceCreated,proto3,oneof"`
+}
+
+type WatchEventsResponse_NamespaceDeleted struct {
+	// namespace_deleted is set for "namespaces.deleted" events.
+	NamespaceDeleted *NamespaceDeletedEvent `protobuf:"bytes,14,opt,name=namespace_deleted,json=namespaceDeleted,proto3,oneof"`
+}
+
+func (*WatchEventsResponse_DocumentUploaded) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_DocumentUpdated) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_DocumentDeleted) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_AttributesUpdated) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_SchemaChanged) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_TagExtracted) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_TagRemoved) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_TagCreated) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_TagUpdated) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_TagDeleted) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_NamespaceCreated) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_NamespaceDeleted) isWatchEventsResponse_Event() {}
+
+// EventContext identifies a single occurrence of an event. Every event carries it in
+// field 15.
+type EventContext struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_id is a unique identifier for this event, stable across redeliveries.
+	EventId string `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// occurred_at is when the change was made.
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// actor identifies who made the change, e.g. "api" or "import:<id>".
+	Actor         string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventContext) Reset() {
+	*x = EventContext{}
+	mi := &file_events_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventContext) ProtoMessage() {}
+
+func (x *EventContext) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventContext.ProtoReflect.Descriptor instead.
+func (*EventContext) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EventContext) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *EventContext) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *EventContext) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
 // DocumentUploadedEvent is published when a document is successfully uploaded.
 type DocumentUploadedEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// document_id is the unique identifier of the uploaded document.
+	// document_id is the unique identifier of the uploaded document.
+	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// namespace is the name of the namespace containing the document.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// filename is the original name of the uploaded file.
+	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	// mime_type is the MIME type declared by the uploader.
+	MimeType string `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// detected_mime_type is the MIME type sniffed from the file content.
+	DetectedMimeType string `protobuf:"bytes,5,opt,name=detected_mime_type,json=detectedMimeType,proto3" json:"detected_mime_type,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentUploadedEvent) Reset() {
+	*x = DocumentUploadedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentUploadedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentUploadedEvent) ProtoMessage() {}
+
+func (x *DocumentUploadedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentUploadedEvent.ProtoReflect.Descriptor instead.
+func (*DocumentUploadedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DocumentUploadedEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DocumentUploadedEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DocumentUploadedEvent) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DocumentUploadedEvent) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *DocumentUploadedEvent) GetDetectedMimeType() string {
+	if x != nil {
+		return x.DetectedMimeType
+	}
+	return ""
+}
+
+func (x *DocumentUploadedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// DocumentUpdatedEvent is published when a document's metadata, such as its title or
+// document date, changes.
+type DocumentUpdatedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// document_id is the unique identifier of the document.
+	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// namespace is the name of the namespace containing the document.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// title is the document's title after the update.
+	Title string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// document_date is the document's date after the update (YYYY-MM-DD, empty if unset).
+	DocumentDate string `protobuf:"bytes,4,opt,name=document_date,json=documentDate,proto3" json:"document_date,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentUpdatedEvent) Reset() {
+	*x = DocumentUpdatedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentUpdatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentUpdatedEvent) ProtoMessage() {}
+
+func (x *DocumentUpdatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentUpdatedEvent.ProtoReflect.Descriptor instead.
+func (*DocumentUpdatedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DocumentUpdatedEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DocumentUpdatedEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DocumentUpdatedEvent) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DocumentUpdatedEvent) GetDocumentDate() string {
+	if x != nil {
+		return x.DocumentDate
+	}
+	return ""
+}
+
+func (x *DocumentUpdatedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// DocumentDeletedEvent is published when a document is deleted.
+type DocumentDeletedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// document_id is the unique identifier of the deleted document.
+	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// namespace is the name of the namespace that contained the document.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// filename is the original name of the deleted file.
+	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentDeletedEvent) Reset() {
+	*x = DocumentDeletedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentDeletedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentDeletedEvent) ProtoMessage() {}
+
+func (x *DocumentDeletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentDeletedEvent.ProtoReflect.Descriptor instead.
+func (*DocumentDeletedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DocumentDeletedEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DocumentDeletedEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DocumentDeletedEvent) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DocumentDeletedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// SchemaChangedEvent is published when a tag's attribute schema or document schema changes.
+type SchemaChangedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace containing the tag.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// tag_path is the full path of the tag (empty for document global schema).
+	TagPath string `protobuf:"bytes,2,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// old_json_schema is the previous JSON Schema definition (empty if first schema).
+	OldJsonSchema string `protobuf:"bytes,3,opt,name=old_json_schema,json=oldJsonSchema,proto3" json:"old_json_schema,omitempty"`
+	// new_json_schema is the new JSON Schema definition.
+	NewJsonSchema string `protobuf:"bytes,4,opt,name=new_json_schema,json=newJsonSchema,proto3" json:"new_json_schema,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaChangedEvent) Reset() {
+	*x = SchemaChangedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaChangedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaChangedEvent) ProtoMessage() {}
+
+func (x *SchemaChangedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaChangedEvent.ProtoReflect.Descriptor instead.
+func (*SchemaChangedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SchemaChangedEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SchemaChangedEvent) GetTagPath() string {
+	if x != nil {
+		return x.TagPath
+	}
+	return ""
+}
+
+func (x *SchemaChangedEvent) GetOldJsonSchema() string {
+	if x != nil {
+		return x.OldJsonSchema
+	}
+	return ""
+}
+
+func (x *SchemaChangedEvent) GetNewJsonSchema() string {
+	if x != nil {
+		return x.NewJsonSchema
+	}
+	return ""
+}
+
+func (x *SchemaChangedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// TagExtractedEvent is published when a tag is successfully extracted/added to a document.
+type TagExtractedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// document_id is the unique identifier of the document.
+	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// namespace is the name of the namespace containing the document.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// tag_path is the hierarchical path of the tag.
+	TagPath string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// metadata contains additional information about the extraction context.
+	Metadata string `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// attributes is the JSON representation of tag attributes (may be empty).
+	Attributes string `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagExtractedEvent) Reset() {
+	*x = TagExtractedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagExtractedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagExtractedEvent) ProtoMessage() {}
+
+func (x *TagExtractedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagExtractedEvent.ProtoReflect.Descriptor instead.
+func (*TagExtractedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TagExtractedEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *TagExtractedEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *TagExtractedEvent) GetTagPath() string {
+	if x != nil {
+		return x.TagPath
+	}
+	return ""
+}
+
+func (x *TagExtractedEvent) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *TagExtractedEvent) GetAttributes() string {
+	if x != nil {
+		return x.Attributes
+	}
+	return ""
+}
+
+func (x *TagExtractedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// TagRemovedEvent is published when a tag is removed from a document.
+type TagRemovedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// document_id is the unique identifier of the document.
+	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// namespace is the name of the namespace containing the document.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// tag_path is the hierarchical path of the removed tag.
+	TagPath string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagRemovedEvent) Reset() {
+	*x = TagRemovedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagRemovedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagRemovedEvent) ProtoMessage() {}
+
+func (x *TagRemovedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagRemovedEvent.ProtoReflect.Descriptor instead.
+func (*TagRemovedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TagRemovedEvent) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *TagRemovedEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *TagRemovedEvent) GetTagPath() string {
+	if x != nil {
+		return x.TagPath
+	}
+	return ""
+}
+
+func (x *TagRemovedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// AttributesUpdatedEvent is published when a document's global or tag attributes are
+// replaced.
+type AttributesUpdatedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// document_id is the unique identifier of the document.
 	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
 	// namespace is the name of the namespace containing the document.
 	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	// filename is the original name of the uploaded file.
-	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	// mime_type is the MIME type of the uploaded file.
-	MimeType      string `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// tag_path is the path of the tag the attributes belong to (empty for global attributes).
+	TagPath string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// attributes is the JSON representation of the attributes after the update.
+	Attributes string `protobuf:"bytes,4,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DocumentUploadedEvent) Reset() {
-	*x = DocumentUploadedEvent{}
-	mi := &file_events_v1_events_proto_msgTypes[0]
+func (x *AttributesUpdatedEvent) Reset() {
+	*x = AttributesUpdatedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DocumentUploadedEvent) String() string {
+func (x *AttributesUpdatedEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentUploadedEvent) ProtoMessage() {}
+func (*AttributesUpdatedEvent) ProtoMessage() {}
 
-func (x *DocumentUploadedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_v1_events_proto_msgTypes[0]
+func (x *AttributesUpdatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -61,69 +953,76 @@ func (x *DocumentUploadedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentUploadedEvent.ProtoReflect.Descriptor instead.
-func (*DocumentUploadedEvent) Descriptor() ([]byte, []int) {
-	return file_events_v1_events_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use AttributesUpdatedEvent.ProtoReflect.Descriptor instead.
+func (*AttributesUpdatedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *DocumentUploadedEvent) GetDocumentId() string {
+func (x *AttributesUpdatedEvent) GetDocumentId() string {
 	if x != nil {
 		return x.DocumentId
 	}
 	return ""
 }
 
-func (x *DocumentUploadedEvent) GetNamespace() string {
+func (x *AttributesUpdatedEvent) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-func (x *DocumentUploadedEvent) GetFilename() string {
+func (x *AttributesUpdatedEvent) GetTagPath() string {
 	if x != nil {
-		return x.Filename
+		return x.TagPath
 	}
 	return ""
 }
 
-func (x *DocumentUploadedEvent) GetMimeType() string {
+func (x *AttributesUpdatedEvent) GetAttributes() string {
 	if x != nil {
-		return x.MimeType
+		return x.Attributes
 	}
 	return ""
 }
 
-// SchemaChangedEvent is published when a tag's attribute schema or document schema changes.
-type SchemaChangedEvent struct {
+func (x *AttributesUpdatedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// TagCreatedEvent is published when a tag is created in a namespace.
+type TagCreatedEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
+	// tag_id is the unique identifier of the tag.
+	TagId string `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
 	// namespace is the name of the namespace containing the tag.
-	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	// tag_path is the full path of the tag (empty for document global schema).
-	TagPath string `protobuf:"bytes,2,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
-	// old_json_schema is the previous JSON Schema definition (empty if first schema).
-	OldJsonSchema string `protobuf:"bytes,3,opt,name=old_json_schema,json=oldJsonSchema,proto3" json:"old_json_schema,omitempty"`
-	// new_json_schema is the new JSON Schema definition.
-	NewJsonSchema string `protobuf:"bytes,4,opt,name=new_json_schema,json=newJsonSchema,proto3" json:"new_json_schema,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// tag_path is the full path of the new tag.
+	TagPath string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SchemaChangedEvent) Reset() {
-	*x = SchemaChangedEvent{}
-	mi := &file_events_v1_events_proto_msgTypes[1]
+func (x *TagCreatedEvent) Reset() {
+	*x = TagCreatedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SchemaChangedEvent) String() string {
+func (x *TagCreatedEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SchemaChangedEvent) ProtoMessage() {}
+func (*TagCreatedEvent) ProtoMessage() {}
 
-func (x *SchemaChangedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_v1_events_proto_msgTypes[1]
+func (x *TagCreatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -134,71 +1033,152 @@ func (x *SchemaChangedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SchemaChangedEvent.ProtoReflect.Descriptor instead.
-func (*SchemaChangedEvent) Descriptor() ([]byte, []int) {
-	return file_events_v1_events_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use TagCreatedEvent.ProtoReflect.Descriptor instead.
+func (*TagCreatedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *SchemaChangedEvent) GetNamespace() string {
+func (x *TagCreatedEvent) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+func (x *TagCreatedEvent) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-func (x *SchemaChangedEvent) GetTagPath() string {
+func (x *TagCreatedEvent) GetTagPath() string {
 	if x != nil {
 		return x.TagPath
 	}
 	return ""
 }
 
-func (x *SchemaChangedEvent) GetOldJsonSchema() string {
+func (x *TagCreatedEvent) GetContext() *EventContext {
 	if x != nil {
-		return x.OldJsonSchema
+		return x.Context
+	}
+	return nil
+}
+
+// TagUpdatedEvent is published when a tag is renamed, moved or its details change.
+type TagUpdatedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tag_id is the unique identifier of the tag.
+	TagId string `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	// namespace is the name of the namespace containing the tag.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// old_tag_path is the tag's path before the update.
+	OldTagPath string `protobuf:"bytes,3,opt,name=old_tag_path,json=oldTagPath,proto3" json:"old_tag_path,omitempty"`
+	// tag_path is the tag's path after the update; it differs from old_tag_path on a
+	// rename or move.
+	TagPath string `protobuf:"bytes,4,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagUpdatedEvent) Reset() {
+	*x = TagUpdatedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagUpdatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagUpdatedEvent) ProtoMessage() {}
+
+func (x *TagUpdatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagUpdatedEvent.ProtoReflect.Descriptor instead.
+func (*TagUpdatedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TagUpdatedEvent) GetTagId() string {
+	if x != nil {
+		return x.TagId
 	}
 	return ""
 }
 
-func (x *SchemaChangedEvent) GetNewJsonSchema() string {
+func (x *TagUpdatedEvent) GetNamespace() string {
 	if x != nil {
-		return x.NewJsonSchema
+		return x.Namespace
 	}
 	return ""
 }
 
-// TagExtractedEvent is published when a tag is successfully extracted/added to a document.
-type TagExtractedEvent struct {
+func (x *TagUpdatedEvent) GetOldTagPath() string {
+	if x != nil {
+		return x.OldTagPath
+	}
+	return ""
+}
+
+func (x *TagUpdatedEvent) GetTagPath() string {
+	if x != nil {
+		return x.TagPath
+	}
+	return ""
+}
+
+func (x *TagUpdatedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// TagDeletedEvent is published when a tag, along with its descendants, is deleted.
+type TagDeletedEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// document_id is the unique identifier of the document.
-	DocumentId string `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	// namespace is the name of the namespace containing the document.
+	// tag_id is the unique identifier of the deleted tag.
+	TagId string `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	// namespace is the name of the namespace that contained the tag.
 	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	// tag_path is the hierarchical path of the tag.
+	// tag_path is the full path of the deleted tag.
 	TagPath string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
-	// metadata contains additional information about the extraction context.
-	Metadata string `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	// attributes is the JSON representation of tag attributes (may be empty).
-	Attributes    string `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TagExtractedEvent) Reset() {
-	*x = TagExtractedEvent{}
-	mi := &file_events_v1_events_proto_msgTypes[2]
+func (x *TagDeletedEvent) Reset() {
+	*x = TagDeletedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TagExtractedEvent) String() string {
+func (x *TagDeletedEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TagExtractedEvent) ProtoMessage() {}
+func (*TagDeletedEvent) ProtoMessage() {}
 
-func (x *TagExtractedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_v1_events_proto_msgTypes[2]
+func (x *TagDeletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -209,62 +1189,229 @@ func (x *TagExtractedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TagExtractedEvent.ProtoReflect.Descriptor instead.
-func (*TagExtractedEvent) Descriptor() ([]byte, []int) {
-	return file_events_v1_events_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use TagDeletedEvent.ProtoReflect.Descriptor instead.
+func (*TagDeletedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *TagExtractedEvent) GetDocumentId() string {
+func (x *TagDeletedEvent) GetTagId() string {
 	if x != nil {
-		return x.DocumentId
+		return x.TagId
 	}
 	return ""
 }
 
-func (x *TagExtractedEvent) GetNamespace() string {
+func (x *TagDeletedEvent) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-func (x *TagExtractedEvent) GetTagPath() string {
+func (x *TagDeletedEvent) GetTagPath() string {
 	if x != nil {
 		return x.TagPath
 	}
 	return ""
 }
 
-func (x *TagExtractedEvent) GetMetadata() string {
+func (x *TagDeletedEvent) GetContext() *EventContext {
 	if x != nil {
-		return x.Metadata
+		return x.Context
+	}
+	return nil
+}
+
+// NamespaceCreatedEvent is published when a namespace is created.
+type NamespaceCreatedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace_id is the unique identifier of the namespace.
+	NamespaceId string `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	// namespace is the name of the namespace.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceCreatedEvent) Reset() {
+	*x = NamespaceCreatedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceCreatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceCreatedEvent) ProtoMessage() {}
+
+func (x *NamespaceCreatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceCreatedEvent.ProtoReflect.Descriptor instead.
+func (*NamespaceCreatedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NamespaceCreatedEvent) GetNamespaceId() string {
+	if x != nil {
+		return x.NamespaceId
 	}
 	return ""
 }
 
-func (x *TagExtractedEvent) GetAttributes() string {
+func (x *NamespaceCreatedEvent) GetNamespace() string {
 	if x != nil {
-		return x.Attributes
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NamespaceCreatedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// NamespaceDeletedEvent is published when a namespace and everything in it is deleted.
+type NamespaceDeletedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace_id is the unique identifier of the deleted namespace.
+	NamespaceId string `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	// namespace is the name of the deleted namespace.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// context identifies this event.
+	Context       *EventContext `protobuf:"bytes,15,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceDeletedEvent) Reset() {
+	*x = NamespaceDeletedEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceDeletedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceDeletedEvent) ProtoMessage() {}
+
+func (x *NamespaceDeletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceDeletedEvent.ProtoReflect.Descriptor instead.
+func (*NamespaceDeletedEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NamespaceDeletedEvent) GetNamespaceId() string {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return ""
+}
+
+func (x *NamespaceDeletedEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
 	}
 	return ""
 }
 
+func (x *NamespaceDeletedEvent) GetContext() *EventContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
 var File_events_v1_events_proto protoreflect.FileDescriptor
 
 const file_events_v1_events_proto_rawDesc = "" +
 	"\n" +
-	"\x16events/v1/events.proto\x12\tevents.v1\"\x8f\x01\n" +
+	"\x16events/v1/events.proto\x12\tevents.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"Y\n" +
+	"\x12WatchEventsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x04R\rafterSequence\"\xc5\a\n" +
+	"\x13WatchEventsResponse\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12O\n" +
+	"\x11document_uploaded\x18\x03 \x01(\v2 .events.v1.DocumentUploadedEventH\x00R\x10documentUploaded\x12L\n" +
+	"\x10document_updated\x18\x04 \x01(\v2\x1f.events.v1.DocumentUpdatedEventH\x00R\x0fdocumentUpdated\x12L\n" +
+	"\x10document_deleted\x18\x05 \x01(\v2\x1f.events.v1.DocumentDeletedEventH\x00R\x0fdocumentDeleted\x12R\n" +
+	"\x12attributes_updated\x18\x06 \x01(\v2!.events.v1.AttributesUpdatedEventH\x00R\x11attributesUpdated\x12F\n" +
+	"\x0eschema_changed\x18\a \x01(\v2\x1d.events.v1.SchemaChangedEventH\x00R\rschemaChanged\x12C\n" +
+	"\rtag_extracted\x18\b \x01(\v2\x1c.events.v1.TagExtractedEventH\x00R\ftagExtracted\x12=\n" +
+	"\vtag_removed\x18\t \x01(\v2\x1a.events.v1.TagRemovedEventH\x00R\n" +
+	"tagRemoved\x12=\n" +
+	"\vtag_created\x18\n" +
+	" \x01(\v2\x1a.events.v1.TagCreatedEventH\x00R\n" +
+	"tagCreated\x12=\n" +
+	"\vtag_updated\x18\v \x01(\v2\x1a.events.v1.TagUpdatedEventH\x00R\n" +
+	"tagUpdated\x12=\n" +
+	"\vtag_deleted\x18\f \x01(\v2\x1a.events.v1.TagDeletedEventH\x00R\n" +
+	"tagDeleted\x12O\n" +
+	"\x11namespace_created\x18\r \x01(\v2 .events.v1.NamespaceCreatedEventH\x00R\x10namespaceCreated\x12O\n" +
+	"\x11namespace_deleted\x18\x0e \x01(\v2 .events.v1.NamespaceDeletedEventH\x00R\x10namespaceDeletedB\a\n" +
+	"\x05event\"|\n" +
+	"\fEventContext\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12;\n" +
+	"\voccurred_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"\xf0\x01\n" +
 	"\x15DocumentUploadedEvent\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
 	"documentId\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1a\n" +
 	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x1b\n" +
-	"\tmime_type\x18\x04 \x01(\tR\bmimeType\"\x9d\x01\n" +
+	"\tmime_type\x18\x04 \x01(\tR\bmimeType\x12,\n" +
+	"\x12detected_mime_type\x18\x05 \x01(\tR\x10detectedMimeType\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\xc3\x01\n" +
+	"\x14DocumentUpdatedEvent\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12#\n" +
+	"\rdocument_date\x18\x04 \x01(\tR\fdocumentDate\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\xa4\x01\n" +
+	"\x14DocumentDeletedEvent\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\xd0\x01\n" +
 	"\x12SchemaChangedEvent\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x19\n" +
 	"\btag_path\x18\x02 \x01(\tR\atagPath\x12&\n" +
 	"\x0fold_json_schema\x18\x03 \x01(\tR\roldJsonSchema\x12&\n" +
-	"\x0fnew_json_schema\x18\x04 \x01(\tR\rnewJsonSchema\"\xa9\x01\n" +
+	"\x0fnew_json_schema\x18\x04 \x01(\tR\rnewJsonSchema\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\xdc\x01\n" +
 	"\x11TagExtractedEvent\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
 	"documentId\x12\x1c\n" +
@@ -273,7 +1420,50 @@ const file_events_v1_events_proto_rawDesc = "" +
 	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x1e\n" +
 	"\n" +
 	"attributes\x18\x05 \x01(\tR\n" +
-	"attributesB\x97\x01\n" +
+	"attributes\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\x9e\x01\n" +
+	"\x0fTagRemovedEvent\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x19\n" +
+	"\btag_path\x18\x03 \x01(\tR\atagPath\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\xc5\x01\n" +
+	"\x16AttributesUpdatedEvent\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x19\n" +
+	"\btag_path\x18\x03 \x01(\tR\atagPath\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x04 \x01(\tR\n" +
+	"attributes\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\x94\x01\n" +
+	"\x0fTagCreatedEvent\x12\x15\n" +
+	"\x06tag_id\x18\x01 \x01(\tR\x05tagId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x19\n" +
+	"\btag_path\x18\x03 \x01(\tR\atagPath\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\xb6\x01\n" +
+	"\x0fTagUpdatedEvent\x12\x15\n" +
+	"\x06tag_id\x18\x01 \x01(\tR\x05tagId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12 \n" +
+	"\fold_tag_path\x18\x03 \x01(\tR\n" +
+	"oldTagPath\x12\x19\n" +
+	"\btag_path\x18\x04 \x01(\tR\atagPath\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\x94\x01\n" +
+	"\x0fTagDeletedEvent\x12\x15\n" +
+	"\x06tag_id\x18\x01 \x01(\tR\x05tagId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x19\n" +
+	"\btag_path\x18\x03 \x01(\tR\atagPath\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\x8b\x01\n" +
+	"\x15NamespaceCreatedEvent\x12!\n" +
+	"\fnamespace_id\x18\x01 \x01(\tR\vnamespaceId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext\"\x8b\x01\n" +
+	"\x15NamespaceDeletedEvent\x12!\n" +
+	"\fnamespace_id\x18\x01 \x01(\tR\vnamespaceId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x121\n" +
+	"\acontext\x18\x0f \x01(\v2\x17.events.v1.EventContextR\acontext2^\n" +
+	"\fEventService\x12N\n" +
+	"\vWatchEvents\x12\x1d.events.v1.WatchEventsRequest\x1a\x1e.events.v1.WatchEventsResponse0\x01B\x97\x01\n" +
 	"\rcom.events.v1B\vEventsProtoP\x01Z4github.com/RynoXLI/Wayfile/gen/go/events/v1;eventsv1\xa2\x02\x03EXX\xaa\x02\tEvents.V1\xca\x02\tEvents\\V1\xe2\x02\x15Events\\V1\\GPBMetadata\xea\x02\n" +
 	"Events::V1b\x06proto3"
 
@@ -289,18 +1479,58 @@ func file_events_v1_events_proto_rawDescGZIP() []byte {
 	return file_events_v1_events_proto_rawDescData
 }
 
-var file_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_events_v1_events_proto_goTypes = []any{
-	(*DocumentUploadedEvent)(nil), // 0: events.v1.DocumentUploadedEvent
-	(*SchemaChangedEvent)(nil),    // 1: events.v1.SchemaChangedEvent
-	(*TagExtractedEvent)(nil),     // 2: events.v1.TagExtractedEvent
+	(*WatchEventsRequest)(nil),     // 0: events.v1.WatchEventsRequest
+	(*WatchEventsResponse)(nil),    // 1: events.v1.WatchEventsResponse
+	(*EventContext)(nil),           // 2: events.v1.EventContext
+	(*DocumentUploadedEvent)(nil),  // 3: events.v1.DocumentUploadedEvent
+	(*DocumentUpdatedEvent)(nil),   // 4: events.v1.DocumentUpdatedEvent
+	(*DocumentDeletedEvent)(nil),   // 5: events.v1.DocumentDeletedEvent
+	(*SchemaChangedEvent)(nil),     // 6: events.v1.SchemaChangedEvent
+	(*TagExtractedEvent)(nil),      // 7: events.v1.TagExtractedEvent
+	(*TagRemovedEvent)(nil),        // 8: events.v1.TagRemovedEvent
+	(*AttributesUpdatedEvent)(nil), // 9: events.v1.AttributesUpdatedEvent
+	(*TagCreatedEvent)(nil),        // 10: events.v1.TagCreatedEvent
+	(*TagUpdatedEvent)(nil),        // 11: events.v1.TagUpdatedEvent
+	(*TagDeletedEvent)(nil),        // 12: events.v1.TagDeletedEvent
+	(*NamespaceCreatedEvent)(nil),  // 13: events.v1.NamespaceCreatedEvent
+	(*NamespaceDeletedEvent)(nil),  // 14: events.v1.NamespaceDeletedEvent
+	(*timestamppb.Timestamp)(nil),  // 15: google.protobuf.Timestamp
 }
 var file_events_v1_events_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3,  // 0: events.v1.WatchEventsResponse.document_uploaded:type_name -> events.v1.DocumentUploadedEvent
+	4,  // 1: events.v1.WatchEventsResponse.document_updated:type_name -> events.v1.DocumentUpdatedEvent
+	5,  // 2: events.v1.WatchEventsResponse.document_deleted:type_name -> events.v1.DocumentDeletedEvent
+	9,  // 3: events.v1.WatchEventsResponse.attributes_updated:type_name -> events.v1.AttributesUpdatedEvent
+	6,  // 4: events.v1.WatchEventsResponse.schema_changed:type_name -> events.v1.SchemaChangedEvent
+	7,  // 5: events.v1.WatchEventsResponse.tag_extracted:type_name -> events.v1.TagExtractedEvent
+	8,  // 6: events.v1.WatchEventsResponse.tag_removed:type_name -> events.v1.TagRemovedEvent
+	10, // 7: events.v1.WatchEventsResponse.tag_created:type_name -> events.v1.TagCreatedEvent
+	11, // 8: events.v1.WatchEventsResponse.tag_updated:type_name -> events.v1.TagUpdatedEvent
+	12, // 9: events.v1.WatchEventsResponse.tag_deleted:type_name -> events.v1.TagDeletedEvent
+	13, // 10: events.v1.WatchEventsResponse.namespace_created:type_name -> events.v1.NamespaceCreatedEvent
+	14, // 11: events.v1.WatchEventsResponse.namespace_deleted:type_name -> events.v1.NamespaceDeletedEvent
+	15, // 12: events.v1.EventContext.occurred_at:type_name -> google.protobuf.Timestamp
+	2,  // 13: events.v1.DocumentUploadedEvent.context:type_name -> events.v1.EventContext
+	2,  // 14: events.v1.DocumentUpdatedEvent.context:type_name -> events.v1.EventContext
+	2,  // 15: events.v1.DocumentDeletedEvent.context:type_name -> events.v1.EventContext
+	2,  // 16: events.v1.SchemaChangedEvent.context:type_name -> events.v1.EventContext
+	2,  // 17: events.v1.TagExtractedEvent.context:type_name -> events.v1.EventContext
+	2,  // 18: events.v1.TagRemovedEvent.context:type_name -> events.v1.EventContext
+	2,  // 19: events.v1.AttributesUpdatedEvent.context:type_name -> events.v1.EventContext
+	2,  // 20: events.v1.TagCreatedEvent.context:type_name -> events.v1.EventContext
+	2,  // 21: events.v1.TagUpdatedEvent.context:type_name -> events.v1.EventContext
+	2,  // 22: events.v1.TagDeletedEvent.context:type_name -> events.v1.EventContext
+	2,  // 23: events.v1.NamespaceCreatedEvent.context:type_name -> events.v1.EventContext
+	2,  // 24: events.v1.NamespaceDeletedEvent.context:type_name -> events.v1.EventContext
+	0,  // 25: events.v1.EventService.WatchEvents:input_type -> events.v1.WatchEventsRequest
+	1,  // 26: events.v1.EventService.WatchEvents:output_type -> events.v1.WatchEventsResponse
+	26, // [26:27] is the sub-list for method output_type
+	25, // [25:26] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_events_v1_events_proto_init() }
@@ -308,15 +1538,29 @@ func file_events_v1_events_proto_init() {
 	if File_events_v1_events_proto != nil {
 		return
 	}
+	file_events_v1_events_proto_msgTypes[1].OneofWrappers = []any{
+		(*WatchEventsResponse_DocumentUploaded)(nil),
+		(*WatchEventsResponse_DocumentUpdated)(nil),
+		(*WatchEventsResponse_DocumentDeleted)(nil),
+		(*WatchEventsResponse_AttributesUpdated)(nil),
+		(*WatchEventsResponse_SchemaChanged)(nil),
+		(*WatchEventsResponse_TagExtracted)(nil),
+		(*WatchEventsResponse_TagRemoved)(nil),
+		(*WatchEventsResponse_TagCreated)(nil),
+		(*WatchEventsResponse_TagUpdated)(nil),
+		(*WatchEventsResponse_TagDeleted)(nil),
+		(*WatchEventsResponse_NamespaceCreated)(nil),
+		(*WatchEventsResponse_NamespaceDeleted)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_v1_events_proto_rawDesc), len(file_events_v1_events_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   15,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_events_v1_events_proto_goTypes,
 		DependencyIndexes: file_events_v1_events_proto_depIdxs,
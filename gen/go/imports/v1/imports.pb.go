@@ -0,0 +1,642 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: imports/v1/imports.proto
+
+package importsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Import describes an archive import and its progress.
+type Import struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the unique identifier of the import.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// file_name is the name of the uploaded archive.
+	FileName string `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// format is the archive format: "zip", "tar" or "tar.gz".
+	Format string `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	// tag_path is the tag that archive directories are created under (unset for the root).
+	TagPath *string `protobuf:"bytes,4,opt,name=tag_path,json=tagPath,proto3,oneof" json:"tag_path,omitempty"`
+	// status is one of "pending", "running", "completed" or "failed".
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// archive_size is the size of the uploaded archive in bytes.
+	ArchiveSize int64 `protobuf:"varint,6,opt,name=archive_size,json=archiveSize,proto3" json:"archive_size,omitempty"`
+	// bytes_processed is how much of the archive has been read so far.
+	BytesProcessed int64 `protobuf:"varint,7,opt,name=bytes_processed,json=bytesProcessed,proto3" json:"bytes_processed,omitempty"`
+	// total_entries is the number of files in the archive, known up front for ZIP
+	// archives and once the import finishes for tar archives.
+	TotalEntries *int32 `protobuf:"varint,8,opt,name=total_entries,json=totalEntries,proto3,oneof" json:"total_entries,omitempty"`
+	// processed_entries is the number of files handled so far.
+	ProcessedEntries int32 `protobuf:"varint,9,opt,name=processed_entries,json=processedEntries,proto3" json:"processed_entries,omitempty"`
+	// created_count is the number of documents created.
+	CreatedCount int32 `protobuf:"varint,10,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	// duplicate_count is the number of files whose content already existed.
+	DuplicateCount int32 `protobuf:"varint,11,opt,name=duplicate_count,json=duplicateCount,proto3" json:"duplicate_count,omitempty"`
+	// skipped_count is the number of entries ignored, such as links and OS metadata.
+	SkippedCount int32 `protobuf:"varint,12,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	// failed_count is the number of files that could not be imported.
+	FailedCount int32 `protobuf:"varint,13,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	// error describes why the import failed as a whole.
+	Error *string `protobuf:"bytes,14,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	// created_at is the timestamp when the archive was uploaded.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// started_at is the timestamp when processing started.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
+	// completed_at is the timestamp when processing finished.
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=completed_at,json=completedAt,proto3,oneof" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Import) Reset() {
+	*x = Import{}
+	mi := &file_imports_v1_imports_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Import) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Import) ProtoMessage() {}
+
+func (x *Import) ProtoReflect() protoreflect.Message {
+	mi := &file_imports_v1_imports_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Import.ProtoReflect.Descriptor instead.
+func (*Import) Descriptor() ([]byte, []int) {
+	return file_imports_v1_imports_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Import) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Import) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Import) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Import) GetTagPath() string {
+	if x != nil && x.TagPath != nil {
+		return *x.TagPath
+	}
+	return ""
+}
+
+func (x *Import) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Import) GetArchiveSize() int64 {
+	if x != nil {
+		return x.ArchiveSize
+	}
+	return 0
+}
+
+func (x *Import) GetBytesProcessed() int64 {
+	if x != nil {
+		return x.BytesProcessed
+	}
+	return 0
+}
+
+func (x *Import) GetTotalEntries() int32 {
+	if x != nil && x.TotalEntries != nil {
+		return *x.TotalEntries
+	}
+	return 0
+}
+
+func (x *Import) GetProcessedEntries() int32 {
+	if x != nil {
+		return x.ProcessedEntries
+	}
+	return 0
+}
+
+func (x *Import) GetCreatedCount() int32 {
+	if x != nil {
+		return x.CreatedCount
+	}
+	return 0
+}
+
+func (x *Import) GetDuplicateCount() int32 {
+	if x != nil {
+		return x.DuplicateCount
+	}
+	return 0
+}
+
+func (x *Import) GetSkippedCount() int32 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+func (x *Import) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *Import) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *Import) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Import) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Import) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// ImportEntry is the result of importing a single archive entry.
+type ImportEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path is the entry's path inside the archive.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// status is one of "created", "duplicate", "skipped" or "failed".
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// document_id is the created document, or the existing one for duplicates.
+	DocumentId *string `protobuf:"bytes,3,opt,name=document_id,json=documentId,proto3,oneof" json:"document_id,omitempty"`
+	// tag_path is the tag derived from the entry's directory.
+	TagPath *string `protobuf:"bytes,4,opt,name=tag_path,json=tagPath,proto3,oneof" json:"tag_path,omitempty"`
+	// error explains why the entry was skipped or failed.
+	Error         *string `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEntry) Reset() {
+	*x = ImportEntry{}
+	mi := &file_imports_v1_imports_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEntry) ProtoMessage() {}
+
+func (x *ImportEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_imports_v1_imports_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEntry.ProtoReflect.Descriptor instead.
+func (*ImportEntry) Descriptor() ([]byte, []int) {
+	return file_imports_v1_imports_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImportEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ImportEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportEntry) GetDocumentId() string {
+	if x != nil && x.DocumentId != nil {
+		return *x.DocumentId
+	}
+	return ""
+}
+
+func (x *ImportEntry) GetTagPath() string {
+	if x != nil && x.TagPath != nil {
+		return *x.TagPath
+	}
+	return ""
+}
+
+func (x *ImportEntry) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// GetImportRequest contains the information needed to get an import.
+type GetImportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace the archive was imported into.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// import_id is the unique identifier of the import.
+	ImportId      string `protobuf:"bytes,2,opt,name=import_id,json=importId,proto3" json:"import_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportRequest) Reset() {
+	*x = GetImportRequest{}
+	mi := &file_imports_v1_imports_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportRequest) ProtoMessage() {}
+
+func (x *GetImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imports_v1_imports_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportRequest.ProtoReflect.Descriptor instead.
+func (*GetImportRequest) Descriptor() ([]byte, []int) {
+	return file_imports_v1_imports_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetImportRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetImportRequest) GetImportId() string {
+	if x != nil {
+		return x.ImportId
+	}
+	return ""
+}
+
+// GetImportResponse contains the requested import.
+type GetImportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// import is the requested import.
+	Import        *Import `protobuf:"bytes,1,opt,name=import,proto3" json:"import,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportResponse) Reset() {
+	*x = GetImportResponse{}
+	mi := &file_imports_v1_imports_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportResponse) ProtoMessage() {}
+
+func (x *GetImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_imports_v1_imports_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportResponse.ProtoReflect.Descriptor instead.
+func (*GetImportResponse) Descriptor() ([]byte, []int) {
+	return file_imports_v1_imports_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetImportResponse) GetImport() *Import {
+	if x != nil {
+		return x.Import
+	}
+	return nil
+}
+
+// ListImportEntriesRequest contains the information needed to list import entries.
+type ListImportEntriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace the archive was imported into.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// import_id is the unique identifier of the import.
+	ImportId string `protobuf:"bytes,2,opt,name=import_id,json=importId,proto3" json:"import_id,omitempty"`
+	// limit is the maximum number of entries to return (default 100, maximum 1000).
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// offset is the number of entries to skip.
+	Offset        int32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportEntriesRequest) Reset() {
+	*x = ListImportEntriesRequest{}
+	mi := &file_imports_v1_imports_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportEntriesRequest) ProtoMessage() {}
+
+func (x *ListImportEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_imports_v1_imports_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListImportEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_imports_v1_imports_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListImportEntriesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListImportEntriesRequest) GetImportId() string {
+	if x != nil {
+		return x.ImportId
+	}
+	return ""
+}
+
+func (x *ListImportEntriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListImportEntriesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// ListImportEntriesResponse contains entries of an import in processing order.
+type ListImportEntriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// entries are the import's entry results.
+	Entries       []*ImportEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportEntriesResponse) Reset() {
+	*x = ListImportEntriesResponse{}
+	mi := &file_imports_v1_imports_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportEntriesResponse) ProtoMessage() {}
+
+func (x *ListImportEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_imports_v1_imports_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListImportEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_imports_v1_imports_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListImportEntriesResponse) GetEntries() []*ImportEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_imports_v1_imports_proto protoreflect.FileDescriptor
+
+const file_imports_v1_imports_proto_rawDesc = "" +
+	"\n" +
+	"\x18imports/v1/imports.proto\x12\n" +
+	"imports.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe1\x05\n" +
+	"\x06Import\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x1e\n" +
+	"\btag_path\x18\x04 \x01(\tH\x00R\atagPath\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
+	"\farchive_size\x18\x06 \x01(\x03R\varchiveSize\x12'\n" +
+	"\x0fbytes_processed\x18\a \x01(\x03R\x0ebytesProcessed\x12(\n" +
+	"\rtotal_entries\x18\b \x01(\x05H\x01R\ftotalEntries\x88\x01\x01\x12+\n" +
+	"\x11processed_entries\x18\t \x01(\x05R\x10processedEntries\x12#\n" +
+	"\rcreated_count\x18\n" +
+	" \x01(\x05R\fcreatedCount\x12'\n" +
+	"\x0fduplicate_count\x18\v \x01(\x05R\x0eduplicateCount\x12#\n" +
+	"\rskipped_count\x18\f \x01(\x05R\fskippedCount\x12!\n" +
+	"\ffailed_count\x18\r \x01(\x05R\vfailedCount\x12\x19\n" +
+	"\x05error\x18\x0e \x01(\tH\x02R\x05error\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"started_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\tstartedAt\x88\x01\x01\x12B\n" +
+	"\fcompleted_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\vcompletedAt\x88\x01\x01B\v\n" +
+	"\t_tag_pathB\x10\n" +
+	"\x0e_total_entriesB\b\n" +
+	"\x06_errorB\r\n" +
+	"\v_started_atB\x0f\n" +
+	"\r_completed_at\"\xc1\x01\n" +
+	"\vImportEntry\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12$\n" +
+	"\vdocument_id\x18\x03 \x01(\tH\x00R\n" +
+	"documentId\x88\x01\x01\x12\x1e\n" +
+	"\btag_path\x18\x04 \x01(\tH\x01R\atagPath\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\x05 \x01(\tH\x02R\x05error\x88\x01\x01B\x0e\n" +
+	"\f_document_idB\v\n" +
+	"\t_tag_pathB\b\n" +
+	"\x06_error\"M\n" +
+	"\x10GetImportRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\timport_id\x18\x02 \x01(\tR\bimportId\"?\n" +
+	"\x11GetImportResponse\x12*\n" +
+	"\x06import\x18\x01 \x01(\v2\x12.imports.v1.ImportR\x06import\"\x83\x01\n" +
+	"\x18ListImportEntriesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\timport_id\x18\x02 \x01(\tR\bimportId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"N\n" +
+	"\x19ListImportEntriesResponse\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.imports.v1.ImportEntryR\aentries2\xbb\x01\n" +
+	"\rImportService\x12H\n" +
+	"\tGetImport\x12\x1c.imports.v1.GetImportRequest\x1a\x1d.imports.v1.GetImportResponse\x12`\n" +
+	"\x11ListImportEntries\x12$.imports.v1.ListImportEntriesRequest\x1a%.imports.v1.ListImportEntriesResponseB\x9f\x01\n" +
+	"\x0ecom.imports.v1B\fImportsProtoP\x01Z6github.com/RynoXLI/Wayfile/gen/go/imports/v1;importsv1\xa2\x02\x03IXX\xaa\x02\n" +
+	"Imports.V1\xca\x02\n" +
+	"Imports\\V1\xe2\x02\x16Imports\\V1\\GPBMetadata\xea\x02\vImports::V1b\x06proto3"
+
+var (
+	file_imports_v1_imports_proto_rawDescOnce sync.Once
+	file_imports_v1_imports_proto_rawDescData []byte
+)
+
+func file_imports_v1_imports_proto_rawDescGZIP() []byte {
+	file_imports_v1_imports_proto_rawDescOnce.Do(func() {
+		file_imports_v1_imports_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_imports_v1_imports_proto_rawDesc), len(file_imports_v1_imports_proto_rawDesc)))
+	})
+	return file_imports_v1_imports_proto_rawDescData
+}
+
+var file_imports_v1_imports_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_imports_v1_imports_proto_goTypes = []any{
+	(*Import)(nil),                    // 0: imports.v1.Import
+	(*ImportEntry)(nil),               // 1: imports.v1.ImportEntry
+	(*GetImportRequest)(nil),          // 2: imports.v1.GetImportRequest
+	(*GetImportResponse)(nil),         // 3: imports.v1.GetImportResponse
+	(*ListImportEntriesRequest)(nil),  // 4: imports.v1.ListImportEntriesRequest
+	(*ListImportEntriesResponse)(nil), // 5: imports.v1.ListImportEntriesResponse
+	(*timestamppb.Timestamp)(nil),     // 6: google.protobuf.Timestamp
+}
+var file_imports_v1_imports_proto_depIdxs = []int32{
+	6, // 0: imports.v1.Import.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: imports.v1.Import.started_at:type_name -> google.protobuf.Timestamp
+	6, // 2: imports.v1.Import.completed_at:type_name -> google.protobuf.Timestamp
+	0, // 3: imports.v1.GetImportResponse.import:type_name -> imports.v1.Import
+	1, // 4: imports.v1.ListImportEntriesResponse.entries:type_name -> imports.v1.ImportEntry
+	2, // 5: imports.v1.ImportService.GetImport:input_type -> imports.v1.GetImportRequest
+	4, // 6: imports.v1.ImportService.ListImportEntries:input_type -> imports.v1.ListImportEntriesRequest
+	3, // 7: imports.v1.ImportService.GetImport:output_type -> imports.v1.GetImportResponse
+	5, // 8: imports.v1.ImportService.ListImportEntries:output_type -> imports.v1.ListImportEntriesResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_imports_v1_imports_proto_init() }
+func file_imports_v1_imports_proto_init() {
+	if File_imports_v1_imports_proto != nil {
+		return
+	}
+	file_imports_v1_imports_proto_msgTypes[0].OneofWrappers = []any{}
+	file_imports_v1_imports_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_imports_v1_imports_proto_rawDesc), len(file_imports_v1_imports_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_imports_v1_imports_proto_goTypes,
+		DependencyIndexes: file_imports_v1_imports_proto_depIdxs,
+		MessageInfos:      file_imports_v1_imports_proto_msgTypes,
+	}.Build()
+	File_imports_v1_imports_proto = out.File
+	file_imports_v1_imports_proto_goTypes = nil
+	file_imports_v1_imports_proto_depIdxs = nil
+}
@@ -0,0 +1,149 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: imports/v1/imports.proto
+
+package importsv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/RynoXLI/Wayfile/gen/go/imports/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ImportServiceName is the fully-qualified name of the ImportService service.
+	ImportServiceName = "imports.v1.ImportService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ImportServiceGetImportProcedure is the fully-qualified name of the ImportService's GetImport RPC.
+	ImportServiceGetImportProcedure = "/imports.v1.ImportService/GetImport"
+	// ImportServiceListImportEntriesProcedure is the fully-qualified name of the ImportService's
+	// ListImportEntries RPC.
+	ImportServiceListImportEntriesProcedure = "/imports.v1.ImportService/ListImportEntries"
+)
+
+// ImportServiceClient is a client for the imports.v1.ImportService service.
+type ImportServiceClient interface {
+	// GetImport retrieves the status and progress of an import.
+	GetImport(context.Context, *v1.GetImportRequest) (*v1.GetImportResponse, error)
+	// ListImportEntries retrieves the per-entry results of an import.
+	ListImportEntries(context.Context, *v1.ListImportEntriesRequest) (*v1.ListImportEntriesResponse, error)
+}
+
+// NewImportServiceClient constructs a client for the imports.v1.ImportService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewImportServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ImportServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	importServiceMethods := v1.File_imports_v1_imports_proto.Services().ByName("ImportService").Methods()
+	return &importServiceClient{
+		getImport: connect.NewClient[v1.GetImportRequest, v1.GetImportResponse](
+			httpClient,
+			baseURL+ImportServiceGetImportProcedure,
+			connect.WithSchema(importServiceMethods.ByName("GetImport")),
+			connect.WithClientOptions(opts...),
+		),
+		listImportEntries: connect.NewClient[v1.ListImportEntriesRequest, v1.ListImportEntriesResponse](
+			httpClient,
+			baseURL+ImportServiceListImportEntriesProcedure,
+			connect.WithSchema(importServiceMethods.ByName("ListImportEntries")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// importServiceClient implements ImportServiceClient.
+type importServiceClient struct {
+	getImport         *connect.Client[v1.GetImportRequest, v1.GetImportResponse]
+	listImportEntries *connect.Client[v1.ListImportEntriesRequest, v1.ListImportEntriesResponse]
+}
+
+// GetImport calls imports.v1.ImportService.GetImport.
+func (c *importServiceClient) GetImport(ctx context.Context, req *v1.GetImportRequest) (*v1.GetImportResponse, error) {
+	response, err := c.getImport.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListImportEntries calls imports.v1.ImportService.ListImportEntries.
+func (c *importServiceClient) ListImportEntries(ctx context.Context, req *v1.ListImportEntriesRequest) (*v1.ListImportEntriesResponse, error) {
+	response, err := c.listImportEntries.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ImportServiceHandler is an implementation of the imports.v1.ImportService service.
+type ImportServiceHandler interface {
+	// GetImport retrieves the status and progress of an import.
+	GetImport(context.Context, *v1.GetImportRequest) (*v1.GetImportResponse, error)
+	// ListImportEntries retrieves the per-entry results of an import.
+	ListImportEntries(context.Context, *v1.ListImportEntriesRequest) (*v1.ListImportEntriesResponse, error)
+}
+
+// NewImportServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewImportServiceHandler(svc ImportServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	importServiceMethods := v1.File_imports_v1_imports_proto.Services().ByName("ImportService").Methods()
+	importServiceGetImportHandler := connect.NewUnaryHandlerSimple(
+		ImportServiceGetImportProcedure,
+		svc.GetImport,
+		connect.WithSchema(importServiceMethods.ByName("GetImport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	importServiceListImportEntriesHandler := connect.NewUnaryHandlerSimple(
+		ImportServiceListImportEntriesProcedure,
+		svc.ListImportEntries,
+		connect.WithSchema(importServiceMethods.ByName("ListImportEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/imports.v1.ImportService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ImportServiceGetImportProcedure:
+			importServiceGetImportHandler.ServeHTTP(w, r)
+		case ImportServiceListImportEntriesProcedure:
+			importServiceListImportEntriesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedImportServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedImportServiceHandler struct{}
+
+func (UnimplementedImportServiceHandler) GetImport(context.Context, *v1.GetImportRequest) (*v1.GetImportResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("imports.v1.ImportService.GetImport is not implemented"))
+}
+
+func (UnimplementedImportServiceHandler) ListImportEntries(context.Context, *v1.ListImportEntriesRequest) (*v1.ListImportEntriesResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("imports.v1.ImportService.ListImportEntries is not implemented"))
+}
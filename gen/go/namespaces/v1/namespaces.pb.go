@@ -32,9 +32,12 @@ type Namespace struct {
 	// created_at is the timestamp when the namespace was created.
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// modified_at is the timestamp when the namespace was last modified.
-	ModifiedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ModifiedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	// review_threshold is the confidence below which automatic extractions are queued for
+	// review, unset if every extraction is applied.
+	ReviewThreshold *float64 `protobuf:"fixed64,5,opt,name=review_threshold,json=reviewThreshold,proto3,oneof" json:"review_threshold,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Namespace) Reset() {
@@ -95,6 +98,13 @@ func (x *Namespace) GetModifiedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Namespace) GetReviewThreshold() float64 {
+	if x != nil && x.ReviewThreshold != nil {
+		return *x.ReviewThreshold
+	}
+	return 0
+}
+
 // CreateNamespaceRequest contains the data needed to create a namespace.
 type CreateNamespaceRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -449,14 +459,16 @@ var File_namespaces_v1_namespaces_proto protoreflect.FileDescriptor
 
 const file_namespaces_v1_namespaces_proto_rawDesc = "" +
 	"\n" +
-	"\x1enamespaces/v1/namespaces.proto\x12\rnamespaces.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa7\x01\n" +
+	"\x1enamespaces/v1/namespaces.proto\x12\rnamespaces.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xec\x01\n" +
 	"\tNamespace\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
 	"\n" +
 	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
 	"\vmodified_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"modifiedAt\",\n" +
+	"modifiedAt\x12.\n" +
+	"\x10review_threshold\x18\x05 \x01(\x01H\x00R\x0freviewThreshold\x88\x01\x01B\x13\n" +
+	"\x11_review_threshold\",\n" +
 	"\x16CreateNamespaceRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"Q\n" +
 	"\x17CreateNamespaceResponse\x126\n" +
@@ -531,6 +543,7 @@ func file_namespaces_v1_namespaces_proto_init() {
 	if File_namespaces_v1_namespaces_proto != nil {
 		return
 	}
+	file_namespaces_v1_namespaces_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -0,0 +1,658 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: reviews/v1/reviews.proto
+
+package reviewsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ReviewItem is an automatic extraction awaiting review.
+type ReviewItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the unique identifier of the review item.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// document_id is the document the tag was extracted for.
+	DocumentId string `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// tag_path is the path of the extracted tag.
+	TagPath string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// attributes is a JSON object of the extracted attributes, if any.
+	Attributes *string `protobuf:"bytes,4,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"`
+	// confidence is the extractor's confidence, from 0 to 1.
+	Confidence float64 `protobuf:"fixed64,5,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	// extracted_by identifies the extractor, or the rule, that proposed the tag.
+	ExtractedBy string `protobuf:"bytes,6,opt,name=extracted_by,json=extractedBy,proto3" json:"extracted_by,omitempty"`
+	// created_at is the timestamp when the extraction was queued.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewItem) Reset() {
+	*x = ReviewItem{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewItem) ProtoMessage() {}
+
+func (x *ReviewItem) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewItem.ProtoReflect.Descriptor instead.
+func (*ReviewItem) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReviewItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReviewItem) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *ReviewItem) GetTagPath() string {
+	if x != nil {
+		return x.TagPath
+	}
+	return ""
+}
+
+func (x *ReviewItem) GetAttributes() string {
+	if x != nil && x.Attributes != nil {
+		return *x.Attributes
+	}
+	return ""
+}
+
+func (x *ReviewItem) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *ReviewItem) GetExtractedBy() string {
+	if x != nil {
+		return x.ExtractedBy
+	}
+	return ""
+}
+
+func (x *ReviewItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// SetReviewThresholdRequest contains a namespace's new review threshold.
+type SetReviewThresholdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// threshold is the confidence, from 0 to 1, below which extractions are queued.
+	// Leave unset to apply every extraction.
+	Threshold     *float64 `protobuf:"fixed64,2,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReviewThresholdRequest) Reset() {
+	*x = SetReviewThresholdRequest{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReviewThresholdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReviewThresholdRequest) ProtoMessage() {}
+
+func (x *SetReviewThresholdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReviewThresholdRequest.ProtoReflect.Descriptor instead.
+func (*SetReviewThresholdRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetReviewThresholdRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SetReviewThresholdRequest) GetThreshold() float64 {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return 0
+}
+
+// SetReviewThresholdResponse contains the namespace's review threshold.
+type SetReviewThresholdResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// threshold is the namespace's review threshold, unset if there is none.
+	Threshold     *float64 `protobuf:"fixed64,1,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReviewThresholdResponse) Reset() {
+	*x = SetReviewThresholdResponse{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReviewThresholdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReviewThresholdResponse) ProtoMessage() {}
+
+func (x *SetReviewThresholdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReviewThresholdResponse.ProtoReflect.Descriptor instead.
+func (*SetReviewThresholdResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetReviewThresholdResponse) GetThreshold() float64 {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return 0
+}
+
+// ListReviewItemsRequest contains the namespace whose review queue to retrieve.
+type ListReviewItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace.
+	Namespace     string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewItemsRequest) Reset() {
+	*x = ListReviewItemsRequest{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewItemsRequest) ProtoMessage() {}
+
+func (x *ListReviewItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewItemsRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListReviewItemsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ListReviewItemsResponse contains the pending review items.
+type ListReviewItemsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// items are the pending review items, oldest first.
+	Items         []*ReviewItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewItemsResponse) Reset() {
+	*x = ListReviewItemsResponse{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewItemsResponse) ProtoMessage() {}
+
+func (x *ListReviewItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewItemsResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListReviewItemsResponse) GetItems() []*ReviewItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// AcceptReviewItemRequest identifies the review item to accept.
+type AcceptReviewItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace containing the review item.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// item_id is the unique identifier of the review item.
+	ItemId string `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	// reviewer identifies who accepted the extraction.
+	Reviewer string `protobuf:"bytes,3,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	// attributes is a JSON object that replaces the extracted attributes.
+	// Leave unset to accept the extracted attributes as they are.
+	Attributes    *string `protobuf:"bytes,4,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptReviewItemRequest) Reset() {
+	*x = AcceptReviewItemRequest{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptReviewItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptReviewItemRequest) ProtoMessage() {}
+
+func (x *AcceptReviewItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptReviewItemRequest.ProtoReflect.Descriptor instead.
+func (*AcceptReviewItemRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AcceptReviewItemRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AcceptReviewItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AcceptReviewItemRequest) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+func (x *AcceptReviewItemRequest) GetAttributes() string {
+	if x != nil && x.Attributes != nil {
+		return *x.Attributes
+	}
+	return ""
+}
+
+// AcceptReviewItemResponse is returned when an extraction has been applied.
+type AcceptReviewItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptReviewItemResponse) Reset() {
+	*x = AcceptReviewItemResponse{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptReviewItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptReviewItemResponse) ProtoMessage() {}
+
+func (x *AcceptReviewItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptReviewItemResponse.ProtoReflect.Descriptor instead.
+func (*AcceptReviewItemResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{6}
+}
+
+// RejectReviewItemRequest identifies the review item to reject.
+type RejectReviewItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace containing the review item.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// item_id is the unique identifier of the review item.
+	ItemId string `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	// reviewer identifies who rejected the extraction.
+	Reviewer      string `protobuf:"bytes,3,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectReviewItemRequest) Reset() {
+	*x = RejectReviewItemRequest{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectReviewItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectReviewItemRequest) ProtoMessage() {}
+
+func (x *RejectReviewItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectReviewItemRequest.ProtoReflect.Descriptor instead.
+func (*RejectReviewItemRequest) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RejectReviewItemRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RejectReviewItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *RejectReviewItemRequest) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+// RejectReviewItemResponse is returned when an extraction has been discarded.
+type RejectReviewItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectReviewItemResponse) Reset() {
+	*x = RejectReviewItemResponse{}
+	mi := &file_reviews_v1_reviews_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectReviewItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectReviewItemResponse) ProtoMessage() {}
+
+func (x *RejectReviewItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviews_v1_reviews_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectReviewItemResponse.ProtoReflect.Descriptor instead.
+func (*RejectReviewItemResponse) Descriptor() ([]byte, []int) {
+	return file_reviews_v1_reviews_proto_rawDescGZIP(), []int{8}
+}
+
+var File_reviews_v1_reviews_proto protoreflect.FileDescriptor
+
+const file_reviews_v1_reviews_proto_rawDesc = "" +
+	"\n" +
+	"\x18reviews/v1/reviews.proto\x12\n" +
+	"reviews.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x02\n" +
+	"\n" +
+	"ReviewItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12\x19\n" +
+	"\btag_path\x18\x03 \x01(\tR\atagPath\x12#\n" +
+	"\n" +
+	"attributes\x18\x04 \x01(\tH\x00R\n" +
+	"attributes\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x05 \x01(\x01R\n" +
+	"confidence\x12!\n" +
+	"\fextracted_by\x18\x06 \x01(\tR\vextractedBy\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\r\n" +
+	"\v_attributes\"j\n" +
+	"\x19SetReviewThresholdRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12!\n" +
+	"\tthreshold\x18\x02 \x01(\x01H\x00R\tthreshold\x88\x01\x01B\f\n" +
+	"\n" +
+	"_threshold\"M\n" +
+	"\x1aSetReviewThresholdResponse\x12!\n" +
+	"\tthreshold\x18\x01 \x01(\x01H\x00R\tthreshold\x88\x01\x01B\f\n" +
+	"\n" +
+	"_threshold\"6\n" +
+	"\x16ListReviewItemsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"G\n" +
+	"\x17ListReviewItemsResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.reviews.v1.ReviewItemR\x05items\"\xa0\x01\n" +
+	"\x17AcceptReviewItemRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\breviewer\x18\x03 \x01(\tR\breviewer\x12#\n" +
+	"\n" +
+	"attributes\x18\x04 \x01(\tH\x00R\n" +
+	"attributes\x88\x01\x01B\r\n" +
+	"\v_attributes\"\x1a\n" +
+	"\x18AcceptReviewItemResponse\"l\n" +
+	"\x17RejectReviewItemRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\breviewer\x18\x03 \x01(\tR\breviewer\"\x1a\n" +
+	"\x18RejectReviewItemResponse2\x8e\x03\n" +
+	"\rReviewService\x12c\n" +
+	"\x12SetReviewThreshold\x12%.reviews.v1.SetReviewThresholdRequest\x1a&.reviews.v1.SetReviewThresholdResponse\x12Z\n" +
+	"\x0fListReviewItems\x12\".reviews.v1.ListReviewItemsRequest\x1a#.reviews.v1.ListReviewItemsResponse\x12]\n" +
+	"\x10AcceptReviewItem\x12#.reviews.v1.AcceptReviewItemRequest\x1a$.reviews.v1.AcceptReviewItemResponse\x12]\n" +
+	"\x10RejectReviewItem\x12#.reviews.v1.RejectReviewItemRequest\x1a$.reviews.v1.RejectReviewItemResponseB\x9f\x01\n" +
+	"\x0ecom.reviews.v1B\fReviewsProtoP\x01Z6github.com/RynoXLI/Wayfile/gen/go/reviews/v1;reviewsv1\xa2\x02\x03RXX\xaa\x02\n" +
+	"Reviews.V1\xca\x02\n" +
+	"Reviews\\V1\xe2\x02\x16Reviews\\V1\\GPBMetadata\xea\x02\vReviews::V1b\x06proto3"
+
+var (
+	file_reviews_v1_reviews_proto_rawDescOnce sync.Once
+	file_reviews_v1_reviews_proto_rawDescData []byte
+)
+
+func file_reviews_v1_reviews_proto_rawDescGZIP() []byte {
+	file_reviews_v1_reviews_proto_rawDescOnce.Do(func() {
+		file_reviews_v1_reviews_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviews_v1_reviews_proto_rawDesc), len(file_reviews_v1_reviews_proto_rawDesc)))
+	})
+	return file_reviews_v1_reviews_proto_rawDescData
+}
+
+var file_reviews_v1_reviews_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_reviews_v1_reviews_proto_goTypes = []any{
+	(*ReviewItem)(nil),                 // 0: reviews.v1.ReviewItem
+	(*SetReviewThresholdRequest)(nil),  // 1: reviews.v1.SetReviewThresholdRequest
+	(*SetReviewThresholdResponse)(nil), // 2: reviews.v1.SetReviewThresholdResponse
+	(*ListReviewItemsRequest)(nil),     // 3: reviews.v1.ListReviewItemsRequest
+	(*ListReviewItemsResponse)(nil),    // 4: reviews.v1.ListReviewItemsResponse
+	(*AcceptReviewItemRequest)(nil),    // 5: reviews.v1.AcceptReviewItemRequest
+	(*AcceptReviewItemResponse)(nil),   // 6: reviews.v1.AcceptReviewItemResponse
+	(*RejectReviewItemRequest)(nil),    // 7: reviews.v1.RejectReviewItemRequest
+	(*RejectReviewItemResponse)(nil),   // 8: reviews.v1.RejectReviewItemResponse
+	(*timestamppb.Timestamp)(nil),      // 9: google.protobuf.Timestamp
+}
+var file_reviews_v1_reviews_proto_depIdxs = []int32{
+	9, // 0: reviews.v1.ReviewItem.created_at:type_name -> google.protobuf.Timestamp
+	0, // 1: reviews.v1.ListReviewItemsResponse.items:type_name -> reviews.v1.ReviewItem
+	1, // 2: reviews.v1.ReviewService.SetReviewThreshold:input_type -> reviews.v1.SetReviewThresholdRequest
+	3, // 3: reviews.v1.ReviewService.ListReviewItems:input_type -> reviews.v1.ListReviewItemsRequest
+	5, // 4: reviews.v1.ReviewService.AcceptReviewItem:input_type -> reviews.v1.AcceptReviewItemRequest
+	7, // 5: reviews.v1.ReviewService.RejectReviewItem:input_type -> reviews.v1.RejectReviewItemRequest
+	2, // 6: reviews.v1.ReviewService.SetReviewThreshold:output_type -> reviews.v1.SetReviewThresholdResponse
+	4, // 7: reviews.v1.ReviewService.ListReviewItems:output_type -> reviews.v1.ListReviewItemsResponse
+	6, // 8: reviews.v1.ReviewService.AcceptReviewItem:output_type -> reviews.v1.AcceptReviewItemResponse
+	8, // 9: reviews.v1.ReviewService.RejectReviewItem:output_type -> reviews.v1.RejectReviewItemResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_reviews_v1_reviews_proto_init() }
+func file_reviews_v1_reviews_proto_init() {
+	if File_reviews_v1_reviews_proto != nil {
+		return
+	}
+	file_reviews_v1_reviews_proto_msgTypes[0].OneofWrappers = []any{}
+	file_reviews_v1_reviews_proto_msgTypes[1].OneofWrappers = []any{}
+	file_reviews_v1_reviews_proto_msgTypes[2].OneofWrappers = []any{}
+	file_reviews_v1_reviews_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviews_v1_reviews_proto_rawDesc), len(file_reviews_v1_reviews_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reviews_v1_reviews_proto_goTypes,
+		DependencyIndexes: file_reviews_v1_reviews_proto_depIdxs,
+		MessageInfos:      file_reviews_v1_reviews_proto_msgTypes,
+	}.Build()
+	File_reviews_v1_reviews_proto = out.File
+	file_reviews_v1_reviews_proto_goTypes = nil
+	file_reviews_v1_reviews_proto_depIdxs = nil
+}
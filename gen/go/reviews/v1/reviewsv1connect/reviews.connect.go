@@ -0,0 +1,224 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: reviews/v1/reviews.proto
+
+package reviewsv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/RynoXLI/Wayfile/gen/go/reviews/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ReviewServiceName is the fully-qualified name of the ReviewService service.
+	ReviewServiceName = "reviews.v1.ReviewService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ReviewServiceSetReviewThresholdProcedure is the fully-qualified name of the ReviewService's
+	// SetReviewThreshold RPC.
+	ReviewServiceSetReviewThresholdProcedure = "/reviews.v1.ReviewService/SetReviewThreshold"
+	// ReviewServiceListReviewItemsProcedure is the fully-qualified name of the ReviewService's
+	// ListReviewItems RPC.
+	ReviewServiceListReviewItemsProcedure = "/reviews.v1.ReviewService/ListReviewItems"
+	// ReviewServiceAcceptReviewItemProcedure is the fully-qualified name of the ReviewService's
+	// AcceptReviewItem RPC.
+	ReviewServiceAcceptReviewItemProcedure = "/reviews.v1.ReviewService/AcceptReviewItem"
+	// ReviewServiceRejectReviewItemProcedure is the fully-qualified name of the ReviewService's
+	// RejectReviewItem RPC.
+	ReviewServiceRejectReviewItemProcedure = "/reviews.v1.ReviewService/RejectReviewItem"
+)
+
+// ReviewServiceClient is a client for the reviews.v1.ReviewService service.
+type ReviewServiceClient interface {
+	// SetReviewThreshold sets the confidence below which a namespace's automatic
+	// extractions are queued for review, or clears it to apply every extraction.
+	SetReviewThreshold(context.Context, *v1.SetReviewThresholdRequest) (*v1.SetReviewThresholdResponse, error)
+	// ListReviewItems retrieves a namespace's pending review items, oldest first.
+	ListReviewItems(context.Context, *v1.ListReviewItemsRequest) (*v1.ListReviewItemsResponse, error)
+	// AcceptReviewItem applies a pending extraction, optionally with corrected attributes.
+	// The tag and its attributes are recorded as set manually by the reviewer.
+	AcceptReviewItem(context.Context, *v1.AcceptReviewItemRequest) (*v1.AcceptReviewItemResponse, error)
+	// RejectReviewItem discards a pending extraction without applying it.
+	RejectReviewItem(context.Context, *v1.RejectReviewItemRequest) (*v1.RejectReviewItemResponse, error)
+}
+
+// NewReviewServiceClient constructs a client for the reviews.v1.ReviewService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewReviewServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ReviewServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	reviewServiceMethods := v1.File_reviews_v1_reviews_proto.Services().ByName("ReviewService").Methods()
+	return &reviewServiceClient{
+		setReviewThreshold: connect.NewClient[v1.SetReviewThresholdRequest, v1.SetReviewThresholdResponse](
+			httpClient,
+			baseURL+ReviewServiceSetReviewThresholdProcedure,
+			connect.WithSchema(reviewServiceMethods.ByName("SetReviewThreshold")),
+			connect.WithClientOptions(opts...),
+		),
+		listReviewItems: connect.NewClient[v1.ListReviewItemsRequest, v1.ListReviewItemsResponse](
+			httpClient,
+			baseURL+ReviewServiceListReviewItemsProcedure,
+			connect.WithSchema(reviewServiceMethods.ByName("ListReviewItems")),
+			connect.WithClientOptions(opts...),
+		),
+		acceptReviewItem: connect.NewClient[v1.AcceptReviewItemRequest, v1.AcceptReviewItemResponse](
+			httpClient,
+			baseURL+ReviewServiceAcceptReviewItemProcedure,
+			connect.WithSchema(reviewServiceMethods.ByName("AcceptReviewItem")),
+			connect.WithClientOptions(opts...),
+		),
+		rejectReviewItem: connect.NewClient[v1.RejectReviewItemRequest, v1.RejectReviewItemResponse](
+			httpClient,
+			baseURL+ReviewServiceRejectReviewItemProcedure,
+			connect.WithSchema(reviewServiceMethods.ByName("RejectReviewItem")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// reviewServiceClient implements ReviewServiceClient.
+type reviewServiceClient struct {
+	setReviewThreshold *connect.Client[v1.SetReviewThresholdRequest, v1.SetReviewThresholdResponse]
+	listReviewItems    *connect.Client[v1.ListReviewItemsRequest, v1.ListReviewItemsResponse]
+	acceptReviewItem   *connect.Client[v1.AcceptReviewItemRequest, v1.AcceptReviewItemResponse]
+	rejectReviewItem   *connect.Client[v1.RejectReviewItemRequest, v1.RejectReviewItemResponse]
+}
+
+// SetReviewThreshold calls reviews.v1.ReviewService.SetReviewThreshold.
+func (c *reviewServiceClient) SetReviewThreshold(ctx context.Context, req *v1.SetReviewThresholdRequest) (*v1.SetReviewThresholdResponse, error) {
+	response, err := c.setReviewThreshold.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListReviewItems calls reviews.v1.ReviewService.ListReviewItems.
+func (c *reviewServiceClient) ListReviewItems(ctx context.Context, req *v1.ListReviewItemsRequest) (*v1.ListReviewItemsResponse, error) {
+	response, err := c.listReviewItems.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// AcceptReviewItem calls reviews.v1.ReviewService.AcceptReviewItem.
+func (c *reviewServiceClient) AcceptReviewItem(ctx context.Context, req *v1.AcceptReviewItemRequest) (*v1.AcceptReviewItemResponse, error) {
+	response, err := c.acceptReviewItem.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// RejectReviewItem calls reviews.v1.ReviewService.RejectReviewItem.
+func (c *reviewServiceClient) RejectReviewItem(ctx context.Context, req *v1.RejectReviewItemRequest) (*v1.RejectReviewItemResponse, error) {
+	response, err := c.rejectReviewItem.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ReviewServiceHandler is an implementation of the reviews.v1.ReviewService service.
+type ReviewServiceHandler interface {
+	// SetReviewThreshold sets the confidence below which a namespace's automatic
+	// extractions are queued for review, or clears it to apply every extraction.
+	SetReviewThreshold(context.Context, *v1.SetReviewThresholdRequest) (*v1.SetReviewThresholdResponse, error)
+	// ListReviewItems retrieves a namespace's pending review items, oldest first.
+	ListReviewItems(context.Context, *v1.ListReviewItemsRequest) (*v1.ListReviewItemsResponse, error)
+	// AcceptReviewItem applies a pending extraction, optionally with corrected attributes.
+	// The tag and its attributes are recorded as set manually by the reviewer.
+	AcceptReviewItem(context.Context, *v1.AcceptReviewItemRequest) (*v1.AcceptReviewItemResponse, error)
+	// RejectReviewItem discards a pending extraction without applying it.
+	RejectReviewItem(context.Context, *v1.RejectReviewItemRequest) (*v1.RejectReviewItemResponse, error)
+}
+
+// NewReviewServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewReviewServiceHandler(svc ReviewServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	reviewServiceMethods := v1.File_reviews_v1_reviews_proto.Services().ByName("ReviewService").Methods()
+	reviewServiceSetReviewThresholdHandler := connect.NewUnaryHandlerSimple(
+		ReviewServiceSetReviewThresholdProcedure,
+		svc.SetReviewThreshold,
+		connect.WithSchema(reviewServiceMethods.ByName("SetReviewThreshold")),
+		connect.WithHandlerOptions(opts...),
+	)
+	reviewServiceListReviewItemsHandler := connect.NewUnaryHandlerSimple(
+		ReviewServiceListReviewItemsProcedure,
+		svc.ListReviewItems,
+		connect.WithSchema(reviewServiceMethods.ByName("ListReviewItems")),
+		connect.WithHandlerOptions(opts...),
+	)
+	reviewServiceAcceptReviewItemHandler := connect.NewUnaryHandlerSimple(
+		ReviewServiceAcceptReviewItemProcedure,
+		svc.AcceptReviewItem,
+		connect.WithSchema(reviewServiceMethods.ByName("AcceptReviewItem")),
+		connect.WithHandlerOptions(opts...),
+	)
+	reviewServiceRejectReviewItemHandler := connect.NewUnaryHandlerSimple(
+		ReviewServiceRejectReviewItemProcedure,
+		svc.RejectReviewItem,
+		connect.WithSchema(reviewServiceMethods.ByName("RejectReviewItem")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/reviews.v1.ReviewService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ReviewServiceSetReviewThresholdProcedure:
+			reviewServiceSetReviewThresholdHandler.ServeHTTP(w, r)
+		case ReviewServiceListReviewItemsProcedure:
+			reviewServiceListReviewItemsHandler.ServeHTTP(w, r)
+		case ReviewServiceAcceptReviewItemProcedure:
+			reviewServiceAcceptReviewItemHandler.ServeHTTP(w, r)
+		case ReviewServiceRejectReviewItemProcedure:
+			reviewServiceRejectReviewItemHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedReviewServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedReviewServiceHandler struct{}
+
+func (UnimplementedReviewServiceHandler) SetReviewThreshold(context.Context, *v1.SetReviewThresholdRequest) (*v1.SetReviewThresholdResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("reviews.v1.ReviewService.SetReviewThreshold is not implemented"))
+}
+
+func (UnimplementedReviewServiceHandler) ListReviewItems(context.Context, *v1.ListReviewItemsRequest) (*v1.ListReviewItemsResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("reviews.v1.ReviewService.ListReviewItems is not implemented"))
+}
+
+func (UnimplementedReviewServiceHandler) AcceptReviewItem(context.Context, *v1.AcceptReviewItemRequest) (*v1.AcceptReviewItemResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("reviews.v1.ReviewService.AcceptReviewItem is not implemented"))
+}
+
+func (UnimplementedReviewServiceHandler) RejectReviewItem(context.Context, *v1.RejectReviewItemRequest) (*v1.RejectReviewItemResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("reviews.v1.ReviewService.RejectReviewItem is not implemented"))
+}
@@ -0,0 +1,1284 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rules/v1/rules.proto
+
+package rulesv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Rule tags the documents that meet all of its conditions.
+type Rule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the unique identifier of the rule, recorded as extracted_by on the tags it adds.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// name is unique within the namespace.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// enabled rules are evaluated on upload.
+	Enabled bool `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// priority orders the rules, lowest first; a later rule setting the same tag wins.
+	Priority int32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// conditions a document must meet for the rule to apply.
+	Conditions *RuleConditions `protobuf:"bytes,5,opt,name=conditions,proto3" json:"conditions,omitempty"`
+	// action is applied to matching documents.
+	Action *RuleAction `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	// created_at is the timestamp when the rule was created.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// modified_at is the timestamp when the rule was last changed.
+	ModifiedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	mi := &file_rules_v1_rules_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Rule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Rule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Rule) GetConditions() *RuleConditions {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *Rule) GetAction() *RuleAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *Rule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Rule) GetModifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return nil
+}
+
+// RuleConditions are the tests a document must pass. Unset conditions are ignored; at
+// least one must be set.
+type RuleConditions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filename_glob matches the whole filename, e.g. "invoice-*.pdf".
+	FilenameGlob *string `protobuf:"bytes,1,opt,name=filename_glob,json=filenameGlob,proto3,oneof" json:"filename_glob,omitempty"`
+	// filename_regex is a regular expression searched for in the filename. Its named
+	// capture groups can be mapped to attributes.
+	FilenameRegex *string `protobuf:"bytes,2,opt,name=filename_regex,json=filenameRegex,proto3,oneof" json:"filename_regex,omitempty"`
+	// mime_type matches the document's MIME type and may use a wildcard, e.g. "image/*".
+	MimeType *string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3,oneof" json:"mime_type,omitempty"`
+	// min_size is the smallest matching file size in bytes.
+	MinSize *int64 `protobuf:"varint,4,opt,name=min_size,json=minSize,proto3,oneof" json:"min_size,omitempty"`
+	// max_size is the largest matching file size in bytes.
+	MaxSize *int64 `protobuf:"varint,5,opt,name=max_size,json=maxSize,proto3,oneof" json:"max_size,omitempty"`
+	// text_regex is a regular expression searched for in the document's text. Only text
+	// documents have text. Its named capture groups can be mapped to attributes.
+	TextRegex *string `protobuf:"bytes,6,opt,name=text_regex,json=textRegex,proto3,oneof" json:"text_regex,omitempty"`
+	// attributes are tests on the document's global attributes.
+	Attributes    []*AttributeCondition `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleConditions) Reset() {
+	*x = RuleConditions{}
+	mi := &file_rules_v1_rules_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleConditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleConditions) ProtoMessage() {}
+
+func (x *RuleConditions) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleConditions.ProtoReflect.Descriptor instead.
+func (*RuleConditions) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RuleConditions) GetFilenameGlob() string {
+	if x != nil && x.FilenameGlob != nil {
+		return *x.FilenameGlob
+	}
+	return ""
+}
+
+func (x *RuleConditions) GetFilenameRegex() string {
+	if x != nil && x.FilenameRegex != nil {
+		return *x.FilenameRegex
+	}
+	return ""
+}
+
+func (x *RuleConditions) GetMimeType() string {
+	if x != nil && x.MimeType != nil {
+		return *x.MimeType
+	}
+	return ""
+}
+
+func (x *RuleConditions) GetMinSize() int64 {
+	if x != nil && x.MinSize != nil {
+		return *x.MinSize
+	}
+	return 0
+}
+
+func (x *RuleConditions) GetMaxSize() int64 {
+	if x != nil && x.MaxSize != nil {
+		return *x.MaxSize
+	}
+	return 0
+}
+
+func (x *RuleConditions) GetTextRegex() string {
+	if x != nil && x.TextRegex != nil {
+		return *x.TextRegex
+	}
+	return ""
+}
+
+func (x *RuleConditions) GetAttributes() []*AttributeCondition {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// AttributeCondition tests one global attribute. With neither equals nor matches set,
+// the attribute only has to be present.
+type AttributeCondition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key is the attribute name.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// equals is a JSON value the attribute must equal.
+	Equals *string `protobuf:"bytes,2,opt,name=equals,proto3,oneof" json:"equals,omitempty"`
+	// matches is a regular expression the attribute's string value must contain.
+	Matches       *string `protobuf:"bytes,3,opt,name=matches,proto3,oneof" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeCondition) Reset() {
+	*x = AttributeCondition{}
+	mi := &file_rules_v1_rules_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeCondition) ProtoMessage() {}
+
+func (x *AttributeCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeCondition.ProtoReflect.Descriptor instead.
+func (*AttributeCondition) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AttributeCondition) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AttributeCondition) GetEquals() string {
+	if x != nil && x.Equals != nil {
+		return *x.Equals
+	}
+	return ""
+}
+
+func (x *AttributeCondition) GetMatches() string {
+	if x != nil && x.Matches != nil {
+		return *x.Matches
+	}
+	return ""
+}
+
+// RuleAction is what a rule does to a matching document.
+type RuleAction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tag_path is the full path of the tag to add, e.g. "/finance/invoices".
+	TagPath string `protobuf:"bytes,1,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// attributes is a JSON object of attributes to set on the tag.
+	Attributes *string `protobuf:"bytes,2,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"`
+	// captures maps attribute names to named capture groups of filename_regex or
+	// text_regex. Captured text is converted to the type the tag's schema declares.
+	Captures      map[string]string `protobuf:"bytes,3,rep,name=captures,proto3" json:"captures,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleAction) Reset() {
+	*x = RuleAction{}
+	mi := &file_rules_v1_rules_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleAction) ProtoMessage() {}
+
+func (x *RuleAction) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleAction.ProtoReflect.Descriptor instead.
+func (*RuleAction) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RuleAction) GetTagPath() string {
+	if x != nil {
+		return x.TagPath
+	}
+	return ""
+}
+
+func (x *RuleAction) GetAttributes() string {
+	if x != nil && x.Attributes != nil {
+		return *x.Attributes
+	}
+	return ""
+}
+
+func (x *RuleAction) GetCaptures() map[string]string {
+	if x != nil {
+		return x.Captures
+	}
+	return nil
+}
+
+// CreateRuleRequest contains the data needed to create a rule.
+type CreateRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace the rule belongs to.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// name is unique within the namespace.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// enabled defaults to true.
+	Enabled *bool `protobuf:"varint,3,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// priority orders the rules, lowest first.
+	Priority int32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// conditions a document must meet for the rule to apply.
+	Conditions *RuleConditions `protobuf:"bytes,5,opt,name=conditions,proto3" json:"conditions,omitempty"`
+	// action is applied to matching documents.
+	Action        *RuleAction `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRuleRequest) Reset() {
+	*x = CreateRuleRequest{}
+	mi := &file_rules_v1_rules_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRuleRequest) ProtoMessage() {}
+
+func (x *CreateRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateRuleRequest) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateRuleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CreateRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateRuleRequest) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *CreateRuleRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreateRuleRequest) GetConditions() *RuleConditions {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *CreateRuleRequest) GetAction() *RuleAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+// CreateRuleResponse contains the created rule.
+type CreateRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rule is the newly created rule.
+	Rule          *Rule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRuleResponse) Reset() {
+	*x = CreateRuleResponse{}
+	mi := &file_rules_v1_rules_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRuleResponse) ProtoMessage() {}
+
+func (x *CreateRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateRuleResponse) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateRuleResponse) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// GetRuleRequest contains the identifier of the rule to retrieve.
+type GetRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace containing the rule.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// rule_id is the unique identifier of the rule.
+	RuleId        string `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRuleRequest) Reset() {
+	*x = GetRuleRequest{}
+	mi := &file_rules_v1_rules_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRuleRequest) ProtoMessage() {}
+
+func (x *GetRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRuleRequest.ProtoReflect.Descriptor instead.
+func (*GetRuleRequest) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetRuleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+// GetRuleResponse contains the requested rule.
+type GetRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rule is the requested rule.
+	Rule          *Rule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRuleResponse) Reset() {
+	*x = GetRuleResponse{}
+	mi := &file_rules_v1_rules_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRuleResponse) ProtoMessage() {}
+
+func (x *GetRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRuleResponse.ProtoReflect.Descriptor instead.
+func (*GetRuleResponse) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRuleResponse) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// ListRulesRequest contains the namespace to list rules for.
+type ListRulesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace.
+	Namespace     string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRulesRequest) Reset() {
+	*x = ListRulesRequest{}
+	mi := &file_rules_v1_rules_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRulesRequest) ProtoMessage() {}
+
+func (x *ListRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListRulesRequest) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListRulesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ListRulesResponse contains the rules in a namespace.
+type ListRulesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rules are the namespace's rules in the order they run.
+	Rules         []*Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRulesResponse) Reset() {
+	*x = ListRulesResponse{}
+	mi := &file_rules_v1_rules_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRulesResponse) ProtoMessage() {}
+
+func (x *ListRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListRulesResponse) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListRulesResponse) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// UpdateRuleRequest contains a rule's new definition.
+type UpdateRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace containing the rule.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// rule_id is the unique identifier of the rule.
+	RuleId string `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// name is unique within the namespace.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// enabled rules are evaluated on upload.
+	Enabled bool `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// priority orders the rules, lowest first.
+	Priority int32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	// conditions a document must meet for the rule to apply.
+	Conditions *RuleConditions `protobuf:"bytes,6,opt,name=conditions,proto3" json:"conditions,omitempty"`
+	// action is applied to matching documents.
+	Action        *RuleAction `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRuleRequest) Reset() {
+	*x = UpdateRuleRequest{}
+	mi := &file_rules_v1_rules_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRuleRequest) ProtoMessage() {}
+
+func (x *UpdateRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRuleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRuleRequest) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateRuleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *UpdateRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *UpdateRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRuleRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpdateRuleRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateRuleRequest) GetConditions() *RuleConditions {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *UpdateRuleRequest) GetAction() *RuleAction {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+// UpdateRuleResponse contains the updated rule.
+type UpdateRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rule is the updated rule.
+	Rule          *Rule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRuleResponse) Reset() {
+	*x = UpdateRuleResponse{}
+	mi := &file_rules_v1_rules_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRuleResponse) ProtoMessage() {}
+
+func (x *UpdateRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRuleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRuleResponse) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateRuleResponse) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// DeleteRuleRequest contains the identifier of the rule to delete.
+type DeleteRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace containing the rule.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// rule_id is the unique identifier of the rule.
+	RuleId        string `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRuleRequest) Reset() {
+	*x = DeleteRuleRequest{}
+	mi := &file_rules_v1_rules_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRuleRequest) ProtoMessage() {}
+
+func (x *DeleteRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRuleRequest) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteRuleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeleteRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+// DeleteRuleResponse is returned when a rule is successfully deleted.
+type DeleteRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRuleResponse) Reset() {
+	*x = DeleteRuleResponse{}
+	mi := &file_rules_v1_rules_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRuleResponse) ProtoMessage() {}
+
+func (x *DeleteRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRuleResponse) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{13}
+}
+
+// TestRuleRequest contains the rule and document to evaluate.
+type TestRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// namespace is the name of the namespace containing the rule and document.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// rule_id is the unique identifier of the rule.
+	RuleId string `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// document_id is the unique identifier of the document.
+	DocumentId    string `protobuf:"bytes,3,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestRuleRequest) Reset() {
+	*x = TestRuleRequest{}
+	mi := &file_rules_v1_rules_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestRuleRequest) ProtoMessage() {}
+
+func (x *TestRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestRuleRequest.ProtoReflect.Descriptor instead.
+func (*TestRuleRequest) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TestRuleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *TestRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *TestRuleRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+// TestRuleResponse describes what the rule would do to the document.
+type TestRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// matched is true when the document meets all of the rule's conditions.
+	Matched bool `protobuf:"varint,1,opt,name=matched,proto3" json:"matched,omitempty"`
+	// unmet names the conditions the document fails, e.g. "filename_glob".
+	Unmet []string `protobuf:"bytes,2,rep,name=unmet,proto3" json:"unmet,omitempty"`
+	// tag_path is the tag the rule would add when matched.
+	TagPath string `protobuf:"bytes,3,opt,name=tag_path,json=tagPath,proto3" json:"tag_path,omitempty"`
+	// attributes is the JSON object of attributes the rule would set when matched.
+	Attributes    *string `protobuf:"bytes,4,opt,name=attributes,proto3,oneof" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestRuleResponse) Reset() {
+	*x = TestRuleResponse{}
+	mi := &file_rules_v1_rules_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestRuleResponse) ProtoMessage() {}
+
+func (x *TestRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rules_v1_rules_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestRuleResponse.ProtoReflect.Descriptor instead.
+func (*TestRuleResponse) Descriptor() ([]byte, []int) {
+	return file_rules_v1_rules_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TestRuleResponse) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *TestRuleResponse) GetUnmet() []string {
+	if x != nil {
+		return x.Unmet
+	}
+	return nil
+}
+
+func (x *TestRuleResponse) GetTagPath() string {
+	if x != nil {
+		return x.TagPath
+	}
+	return ""
+}
+
+func (x *TestRuleResponse) GetAttributes() string {
+	if x != nil && x.Attributes != nil {
+		return *x.Attributes
+	}
+	return ""
+}
+
+var File_rules_v1_rules_proto protoreflect.FileDescriptor
+
+const file_rules_v1_rules_proto_rawDesc = "" +
+	"\n" +
+	"\x14rules/v1/rules.proto\x12\brules.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc0\x02\n" +
+	"\x04Rule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x128\n" +
+	"\n" +
+	"conditions\x18\x05 \x01(\v2\x18.rules.v1.RuleConditionsR\n" +
+	"conditions\x12,\n" +
+	"\x06action\x18\x06 \x01(\v2\x14.rules.v1.RuleActionR\x06action\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vmodified_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"modifiedAt\"\x86\x03\n" +
+	"\x0eRuleConditions\x12(\n" +
+	"\rfilename_glob\x18\x01 \x01(\tH\x00R\ffilenameGlob\x88\x01\x01\x12*\n" +
+	"\x0efilename_regex\x18\x02 \x01(\tH\x01R\rfilenameRegex\x88\x01\x01\x12 \n" +
+	"\tmime_type\x18\x03 \x01(\tH\x02R\bmimeType\x88\x01\x01\x12\x1e\n" +
+	"\bmin_size\x18\x04 \x01(\x03H\x03R\aminSize\x88\x01\x01\x12\x1e\n" +
+	"\bmax_size\x18\x05 \x01(\x03H\x04R\amaxSize\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"text_regex\x18\x06 \x01(\tH\x05R\ttextRegex\x88\x01\x01\x12<\n" +
+	"\n" +
+	"attributes\x18\a \x03(\v2\x1c.rules.v1.AttributeConditionR\n" +
+	"attributesB\x10\n" +
+	"\x0e_filename_globB\x11\n" +
+	"\x0f_filename_regexB\f\n" +
+	"\n" +
+	"_mime_typeB\v\n" +
+	"\t_min_sizeB\v\n" +
+	"\t_max_sizeB\r\n" +
+	"\v_text_regex\"y\n" +
+	"\x12AttributeCondition\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\x06equals\x18\x02 \x01(\tH\x00R\x06equals\x88\x01\x01\x12\x1d\n" +
+	"\amatches\x18\x03 \x01(\tH\x01R\amatches\x88\x01\x01B\t\n" +
+	"\a_equalsB\n" +
+	"\n" +
+	"\b_matches\"\xd8\x01\n" +
+	"\n" +
+	"RuleAction\x12\x19\n" +
+	"\btag_path\x18\x01 \x01(\tR\atagPath\x12#\n" +
+	"\n" +
+	"attributes\x18\x02 \x01(\tH\x00R\n" +
+	"attributes\x88\x01\x01\x12>\n" +
+	"\bcaptures\x18\x03 \x03(\v2\".rules.v1.RuleAction.CapturesEntryR\bcaptures\x1a;\n" +
+	"\rCapturesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\r\n" +
+	"\v_attributes\"\xf4\x01\n" +
+	"\x11CreateRuleRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\aenabled\x18\x03 \x01(\bH\x00R\aenabled\x88\x01\x01\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x128\n" +
+	"\n" +
+	"conditions\x18\x05 \x01(\v2\x18.rules.v1.RuleConditionsR\n" +
+	"conditions\x12,\n" +
+	"\x06action\x18\x06 \x01(\v2\x14.rules.v1.RuleActionR\x06actionB\n" +
+	"\n" +
+	"\b_enabled\"8\n" +
+	"\x12CreateRuleResponse\x12\"\n" +
+	"\x04rule\x18\x01 \x01(\v2\x0e.rules.v1.RuleR\x04rule\"G\n" +
+	"\x0eGetRuleRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\tR\x06ruleId\"5\n" +
+	"\x0fGetRuleResponse\x12\"\n" +
+	"\x04rule\x18\x01 \x01(\v2\x0e.rules.v1.RuleR\x04rule\"0\n" +
+	"\x10ListRulesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"9\n" +
+	"\x11ListRulesResponse\x12$\n" +
+	"\x05rules\x18\x01 \x03(\v2\x0e.rules.v1.RuleR\x05rules\"\xfc\x01\n" +
+	"\x11UpdateRuleRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\tR\x06ruleId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x128\n" +
+	"\n" +
+	"conditions\x18\x06 \x01(\v2\x18.rules.v1.RuleConditionsR\n" +
+	"conditions\x12,\n" +
+	"\x06action\x18\a \x01(\v2\x14.rules.v1.RuleActionR\x06action\"8\n" +
+	"\x12UpdateRuleResponse\x12\"\n" +
+	"\x04rule\x18\x01 \x01(\v2\x0e.rules.v1.RuleR\x04rule\"J\n" +
+	"\x11DeleteRuleRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\tR\x06ruleId\"\x14\n" +
+	"\x12DeleteRuleResponse\"i\n" +
+	"\x0fTestRuleRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\tR\x06ruleId\x12\x1f\n" +
+	"\vdocument_id\x18\x03 \x01(\tR\n" +
+	"documentId\"\x91\x01\n" +
+	"\x10TestRuleResponse\x12\x18\n" +
+	"\amatched\x18\x01 \x01(\bR\amatched\x12\x14\n" +
+	"\x05unmet\x18\x02 \x03(\tR\x05unmet\x12\x19\n" +
+	"\btag_path\x18\x03 \x01(\tR\atagPath\x12#\n" +
+	"\n" +
+	"attributes\x18\x04 \x01(\tH\x00R\n" +
+	"attributes\x88\x01\x01B\r\n" +
+	"\v_attributes2\xb1\x03\n" +
+	"\vRuleService\x12G\n" +
+	"\n" +
+	"CreateRule\x12\x1b.rules.v1.CreateRuleRequest\x1a\x1c.rules.v1.CreateRuleResponse\x12>\n" +
+	"\aGetRule\x12\x18.rules.v1.GetRuleRequest\x1a\x19.rules.v1.GetRuleResponse\x12D\n" +
+	"\tListRules\x12\x1a.rules.v1.ListRulesRequest\x1a\x1b.rules.v1.ListRulesResponse\x12G\n" +
+	"\n" +
+	"UpdateRule\x12\x1b.rules.v1.UpdateRuleRequest\x1a\x1c.rules.v1.UpdateRuleResponse\x12G\n" +
+	"\n" +
+	"DeleteRule\x12\x1b.rules.v1.DeleteRuleRequest\x1a\x1c.rules.v1.DeleteRuleResponse\x12A\n" +
+	"\bTestRule\x12\x19.rules.v1.TestRuleRequest\x1a\x1a.rules.v1.TestRuleResponseB\x8f\x01\n" +
+	"\fcom.rules.v1B\n" +
+	"RulesProtoP\x01Z2github.com/RynoXLI/Wayfile/gen/go/rules/v1;rulesv1\xa2\x02\x03RXX\xaa\x02\bRules.V1\xca\x02\bRules\\V1\xe2\x02\x14Rules\\V1\\GPBMetadata\xea\x02\tRules::V1b\x06proto3"
+
+var (
+	file_rules_v1_rules_proto_rawDescOnce sync.Once
+	file_rules_v1_rules_proto_rawDescData []byte
+)
+
+func file_rules_v1_rules_proto_rawDescGZIP() []byte {
+	file_rules_v1_rules_proto_rawDescOnce.Do(func() {
+		file_rules_v1_rules_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rules_v1_rules_proto_rawDesc), len(file_rules_v1_rules_proto_rawDesc)))
+	})
+	return file_rules_v1_rules_proto_rawDescData
+}
+
+var file_rules_v1_rules_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_rules_v1_rules_proto_goTypes = []any{
+	(*Rule)(nil),                  // 0: rules.v1.Rule
+	(*RuleConditions)(nil),        // 1: rules.v1.RuleConditions
+	(*AttributeCondition)(nil),    // 2: rules.v1.AttributeCondition
+	(*RuleAction)(nil),            // 3: rules.v1.RuleAction
+	(*CreateRuleRequest)(nil),     // 4: rules.v1.CreateRuleRequest
+	(*CreateRuleResponse)(nil),    // 5: rules.v1.CreateRuleResponse
+	(*GetRuleRequest)(nil),        // 6: rules.v1.GetRuleRequest
+	(*GetRuleResponse)(nil),       // 7: rules.v1.GetRuleResponse
+	(*ListRulesRequest)(nil),      // 8: rules.v1.ListRulesRequest
+	(*ListRulesResponse)(nil),     // 9: rules.v1.ListRulesResponse
+	(*UpdateRuleRequest)(nil),     // 10: rules.v1.UpdateRuleRequest
+	(*UpdateRuleResponse)(nil),    // 11: rules.v1.UpdateRuleResponse
+	(*DeleteRuleRequest)(nil),     // 12: rules.v1.DeleteRuleRequest
+	(*DeleteRuleResponse)(nil),    // 13: rules.v1.DeleteRuleResponse
+	(*TestRuleRequest)(nil),       // 14: rules.v1.TestRuleRequest
+	(*TestRuleResponse)(nil),      // 15: rules.v1.TestRuleResponse
+	nil,                           // 16: rules.v1.RuleAction.CapturesEntry
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+}
+var file_rules_v1_rules_proto_depIdxs = []int32{
+	1,  // 0: rules.v1.Rule.conditions:type_name -> rules.v1.RuleConditions
+	3,  // 1: rules.v1.Rule.action:type_name -> rules.v1.RuleAction
+	17, // 2: rules.v1.Rule.created_at:type_name -> google.protobuf.Timestamp
+	17, // 3: rules.v1.Rule.modified_at:type_name -> google.protobuf.Timestamp
+	2,  // 4: rules.v1.RuleConditions.attributes:type_name -> rules.v1.AttributeCondition
+	16, // 5: rules.v1.RuleAction.captures:type_name -> rules.v1.RuleAction.CapturesEntry
+	1,  // 6: rules.v1.CreateRuleRequest.conditions:type_name -> rules.v1.RuleConditions
+	3,  // 7: rules.v1.CreateRuleRequest.action:type_name -> rules.v1.RuleAction
+	0,  // 8: rules.v1.CreateRuleResponse.rule:type_name -> rules.v1.Rule
+	0,  // 9: rules.v1.GetRuleResponse.rule:type_name -> rules.v1.Rule
+	0,  // 10: rules.v1.ListRulesResponse.rules:type_name -> rules.v1.Rule
+	1,  // 11: rules.v1.UpdateRuleRequest.conditions:type_name -> rules.v1.RuleConditions
+	3,  // 12: rules.v1.UpdateRuleRequest.action:type_name -> rules.v1.RuleAction
+	0,  // 13: rules.v1.UpdateRuleResponse.rule:type_name -> rules.v1.Rule
+	4,  // 14: rules.v1.RuleService.CreateRule:input_type -> rules.v1.CreateRuleRequest
+	6,  // 15: rules.v1.RuleService.GetRule:input_type -> rules.v1.GetRuleRequest
+	8,  // 16: rules.v1.RuleService.ListRules:input_type -> rules.v1.ListRulesRequest
+	10, // 17: rules.v1.RuleService.UpdateRule:input_type -> rules.v1.UpdateRuleRequest
+	12, // 18: rules.v1.RuleService.DeleteRule:input_type -> rules.v1.DeleteRuleRequest
+	14, // 19: rules.v1.RuleService.TestRule:input_type -> rules.v1.TestRuleRequest
+	5,  // 20: rules.v1.RuleService.CreateRule:output_type -> rules.v1.CreateRuleResponse
+	7,  // 21: rules.v1.RuleService.GetRule:output_type -> rules.v1.GetRuleResponse
+	9,  // 22: rules.v1.RuleService.ListRules:output_type -> rules.v1.ListRulesResponse
+	11, // 23: rules.v1.RuleService.UpdateRule:output_type -> rules.v1.UpdateRuleResponse
+	13, // 24: rules.v1.RuleService.DeleteRule:output_type -> rules.v1.DeleteRuleResponse
+	15, // 25: rules.v1.RuleService.TestRule:output_type -> rules.v1.TestRuleResponse
+	20, // [20:26] is the sub-list for method output_type
+	14, // [14:20] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_rules_v1_rules_proto_init() }
+func file_rules_v1_rules_proto_init() {
+	if File_rules_v1_rules_proto != nil {
+		return
+	}
+	file_rules_v1_rules_proto_msgTypes[1].OneofWrappers = []any{}
+	file_rules_v1_rules_proto_msgTypes[2].OneofWrappers = []any{}
+	file_rules_v1_rules_proto_msgTypes[3].OneofWrappers = []any{}
+	file_rules_v1_rules_proto_msgTypes[4].OneofWrappers = []any{}
+	file_rules_v1_rules_proto_msgTypes[15].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rules_v1_rules_proto_rawDesc), len(file_rules_v1_rules_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rules_v1_rules_proto_goTypes,
+		DependencyIndexes: file_rules_v1_rules_proto_depIdxs,
+		MessageInfos:      file_rules_v1_rules_proto_msgTypes,
+	}.Build()
+	File_rules_v1_rules_proto = out.File
+	file_rules_v1_rules_proto_goTypes = nil
+	file_rules_v1_rules_proto_depIdxs = nil
+}
@@ -0,0 +1,286 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: rules/v1/rules.proto
+
+package rulesv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/RynoXLI/Wayfile/gen/go/rules/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// RuleServiceName is the fully-qualified name of the RuleService service.
+	RuleServiceName = "rules.v1.RuleService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// RuleServiceCreateRuleProcedure is the fully-qualified name of the RuleService's CreateRule RPC.
+	RuleServiceCreateRuleProcedure = "/rules.v1.RuleService/CreateRule"
+	// RuleServiceGetRuleProcedure is the fully-qualified name of the RuleService's GetRule RPC.
+	RuleServiceGetRuleProcedure = "/rules.v1.RuleService/GetRule"
+	// RuleServiceListRulesProcedure is the fully-qualified name of the RuleService's ListRules RPC.
+	RuleServiceListRulesProcedure = "/rules.v1.RuleService/ListRules"
+	// RuleServiceUpdateRuleProcedure is the fully-qualified name of the RuleService's UpdateRule RPC.
+	RuleServiceUpdateRuleProcedure = "/rules.v1.RuleService/UpdateRule"
+	// RuleServiceDeleteRuleProcedure is the fully-qualified name of the RuleService's DeleteRule RPC.
+	RuleServiceDeleteRuleProcedure = "/rules.v1.RuleService/DeleteRule"
+	// RuleServiceTestRuleProcedure is the fully-qualified name of the RuleService's TestRule RPC.
+	RuleServiceTestRuleProcedure = "/rules.v1.RuleService/TestRule"
+)
+
+// RuleServiceClient is a client for the rules.v1.RuleService service.
+type RuleServiceClient interface {
+	// CreateRule adds a tagging rule to a namespace.
+	CreateRule(context.Context, *v1.CreateRuleRequest) (*v1.CreateRuleResponse, error)
+	// GetRule retrieves a tagging rule.
+	GetRule(context.Context, *v1.GetRuleRequest) (*v1.GetRuleResponse, error)
+	// ListRules retrieves a namespace's tagging rules in the order they run.
+	ListRules(context.Context, *v1.ListRulesRequest) (*v1.ListRulesResponse, error)
+	// UpdateRule replaces a tagging rule's definition.
+	UpdateRule(context.Context, *v1.UpdateRuleRequest) (*v1.UpdateRuleResponse, error)
+	// DeleteRule removes a tagging rule. Tags it already added are kept.
+	DeleteRule(context.Context, *v1.DeleteRuleRequest) (*v1.DeleteRuleResponse, error)
+	// TestRule evaluates a rule against an existing document without changing it.
+	// Disabled rules can be tested, so a rule can be tried out before it is enabled.
+	TestRule(context.Context, *v1.TestRuleRequest) (*v1.TestRuleResponse, error)
+}
+
+// NewRuleServiceClient constructs a client for the rules.v1.RuleService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewRuleServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) RuleServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	ruleServiceMethods := v1.File_rules_v1_rules_proto.Services().ByName("RuleService").Methods()
+	return &ruleServiceClient{
+		createRule: connect.NewClient[v1.CreateRuleRequest, v1.CreateRuleResponse](
+			httpClient,
+			baseURL+RuleServiceCreateRuleProcedure,
+			connect.WithSchema(ruleServiceMethods.ByName("CreateRule")),
+			connect.WithClientOptions(opts...),
+		),
+		getRule: connect.NewClient[v1.GetRuleRequest, v1.GetRuleResponse](
+			httpClient,
+			baseURL+RuleServiceGetRuleProcedure,
+			connect.WithSchema(ruleServiceMethods.ByName("GetRule")),
+			connect.WithClientOptions(opts...),
+		),
+		listRules: connect.NewClient[v1.ListRulesRequest, v1.ListRulesResponse](
+			httpClient,
+			baseURL+RuleServiceListRulesProcedure,
+			connect.WithSchema(ruleServiceMethods.ByName("ListRules")),
+			connect.WithClientOptions(opts...),
+		),
+		updateRule: connect.NewClient[v1.UpdateRuleRequest, v1.UpdateRuleResponse](
+			httpClient,
+			baseURL+RuleServiceUpdateRuleProcedure,
+			connect.WithSchema(ruleServiceMethods.ByName("UpdateRule")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteRule: connect.NewClient[v1.DeleteRuleRequest, v1.DeleteRuleResponse](
+			httpClient,
+			baseURL+RuleServiceDeleteRuleProcedure,
+			connect.WithSchema(ruleServiceMethods.ByName("DeleteRule")),
+			connect.WithClientOptions(opts...),
+		),
+		testRule: connect.NewClient[v1.TestRuleRequest, v1.TestRuleResponse](
+			httpClient,
+			baseURL+RuleServiceTestRuleProcedure,
+			connect.WithSchema(ruleServiceMethods.ByName("TestRule")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// ruleServiceClient implements RuleServiceClient.
+type ruleServiceClient struct {
+	createRule *connect.Client[v1.CreateRuleRequest, v1.CreateRuleResponse]
+	getRule    *connect.Client[v1.GetRuleRequest, v1.GetRuleResponse]
+	listRules  *connect.Client[v1.ListRulesRequest, v1.ListRulesResponse]
+	updateRule *connect.Client[v1.UpdateRuleRequest, v1.UpdateRuleResponse]
+	deleteRule *connect.Client[v1.DeleteRuleRequest, v1.DeleteRuleResponse]
+	testRule   *connect.Client[v1.TestRuleRequest, v1.TestRuleResponse]
+}
+
+// CreateRule calls rules.v1.RuleService.CreateRule.
+func (c *ruleServiceClient) CreateRule(ctx context.Context, req *v1.CreateRuleRequest) (*v1.CreateRuleResponse, error) {
+	response, err := c.createRule.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// GetRule calls rules.v1.RuleService.GetRule.
+func (c *ruleServiceClient) GetRule(ctx context.Context, req *v1.GetRuleRequest) (*v1.GetRuleResponse, error) {
+	response, err := c.getRule.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListRules calls rules.v1.RuleService.ListRules.
+func (c *ruleServiceClient) ListRules(ctx context.Context, req *v1.ListRulesRequest) (*v1.ListRulesResponse, error) {
+	response, err := c.listRules.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// UpdateRule calls rules.v1.RuleService.UpdateRule.
+func (c *ruleServiceClient) UpdateRule(ctx context.Context, req *v1.UpdateRuleRequest) (*v1.UpdateRuleResponse, error) {
+	response, err := c.updateRule.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// DeleteRule calls rules.v1.RuleService.DeleteRule.
+func (c *ruleServiceClient) DeleteRule(ctx context.Context, req *v1.DeleteRuleRequest) (*v1.DeleteRuleResponse, error) {
+	response, err := c.deleteRule.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TestRule calls rules.v1.RuleService.TestRule.
+func (c *ruleServiceClient) TestRule(ctx context.Context, req *v1.TestRuleRequest) (*v1.TestRuleResponse, error) {
+	response, err := c.testRule.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// RuleServiceHandler is an implementation of the rules.v1.RuleService service.
+type RuleServiceHandler interface {
+	// CreateRule adds a tagging rule to a namespace.
+	CreateRule(context.Context, *v1.CreateRuleRequest) (*v1.CreateRuleResponse, error)
+	// GetRule retrieves a tagging rule.
+	GetRule(context.Context, *v1.GetRuleRequest) (*v1.GetRuleResponse, error)
+	// ListRules retrieves a namespace's tagging rules in the order they run.
+	ListRules(context.Context, *v1.ListRulesRequest) (*v1.ListRulesResponse, error)
+	// UpdateRule replaces a tagging rule's definition.
+	UpdateRule(context.Context, *v1.UpdateRuleRequest) (*v1.UpdateRuleResponse, error)
+	// DeleteRule removes a tagging rule. Tags it already added are kept.
+	DeleteRule(context.Context, *v1.DeleteRuleRequest) (*v1.DeleteRuleResponse, error)
+	// TestRule evaluates a rule against an existing document without changing it.
+	// Disabled rules can be tested, so a rule can be tried out before it is enabled.
+	TestRule(context.Context, *v1.TestRuleRequest) (*v1.TestRuleResponse, error)
+}
+
+// NewRuleServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewRuleServiceHandler(svc RuleServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	ruleServiceMethods := v1.File_rules_v1_rules_proto.Services().ByName("RuleService").Methods()
+	ruleServiceCreateRuleHandler := connect.NewUnaryHandlerSimple(
+		RuleServiceCreateRuleProcedure,
+		svc.CreateRule,
+		connect.WithSchema(ruleServiceMethods.ByName("CreateRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ruleServiceGetRuleHandler := connect.NewUnaryHandlerSimple(
+		RuleServiceGetRuleProcedure,
+		svc.GetRule,
+		connect.WithSchema(ruleServiceMethods.ByName("GetRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ruleServiceListRulesHandler := connect.NewUnaryHandlerSimple(
+		RuleServiceListRulesProcedure,
+		svc.ListRules,
+		connect.WithSchema(ruleServiceMethods.ByName("ListRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ruleServiceUpdateRuleHandler := connect.NewUnaryHandlerSimple(
+		RuleServiceUpdateRuleProcedure,
+		svc.UpdateRule,
+		connect.WithSchema(ruleServiceMethods.ByName("UpdateRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ruleServiceDeleteRuleHandler := connect.NewUnaryHandlerSimple(
+		RuleServiceDeleteRuleProcedure,
+		svc.DeleteRule,
+		connect.WithSchema(ruleServiceMethods.ByName("DeleteRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ruleServiceTestRuleHandler := connect.NewUnaryHandlerSimple(
+		RuleServiceTestRuleProcedure,
+		svc.TestRule,
+		connect.WithSchema(ruleServiceMethods.ByName("TestRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/rules.v1.RuleService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case RuleServiceCreateRuleProcedure:
+			ruleServiceCreateRuleHandler.ServeHTTP(w, r)
+		case RuleServiceGetRuleProcedure:
+			ruleServiceGetRuleHandler.ServeHTTP(w, r)
+		case RuleServiceListRulesProcedure:
+			ruleServiceListRulesHandler.ServeHTTP(w, r)
+		case RuleServiceUpdateRuleProcedure:
+			ruleServiceUpdateRuleHandler.ServeHTTP(w, r)
+		case RuleServiceDeleteRuleProcedure:
+			ruleServiceDeleteRuleHandler.ServeHTTP(w, r)
+		case RuleServiceTestRuleProcedure:
+			ruleServiceTestRuleHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedRuleServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedRuleServiceHandler struct{}
+
+func (UnimplementedRuleServiceHandler) CreateRule(context.Context, *v1.CreateRuleRequest) (*v1.CreateRuleResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rules.v1.RuleService.CreateRule is not implemented"))
+}
+
+func (UnimplementedRuleServiceHandler) GetRule(context.Context, *v1.GetRuleRequest) (*v1.GetRuleResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rules.v1.RuleService.GetRule is not implemented"))
+}
+
+func (UnimplementedRuleServiceHandler) ListRules(context.Context, *v1.ListRulesRequest) (*v1.ListRulesResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rules.v1.RuleService.ListRules is not implemented"))
+}
+
+func (UnimplementedRuleServiceHandler) UpdateRule(context.Context, *v1.UpdateRuleRequest) (*v1.UpdateRuleResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rules.v1.RuleService.UpdateRule is not implemented"))
+}
+
+func (UnimplementedRuleServiceHandler) DeleteRule(context.Context, *v1.DeleteRuleRequest) (*v1.DeleteRuleResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rules.v1.RuleService.DeleteRule is not implemented"))
+}
+
+func (UnimplementedRuleServiceHandler) TestRule(context.Context, *v1.TestRuleRequest) (*v1.TestRuleResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rules.v1.RuleService.TestRule is not implemented"))
+}
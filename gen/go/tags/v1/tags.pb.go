@@ -22,6 +22,72 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SchemaCompatibility is how a tag's new schema versions must relate to its latest one.
+// Incompatible versions are rejected with FAILED_PRECONDITION and a
+// SchemaCompatibilityErrorDetails listing every violation.
+type SchemaCompatibility int32
+
+const (
+	// SCHEMA_COMPATIBILITY_UNSPECIFIED is NONE when creating a tag and keeps the tag's mode
+	// when updating one.
+	SchemaCompatibility_SCHEMA_COMPATIBILITY_UNSPECIFIED SchemaCompatibility = 0
+	// SCHEMA_COMPATIBILITY_NONE accepts any new schema.
+	SchemaCompatibility_SCHEMA_COMPATIBILITY_NONE SchemaCompatibility = 1
+	// SCHEMA_COMPATIBILITY_BACKWARD requires attributes valid under the latest schema to stay
+	// valid under the new one, e.g. fields may become optional but not required.
+	SchemaCompatibility_SCHEMA_COMPATIBILITY_BACKWARD SchemaCompatibility = 2
+	// SCHEMA_COMPATIBILITY_FORWARD requires attributes valid under the new schema to be valid
+	// under the latest one, e.g. fields may become required but not optional.
+	SchemaCompatibility_SCHEMA_COMPATIBILITY_FORWARD SchemaCompatibility = 3
+	// SCHEMA_COMPATIBILITY_FULL requires both backward and forward compatibility.
+	SchemaCompatibility_SCHEMA_COMPATIBILITY_FULL SchemaCompatibility = 4
+)
+
+// Enum value maps for SchemaCompatibility.
+var (
+	SchemaCompatibility_name = map[int32]string{
+		0: "SCHEMA_COMPATIBILITY_UNSPECIFIED",
+		1: "SCHEMA_COMPATIBILITY_NONE",
+		2: "SCHEMA_COMPATIBILITY_BACKWARD",
+		3: "SCHEMA_COMPATIBILITY_FORWARD",
+		4: "SCHEMA_COMPATIBILITY_FULL",
+	}
+	SchemaCompatibility_value = map[string]int32{
+		"SCHEMA_COMPATIBILITY_UNSPECIFIED": 0,
+		"SCHEMA_COMPATIBILITY_NONE":        1,
+		"SCHEMA_COMPATIBILITY_BACKWARD":    2,
+		"SCHEMA_COMPATIBILITY_FORWARD":     3,
+		"SCHEMA_COMPATIBILITY_FULL":        4,
+	}
+)
+
+func (x SchemaCompatibility) Enum() *SchemaCompatibility {
+	p := new(SchemaCompatibility)
+	*p = x
+	return p
+}
+
+func (x SchemaCompatibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SchemaCompatibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_tags_v1_tags_proto_enumTypes[0].Descriptor()
+}
+
+func (SchemaCompatibility) Type() protoreflect.EnumType {
+	return &file_tags_v1_tags_proto_enumTypes[0]
+}
+
+func (x SchemaCompatibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SchemaCompatibility.Descriptor instead.
+func (SchemaCompatibility) EnumDescriptor() ([]byte, []int) {
+	return file_tags_v1_tags_proto_rawDescGZIP(), []int{0}
+}
+
 // Tag represents a label for organizing documents.
 type Tag struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -38,9 +104,16 @@ type Tag struct {
 	// modified_at is the timestamp when the tag was last modified.
 	ModifiedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
 	// json_schema is the JSON Schema definition for tag-specific attributes.
-	JsonSchema    *string `protobuf:"bytes,8,opt,name=json_schema,json=jsonSchema,proto3,oneof" json:"json_schema,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	JsonSchema *string `protobuf:"bytes,8,opt,name=json_schema,json=jsonSchema,proto3,oneof" json:"json_schema,omitempty"`
+	// inherit_schema is whether the tag inherits its parent's effective schema.
+	InheritSchema bool `protobuf:"varint,9,opt,name=inherit_schema,json=inheritSchema,proto3" json:"inherit_schema,omitempty"`
+	// effective_json_schema is the schema attributes are validated against: json_schema
+	// composed with the inherited schemas.
+	EffectiveJsonSchema *string `protobuf:"bytes,10,opt,name=effective_json_schema,json=effectiveJsonSchema,proto3,oneof" json:"effective_json_schema,omitempty"`
+	// schema_compatibility is how new versions of json_schema must relate to the latest one.
+	SchemaCompatibility SchemaCompatibility `protobuf:"varint,11,opt,name=schema_compatibility,json=schemaCompatibility,proto3,enum=tags.v1.SchemaCompatibility" json:"schema_compatibility,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Tag) Reset() {
@@ -122,6 +195,27 @@ func (x *Tag) GetJsonSchema() string {
 	return ""
 }
 
+func (x *Tag) GetInheritSchema() bool {
+	if x != nil {
+		return x.InheritSchema
+	}
+	return false
+}
+
+func (x *Tag) GetEffectiveJsonSchema() string {
+	if x != nil && x.EffectiveJsonSchema != nil {
+		return *x.EffectiveJsonSchema
+	}
+	return ""
+}
+
+func (x *Tag) GetSchemaCompatibility() SchemaCompatibility {
+	if x != nil {
+		return x.SchemaCompatibility
+	}
+	return SchemaCompatibility_SCHEMA_COMPATIBILITY_UNSPECIFIED
+}
+
 // CreateTagRequest contains the data needed to create a tag.
 type CreateTagRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -135,10 +229,21 @@ type CreateTagRequest struct {
 	ParentPath *string `protobuf:"bytes,4,opt,name=parent_path,json=parentPath,proto3,oneof" json:"parent_path,omitempty"`
 	// color is a hex color code for visual representation.
 	Color *string `protobuf:"bytes,5,opt,name=color,proto3,oneof" json:"color,omitempty"`
-	// json_schema is the JSON Schema definition for tag-specific attributes.
-	JsonSchema    *string `protobuf:"bytes,7,opt,name=json_schema,json=jsonSchema,proto3,oneof" json:"json_schema,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// json_schema is the JSON Schema definition for tag-specific attributes. Missing
+	// attributes are set to their property's default when attributes are written, and
+	// top-level properties with an x-computed expression, e.g. "net + tax" or
+	// "year($document.document_date)", are computed on every write from the other
+	// attributes and the fields of the document: $document.document_date, file_name,
+	// mime_type and title.
+	JsonSchema *string `protobuf:"bytes,7,opt,name=json_schema,json=jsonSchema,proto3,oneof" json:"json_schema,omitempty"`
+	// inherit_schema makes the tag inherit its parent's effective schema. Its own schema
+	// adds properties and required fields to it and may override inherited properties with
+	// ones of the same type.
+	InheritSchema bool `protobuf:"varint,8,opt,name=inherit_schema,json=inheritSchema,proto3" json:"inherit_schema,omitempty"`
+	// schema_compatibility is how new versions of json_schema must relate to the latest one.
+	SchemaCompatibility SchemaCompatibility `protobuf:"varint,9,opt,name=schema_compatibility,json=schemaCompatibility,proto3,enum=tags.v1.SchemaCompatibility" json:"schema_compatibility,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *CreateTagRequest) Reset() {
@@ -213,6 +318,20 @@ func (x *CreateTagRequest) GetJsonSchema() string {
 	return ""
 }
 
+func (x *CreateTagRequest) GetInheritSchema() bool {
+	if x != nil {
+		return x.InheritSchema
+	}
+	return false
+}
+
+func (x *CreateTagRequest) GetSchemaCompatibility() SchemaCompatibility {
+	if x != nil {
+		return x.SchemaCompatibility
+	}
+	return SchemaCompatibility_SCHEMA_COMPATIBILITY_UNSPECIFIED
+}
+
 // CreateTagResponse contains the created tag.
 type CreateTagResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -468,9 +587,14 @@ type UpdateTagRequest struct {
 	// color is the new hex color code.
 	Color *string `protobuf:"bytes,6,opt,name=color,proto3,oneof" json:"color,omitempty"`
 	// json_schema is the new JSON Schema definition for tag-specific attributes.
-	JsonSchema    *string `protobuf:"bytes,8,opt,name=json_schema,json=jsonSchema,proto3,oneof" json:"json_schema,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	JsonSchema *string `protobuf:"bytes,8,opt,name=json_schema,json=jsonSchema,proto3,oneof" json:"json_schema,omitempty"`
+	// inherit_schema sets whether the tag inherits its parent's effective schema.
+	InheritSchema *bool `protobuf:"varint,9,opt,name=inherit_schema,json=inheritSchema,proto3,oneof" json:"inherit_schema,omitempty"`
+	// schema_compatibility sets how new versions of json_schema must relate to the latest
+	// one. It applies to a json_schema in the same request.
+	SchemaCompatibility SchemaCompatibility `protobuf:"varint,10,opt,name=schema_compatibility,json=schemaCompatibility,proto3,enum=tags.v1.SchemaCompatibility" json:"schema_compatibility,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *UpdateTagRequest) Reset() {
@@ -552,6 +676,20 @@ func (x *UpdateTagRequest) GetJsonSchema() string {
 	return ""
 }
 
+func (x *UpdateTagRequest) GetInheritSchema() bool {
+	if x != nil && x.InheritSchema != nil {
+		return *x.InheritSchema
+	}
+	return false
+}
+
+func (x *UpdateTagRequest) GetSchemaCompatibility() SchemaCompatibility {
+	if x != nil {
+		return x.SchemaCompatibility
+	}
+	return SchemaCompatibility_SCHEMA_COMPATIBILITY_UNSPECIFIED
+}
+
 // UpdateTagResponse contains the updated tag.
 type UpdateTagResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -690,11 +828,256 @@ func (*DeleteTagResponse) Descriptor() ([]byte, []int) {
 	return file_tags_v1_tags_proto_rawDescGZIP(), []int{10}
 }
 
+// ValidationErrorDetails is attached to INVALID_ARGUMENT errors when attributes fail
+// validation against a tag's schema, or a tag's JSON schema is rejected.
+type ValidationErrorDetails struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// violations are the reasons validation failed.
+	Violations    []*ValidationViolation `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationErrorDetails) Reset() {
+	*x = ValidationErrorDetails{}
+	mi := &file_tags_v1_tags_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationErrorDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationErrorDetails) ProtoMessage() {}
+
+func (x *ValidationErrorDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_v1_tags_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationErrorDetails.ProtoReflect.Descriptor instead.
+func (*ValidationErrorDetails) Descriptor() ([]byte, []int) {
+	return file_tags_v1_tags_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidationErrorDetails) GetViolations() []*ValidationViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// ValidationViolation is one reason a value failed validation against a schema.
+type ValidationViolation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// pointer is the JSON pointer (RFC 6901) to the failing value within the attributes or
+	// schema (e.g. "/vendor/name"), empty for the whole value.
+	Pointer string `protobuf:"bytes,1,opt,name=pointer,proto3" json:"pointer,omitempty"`
+	// keyword is the JSON schema keyword the value failed (e.g. "type" or "required").
+	Keyword string `protobuf:"bytes,2,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	// expected is the JSON encoded value of the keyword in the schema (e.g. "\"number\""),
+	// unset if there is none to show.
+	Expected *string `protobuf:"bytes,3,opt,name=expected,proto3,oneof" json:"expected,omitempty"`
+	// actual is the JSON encoded failing value, or its type for the type keyword, unset if
+	// the value is missing.
+	Actual *string `protobuf:"bytes,4,opt,name=actual,proto3,oneof" json:"actual,omitempty"`
+	// message describes the failure.
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationViolation) Reset() {
+	*x = ValidationViolation{}
+	mi := &file_tags_v1_tags_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationViolation) ProtoMessage() {}
+
+func (x *ValidationViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_v1_tags_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationViolation.ProtoReflect.Descriptor instead.
+func (*ValidationViolation) Descriptor() ([]byte, []int) {
+	return file_tags_v1_tags_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ValidationViolation) GetPointer() string {
+	if x != nil {
+		return x.Pointer
+	}
+	return ""
+}
+
+func (x *ValidationViolation) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *ValidationViolation) GetExpected() string {
+	if x != nil && x.Expected != nil {
+		return *x.Expected
+	}
+	return ""
+}
+
+func (x *ValidationViolation) GetActual() string {
+	if x != nil && x.Actual != nil {
+		return *x.Actual
+	}
+	return ""
+}
+
+func (x *ValidationViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// SchemaCompatibilityErrorDetails is attached to FAILED_PRECONDITION errors when a new
+// schema version breaks the tag's schema compatibility.
+type SchemaCompatibilityErrorDetails struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// violations are the changes that break compatibility.
+	Violations    []*SchemaCompatibilityViolation `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaCompatibilityErrorDetails) Reset() {
+	*x = SchemaCompatibilityErrorDetails{}
+	mi := &file_tags_v1_tags_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaCompatibilityErrorDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaCompatibilityErrorDetails) ProtoMessage() {}
+
+func (x *SchemaCompatibilityErrorDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_v1_tags_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaCompatibilityErrorDetails.ProtoReflect.Descriptor instead.
+func (*SchemaCompatibilityErrorDetails) Descriptor() ([]byte, []int) {
+	return file_tags_v1_tags_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SchemaCompatibilityErrorDetails) GetViolations() []*SchemaCompatibilityViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// SchemaCompatibilityViolation is one change between schema versions that breaks
+// compatibility.
+type SchemaCompatibilityViolation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field is the path to the attribute, with nested properties separated by dots and array
+	// items marked with [] (e.g. "vendor.name"), empty for the schema itself.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// compatibility is the direction the change breaks, BACKWARD or FORWARD.
+	Compatibility SchemaCompatibility `protobuf:"varint,2,opt,name=compatibility,proto3,enum=tags.v1.SchemaCompatibility" json:"compatibility,omitempty"`
+	// message describes the change.
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaCompatibilityViolation) Reset() {
+	*x = SchemaCompatibilityViolation{}
+	mi := &file_tags_v1_tags_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaCompatibilityViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaCompatibilityViolation) ProtoMessage() {}
+
+func (x *SchemaCompatibilityViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_v1_tags_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaCompatibilityViolation.ProtoReflect.Descriptor instead.
+func (*SchemaCompatibilityViolation) Descriptor() ([]byte, []int) {
+	return file_tags_v1_tags_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SchemaCompatibilityViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SchemaCompatibilityViolation) GetCompatibility() SchemaCompatibility {
+	if x != nil {
+		return x.Compatibility
+	}
+	return SchemaCompatibility_SCHEMA_COMPATIBILITY_UNSPECIFIED
+}
+
+func (x *SchemaCompatibilityViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_tags_v1_tags_proto protoreflect.FileDescriptor
 
 const file_tags_v1_tags_proto_rawDesc = "" +
 	"\n" +
-	"\x12tags/v1/tags.proto\x12\atags.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb7\x02\n" +
+	"\x12tags/v1/tags.proto\x12\atags.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x82\x04\n" +
 	"\x03Tag\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
 	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x12\n" +
@@ -705,10 +1088,15 @@ const file_tags_v1_tags_proto_rawDesc = "" +
 	"\vmodified_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"modifiedAt\x12$\n" +
 	"\vjson_schema\x18\b \x01(\tH\x02R\n" +
-	"jsonSchema\x88\x01\x01B\x0e\n" +
+	"jsonSchema\x88\x01\x01\x12%\n" +
+	"\x0einherit_schema\x18\t \x01(\bR\rinheritSchema\x127\n" +
+	"\x15effective_json_schema\x18\n" +
+	" \x01(\tH\x03R\x13effectiveJsonSchema\x88\x01\x01\x12O\n" +
+	"\x14schema_compatibility\x18\v \x01(\x0e2\x1c.tags.v1.SchemaCompatibilityR\x13schemaCompatibilityB\x0e\n" +
 	"\f_descriptionB\b\n" +
 	"\x06_colorB\x0e\n" +
-	"\f_json_schema\"\x8c\x02\n" +
+	"\f_json_schemaB\x18\n" +
+	"\x16_effective_json_schema\"\x84\x03\n" +
 	"\x10CreateTagRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
@@ -717,7 +1105,9 @@ const file_tags_v1_tags_proto_rawDesc = "" +
 	"parentPath\x88\x01\x01\x12\x19\n" +
 	"\x05color\x18\x05 \x01(\tH\x02R\x05color\x88\x01\x01\x12$\n" +
 	"\vjson_schema\x18\a \x01(\tH\x03R\n" +
-	"jsonSchema\x88\x01\x01B\x0e\n" +
+	"jsonSchema\x88\x01\x01\x12%\n" +
+	"\x0einherit_schema\x18\b \x01(\bR\rinheritSchema\x12O\n" +
+	"\x14schema_compatibility\x18\t \x01(\x0e2\x1c.tags.v1.SchemaCompatibilityR\x13schemaCompatibilityB\x0e\n" +
 	"\f_descriptionB\x0e\n" +
 	"\f_parent_pathB\b\n" +
 	"\x06_colorB\x0e\n" +
@@ -732,7 +1122,7 @@ const file_tags_v1_tags_proto_rawDesc = "" +
 	"\x0fListTagsRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"4\n" +
 	"\x10ListTagsResponse\x12 \n" +
-	"\x04tags\x18\x01 \x03(\v2\f.tags.v1.TagR\x04tags\"\xb9\x02\n" +
+	"\x04tags\x18\x01 \x03(\v2\f.tags.v1.TagR\x04tags\"\xc9\x03\n" +
 	"\x10UpdateTagRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1e\n" +
@@ -742,18 +1132,48 @@ const file_tags_v1_tags_proto_rawDesc = "" +
 	"parentPath\x88\x01\x01\x12\x19\n" +
 	"\x05color\x18\x06 \x01(\tH\x03R\x05color\x88\x01\x01\x12$\n" +
 	"\vjson_schema\x18\b \x01(\tH\x04R\n" +
-	"jsonSchema\x88\x01\x01B\v\n" +
+	"jsonSchema\x88\x01\x01\x12*\n" +
+	"\x0einherit_schema\x18\t \x01(\bH\x05R\rinheritSchema\x88\x01\x01\x12O\n" +
+	"\x14schema_compatibility\x18\n" +
+	" \x01(\x0e2\x1c.tags.v1.SchemaCompatibilityR\x13schemaCompatibilityB\v\n" +
 	"\t_new_nameB\x0e\n" +
 	"\f_descriptionB\x0e\n" +
 	"\f_parent_pathB\b\n" +
 	"\x06_colorB\x0e\n" +
-	"\f_json_schema\"3\n" +
+	"\f_json_schemaB\x11\n" +
+	"\x0f_inherit_schema\"3\n" +
 	"\x11UpdateTagResponse\x12\x1e\n" +
 	"\x03tag\x18\x01 \x01(\v2\f.tags.v1.TagR\x03tag\"D\n" +
 	"\x10DeleteTagRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\"\x13\n" +
-	"\x11DeleteTagResponse2\xd4\x02\n" +
+	"\x11DeleteTagResponse\"V\n" +
+	"\x16ValidationErrorDetails\x12<\n" +
+	"\n" +
+	"violations\x18\x01 \x03(\v2\x1c.tags.v1.ValidationViolationR\n" +
+	"violations\"\xb9\x01\n" +
+	"\x13ValidationViolation\x12\x18\n" +
+	"\apointer\x18\x01 \x01(\tR\apointer\x12\x18\n" +
+	"\akeyword\x18\x02 \x01(\tR\akeyword\x12\x1f\n" +
+	"\bexpected\x18\x03 \x01(\tH\x00R\bexpected\x88\x01\x01\x12\x1b\n" +
+	"\x06actual\x18\x04 \x01(\tH\x01R\x06actual\x88\x01\x01\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessageB\v\n" +
+	"\t_expectedB\t\n" +
+	"\a_actual\"h\n" +
+	"\x1fSchemaCompatibilityErrorDetails\x12E\n" +
+	"\n" +
+	"violations\x18\x01 \x03(\v2%.tags.v1.SchemaCompatibilityViolationR\n" +
+	"violations\"\x92\x01\n" +
+	"\x1cSchemaCompatibilityViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12B\n" +
+	"\rcompatibility\x18\x02 \x01(\x0e2\x1c.tags.v1.SchemaCompatibilityR\rcompatibility\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage*\xbe\x01\n" +
+	"\x13SchemaCompatibility\x12$\n" +
+	" SCHEMA_COMPATIBILITY_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19SCHEMA_COMPATIBILITY_NONE\x10\x01\x12!\n" +
+	"\x1dSCHEMA_COMPATIBILITY_BACKWARD\x10\x02\x12 \n" +
+	"\x1cSCHEMA_COMPATIBILITY_FORWARD\x10\x03\x12\x1d\n" +
+	"\x19SCHEMA_COMPATIBILITY_FULL\x10\x042\xd4\x02\n" +
 	"\n" +
 	"TagService\x12B\n" +
 	"\tCreateTag\x12\x19.tags.v1.CreateTagRequest\x1a\x1a.tags.v1.CreateTagResponse\x129\n" +
@@ -775,43 +1195,55 @@ func file_tags_v1_tags_proto_rawDescGZIP() []byte {
 	return file_tags_v1_tags_proto_rawDescData
 }
 
-var file_tags_v1_tags_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_tags_v1_tags_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_tags_v1_tags_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_tags_v1_tags_proto_goTypes = []any{
-	(*Tag)(nil),                   // 0: tags.v1.Tag
-	(*CreateTagRequest)(nil),      // 1: tags.v1.CreateTagRequest
-	(*CreateTagResponse)(nil),     // 2: tags.v1.CreateTagResponse
-	(*GetTagRequest)(nil),         // 3: tags.v1.GetTagRequest
-	(*GetTagResponse)(nil),        // 4: tags.v1.GetTagResponse
-	(*ListTagsRequest)(nil),       // 5: tags.v1.ListTagsRequest
-	(*ListTagsResponse)(nil),      // 6: tags.v1.ListTagsResponse
-	(*UpdateTagRequest)(nil),      // 7: tags.v1.UpdateTagRequest
-	(*UpdateTagResponse)(nil),     // 8: tags.v1.UpdateTagResponse
-	(*DeleteTagRequest)(nil),      // 9: tags.v1.DeleteTagRequest
-	(*DeleteTagResponse)(nil),     // 10: tags.v1.DeleteTagResponse
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(SchemaCompatibility)(0),                // 0: tags.v1.SchemaCompatibility
+	(*Tag)(nil),                             // 1: tags.v1.Tag
+	(*CreateTagRequest)(nil),                // 2: tags.v1.CreateTagRequest
+	(*CreateTagResponse)(nil),               // 3: tags.v1.CreateTagResponse
+	(*GetTagRequest)(nil),                   // 4: tags.v1.GetTagRequest
+	(*GetTagResponse)(nil),                  // 5: tags.v1.GetTagResponse
+	(*ListTagsRequest)(nil),                 // 6: tags.v1.ListTagsRequest
+	(*ListTagsResponse)(nil),                // 7: tags.v1.ListTagsResponse
+	(*UpdateTagRequest)(nil),                // 8: tags.v1.UpdateTagRequest
+	(*UpdateTagResponse)(nil),               // 9: tags.v1.UpdateTagResponse
+	(*DeleteTagRequest)(nil),                // 10: tags.v1.DeleteTagRequest
+	(*DeleteTagResponse)(nil),               // 11: tags.v1.DeleteTagResponse
+	(*ValidationErrorDetails)(nil),          // 12: tags.v1.ValidationErrorDetails
+	(*ValidationViolation)(nil),             // 13: tags.v1.ValidationViolation
+	(*SchemaCompatibilityErrorDetails)(nil), // 14: tags.v1.SchemaCompatibilityErrorDetails
+	(*SchemaCompatibilityViolation)(nil),    // 15: tags.v1.SchemaCompatibilityViolation
+	(*timestamppb.Timestamp)(nil),           // 16: google.protobuf.Timestamp
 }
 var file_tags_v1_tags_proto_depIdxs = []int32{
-	11, // 0: tags.v1.Tag.created_at:type_name -> google.protobuf.Timestamp
-	11, // 1: tags.v1.Tag.modified_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: tags.v1.CreateTagResponse.tag:type_name -> tags.v1.Tag
-	0,  // 3: tags.v1.GetTagResponse.tag:type_name -> tags.v1.Tag
-	0,  // 4: tags.v1.ListTagsResponse.tags:type_name -> tags.v1.Tag
-	0,  // 5: tags.v1.UpdateTagResponse.tag:type_name -> tags.v1.Tag
-	1,  // 6: tags.v1.TagService.CreateTag:input_type -> tags.v1.CreateTagRequest
-	3,  // 7: tags.v1.TagService.GetTag:input_type -> tags.v1.GetTagRequest
-	5,  // 8: tags.v1.TagService.ListTags:input_type -> tags.v1.ListTagsRequest
-	7,  // 9: tags.v1.TagService.UpdateTag:input_type -> tags.v1.UpdateTagRequest
-	9,  // 10: tags.v1.TagService.DeleteTag:input_type -> tags.v1.DeleteTagRequest
-	2,  // 11: tags.v1.TagService.CreateTag:output_type -> tags.v1.CreateTagResponse
-	4,  // 12: tags.v1.TagService.GetTag:output_type -> tags.v1.GetTagResponse
-	6,  // 13: tags.v1.TagService.ListTags:output_type -> tags.v1.ListTagsResponse
-	8,  // 14: tags.v1.TagService.UpdateTag:output_type -> tags.v1.UpdateTagResponse
-	10, // 15: tags.v1.TagService.DeleteTag:output_type -> tags.v1.DeleteTagResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	16, // 0: tags.v1.Tag.created_at:type_name -> google.protobuf.Timestamp
+	16, // 1: tags.v1.Tag.modified_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: tags.v1.Tag.schema_compatibility:type_name -> tags.v1.SchemaCompatibility
+	0,  // 3: tags.v1.CreateTagRequest.schema_compatibility:type_name -> tags.v1.SchemaCompatibility
+	1,  // 4: tags.v1.CreateTagResponse.tag:type_name -> tags.v1.Tag
+	1,  // 5: tags.v1.GetTagResponse.tag:type_name -> tags.v1.Tag
+	1,  // 6: tags.v1.ListTagsResponse.tags:type_name -> tags.v1.Tag
+	0,  // 7: tags.v1.UpdateTagRequest.schema_compatibility:type_name -> tags.v1.SchemaCompatibility
+	1,  // 8: tags.v1.UpdateTagResponse.tag:type_name -> tags.v1.Tag
+	13, // 9: tags.v1.ValidationErrorDetails.violations:type_name -> tags.v1.ValidationViolation
+	15, // 10: tags.v1.SchemaCompatibilityErrorDetails.violations:type_name -> tags.v1.SchemaCompatibilityViolation
+	0,  // 11: tags.v1.SchemaCompatibilityViolation.compatibility:type_name -> tags.v1.SchemaCompatibility
+	2,  // 12: tags.v1.TagService.CreateTag:input_type -> tags.v1.CreateTagRequest
+	4,  // 13: tags.v1.TagService.GetTag:input_type -> tags.v1.GetTagRequest
+	6,  // 14: tags.v1.TagService.ListTags:input_type -> tags.v1.ListTagsRequest
+	8,  // 15: tags.v1.TagService.UpdateTag:input_type -> tags.v1.UpdateTagRequest
+	10, // 16: tags.v1.TagService.DeleteTag:input_type -> tags.v1.DeleteTagRequest
+	3,  // 17: tags.v1.TagService.CreateTag:output_type -> tags.v1.CreateTagResponse
+	5,  // 18: tags.v1.TagService.GetTag:output_type -> tags.v1.GetTagResponse
+	7,  // 19: tags.v1.TagService.ListTags:output_type -> tags.v1.ListTagsResponse
+	9,  // 20: tags.v1.TagService.UpdateTag:output_type -> tags.v1.UpdateTagResponse
+	11, // 21: tags.v1.TagService.DeleteTag:output_type -> tags.v1.DeleteTagResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_tags_v1_tags_proto_init() }
@@ -822,18 +1254,20 @@ func file_tags_v1_tags_proto_init() {
 	file_tags_v1_tags_proto_msgTypes[0].OneofWrappers = []any{}
 	file_tags_v1_tags_proto_msgTypes[1].OneofWrappers = []any{}
 	file_tags_v1_tags_proto_msgTypes[7].OneofWrappers = []any{}
+	file_tags_v1_tags_proto_msgTypes[12].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tags_v1_tags_proto_rawDesc), len(file_tags_v1_tags_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_tags_v1_tags_proto_goTypes,
 		DependencyIndexes: file_tags_v1_tags_proto_depIdxs,
+		EnumInfos:         file_tags_v1_tags_proto_enumTypes,
 		MessageInfos:      file_tags_v1_tags_proto_msgTypes,
 	}.Build()
 	File_tags_v1_tags_proto = out.File
@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: webhooks/v1/webhooks.proto
+
+package webhooksv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/RynoXLI/Wayfile/gen/go/webhooks/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// WebhookServiceName is the fully-qualified name of the WebhookService service.
+	WebhookServiceName = "webhooks.v1.WebhookService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// WebhookServiceCreateWebhookProcedure is the fully-qualified name of the WebhookService's
+	// CreateWebhook RPC.
+	WebhookServiceCreateWebhookProcedure = "/webhooks.v1.WebhookService/CreateWebhook"
+	// WebhookServiceListWebhooksProcedure is the fully-qualified name of the WebhookService's
+	// ListWebhooks RPC.
+	WebhookServiceListWebhooksProcedure = "/webhooks.v1.WebhookService/ListWebhooks"
+	// WebhookServiceDeleteWebhookProcedure is the fully-qualified name of the WebhookService's
+	// DeleteWebhook RPC.
+	WebhookServiceDeleteWebhookProcedure = "/webhooks.v1.WebhookService/DeleteWebhook"
+	// WebhookServiceListWebhookDeliveriesProcedure is the fully-qualified name of the WebhookService's
+	// ListWebhookDeliveries RPC.
+	WebhookServiceListWebhookDeliveriesProcedure = "/webhooks.v1.WebhookService/ListWebhookDeliveries"
+	// WebhookServiceRetryWebhookDeliveryProcedure is the fully-qualified name of the WebhookService's
+	// RetryWebhookDelivery RPC.
+	WebhookServiceRetryWebhookDeliveryProcedure = "/webhooks.v1.WebhookService/RetryWebhookDelivery"
+)
+
+// WebhookServiceClient is a client for the webhooks.v1.WebhookService service.
+type WebhookServiceClient interface {
+	// CreateWebhook subscribes a URL to events in a namespace.
+	CreateWebhook(context.Context, *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error)
+	// ListWebhooks retrieves the webhook subscriptions in a namespace.
+	ListWebhooks(context.Context, *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error)
+	// DeleteWebhook removes a webhook subscription along with its delivery log.
+	DeleteWebhook(context.Context, *v1.DeleteWebhookRequest) (*v1.DeleteWebhookResponse, error)
+	// ListWebhookDeliveries retrieves the delivery log of a webhook, newest first.
+	ListWebhookDeliveries(context.Context, *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error)
+	// RetryWebhookDelivery queues a dead-lettered delivery to be sent again.
+	RetryWebhookDelivery(context.Context, *v1.RetryWebhookDeliveryRequest) (*v1.RetryWebhookDeliveryResponse, error)
+}
+
+// NewWebhookServiceClient constructs a client for the webhooks.v1.WebhookService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWebhookServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WebhookServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	webhookServiceMethods := v1.File_webhooks_v1_webhooks_proto.Services().ByName("WebhookService").Methods()
+	return &webhookServiceClient{
+		createWebhook: connect.NewClient[v1.CreateWebhookRequest, v1.CreateWebhookResponse](
+			httpClient,
+			baseURL+WebhookServiceCreateWebhookProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("CreateWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhooks: connect.NewClient[v1.ListWebhooksRequest, v1.ListWebhooksResponse](
+			httpClient,
+			baseURL+WebhookServiceListWebhooksProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("ListWebhooks")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWebhook: connect.NewClient[v1.DeleteWebhookRequest, v1.DeleteWebhookResponse](
+			httpClient,
+			baseURL+WebhookServiceDeleteWebhookProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("DeleteWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookDeliveries: connect.NewClient[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+WebhookServiceListWebhookDeliveriesProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("ListWebhookDeliveries")),
+			connect.WithClientOptions(opts...),
+		),
+		retryWebhookDelivery: connect.NewClient[v1.RetryWebhookDeliveryRequest, v1.RetryWebhookDeliveryResponse](
+			httpClient,
+			baseURL+WebhookServiceRetryWebhookDeliveryProcedure,
+			connect.WithSchema(webhookServiceMethods.ByName("RetryWebhookDelivery")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// webhookServiceClient implements WebhookServiceClient.
+type webhookServiceClient struct {
+	createWebhook         *connect.Client[v1.CreateWebhookRequest, v1.CreateWebhookResponse]
+	listWebhooks          *connect.Client[v1.ListWebhooksRequest, v1.ListWebhooksResponse]
+	deleteWebhook         *connect.Client[v1.DeleteWebhookRequest, v1.DeleteWebhookResponse]
+	listWebhookDeliveries *connect.Client[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse]
+	retryWebhookDelivery  *connect.Client[v1.RetryWebhookDeliveryRequest, v1.RetryWebhookDeliveryResponse]
+}
+
+// CreateWebhook calls webhooks.v1.WebhookService.CreateWebhook.
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, req *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error) {
+	response, err := c.createWebhook.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListWebhooks calls webhooks.v1.WebhookService.ListWebhooks.
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, req *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error) {
+	response, err := c.listWebhooks.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// DeleteWebhook calls webhooks.v1.WebhookService.DeleteWebhook.
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, req *v1.DeleteWebhookRequest) (*v1.DeleteWebhookResponse, error) {
+	response, err := c.deleteWebhook.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListWebhookDeliveries calls webhooks.v1.WebhookService.ListWebhookDeliveries.
+func (c *webhookServiceClient) ListWebhookDeliveries(ctx context.Context, req *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error) {
+	response, err := c.listWebhookDeliveries.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// RetryWebhookDelivery calls webhooks.v1.WebhookService.RetryWebhookDelivery.
+func (c *webhookServiceClient) RetryWebhookDelivery(ctx context.Context, req *v1.RetryWebhookDeliveryRequest) (*v1.RetryWebhookDeliveryResponse, error) {
+	response, err := c.retryWebhookDelivery.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// WebhookServiceHandler is an implementation of the webhooks.v1.WebhookService service.
+type WebhookServiceHandler interface {
+	// CreateWebhook subscribes a URL to events in a namespace.
+	CreateWebhook(context.Context, *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error)
+	// ListWebhooks retrieves the webhook subscriptions in a namespace.
+	ListWebhooks(context.Context, *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error)
+	// DeleteWebhook removes a webhook subscription along with its delivery log.
+	DeleteWebhook(context.Context, *v1.DeleteWebhookRequest) (*v1.DeleteWebhookResponse, error)
+	// ListWebhookDeliveries retrieves the delivery log of a webhook, newest first.
+	ListWebhookDeliveries(context.Context, *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error)
+	// RetryWebhookDelivery queues a dead-lettered delivery to be sent again.
+	RetryWebhookDelivery(context.Context, *v1.RetryWebhookDeliveryRequest) (*v1.RetryWebhookDeliveryResponse, error)
+}
+
+// NewWebhookServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWebhookServiceHandler(svc WebhookServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	webhookServiceMethods := v1.File_webhooks_v1_webhooks_proto.Services().ByName("WebhookService").Methods()
+	webhookServiceCreateWebhookHandler := connect.NewUnaryHandlerSimple(
+		WebhookServiceCreateWebhookProcedure,
+		svc.CreateWebhook,
+		connect.WithSchema(webhookServiceMethods.ByName("CreateWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceListWebhooksHandler := connect.NewUnaryHandlerSimple(
+		WebhookServiceListWebhooksProcedure,
+		svc.ListWebhooks,
+		connect.WithSchema(webhookServiceMethods.ByName("ListWebhooks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceDeleteWebhookHandler := connect.NewUnaryHandlerSimple(
+		WebhookServiceDeleteWebhookProcedure,
+		svc.DeleteWebhook,
+		connect.WithSchema(webhookServiceMethods.ByName("DeleteWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceListWebhookDeliveriesHandler := connect.NewUnaryHandlerSimple(
+		WebhookServiceListWebhookDeliveriesProcedure,
+		svc.ListWebhookDeliveries,
+		connect.WithSchema(webhookServiceMethods.ByName("ListWebhookDeliveries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceRetryWebhookDeliveryHandler := connect.NewUnaryHandlerSimple(
+		WebhookServiceRetryWebhookDeliveryProcedure,
+		svc.RetryWebhookDelivery,
+		connect.WithSchema(webhookServiceMethods.ByName("RetryWebhookDelivery")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/webhooks.v1.WebhookService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WebhookServiceCreateWebhookProcedure:
+			webhookServiceCreateWebhookHandler.ServeHTTP(w, r)
+		case WebhookServiceListWebhooksProcedure:
+			webhookServiceListWebhooksHandler.ServeHTTP(w, r)
+		case WebhookServiceDeleteWebhookProcedure:
+			webhookServiceDeleteWebhookHandler.ServeHTTP(w, r)
+		case WebhookServiceListWebhookDeliveriesProcedure:
+			webhookServiceListWebhookDeliveriesHandler.ServeHTTP(w, r)
+		case WebhookServiceRetryWebhookDeliveryProcedure:
+			webhookServiceRetryWebhookDeliveryHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWebhookServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWebhookServiceHandler struct{}
+
+func (UnimplementedWebhookServiceHandler) CreateWebhook(context.Context, *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.v1.WebhookService.CreateWebhook is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) ListWebhooks(context.Context, *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.v1.WebhookService.ListWebhooks is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) DeleteWebhook(context.Context, *v1.DeleteWebhookRequest) (*v1.DeleteWebhookResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.v1.WebhookService.DeleteWebhook is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) ListWebhookDeliveries(context.Context, *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.v1.WebhookService.ListWebhookDeliveries is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) RetryWebhookDelivery(context.Context, *v1.RetryWebhookDeliveryRequest) (*v1.RetryWebhookDeliveryResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.v1.WebhookService.RetryWebhookDelivery is not implemented"))
+}
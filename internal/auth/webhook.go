@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of a webhook delivery
+const SignatureHeader = "Wayfile-Signature"
+
+var (
+	// ErrInvalidSignatureHeader is returned when a signature header is malformed
+	ErrInvalidSignatureHeader = errors.New("invalid signature header")
+	// ErrSignatureExpired is returned when a signature is older than the allowed tolerance
+	ErrSignatureExpired = errors.New("signature expired")
+)
+
+// SignPayload signs a webhook body sent at timestamp and returns the SignatureHeader value
+// Format: t=timestampUnix,v1=signature
+// The signature covers timestampUnix.body, so a captured delivery can't be replayed with
+// a newer timestamp
+func (s *Signer) SignPayload(timestamp time.Time, body []byte) string {
+	unix := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", unix, s.sign(fmt.Sprintf("%d.%s", unix, body)))
+}
+
+// VerifyPayload validates a SignatureHeader value for body, rejecting signatures made more
+// than tolerance ago
+func (s *Signer) VerifyPayload(header string, body []byte, tolerance time.Duration) error {
+	var timestamp, signature string
+	for part := range strings.SplitSeq(header, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return ErrInvalidSignatureHeader
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return ErrInvalidSignatureHeader
+	}
+	if time.Since(time.Unix(unix, 0)) > tolerance {
+		return ErrSignatureExpired
+	}
+
+	expectedSig := s.sign(fmt.Sprintf("%d.%s", unix, body))
+	if !hmac.Equal([]byte(expectedSig), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignAndVerifyPayload(t *testing.T) {
+	signer := NewSigner("webhook-secret")
+	body := []byte(`{"type":"documents.uploaded"}`)
+
+	header := signer.SignPayload(time.Now(), body)
+	if err := signer.VerifyPayload(header, body, 5*time.Minute); err != nil {
+		t.Fatalf("VerifyPayload failed: %v", err)
+	}
+
+	// Tampered body
+	err := signer.VerifyPayload(header, []byte(`{"type":"documents.deleted"}`), 5*time.Minute)
+	if err != ErrInvalidSignature {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	// Different secret
+	err = NewSigner("other-secret").VerifyPayload(header, body, 5*time.Minute)
+	if err != ErrInvalidSignature {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestPayloadSignatureExpiration(t *testing.T) {
+	signer := NewSigner("webhook-secret")
+	body := []byte(`{}`)
+
+	header := signer.SignPayload(time.Now().Add(-10*time.Minute), body)
+	if err := signer.VerifyPayload(header, body, 5*time.Minute); err != ErrSignatureExpired {
+		t.Errorf("expected ErrSignatureExpired, got %v", err)
+	}
+}
+
+func TestInvalidSignatureHeader(t *testing.T) {
+	signer := NewSigner("webhook-secret")
+
+	testCases := []string{
+		"",
+		"garbage",
+		"t=abc,v1=sig",
+		"t=1700000000",
+		"v1=sig",
+	}
+
+	for _, tc := range testCases {
+		err := signer.VerifyPayload(tc, []byte(`{}`), time.Hour)
+		if err != ErrInvalidSignatureHeader {
+			t.Errorf("header %q: expected ErrInvalidSignatureHeader, got %v", tc, err)
+		}
+	}
+}
@@ -9,11 +9,13 @@ import (
 
 // Config holds the entire configuration for the application
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	NATS     NATSConfig     `mapstructure:"nats"`
-	Storage  StorageConfig  `mapstructure:"storage"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
+	Server     ServerConfig     `mapstructure:"server"`
+	Database   DatabaseConfig   `mapstructure:"database"`
+	NATS       NATSConfig       `mapstructure:"nats"`
+	Webhooks   WebhooksConfig   `mapstructure:"webhooks"`
+	Extraction ExtractionConfig `mapstructure:"extraction"`
+	Storage    StorageConfig    `mapstructure:"storage"`
+	Logging    LoggingConfig    `mapstructure:"logging"`
 }
 
 // ServerConfig holds server-related configuration
@@ -29,6 +31,18 @@ type ServerConfig struct {
 	RateLimitBurst int    `mapstructure:"rate_limit_burst"` // burst size
 	MaxUploadSize  int64  `mapstructure:"max_upload_size"`  // bytes
 	EnableDocs     bool   `mapstructure:"enable_docs"`      // enable /docs endpoint
+
+	// Graceful shutdown: in-flight requests and event acknowledgements
+	ShutdownTimeout int `mapstructure:"shutdown_timeout"` // seconds
+
+	// Resumable (tus) uploads
+	MaxResumableUploadSize int64 `mapstructure:"max_resumable_upload_size"` // bytes
+	UploadExpiry           int   `mapstructure:"upload_expiry"`             // seconds
+	UploadCleanupInterval  int   `mapstructure:"upload_cleanup_interval"`   // seconds
+
+	// Archive imports
+	MaxImportSize      int64 `mapstructure:"max_import_size"`      // bytes
+	ImportPollInterval int   `mapstructure:"import_poll_interval"` // seconds
 }
 
 // DatabaseConfig holds database-related configuration
@@ -39,6 +53,76 @@ type DatabaseConfig struct {
 // NATSConfig holds NATS-related configuration
 type NATSConfig struct {
 	URL string `mapstructure:"url"`
+
+	// Event subjects: namespaced (wayfile.<namespace>.…), legacy (documents.uploaded, …)
+	// or both while consumers migrate
+	SubjectMode string `mapstructure:"subject_mode"`
+
+	// Publishing
+	MaxPendingPublishes int `mapstructure:"max_pending_publishes"` // in-flight publishes before blocking
+	PublishAckTimeout   int `mapstructure:"publish_ack_timeout"`   // seconds
+
+	// Transactional outbox relay
+	OutboxPollInterval int `mapstructure:"outbox_poll_interval"` // seconds
+	OutboxBatchSize    int `mapstructure:"outbox_batch_size"`    // events per relay transaction
+	OutboxRetention    int `mapstructure:"outbox_retention"`     // seconds to keep delivered events
+	OutboxMaxAttempts  int `mapstructure:"outbox_max_attempts"`  // publish attempts before an event is parked
+
+	// Stream that namespace event feeds (SSE and WatchEvents) read from
+	EventStream string `mapstructure:"event_stream"`
+
+	// JetStream streams and durable consumers created or updated at startup
+	Streams   []StreamConfig   `mapstructure:"streams"`
+	Consumers []ConsumerConfig `mapstructure:"consumers"`
+}
+
+// StreamConfig describes a JetStream stream
+type StreamConfig struct {
+	Name            string   `mapstructure:"name"`
+	Subjects        []string `mapstructure:"subjects"`
+	Retention       string   `mapstructure:"retention"`        // limits, interest or workqueue
+	MaxAge          int      `mapstructure:"max_age"`          // seconds, 0 keeps messages forever
+	Replicas        int      `mapstructure:"replicas"`         // 1, 3 or 5
+	Storage         string   `mapstructure:"storage"`          // file or memory
+	DuplicateWindow int      `mapstructure:"duplicate_window"` // seconds, 0 uses the server default
+}
+
+// ConsumerConfig describes a durable pull consumer on a stream
+type ConsumerConfig struct {
+	Stream         string   `mapstructure:"stream"`
+	Durable        string   `mapstructure:"durable"`
+	FilterSubjects []string `mapstructure:"filter_subjects"`
+	AckWait        int      `mapstructure:"ack_wait"`        // seconds
+	MaxDeliver     int      `mapstructure:"max_deliver"`     // attempts, -1 for unlimited
+	MaxAckPending  int      `mapstructure:"max_ack_pending"` // 0 uses the server default
+}
+
+// WebhooksConfig holds webhook delivery configuration. Webhooks consume the namespaced
+// subjects, so they receive nothing when nats.subject_mode is legacy.
+type WebhooksConfig struct {
+	// JetStream consumer that events are read from
+	Stream   string `mapstructure:"stream"`
+	Consumer string `mapstructure:"consumer"`
+
+	// Delivery
+	Timeout        int `mapstructure:"timeout"`         // seconds per request
+	MaxAttempts    int `mapstructure:"max_attempts"`    // attempts before a delivery is dead-lettered
+	InitialBackoff int `mapstructure:"initial_backoff"` // seconds, doubled after each failed attempt
+	MaxBackoff     int `mapstructure:"max_backoff"`     // seconds
+	PollInterval   int `mapstructure:"poll_interval"`   // seconds between checks for due retries
+}
+
+// ExtractionConfig holds configuration for the extractor worker (cmd/worker). How often
+// a document is retried is the consumer's max_deliver.
+type ExtractionConfig struct {
+	// JetStream consumer that documents.uploaded events are read from
+	Stream   string `mapstructure:"stream"`
+	Consumer string `mapstructure:"consumer"`
+
+	// Processing
+	Concurrency    int `mapstructure:"concurrency"`     // documents extracted at once
+	InitialBackoff int `mapstructure:"initial_backoff"` // seconds, doubled after each failed attempt
+	MaxBackoff     int `mapstructure:"max_backoff"`     // seconds
 }
 
 // StorageConfig holds storage-related configuration
@@ -56,6 +140,9 @@ type LocalStorageConfig struct {
 type LoggingConfig struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+
+	// Periodic logging of cache statistics
+	StatsInterval int `mapstructure:"stats_interval"` // seconds, 0 disables
 }
 
 // Load reads the configuration from file and environment variables
@@ -94,10 +181,68 @@ func Load() (*Config, error) {
 	viper.SetDefault("server.rate_limit_burst", 200)      // burst of 200
 	viper.SetDefault("server.max_upload_size", 104857600) // 100 MB
 	viper.SetDefault("server.enable_docs", true)
+	viper.SetDefault("server.max_resumable_upload_size", 10737418240) // 10 GB
+	viper.SetDefault("server.upload_expiry", 86400)                   // 24 hours
+	viper.SetDefault("server.upload_cleanup_interval", 600)           // 10 minutes
+	viper.SetDefault("server.max_import_size", 10737418240)           // 10 GB
+	viper.SetDefault("server.import_poll_interval", 10)               // 10 seconds
+	viper.SetDefault("server.shutdown_timeout", 30)                   // 30 seconds
+	viper.SetDefault("nats.subject_mode", "namespaced")               // wayfile.<namespace>.…
+	viper.SetDefault("nats.max_pending_publishes", 256)               // 256 in-flight publishes
+	viper.SetDefault("nats.publish_ack_timeout", 5)                   // 5 seconds
+	viper.SetDefault("nats.outbox_poll_interval", 1)                  // 1 second
+	viper.SetDefault("nats.outbox_batch_size", 100)                   // 100 events per transaction
+	viper.SetDefault("nats.outbox_retention", 86400)                  // 24 hours
+	viper.SetDefault("nats.outbox_max_attempts", 20)                  // ~10 hours of retries
+	viper.SetDefault("nats.event_stream", "WAYFILE")
+	viper.SetDefault("nats.streams", []map[string]any{
+		{
+			"name": "WAYFILE",
+			"subjects": []string{
+				"wayfile.>",
+				// Legacy subjects, published when nats.subject_mode is legacy or both
+				"documents.>", "schema.>", "tags.>", "namespaces.>",
+			},
+			"retention":        "limits",
+			"max_age":          604800, // 7 days
+			"replicas":         1,
+			"storage":          "file",
+			"duplicate_window": 120, // 2 minutes
+		},
+	})
+	viper.SetDefault("nats.consumers", []map[string]any{
+		{
+			"stream":          "WAYFILE",
+			"durable":         "extractor",
+			"filter_subjects": []string{"wayfile.*.documents.uploaded"},
+			"ack_wait":        60, // 1 minute
+			"max_deliver":     5,
+		},
+		{
+			"stream":          "WAYFILE",
+			"durable":         "webhooks",
+			"filter_subjects": []string{"wayfile.>"},
+			"ack_wait":        30, // 30 seconds
+			"max_deliver":     -1,
+		},
+	})
+	viper.SetDefault("webhooks.stream", "WAYFILE")
+	viper.SetDefault("webhooks.consumer", "webhooks")
+	viper.SetDefault("webhooks.timeout", 10)         // 10 seconds
+	viper.SetDefault("webhooks.max_attempts", 10)    // ~3 hours of retries with defaults
+	viper.SetDefault("webhooks.initial_backoff", 30) // 30 seconds
+	viper.SetDefault("webhooks.max_backoff", 3600)   // 1 hour
+	viper.SetDefault("webhooks.poll_interval", 5)    // 5 seconds
+	viper.SetDefault("extraction.stream", "WAYFILE")
+	viper.SetDefault("extraction.consumer", "extractor")
+	viper.SetDefault("extraction.concurrency", 4)
+	viper.SetDefault("extraction.initial_backoff", 10) // 10 seconds
+	viper.SetDefault("extraction.max_backoff", 300)    // 5 minutes
 	viper.SetDefault("storage.type", "local")
 	viper.SetDefault("storage.local.path", "./data/storage")
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("logging.format", "json")
+	viper.SetDefault("logging.stats_interval", 300) // 5 minutes
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config: %w", err)
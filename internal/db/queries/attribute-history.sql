@@ -0,0 +1,31 @@
+-- name: RecordAttributeChange :exec
+-- The schema version is the latest one of the tag, or of the global schema for global attributes
+INSERT INTO attribute_history (
+    document_id,
+    tag_id,
+    field,
+    old_value,
+    new_value,
+    schema_version,
+    actor,
+    extraction_method,
+    extracted_by
+) VALUES (
+    sqlc.arg(document_id),
+    sqlc.narg(tag_id),
+    sqlc.arg(field),
+    sqlc.narg(old_value),
+    sqlc.narg(new_value),
+    (SELECT MAX(version) FROM attribute_schemas WHERE tag_id IS NOT DISTINCT FROM sqlc.narg(tag_id)),
+    sqlc.arg(actor),
+    sqlc.arg(extraction_method),
+    sqlc.arg(extracted_by)
+);
+
+-- name: ListAttributeHistory :many
+-- Newest first. A NULL tag_id lists the global attributes' history; a NULL field lists every field.
+SELECT * FROM attribute_history
+WHERE document_id = sqlc.arg(document_id)
+  AND tag_id IS NOT DISTINCT FROM sqlc.narg(tag_id)
+  AND (sqlc.narg(field)::text IS NULL OR field = sqlc.narg(field)::text)
+ORDER BY id DESC;
@@ -19,4 +19,24 @@ INSERT INTO attribute_schemas (
     tag_id,
     version,
     json_schema,
-    created_at;
\ No newline at end of file
+    created_at;
+-- name: GetEffectiveSchemaVersion :one
+-- Identifies the schemas a tag's effective schema is composed of, as the latest schema
+-- version of the tag and of each ancestor it inherits from, nearest first. It changes
+-- whenever the effective schema may have: on a new schema version anywhere in the chain,
+-- and when the tag or an ancestor moves or stops or starts inheriting.
+WITH RECURSIVE chain AS (
+    SELECT t.id, t.parent_id, t.inherit_schema, 0 AS depth
+    FROM tags t WHERE t.id = sqlc.arg(tag_id)
+    UNION ALL
+    SELECT p.id, p.parent_id, p.inherit_schema, chain.depth + 1
+    FROM tags p JOIN chain ON p.id = chain.parent_id
+    WHERE chain.inherit_schema
+)
+SELECT COALESCE(string_agg(
+    chain.id::text || ':' || COALESCE(
+        (SELECT MAX(s.version) FROM attribute_schemas s WHERE s.tag_id = chain.id), 0
+    )::text,
+    ',' ORDER BY chain.depth
+), '')::text AS version
+FROM chain;
@@ -6,9 +6,10 @@ SET attributes = EXCLUDED.attributes,
     attributes_metadata = EXCLUDED.attributes_metadata,
     modified_at = NOW();
 
--- name: RemoveDocumentTag :exec
+-- name: RemoveDocumentTag :one
 DELETE FROM document_tags
-WHERE document_id = $1 AND tag_id = $2;
+WHERE document_id = $1 AND tag_id = $2
+RETURNING attributes;
 
 -- name: GetDocumentTagsWithAttributes :many
 SELECT t.id, t.namespace_id, t.name, t.path, dt.attributes, dt.attributes_metadata, dt.modified_at
@@ -23,7 +24,19 @@ SELECT attributes, attributes_metadata
 FROM document_tags
 WHERE document_id = $1 AND tag_id = $2;
 
+-- name: LockDocumentTagAttributes :one
+SELECT attributes, attributes_metadata
+FROM document_tags
+WHERE document_id = $1 AND tag_id = $2
+FOR UPDATE;
+
 -- name: UpdateDocumentTagAttributes :exec
 UPDATE document_tags
 SET attributes = $3, attributes_metadata = $4, modified_at = NOW()
-WHERE document_id = $1 AND tag_id = $2;
\ No newline at end of file
+WHERE document_id = $1 AND tag_id = $2;
+-- name: ListTagsForDocuments :many
+SELECT dt.document_id, t.path, dt.attributes, dt.attributes_metadata
+FROM document_tags dt
+JOIN tags t ON t.id = dt.tag_id
+WHERE dt.document_id = ANY(sqlc.arg(document_ids)::uuid[])
+ORDER BY dt.document_id, t.path;
@@ -5,14 +5,17 @@ INSERT INTO documents (
     file_name,
     title,
     mime_type,
+    detected_mime_type,
     checksum_sha256,
     file_size
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING 
     id,
     file_name,
     title,
+    mime_type,
+    detected_mime_type,
     checksum_sha256,
     created_at;
 
@@ -35,9 +38,71 @@ UPDATE documents SET
 WHERE id = $1
 RETURNING *;
 
+-- name: LockDocumentAttributes :one
+SELECT attributes, attributes_metadata FROM documents WHERE id = $1 FOR UPDATE;
+
 -- name: UpdateDocumentAttributes :exec
 UPDATE documents SET
     attributes = $2,
     attributes_metadata = $3,
     modified_at = NOW()
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: GetDocumentByChecksum :one
+SELECT * FROM documents WHERE namespace_id = $1 AND checksum_sha256 = $2;
+
+-- name: ListDocumentsByIDs :many
+SELECT * FROM documents
+WHERE namespace_id = $1 AND id = ANY(sqlc.arg(document_ids)::uuid[])
+ORDER BY created_at, id;
+
+-- name: ListDocumentsByTagPath :many
+-- Documents tagged with the given path, or with any descendant of it when include_descendants is set
+SELECT d.* FROM documents d
+WHERE d.namespace_id = sqlc.arg(namespace_id)
+  AND EXISTS (
+    SELECT 1 FROM document_tags dt
+    JOIN tags t ON t.id = dt.tag_id
+    WHERE dt.document_id = d.id
+      AND (
+        t.path = sqlc.arg(tag_path)
+        OR (sqlc.arg(include_descendants)::bool AND starts_with(t.path, sqlc.arg(tag_path) || '/'))
+      )
+  )
+ORDER BY d.created_at, d.id;
+
+-- name: SearchDocumentsByAttributes :many
+-- Documents whose global attributes contain the containment object, a single @> the GIN
+-- index on documents.attributes can answer
+SELECT d.id, d.file_name, d.title, d.mime_type, d.attributes, d.created_at
+FROM documents d
+WHERE d.namespace_id = sqlc.arg(namespace_id)
+  AND d.attributes @> sqlc.arg(containment)::jsonb
+ORDER BY d.created_at, d.id;
+
+-- name: SearchDocumentsByTagAttributes :many
+-- Documents whose attributes for the tag contain the containment object, a single @> the GIN
+-- index on document_tags.attributes can answer
+SELECT d.id, d.file_name, d.title, d.mime_type, dt.attributes, d.created_at
+FROM documents d
+JOIN document_tags dt ON dt.document_id = d.id
+WHERE d.namespace_id = sqlc.arg(namespace_id)
+  AND dt.tag_id = sqlc.arg(tag_id)
+  AND dt.attributes @> sqlc.arg(containment)::jsonb
+ORDER BY d.created_at, d.id;
+
+-- name: ListDocumentsWithAttributes :many
+-- Every document in the namespace with its global attributes, for searches without filters
+SELECT d.id, d.file_name, d.title, d.mime_type, d.attributes, d.created_at
+FROM documents d
+WHERE d.namespace_id = sqlc.arg(namespace_id)
+ORDER BY d.created_at, d.id;
+
+-- name: ListDocumentsWithTagAttributes :many
+-- Every document with the tag and its attributes for the tag, for searches without filters
+SELECT d.id, d.file_name, d.title, d.mime_type, dt.attributes, d.created_at
+FROM documents d
+JOIN document_tags dt ON dt.document_id = d.id
+WHERE d.namespace_id = sqlc.arg(namespace_id)
+  AND dt.tag_id = sqlc.arg(tag_id)
+ORDER BY d.created_at, d.id;
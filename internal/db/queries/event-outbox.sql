@@ -0,0 +1,36 @@
+-- name: InsertOutboxEvent :exec
+INSERT INTO event_outbox (subject, payload) VALUES ($1, $2);
+
+-- name: ClaimOutboxEvents :many
+-- Locks the oldest unsent events that are due; SKIP LOCKED lets several relays share the
+-- outbox
+SELECT * FROM event_outbox
+WHERE sent_at IS NULL AND parked_at IS NULL AND next_attempt_at <= NOW()
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventSent :exec
+UPDATE event_outbox SET
+    attempts = attempts + 1,
+    last_error = NULL,
+    sent_at = NOW()
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE event_outbox SET
+    attempts = attempts + 1,
+    last_error = sqlc.arg(error_message),
+    next_attempt_at = sqlc.arg(next_attempt_at)
+WHERE id = sqlc.arg(id);
+
+-- name: ParkOutboxEvent :exec
+-- Gives up on an event whose attempts are exhausted
+UPDATE event_outbox SET
+    attempts = attempts + 1,
+    last_error = sqlc.arg(error_message),
+    parked_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteSentOutboxEvents :execrows
+DELETE FROM event_outbox WHERE sent_at < $1;
@@ -0,0 +1,97 @@
+-- name: CreateImportJob :one
+INSERT INTO import_jobs (
+    id,
+    namespace_id,
+    file_name,
+    format,
+    tag_path,
+    archive_size,
+    total_entries
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetImportJob :one
+SELECT * FROM import_jobs WHERE id = $1;
+
+-- name: ClaimImportJob :one
+-- Claims the oldest pending job; SKIP LOCKED lets several workers share the queue
+UPDATE import_jobs SET
+    status = 'running',
+    started_at = COALESCE(started_at, NOW()),
+    modified_at = NOW()
+WHERE id = (
+    SELECT id FROM import_jobs
+    WHERE status = 'pending'
+    ORDER BY created_at
+    FOR UPDATE SKIP LOCKED
+    LIMIT 1
+)
+RETURNING *;
+
+-- name: RequeueStaleImportJobs :execrows
+-- Returns running jobs whose worker stopped reporting progress to the queue
+UPDATE import_jobs SET
+    status = 'pending',
+    modified_at = NOW()
+WHERE status = 'running' AND modified_at < $1;
+
+-- name: SetImportJobTotal :exec
+UPDATE import_jobs SET
+    total_entries = $2,
+    modified_at = NOW()
+WHERE id = $1;
+
+-- name: TouchImportJob :exec
+UPDATE import_jobs SET
+    bytes_processed = $2,
+    modified_at = NOW()
+WHERE id = $1;
+
+-- name: RecordImportEntry :exec
+-- Records an entry result and updates the job counters in one statement. Entries that
+-- were already recorded (e.g. when a requeued job is resumed) are not counted twice.
+WITH entry AS (
+    INSERT INTO import_job_entries (job_id, path, status, document_id, tag_path, error)
+    VALUES (
+        sqlc.arg(job_id),
+        sqlc.arg(path),
+        sqlc.arg(status),
+        sqlc.arg(document_id),
+        sqlc.arg(tag_path),
+        sqlc.arg(error_message)
+    )
+    ON CONFLICT (job_id, path) DO NOTHING
+    RETURNING status
+)
+UPDATE import_jobs SET
+    processed_entries = processed_entries + (SELECT COUNT(*) FROM entry),
+    created_count = created_count + (SELECT COUNT(*) FROM entry WHERE entry.status = 'created'),
+    duplicate_count = duplicate_count + (SELECT COUNT(*) FROM entry WHERE entry.status = 'duplicate'),
+    skipped_count = skipped_count + (SELECT COUNT(*) FROM entry WHERE entry.status = 'skipped'),
+    failed_count = failed_count + (SELECT COUNT(*) FROM entry WHERE entry.status = 'failed'),
+    bytes_processed = sqlc.arg(bytes_processed),
+    modified_at = NOW()
+WHERE id = sqlc.arg(job_id);
+
+-- name: ListImportEntryPaths :many
+SELECT path FROM import_job_entries WHERE job_id = $1;
+
+-- name: ListImportJobEntries :many
+SELECT * FROM import_job_entries
+WHERE job_id = $1
+ORDER BY created_at, path
+LIMIT $2 OFFSET $3;
+
+-- name: FinishImportJob :exec
+UPDATE import_jobs SET
+    status = sqlc.arg(status),
+    error = sqlc.arg(error_message),
+    total_entries = COALESCE(total_entries, processed_entries),
+    bytes_processed = CASE
+        WHEN sqlc.arg(status) = 'completed' THEN archive_size
+        ELSE bytes_processed
+    END,
+    completed_at = NOW(),
+    modified_at = NOW()
+WHERE id = sqlc.arg(id);
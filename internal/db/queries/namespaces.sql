@@ -8,4 +8,7 @@ SELECT * FROM namespaces ORDER BY created_at DESC;
 SELECT * FROM namespaces WHERE name = $1;
 
 -- name: DeleteNamespace :exec
-DELETE FROM namespaces WHERE name = $1;
\ No newline at end of file
+DELETE FROM namespaces WHERE name = $1;
+
+-- name: GetNamespaceByID :one
+SELECT * FROM namespaces WHERE id = $1;
@@ -0,0 +1,33 @@
+-- name: SetNamespaceReviewThreshold :one
+UPDATE namespaces
+SET review_threshold = $2, modified_at = NOW()
+WHERE name = $1
+RETURNING *;
+
+-- name: QueueReviewItem :one
+INSERT INTO review_items (namespace_id, document_id, tag_id, attributes, confidence, extracted_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (document_id, tag_id) WHERE status = 'pending' DO UPDATE
+SET attributes = EXCLUDED.attributes,
+    confidence = EXCLUDED.confidence,
+    extracted_by = EXCLUDED.extracted_by,
+    modified_at = NOW()
+RETURNING id;
+
+-- name: GetReviewItem :one
+SELECT ri.*, t.path AS tag_path
+FROM review_items ri
+JOIN tags t ON t.id = ri.tag_id
+WHERE ri.id = $1 AND ri.namespace_id = $2;
+
+-- name: ListPendingReviewItems :many
+SELECT ri.*, t.path AS tag_path
+FROM review_items ri
+JOIN tags t ON t.id = ri.tag_id
+WHERE ri.namespace_id = $1 AND ri.status = 'pending'
+ORDER BY ri.created_at, ri.id;
+
+-- name: ResolveReviewItem :execrows
+UPDATE review_items
+SET status = $2, reviewed_by = $3, reviewed_at = NOW(), modified_at = NOW()
+WHERE id = $1 AND status = 'pending';
@@ -0,0 +1,31 @@
+-- name: CreateTaggingRule :one
+INSERT INTO tagging_rules (namespace_id, name, enabled, priority, conditions, action)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTaggingRule :one
+SELECT * FROM tagging_rules WHERE id = $1 AND namespace_id = $2;
+
+-- name: ListTaggingRules :many
+SELECT * FROM tagging_rules
+WHERE namespace_id = $1
+ORDER BY priority, created_at;
+
+-- name: ListEnabledTaggingRules :many
+SELECT * FROM tagging_rules
+WHERE namespace_id = $1 AND enabled
+ORDER BY priority, created_at;
+
+-- name: UpdateTaggingRule :one
+UPDATE tagging_rules SET
+    name = $3,
+    enabled = $4,
+    priority = $5,
+    conditions = $6,
+    action = $7,
+    modified_at = NOW()
+WHERE id = $1 AND namespace_id = $2
+RETURNING *;
+
+-- name: DeleteTaggingRule :execrows
+DELETE FROM tagging_rules WHERE id = $1 AND namespace_id = $2;
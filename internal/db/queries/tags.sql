@@ -1,6 +1,8 @@
 -- name: CreateTag :one
-INSERT INTO tags (namespace_id, name, description, path, parent_id, color)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO tags (
+    namespace_id, name, description, path, parent_id, color, inherit_schema, schema_compatibility
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
 -- name: GetTagByID :one
@@ -15,6 +17,16 @@ SELECT * FROM tags WHERE namespace_id = $1 AND path = $2;
 -- name: GetTagsByNamespace :many
 SELECT * FROM tags WHERE namespace_id = $1 ORDER BY path;
 
+-- name: ListTagDescendants :many
+-- Every tag below the given one, following parent links
+WITH RECURSIVE descendants AS (
+    SELECT child.id FROM tags child WHERE child.parent_id = sqlc.arg(tag_id)
+    UNION ALL
+    SELECT t.id FROM tags t JOIN descendants d ON t.parent_id = d.id
+)
+SELECT tags.* FROM tags JOIN descendants ON descendants.id = tags.id
+ORDER BY tags.path;
+
 -- name: UpdateTag :one
 UPDATE tags
 SET 
@@ -23,6 +35,8 @@ SET
     path = COALESCE($4, path),
     parent_id = COALESCE($5, parent_id),
     color = COALESCE($6, color),
+    inherit_schema = COALESCE($7, inherit_schema),
+    schema_compatibility = COALESCE($8, schema_compatibility),
     modified_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -0,0 +1,36 @@
+-- name: CreateUploadSession :one
+INSERT INTO upload_sessions (
+    namespace_id,
+    file_name,
+    mime_type,
+    upload_length,
+    metadata,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetUploadSession :one
+SELECT * FROM upload_sessions WHERE id = $1;
+
+-- name: AdvanceUploadSession :one
+UPDATE upload_sessions SET
+    upload_offset = sqlc.arg(new_offset),
+    chunk_names = array_append(chunk_names, sqlc.arg(chunk_name)::text),
+    hash_state = sqlc.arg(hash_state),
+    expires_at = sqlc.arg(expires_at),
+    modified_at = NOW()
+WHERE id = sqlc.arg(id) AND upload_offset = sqlc.arg(expected_offset)
+RETURNING *;
+
+-- name: CompleteUploadSession :exec
+UPDATE upload_sessions SET
+    document_id = $2,
+    modified_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteUploadSession :exec
+DELETE FROM upload_sessions WHERE id = $1;
+
+-- name: ListExpiredUploadSessions :many
+SELECT * FROM upload_sessions WHERE expires_at < NOW() ORDER BY expires_at;
@@ -0,0 +1,82 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (namespace_id, url, event_types, secret)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks WHERE id = $1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhooks WHERE namespace_id = $1 ORDER BY created_at;
+
+-- name: ListWebhooksForEvent :many
+-- Webhooks in the namespace subscribed to the event type, or to every event
+SELECT * FROM webhooks
+WHERE namespace_id = sqlc.arg(namespace_id)
+  AND (cardinality(event_types) = 0 OR sqlc.arg(event_type)::text = ANY(event_types))
+ORDER BY created_at;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = $1 AND namespace_id = $2;
+
+-- name: QueueWebhookDelivery :exec
+-- Events redelivered by JetStream are only queued once per webhook
+INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (webhook_id, event_id) DO NOTHING;
+
+-- name: ClaimWebhookDeliveries :many
+-- Claims due deliveries by pushing their next attempt out to lease_until, so a worker
+-- that dies mid-delivery leaves them to be retried once the lease expires. SKIP LOCKED
+-- lets several workers share the queue.
+UPDATE webhook_deliveries d SET
+    next_attempt_at = sqlc.arg(lease_until)
+FROM webhooks w
+WHERE w.id = d.webhook_id
+  AND d.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    FOR UPDATE SKIP LOCKED
+    LIMIT sqlc.arg(batch_size)
+  )
+RETURNING d.id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries SET
+    status = 'succeeded',
+    attempts = attempts + 1,
+    response_status = sqlc.arg(response_status),
+    last_error = NULL,
+    delivered_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: MarkWebhookDeliveryFailed :exec
+-- Records a failed attempt. The delivery is retried at next_attempt_at, or dead-lettered
+-- when status is 'dead'.
+UPDATE webhook_deliveries SET
+    status = sqlc.arg(status),
+    attempts = attempts + 1,
+    response_status = sqlc.arg(response_status),
+    last_error = sqlc.arg(error_message),
+    next_attempt_at = sqlc.arg(next_attempt_at)
+WHERE id = sqlc.arg(id);
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = sqlc.arg(webhook_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: RequeueWebhookDelivery :one
+-- Gives a dead-lettered delivery a fresh set of attempts
+UPDATE webhook_deliveries SET
+    status = 'pending',
+    attempts = 0,
+    next_attempt_at = NOW()
+WHERE id = $1 AND status = 'dead'
+RETURNING *;
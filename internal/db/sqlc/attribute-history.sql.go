@@ -0,0 +1,92 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: attribute-history.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listAttributeHistory = `-- name: ListAttributeHistory :many
+SELECT id, document_id, tag_id, field, old_value, new_value, schema_version, actor, extraction_method, extracted_by, changed_at FROM attribute_history
+WHERE document_id = $1
+  AND tag_id IS NOT DISTINCT FROM $2
+  AND ($3::text IS NULL OR field = $3::text)
+ORDER BY id DESC
+`
+
+// Newest first. A NULL tag_id lists the global attributes' history; a NULL field lists every field.
+func (q *Queries) ListAttributeHistory(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID, field *string) ([]AttributeHistory, error) {
+	rows, err := q.db.Query(ctx, listAttributeHistory, documentID, tagID, field)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AttributeHistory{}
+	for rows.Next() {
+		var i AttributeHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.DocumentID,
+			&i.TagID,
+			&i.Field,
+			&i.OldValue,
+			&i.NewValue,
+			&i.SchemaVersion,
+			&i.Actor,
+			&i.ExtractionMethod,
+			&i.ExtractedBy,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordAttributeChange = `-- name: RecordAttributeChange :exec
+INSERT INTO attribute_history (
+    document_id,
+    tag_id,
+    field,
+    old_value,
+    new_value,
+    schema_version,
+    actor,
+    extraction_method,
+    extracted_by
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    (SELECT MAX(version) FROM attribute_schemas WHERE tag_id IS NOT DISTINCT FROM $2),
+    $6,
+    $7,
+    $8
+)
+`
+
+// The schema version is the latest one of the tag, or of the global schema for global attributes
+func (q *Queries) RecordAttributeChange(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID, field string, oldValue []byte, newValue []byte, actor string, extractionMethod string, extractedBy string) error {
+	_, err := q.db.Exec(ctx, recordAttributeChange,
+		documentID,
+		tagID,
+		field,
+		oldValue,
+		newValue,
+		actor,
+		extractionMethod,
+		extractedBy,
+	)
+	return err
+}
@@ -37,6 +37,35 @@ func (q *Queries) CreateSchema(ctx context.Context, tagID pgtype.UUID, jsonSchem
 	return i, err
 }
 
+const getEffectiveSchemaVersion = `-- name: GetEffectiveSchemaVersion :one
+WITH RECURSIVE chain AS (
+    SELECT t.id, t.parent_id, t.inherit_schema, 0 AS depth
+    FROM tags t WHERE t.id = $1
+    UNION ALL
+    SELECT p.id, p.parent_id, p.inherit_schema, chain.depth + 1
+    FROM tags p JOIN chain ON p.id = chain.parent_id
+    WHERE chain.inherit_schema
+)
+SELECT COALESCE(string_agg(
+    chain.id::text || ':' || COALESCE(
+        (SELECT MAX(s.version) FROM attribute_schemas s WHERE s.tag_id = chain.id), 0
+    )::text,
+    ',' ORDER BY chain.depth
+), '')::text AS version
+FROM chain
+`
+
+// Identifies the schemas a tag's effective schema is composed of, as the latest schema
+// version of the tag and of each ancestor it inherits from, nearest first. It changes
+// whenever the effective schema may have: on a new schema version anywhere in the chain,
+// and when the tag or an ancestor moves or stops or starts inheriting.
+func (q *Queries) GetEffectiveSchemaVersion(ctx context.Context, tagID pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getEffectiveSchemaVersion, tagID)
+	var version string
+	err := row.Scan(&version)
+	return version, err
+}
+
 const getLatestSchemaByTagID = `-- name: GetLatestSchemaByTagID :one
 SELECT 
     tag_id, 
@@ -95,14 +95,76 @@ func (q *Queries) GetDocumentTagsWithAttributes(ctx context.Context, documentID
 	return items, nil
 }
 
-const removeDocumentTag = `-- name: RemoveDocumentTag :exec
+const listTagsForDocuments = `-- name: ListTagsForDocuments :many
+SELECT dt.document_id, t.path, dt.attributes, dt.attributes_metadata
+FROM document_tags dt
+JOIN tags t ON t.id = dt.tag_id
+WHERE dt.document_id = ANY($1::uuid[])
+ORDER BY dt.document_id, t.path
+`
+
+type ListTagsForDocumentsRow struct {
+	DocumentID         pgtype.UUID `json:"document_id"`
+	Path               string      `json:"path"`
+	Attributes         []byte      `json:"attributes"`
+	AttributesMetadata []byte      `json:"attributes_metadata"`
+}
+
+func (q *Queries) ListTagsForDocuments(ctx context.Context, documentIds []pgtype.UUID) ([]ListTagsForDocumentsRow, error) {
+	rows, err := q.db.Query(ctx, listTagsForDocuments, documentIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTagsForDocumentsRow{}
+	for rows.Next() {
+		var i ListTagsForDocumentsRow
+		if err := rows.Scan(
+			&i.DocumentID,
+			&i.Path,
+			&i.Attributes,
+			&i.AttributesMetadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockDocumentTagAttributes = `-- name: LockDocumentTagAttributes :one
+SELECT attributes, attributes_metadata
+FROM document_tags
+WHERE document_id = $1 AND tag_id = $2
+FOR UPDATE
+`
+
+type LockDocumentTagAttributesRow struct {
+	Attributes         []byte `json:"attributes"`
+	AttributesMetadata []byte `json:"attributes_metadata"`
+}
+
+func (q *Queries) LockDocumentTagAttributes(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID) (LockDocumentTagAttributesRow, error) {
+	row := q.db.QueryRow(ctx, lockDocumentTagAttributes, documentID, tagID)
+	var i LockDocumentTagAttributesRow
+	err := row.Scan(&i.Attributes, &i.AttributesMetadata)
+	return i, err
+}
+
+const removeDocumentTag = `-- name: RemoveDocumentTag :one
 DELETE FROM document_tags
 WHERE document_id = $1 AND tag_id = $2
+RETURNING attributes
 `
 
-func (q *Queries) RemoveDocumentTag(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, removeDocumentTag, documentID, tagID)
-	return err
+func (q *Queries) RemoveDocumentTag(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID) ([]byte, error) {
+	row := q.db.QueryRow(ctx, removeDocumentTag, documentID, tagID)
+	var attributes []byte
+	err := row.Scan(&attributes)
+	return attributes, err
 }
 
 const updateDocumentTagAttributes = `-- name: UpdateDocumentTagAttributes :exec
@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -18,33 +19,39 @@ INSERT INTO documents (
     file_name,
     title,
     mime_type,
+    detected_mime_type,
     checksum_sha256,
     file_size
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING 
     id,
     file_name,
     title,
+    mime_type,
+    detected_mime_type,
     checksum_sha256,
     created_at
 `
 
 type CreateDocumentRow struct {
-	ID             pgtype.UUID        `json:"id"`
-	FileName       string             `json:"file_name"`
-	Title          string             `json:"title"`
-	ChecksumSha256 string             `json:"checksum_sha256"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ID               pgtype.UUID        `json:"id"`
+	FileName         string             `json:"file_name"`
+	Title            string             `json:"title"`
+	MimeType         string             `json:"mime_type"`
+	DetectedMimeType *string            `json:"detected_mime_type"`
+	ChecksumSha256   string             `json:"checksum_sha256"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 }
 
-func (q *Queries) CreateDocument(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID, fileName string, title string, mimeType string, checksumSha256 string, fileSize int64) (CreateDocumentRow, error) {
+func (q *Queries) CreateDocument(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID, fileName string, title string, mimeType string, detectedMimeType *string, checksumSha256 string, fileSize int64) (CreateDocumentRow, error) {
 	row := q.db.QueryRow(ctx, createDocument,
 		iD,
 		namespaceID,
 		fileName,
 		title,
 		mimeType,
+		detectedMimeType,
 		checksumSha256,
 		fileSize,
 	)
@@ -53,6 +60,8 @@ func (q *Queries) CreateDocument(ctx context.Context, iD pgtype.UUID, namespaceI
 		&i.ID,
 		&i.FileName,
 		&i.Title,
+		&i.MimeType,
+		&i.DetectedMimeType,
 		&i.ChecksumSha256,
 		&i.CreatedAt,
 	)
@@ -68,8 +77,35 @@ func (q *Queries) DeleteDocument(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const getDocumentByChecksum = `-- name: GetDocumentByChecksum :one
+SELECT id, namespace_id, file_name, title, document_date, mime_type, checksum_sha256, file_size, page_count, attributes, attributes_version, attributes_metadata, created_at, modified_at, detected_mime_type FROM documents WHERE namespace_id = $1 AND checksum_sha256 = $2
+`
+
+func (q *Queries) GetDocumentByChecksum(ctx context.Context, namespaceID pgtype.UUID, checksumSha256 string) (Document, error) {
+	row := q.db.QueryRow(ctx, getDocumentByChecksum, namespaceID, checksumSha256)
+	var i Document
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.FileName,
+		&i.Title,
+		&i.DocumentDate,
+		&i.MimeType,
+		&i.ChecksumSha256,
+		&i.FileSize,
+		&i.PageCount,
+		&i.Attributes,
+		&i.AttributesVersion,
+		&i.AttributesMetadata,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+		&i.DetectedMimeType,
+	)
+	return i, err
+}
+
 const getDocumentByID = `-- name: GetDocumentByID :one
-SELECT id, namespace_id, file_name, title, document_date, mime_type, checksum_sha256, file_size, page_count, attributes, attributes_version, attributes_metadata, created_at, modified_at FROM documents WHERE id = $1
+SELECT id, namespace_id, file_name, title, document_date, mime_type, checksum_sha256, file_size, page_count, attributes, attributes_version, attributes_metadata, created_at, modified_at, detected_mime_type FROM documents WHERE id = $1
 `
 
 func (q *Queries) GetDocumentByID(ctx context.Context, id pgtype.UUID) (Document, error) {
@@ -90,10 +126,305 @@ func (q *Queries) GetDocumentByID(ctx context.Context, id pgtype.UUID) (Document
 		&i.AttributesMetadata,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.DetectedMimeType,
 	)
 	return i, err
 }
 
+const listDocumentsByIDs = `-- name: ListDocumentsByIDs :many
+SELECT id, namespace_id, file_name, title, document_date, mime_type, checksum_sha256, file_size, page_count, attributes, attributes_version, attributes_metadata, created_at, modified_at, detected_mime_type FROM documents
+WHERE namespace_id = $1 AND id = ANY($2::uuid[])
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListDocumentsByIDs(ctx context.Context, namespaceID pgtype.UUID, documentIds []pgtype.UUID) ([]Document, error) {
+	rows, err := q.db.Query(ctx, listDocumentsByIDs, namespaceID, documentIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Document{}
+	for rows.Next() {
+		var i Document
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.FileName,
+			&i.Title,
+			&i.DocumentDate,
+			&i.MimeType,
+			&i.ChecksumSha256,
+			&i.FileSize,
+			&i.PageCount,
+			&i.Attributes,
+			&i.AttributesVersion,
+			&i.AttributesMetadata,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+			&i.DetectedMimeType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDocumentsByTagPath = `-- name: ListDocumentsByTagPath :many
+SELECT d.id, d.namespace_id, d.file_name, d.title, d.document_date, d.mime_type, d.checksum_sha256, d.file_size, d.page_count, d.attributes, d.attributes_version, d.attributes_metadata, d.created_at, d.modified_at, d.detected_mime_type FROM documents d
+WHERE d.namespace_id = $1
+  AND EXISTS (
+    SELECT 1 FROM document_tags dt
+    JOIN tags t ON t.id = dt.tag_id
+    WHERE dt.document_id = d.id
+      AND (
+        t.path = $2
+        OR ($3::bool AND starts_with(t.path, $2 || '/'))
+      )
+  )
+ORDER BY d.created_at, d.id
+`
+
+// Documents tagged with the given path, or with any descendant of it when include_descendants is set
+func (q *Queries) ListDocumentsByTagPath(ctx context.Context, namespaceID pgtype.UUID, tagPath string, includeDescendants bool) ([]Document, error) {
+	rows, err := q.db.Query(ctx, listDocumentsByTagPath, namespaceID, tagPath, includeDescendants)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Document{}
+	for rows.Next() {
+		var i Document
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.FileName,
+			&i.Title,
+			&i.DocumentDate,
+			&i.MimeType,
+			&i.ChecksumSha256,
+			&i.FileSize,
+			&i.PageCount,
+			&i.Attributes,
+			&i.AttributesVersion,
+			&i.AttributesMetadata,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+			&i.DetectedMimeType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDocumentsWithAttributes = `-- name: ListDocumentsWithAttributes :many
+SELECT d.id, d.file_name, d.title, d.mime_type, d.attributes, d.created_at
+FROM documents d
+WHERE d.namespace_id = $1
+ORDER BY d.created_at, d.id
+`
+
+type ListDocumentsWithAttributesRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	FileName   string             `json:"file_name"`
+	Title      string             `json:"title"`
+	MimeType   string             `json:"mime_type"`
+	Attributes []byte             `json:"attributes"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+// Every document in the namespace with its global attributes, for searches without filters
+func (q *Queries) ListDocumentsWithAttributes(ctx context.Context, namespaceID pgtype.UUID) ([]ListDocumentsWithAttributesRow, error) {
+	rows, err := q.db.Query(ctx, listDocumentsWithAttributes, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDocumentsWithAttributesRow{}
+	for rows.Next() {
+		var i ListDocumentsWithAttributesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FileName,
+			&i.Title,
+			&i.MimeType,
+			&i.Attributes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDocumentsWithTagAttributes = `-- name: ListDocumentsWithTagAttributes :many
+SELECT d.id, d.file_name, d.title, d.mime_type, dt.attributes, d.created_at
+FROM documents d
+JOIN document_tags dt ON dt.document_id = d.id
+WHERE d.namespace_id = $1
+  AND dt.tag_id = $2
+ORDER BY d.created_at, d.id
+`
+
+type ListDocumentsWithTagAttributesRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	FileName   string             `json:"file_name"`
+	Title      string             `json:"title"`
+	MimeType   string             `json:"mime_type"`
+	Attributes []byte             `json:"attributes"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+// Every document with the tag and its attributes for the tag, for searches without filters
+func (q *Queries) ListDocumentsWithTagAttributes(ctx context.Context, namespaceID pgtype.UUID, tagID pgtype.UUID) ([]ListDocumentsWithTagAttributesRow, error) {
+	rows, err := q.db.Query(ctx, listDocumentsWithTagAttributes, namespaceID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDocumentsWithTagAttributesRow{}
+	for rows.Next() {
+		var i ListDocumentsWithTagAttributesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FileName,
+			&i.Title,
+			&i.MimeType,
+			&i.Attributes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockDocumentAttributes = `-- name: LockDocumentAttributes :one
+SELECT attributes, attributes_metadata FROM documents WHERE id = $1 FOR UPDATE
+`
+
+type LockDocumentAttributesRow struct {
+	Attributes         []byte `json:"attributes"`
+	AttributesMetadata []byte `json:"attributes_metadata"`
+}
+
+func (q *Queries) LockDocumentAttributes(ctx context.Context, id pgtype.UUID) (LockDocumentAttributesRow, error) {
+	row := q.db.QueryRow(ctx, lockDocumentAttributes, id)
+	var i LockDocumentAttributesRow
+	err := row.Scan(&i.Attributes, &i.AttributesMetadata)
+	return i, err
+}
+
+const searchDocumentsByAttributes = `-- name: SearchDocumentsByAttributes :many
+SELECT d.id, d.file_name, d.title, d.mime_type, d.attributes, d.created_at
+FROM documents d
+WHERE d.namespace_id = $1
+  AND d.attributes @> $2::jsonb
+ORDER BY d.created_at, d.id
+`
+
+type SearchDocumentsByAttributesRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	FileName   string             `json:"file_name"`
+	Title      string             `json:"title"`
+	MimeType   string             `json:"mime_type"`
+	Attributes []byte             `json:"attributes"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+// Documents whose global attributes contain the containment object, a single @> the GIN
+// index on documents.attributes can answer
+func (q *Queries) SearchDocumentsByAttributes(ctx context.Context, namespaceID pgtype.UUID, containment json.RawMessage) ([]SearchDocumentsByAttributesRow, error) {
+	rows, err := q.db.Query(ctx, searchDocumentsByAttributes, namespaceID, containment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchDocumentsByAttributesRow{}
+	for rows.Next() {
+		var i SearchDocumentsByAttributesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FileName,
+			&i.Title,
+			&i.MimeType,
+			&i.Attributes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchDocumentsByTagAttributes = `-- name: SearchDocumentsByTagAttributes :many
+SELECT d.id, d.file_name, d.title, d.mime_type, dt.attributes, d.created_at
+FROM documents d
+JOIN document_tags dt ON dt.document_id = d.id
+WHERE d.namespace_id = $1
+  AND dt.tag_id = $2
+  AND dt.attributes @> $3::jsonb
+ORDER BY d.created_at, d.id
+`
+
+type SearchDocumentsByTagAttributesRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	FileName   string             `json:"file_name"`
+	Title      string             `json:"title"`
+	MimeType   string             `json:"mime_type"`
+	Attributes []byte             `json:"attributes"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+// Documents whose attributes for the tag contain the containment object, a single @> the GIN
+// index on document_tags.attributes can answer
+func (q *Queries) SearchDocumentsByTagAttributes(ctx context.Context, namespaceID pgtype.UUID, tagID pgtype.UUID, containment json.RawMessage) ([]SearchDocumentsByTagAttributesRow, error) {
+	rows, err := q.db.Query(ctx, searchDocumentsByTagAttributes, namespaceID, tagID, containment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchDocumentsByTagAttributesRow{}
+	for rows.Next() {
+		var i SearchDocumentsByTagAttributesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FileName,
+			&i.Title,
+			&i.MimeType,
+			&i.Attributes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateDocument = `-- name: UpdateDocument :one
 UPDATE documents SET
     file_name = COALESCE($2, file_name),
@@ -105,7 +436,7 @@ UPDATE documents SET
     attributes_metadata = COALESCE($8, attributes_metadata),
     modified_at = NOW()
 WHERE id = $1
-RETURNING id, namespace_id, file_name, title, document_date, mime_type, checksum_sha256, file_size, page_count, attributes, attributes_version, attributes_metadata, created_at, modified_at
+RETURNING id, namespace_id, file_name, title, document_date, mime_type, checksum_sha256, file_size, page_count, attributes, attributes_version, attributes_metadata, created_at, modified_at, detected_mime_type
 `
 
 func (q *Queries) UpdateDocument(ctx context.Context, iD pgtype.UUID, fileName string, title string, documentDate pgtype.Date, mimeType string, fileSize int64, attributes []byte, attributesMetadata []byte) (Document, error) {
@@ -135,6 +466,7 @@ func (q *Queries) UpdateDocument(ctx context.Context, iD pgtype.UUID, fileName s
 		&i.AttributesMetadata,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.DetectedMimeType,
 	)
 	return i, err
 }
@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: event-outbox.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+SELECT id, subject, payload, attempts, last_error, next_attempt_at, parked_at, sent_at, created_at FROM event_outbox
+WHERE sent_at IS NULL AND parked_at IS NULL AND next_attempt_at <= NOW()
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+// Locks the oldest unsent events that are due; SKIP LOCKED lets several relays share the
+// outbox
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, limit int32) ([]EventOutbox, error) {
+	rows, err := q.db.Query(ctx, claimOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EventOutbox{}
+	for rows.Next() {
+		var i EventOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Subject,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.ParkedAt,
+			&i.SentAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteSentOutboxEvents = `-- name: DeleteSentOutboxEvents :execrows
+DELETE FROM event_outbox WHERE sent_at < $1
+`
+
+func (q *Queries) DeleteSentOutboxEvents(ctx context.Context, sentAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSentOutboxEvents, sentAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertOutboxEvent = `-- name: InsertOutboxEvent :exec
+INSERT INTO event_outbox (subject, payload) VALUES ($1, $2)
+`
+
+func (q *Queries) InsertOutboxEvent(ctx context.Context, subject string, payload []byte) error {
+	_, err := q.db.Exec(ctx, insertOutboxEvent, subject, payload)
+	return err
+}
+
+const markOutboxEventFailed = `-- name: MarkOutboxEventFailed :exec
+UPDATE event_outbox SET
+    attempts = attempts + 1,
+    last_error = $1,
+    next_attempt_at = $2
+WHERE id = $3
+`
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, errorMessage *string, nextAttemptAt pgtype.Timestamptz, iD int64) error {
+	_, err := q.db.Exec(ctx, markOutboxEventFailed, errorMessage, nextAttemptAt, iD)
+	return err
+}
+
+const markOutboxEventSent = `-- name: MarkOutboxEventSent :exec
+UPDATE event_outbox SET
+    attempts = attempts + 1,
+    last_error = NULL,
+    sent_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventSent(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markOutboxEventSent, id)
+	return err
+}
+
+const parkOutboxEvent = `-- name: ParkOutboxEvent :exec
+UPDATE event_outbox SET
+    attempts = attempts + 1,
+    last_error = $1,
+    parked_at = NOW()
+WHERE id = $2
+`
+
+// Gives up on an event whose attempts are exhausted
+func (q *Queries) ParkOutboxEvent(ctx context.Context, errorMessage *string, iD int64) error {
+	_, err := q.db.Exec(ctx, parkOutboxEvent, errorMessage, iD)
+	return err
+}
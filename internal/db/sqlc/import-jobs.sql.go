@@ -0,0 +1,293 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: import-jobs.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimImportJob = `-- name: ClaimImportJob :one
+UPDATE import_jobs SET
+    status = 'running',
+    started_at = COALESCE(started_at, NOW()),
+    modified_at = NOW()
+WHERE id = (
+    SELECT id FROM import_jobs
+    WHERE status = 'pending'
+    ORDER BY created_at
+    FOR UPDATE SKIP LOCKED
+    LIMIT 1
+)
+RETURNING id, namespace_id, file_name, format, tag_path, archive_size, status, bytes_processed, total_entries, processed_entries, created_count, duplicate_count, skipped_count, failed_count, error, created_at, modified_at, started_at, completed_at
+`
+
+// Claims the oldest pending job; SKIP LOCKED lets several workers share the queue
+func (q *Queries) ClaimImportJob(ctx context.Context) (ImportJob, error) {
+	row := q.db.QueryRow(ctx, claimImportJob)
+	var i ImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.FileName,
+		&i.Format,
+		&i.TagPath,
+		&i.ArchiveSize,
+		&i.Status,
+		&i.BytesProcessed,
+		&i.TotalEntries,
+		&i.ProcessedEntries,
+		&i.CreatedCount,
+		&i.DuplicateCount,
+		&i.SkippedCount,
+		&i.FailedCount,
+		&i.Error,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const createImportJob = `-- name: CreateImportJob :one
+INSERT INTO import_jobs (
+    id,
+    namespace_id,
+    file_name,
+    format,
+    tag_path,
+    archive_size,
+    total_entries
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, namespace_id, file_name, format, tag_path, archive_size, status, bytes_processed, total_entries, processed_entries, created_count, duplicate_count, skipped_count, failed_count, error, created_at, modified_at, started_at, completed_at
+`
+
+func (q *Queries) CreateImportJob(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID, fileName string, format string, tagPath *string, archiveSize int64, totalEntries *int32) (ImportJob, error) {
+	row := q.db.QueryRow(ctx, createImportJob,
+		iD,
+		namespaceID,
+		fileName,
+		format,
+		tagPath,
+		archiveSize,
+		totalEntries,
+	)
+	var i ImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.FileName,
+		&i.Format,
+		&i.TagPath,
+		&i.ArchiveSize,
+		&i.Status,
+		&i.BytesProcessed,
+		&i.TotalEntries,
+		&i.ProcessedEntries,
+		&i.CreatedCount,
+		&i.DuplicateCount,
+		&i.SkippedCount,
+		&i.FailedCount,
+		&i.Error,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const finishImportJob = `-- name: FinishImportJob :exec
+UPDATE import_jobs SET
+    status = $1,
+    error = $2,
+    total_entries = COALESCE(total_entries, processed_entries),
+    bytes_processed = CASE
+        WHEN $1 = 'completed' THEN archive_size
+        ELSE bytes_processed
+    END,
+    completed_at = NOW(),
+    modified_at = NOW()
+WHERE id = $3
+`
+
+func (q *Queries) FinishImportJob(ctx context.Context, status string, errorMessage *string, iD pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, finishImportJob, status, errorMessage, iD)
+	return err
+}
+
+const getImportJob = `-- name: GetImportJob :one
+SELECT id, namespace_id, file_name, format, tag_path, archive_size, status, bytes_processed, total_entries, processed_entries, created_count, duplicate_count, skipped_count, failed_count, error, created_at, modified_at, started_at, completed_at FROM import_jobs WHERE id = $1
+`
+
+func (q *Queries) GetImportJob(ctx context.Context, id pgtype.UUID) (ImportJob, error) {
+	row := q.db.QueryRow(ctx, getImportJob, id)
+	var i ImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.FileName,
+		&i.Format,
+		&i.TagPath,
+		&i.ArchiveSize,
+		&i.Status,
+		&i.BytesProcessed,
+		&i.TotalEntries,
+		&i.ProcessedEntries,
+		&i.CreatedCount,
+		&i.DuplicateCount,
+		&i.SkippedCount,
+		&i.FailedCount,
+		&i.Error,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const listImportEntryPaths = `-- name: ListImportEntryPaths :many
+SELECT path FROM import_job_entries WHERE job_id = $1
+`
+
+func (q *Queries) ListImportEntryPaths(ctx context.Context, jobID pgtype.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, listImportEntryPaths, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		items = append(items, path)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listImportJobEntries = `-- name: ListImportJobEntries :many
+SELECT job_id, path, status, document_id, tag_path, error, created_at FROM import_job_entries
+WHERE job_id = $1
+ORDER BY created_at, path
+LIMIT $2 OFFSET $3
+`
+
+func (q *Queries) ListImportJobEntries(ctx context.Context, jobID pgtype.UUID, limit int32, offset int32) ([]ImportJobEntry, error) {
+	rows, err := q.db.Query(ctx, listImportJobEntries, jobID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ImportJobEntry{}
+	for rows.Next() {
+		var i ImportJobEntry
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Path,
+			&i.Status,
+			&i.DocumentID,
+			&i.TagPath,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordImportEntry = `-- name: RecordImportEntry :exec
+WITH entry AS (
+    INSERT INTO import_job_entries (job_id, path, status, document_id, tag_path, error)
+    VALUES (
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7
+    )
+    ON CONFLICT (job_id, path) DO NOTHING
+    RETURNING status
+)
+UPDATE import_jobs SET
+    processed_entries = processed_entries + (SELECT COUNT(*) FROM entry),
+    created_count = created_count + (SELECT COUNT(*) FROM entry WHERE entry.status = 'created'),
+    duplicate_count = duplicate_count + (SELECT COUNT(*) FROM entry WHERE entry.status = 'duplicate'),
+    skipped_count = skipped_count + (SELECT COUNT(*) FROM entry WHERE entry.status = 'skipped'),
+    failed_count = failed_count + (SELECT COUNT(*) FROM entry WHERE entry.status = 'failed'),
+    bytes_processed = $1,
+    modified_at = NOW()
+WHERE id = $2
+`
+
+// Records an entry result and updates the job counters in one statement. Entries that
+// were already recorded (e.g. when a requeued job is resumed) are not counted twice.
+func (q *Queries) RecordImportEntry(ctx context.Context, bytesProcessed int64, jobID pgtype.UUID, path string, status string, documentID pgtype.UUID, tagPath *string, errorMessage *string) error {
+	_, err := q.db.Exec(ctx, recordImportEntry,
+		bytesProcessed,
+		jobID,
+		path,
+		status,
+		documentID,
+		tagPath,
+		errorMessage,
+	)
+	return err
+}
+
+const requeueStaleImportJobs = `-- name: RequeueStaleImportJobs :execrows
+UPDATE import_jobs SET
+    status = 'pending',
+    modified_at = NOW()
+WHERE status = 'running' AND modified_at < $1
+`
+
+// Returns running jobs whose worker stopped reporting progress to the queue
+func (q *Queries) RequeueStaleImportJobs(ctx context.Context, modifiedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueStaleImportJobs, modifiedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setImportJobTotal = `-- name: SetImportJobTotal :exec
+UPDATE import_jobs SET
+    total_entries = $2,
+    modified_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) SetImportJobTotal(ctx context.Context, iD pgtype.UUID, totalEntries *int32) error {
+	_, err := q.db.Exec(ctx, setImportJobTotal, iD, totalEntries)
+	return err
+}
+
+const touchImportJob = `-- name: TouchImportJob :exec
+UPDATE import_jobs SET
+    bytes_processed = $2,
+    modified_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) TouchImportJob(ctx context.Context, iD pgtype.UUID, bytesProcessed int64) error {
+	_, err := q.db.Exec(ctx, touchImportJob, iD, bytesProcessed)
+	return err
+}
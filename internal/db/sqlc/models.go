@@ -10,6 +10,20 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AttributeHistory struct {
+	ID               int64              `json:"id"`
+	DocumentID       pgtype.UUID        `json:"document_id"`
+	TagID            pgtype.UUID        `json:"tag_id"`
+	Field            string             `json:"field"`
+	OldValue         []byte             `json:"old_value"`
+	NewValue         []byte             `json:"new_value"`
+	SchemaVersion    *int64             `json:"schema_version"`
+	Actor            string             `json:"actor"`
+	ExtractionMethod string             `json:"extraction_method"`
+	ExtractedBy      string             `json:"extracted_by"`
+	ChangedAt        pgtype.Timestamptz `json:"changed_at"`
+}
+
 type AttributeSchema struct {
 	TagID      pgtype.UUID        `json:"tag_id"`
 	Version    int64              `json:"version"`
@@ -32,6 +46,7 @@ type Document struct {
 	AttributesMetadata []byte             `json:"attributes_metadata"`
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	ModifiedAt         pgtype.Timestamptz `json:"modified_at"`
+	DetectedMimeType   *string            `json:"detected_mime_type"`
 }
 
 type DocumentTag struct {
@@ -44,21 +59,136 @@ type DocumentTag struct {
 	ModifiedAt         pgtype.Timestamptz `json:"modified_at"`
 }
 
-type Namespace struct {
-	ID         pgtype.UUID        `json:"id"`
-	Name       string             `json:"name"`
+type EventOutbox struct {
+	ID            int64              `json:"id"`
+	Subject       string             `json:"subject"`
+	Payload       []byte             `json:"payload"`
+	Attempts      int32              `json:"attempts"`
+	LastError     *string            `json:"last_error"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	ParkedAt      pgtype.Timestamptz `json:"parked_at"`
+	SentAt        pgtype.Timestamptz `json:"sent_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type ImportJob struct {
+	ID               pgtype.UUID        `json:"id"`
+	NamespaceID      pgtype.UUID        `json:"namespace_id"`
+	FileName         string             `json:"file_name"`
+	Format           string             `json:"format"`
+	TagPath          *string            `json:"tag_path"`
+	ArchiveSize      int64              `json:"archive_size"`
+	Status           string             `json:"status"`
+	BytesProcessed   int64              `json:"bytes_processed"`
+	TotalEntries     *int32             `json:"total_entries"`
+	ProcessedEntries int32              `json:"processed_entries"`
+	CreatedCount     int32              `json:"created_count"`
+	DuplicateCount   int32              `json:"duplicate_count"`
+	SkippedCount     int32              `json:"skipped_count"`
+	FailedCount      int32              `json:"failed_count"`
+	Error            *string            `json:"error"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	ModifiedAt       pgtype.Timestamptz `json:"modified_at"`
+	StartedAt        pgtype.Timestamptz `json:"started_at"`
+	CompletedAt      pgtype.Timestamptz `json:"completed_at"`
+}
+
+type ImportJobEntry struct {
+	JobID      pgtype.UUID        `json:"job_id"`
+	Path       string             `json:"path"`
+	Status     string             `json:"status"`
+	DocumentID pgtype.UUID        `json:"document_id"`
+	TagPath    *string            `json:"tag_path"`
+	Error      *string            `json:"error"`
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
-	ModifiedAt pgtype.Timestamptz `json:"modified_at"`
+}
+
+type Namespace struct {
+	ID              pgtype.UUID        `json:"id"`
+	Name            string             `json:"name"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	ModifiedAt      pgtype.Timestamptz `json:"modified_at"`
+	ReviewThreshold *float64           `json:"review_threshold"`
+}
+
+type ReviewItem struct {
+	ID          pgtype.UUID        `json:"id"`
+	NamespaceID pgtype.UUID        `json:"namespace_id"`
+	DocumentID  pgtype.UUID        `json:"document_id"`
+	TagID       pgtype.UUID        `json:"tag_id"`
+	Attributes  []byte             `json:"attributes"`
+	Confidence  float64            `json:"confidence"`
+	ExtractedBy string             `json:"extracted_by"`
+	Status      string             `json:"status"`
+	ReviewedBy  *string            `json:"reviewed_by"`
+	ReviewedAt  pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ModifiedAt  pgtype.Timestamptz `json:"modified_at"`
 }
 
 type Tag struct {
+	ID                  pgtype.UUID        `json:"id"`
+	NamespaceID         pgtype.UUID        `json:"namespace_id"`
+	Name                string             `json:"name"`
+	Description         *string            `json:"description"`
+	Path                string             `json:"path"`
+	ParentID            pgtype.UUID        `json:"parent_id"`
+	Color               *string            `json:"color"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	ModifiedAt          pgtype.Timestamptz `json:"modified_at"`
+	InheritSchema       bool               `json:"inherit_schema"`
+	SchemaCompatibility string             `json:"schema_compatibility"`
+}
+
+type TaggingRule struct {
 	ID          pgtype.UUID        `json:"id"`
 	NamespaceID pgtype.UUID        `json:"namespace_id"`
 	Name        string             `json:"name"`
-	Description *string            `json:"description"`
-	Path        string             `json:"path"`
-	ParentID    pgtype.UUID        `json:"parent_id"`
-	Color       *string            `json:"color"`
+	Enabled     bool               `json:"enabled"`
+	Priority    int32              `json:"priority"`
+	Conditions  json.RawMessage    `json:"conditions"`
+	Action      json.RawMessage    `json:"action"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ModifiedAt  pgtype.Timestamptz `json:"modified_at"`
+}
+
+type UploadSession struct {
+	ID           pgtype.UUID        `json:"id"`
+	NamespaceID  pgtype.UUID        `json:"namespace_id"`
+	FileName     string             `json:"file_name"`
+	MimeType     string             `json:"mime_type"`
+	UploadLength int64              `json:"upload_length"`
+	Metadata     []byte             `json:"metadata"`
+	UploadOffset int64              `json:"upload_offset"`
+	ChunkNames   []string           `json:"chunk_names"`
+	HashState    []byte             `json:"hash_state"`
+	DocumentID   pgtype.UUID        `json:"document_id"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	ModifiedAt   pgtype.Timestamptz `json:"modified_at"`
+}
+
+type Webhook struct {
+	ID          pgtype.UUID        `json:"id"`
+	NamespaceID pgtype.UUID        `json:"namespace_id"`
+	Url         string             `json:"url"`
+	EventTypes  []string           `json:"event_types"`
+	Secret      string             `json:"secret"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	ModifiedAt  pgtype.Timestamptz `json:"modified_at"`
 }
+
+type WebhookDelivery struct {
+	ID             pgtype.UUID        `json:"id"`
+	WebhookID      pgtype.UUID        `json:"webhook_id"`
+	EventID        string             `json:"event_id"`
+	EventType      string             `json:"event_type"`
+	Payload        json.RawMessage    `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	ResponseStatus *int32             `json:"response_status"`
+	LastError      *string            `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
@@ -7,10 +7,12 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createNamespace = `-- name: CreateNamespace :one
-INSERT INTO namespaces (name) VALUES ($1) RETURNING id, name, created_at, modified_at
+INSERT INTO namespaces (name) VALUES ($1) RETURNING id, name, created_at, modified_at, review_threshold
 `
 
 func (q *Queries) CreateNamespace(ctx context.Context, name string) (Namespace, error) {
@@ -21,6 +23,7 @@ func (q *Queries) CreateNamespace(ctx context.Context, name string) (Namespace,
 		&i.Name,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.ReviewThreshold,
 	)
 	return i, err
 }
@@ -34,8 +37,25 @@ func (q *Queries) DeleteNamespace(ctx context.Context, name string) error {
 	return err
 }
 
+const getNamespaceByID = `-- name: GetNamespaceByID :one
+SELECT id, name, created_at, modified_at, review_threshold FROM namespaces WHERE id = $1
+`
+
+func (q *Queries) GetNamespaceByID(ctx context.Context, id pgtype.UUID) (Namespace, error) {
+	row := q.db.QueryRow(ctx, getNamespaceByID, id)
+	var i Namespace
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+		&i.ReviewThreshold,
+	)
+	return i, err
+}
+
 const getNamespaceByName = `-- name: GetNamespaceByName :one
-SELECT id, name, created_at, modified_at FROM namespaces WHERE name = $1
+SELECT id, name, created_at, modified_at, review_threshold FROM namespaces WHERE name = $1
 `
 
 func (q *Queries) GetNamespaceByName(ctx context.Context, name string) (Namespace, error) {
@@ -46,12 +66,13 @@ func (q *Queries) GetNamespaceByName(ctx context.Context, name string) (Namespac
 		&i.Name,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.ReviewThreshold,
 	)
 	return i, err
 }
 
 const getNamespaces = `-- name: GetNamespaces :many
-SELECT id, name, created_at, modified_at FROM namespaces ORDER BY created_at DESC
+SELECT id, name, created_at, modified_at, review_threshold FROM namespaces ORDER BY created_at DESC
 `
 
 func (q *Queries) GetNamespaces(ctx context.Context) ([]Namespace, error) {
@@ -68,6 +89,7 @@ func (q *Queries) GetNamespaces(ctx context.Context) ([]Namespace, error) {
 			&i.Name,
 			&i.CreatedAt,
 			&i.ModifiedAt,
+			&i.ReviewThreshold,
 		); err != nil {
 			return nil, err
 		}
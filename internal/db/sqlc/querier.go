@@ -13,29 +13,118 @@ import (
 
 type Querier interface {
 	AddDocumentTag(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID, attributes []byte, attributesMetadata []byte) error
-	CreateDocument(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID, fileName string, title string, mimeType string, checksumSha256 string, fileSize int64) (CreateDocumentRow, error)
+	AdvanceUploadSession(ctx context.Context, newOffset int64, chunkName string, hashState []byte, expiresAt pgtype.Timestamptz, iD pgtype.UUID, expectedOffset int64) (UploadSession, error)
+	// Claims the oldest pending job; SKIP LOCKED lets several workers share the queue
+	ClaimImportJob(ctx context.Context) (ImportJob, error)
+	// Locks the oldest unsent events that are due; SKIP LOCKED lets several relays share the
+	// outbox
+	ClaimOutboxEvents(ctx context.Context, limit int32) ([]EventOutbox, error)
+	// Claims due deliveries by pushing their next attempt out to lease_until, so a worker
+	// that dies mid-delivery leaves them to be retried once the lease expires. SKIP LOCKED
+	// lets several workers share the queue.
+	ClaimWebhookDeliveries(ctx context.Context, leaseUntil pgtype.Timestamptz, batchSize int32) ([]ClaimWebhookDeliveriesRow, error)
+	CompleteUploadSession(ctx context.Context, iD pgtype.UUID, documentID pgtype.UUID) error
+	CreateDocument(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID, fileName string, title string, mimeType string, detectedMimeType *string, checksumSha256 string, fileSize int64) (CreateDocumentRow, error)
+	CreateImportJob(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID, fileName string, format string, tagPath *string, archiveSize int64, totalEntries *int32) (ImportJob, error)
 	CreateNamespace(ctx context.Context, name string) (Namespace, error)
 	CreateSchema(ctx context.Context, tagID pgtype.UUID, jsonSchema json.RawMessage) (AttributeSchema, error)
-	CreateTag(ctx context.Context, namespaceID pgtype.UUID, name string, description *string, path string, parentID pgtype.UUID, color *string) (Tag, error)
+	CreateTag(ctx context.Context, namespaceID pgtype.UUID, name string, description *string, path string, parentID pgtype.UUID, color *string, inheritSchema bool, schemaCompatibility string) (Tag, error)
+	CreateTaggingRule(ctx context.Context, namespaceID pgtype.UUID, name string, enabled bool, priority int32, conditions json.RawMessage, action json.RawMessage) (TaggingRule, error)
+	CreateUploadSession(ctx context.Context, namespaceID pgtype.UUID, fileName string, mimeType string, uploadLength int64, metadata []byte, expiresAt pgtype.Timestamptz) (UploadSession, error)
+	CreateWebhook(ctx context.Context, namespaceID pgtype.UUID, url string, eventTypes []string, secret string) (Webhook, error)
 	DeleteDocument(ctx context.Context, id pgtype.UUID) error
 	DeleteNamespace(ctx context.Context, name string) error
+	DeleteSentOutboxEvents(ctx context.Context, sentAt pgtype.Timestamptz) (int64, error)
 	DeleteTag(ctx context.Context, id pgtype.UUID) error
+	DeleteTaggingRule(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID) (int64, error)
+	DeleteUploadSession(ctx context.Context, id pgtype.UUID) error
+	DeleteWebhook(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID) (int64, error)
+	FinishImportJob(ctx context.Context, status string, errorMessage *string, iD pgtype.UUID) error
+	GetDocumentByChecksum(ctx context.Context, namespaceID pgtype.UUID, checksumSha256 string) (Document, error)
 	GetDocumentByID(ctx context.Context, id pgtype.UUID) (Document, error)
 	//--------- Tag-specific attributes -----------
 	GetDocumentTagAttributes(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID) (GetDocumentTagAttributesRow, error)
 	GetDocumentTagsWithAttributes(ctx context.Context, documentID pgtype.UUID) ([]GetDocumentTagsWithAttributesRow, error)
+	// Identifies the schemas a tag's effective schema is composed of, as the latest schema
+	// version of the tag and of each ancestor it inherits from, nearest first. It changes
+	// whenever the effective schema may have: on a new schema version anywhere in the chain,
+	// and when the tag or an ancestor moves or stops or starts inheriting.
+	GetEffectiveSchemaVersion(ctx context.Context, tagID pgtype.UUID) (string, error)
+	GetImportJob(ctx context.Context, id pgtype.UUID) (ImportJob, error)
 	GetLatestSchemaByTagID(ctx context.Context, tagID pgtype.UUID) (AttributeSchema, error)
+	GetNamespaceByID(ctx context.Context, id pgtype.UUID) (Namespace, error)
 	GetNamespaceByName(ctx context.Context, name string) (Namespace, error)
 	GetNamespaces(ctx context.Context) ([]Namespace, error)
+	GetReviewItem(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID) (GetReviewItemRow, error)
 	GetTagByID(ctx context.Context, id pgtype.UUID) (Tag, error)
 	GetTagByName(ctx context.Context, namespaceID pgtype.UUID, name string) (Tag, error)
 	GetTagByPath(ctx context.Context, namespaceID pgtype.UUID, path string) (Tag, error)
+	GetTaggingRule(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID) (TaggingRule, error)
 	GetTagsByNamespace(ctx context.Context, namespaceID pgtype.UUID) ([]Tag, error)
-	RemoveDocumentTag(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID) error
+	GetUploadSession(ctx context.Context, id pgtype.UUID) (UploadSession, error)
+	GetWebhook(ctx context.Context, id pgtype.UUID) (Webhook, error)
+	GetWebhookDelivery(ctx context.Context, id pgtype.UUID) (WebhookDelivery, error)
+	InsertOutboxEvent(ctx context.Context, subject string, payload []byte) error
+	// Newest first. A NULL tag_id lists the global attributes' history; a NULL field lists every field.
+	ListAttributeHistory(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID, field *string) ([]AttributeHistory, error)
+	ListDocumentsByIDs(ctx context.Context, namespaceID pgtype.UUID, documentIds []pgtype.UUID) ([]Document, error)
+	// Documents tagged with the given path, or with any descendant of it when include_descendants is set
+	ListDocumentsByTagPath(ctx context.Context, namespaceID pgtype.UUID, tagPath string, includeDescendants bool) ([]Document, error)
+	// Every document in the namespace with its global attributes, for searches without filters
+	ListDocumentsWithAttributes(ctx context.Context, namespaceID pgtype.UUID) ([]ListDocumentsWithAttributesRow, error)
+	// Every document with the tag and its attributes for the tag, for searches without filters
+	ListDocumentsWithTagAttributes(ctx context.Context, namespaceID pgtype.UUID, tagID pgtype.UUID) ([]ListDocumentsWithTagAttributesRow, error)
+	ListEnabledTaggingRules(ctx context.Context, namespaceID pgtype.UUID) ([]TaggingRule, error)
+	ListExpiredUploadSessions(ctx context.Context) ([]UploadSession, error)
+	ListImportEntryPaths(ctx context.Context, jobID pgtype.UUID) ([]string, error)
+	ListImportJobEntries(ctx context.Context, jobID pgtype.UUID, limit int32, offset int32) ([]ImportJobEntry, error)
+	ListPendingReviewItems(ctx context.Context, namespaceID pgtype.UUID) ([]ListPendingReviewItemsRow, error)
+	// Every tag below the given one, following parent links
+	ListTagDescendants(ctx context.Context, tagID pgtype.UUID) ([]Tag, error)
+	ListTaggingRules(ctx context.Context, namespaceID pgtype.UUID) ([]TaggingRule, error)
+	ListTagsForDocuments(ctx context.Context, documentIds []pgtype.UUID) ([]ListTagsForDocumentsRow, error)
+	ListWebhookDeliveries(ctx context.Context, webhookID pgtype.UUID, status *string, rowOffset int32, rowLimit int32) ([]WebhookDelivery, error)
+	ListWebhooks(ctx context.Context, namespaceID pgtype.UUID) ([]Webhook, error)
+	// Webhooks in the namespace subscribed to the event type, or to every event
+	ListWebhooksForEvent(ctx context.Context, namespaceID pgtype.UUID, eventType string) ([]Webhook, error)
+	LockDocumentAttributes(ctx context.Context, id pgtype.UUID) (LockDocumentAttributesRow, error)
+	LockDocumentTagAttributes(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID) (LockDocumentTagAttributesRow, error)
+	MarkOutboxEventFailed(ctx context.Context, errorMessage *string, nextAttemptAt pgtype.Timestamptz, iD int64) error
+	MarkOutboxEventSent(ctx context.Context, id int64) error
+	// Records a failed attempt. The delivery is retried at next_attempt_at, or dead-lettered
+	// when status is 'dead'.
+	MarkWebhookDeliveryFailed(ctx context.Context, status string, responseStatus *int32, errorMessage *string, nextAttemptAt pgtype.Timestamptz, iD pgtype.UUID) error
+	MarkWebhookDeliverySucceeded(ctx context.Context, responseStatus *int32, iD pgtype.UUID) error
+	// Gives up on an event whose attempts are exhausted
+	ParkOutboxEvent(ctx context.Context, errorMessage *string, iD int64) error
+	QueueReviewItem(ctx context.Context, namespaceID pgtype.UUID, documentID pgtype.UUID, tagID pgtype.UUID, attributes []byte, confidence float64, extractedBy string) (pgtype.UUID, error)
+	// Events redelivered by JetStream are only queued once per webhook
+	QueueWebhookDelivery(ctx context.Context, webhookID pgtype.UUID, eventID string, eventType string, payload json.RawMessage) error
+	// The schema version is the latest one of the tag, or of the global schema for global attributes
+	RecordAttributeChange(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID, field string, oldValue []byte, newValue []byte, actor string, extractionMethod string, extractedBy string) error
+	// Records an entry result and updates the job counters in one statement. Entries that
+	// were already recorded (e.g. when a requeued job is resumed) are not counted twice.
+	RecordImportEntry(ctx context.Context, bytesProcessed int64, jobID pgtype.UUID, path string, status string, documentID pgtype.UUID, tagPath *string, errorMessage *string) error
+	RemoveDocumentTag(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID) ([]byte, error)
+	// Returns running jobs whose worker stopped reporting progress to the queue
+	RequeueStaleImportJobs(ctx context.Context, modifiedAt pgtype.Timestamptz) (int64, error)
+	// Gives a dead-lettered delivery a fresh set of attempts
+	RequeueWebhookDelivery(ctx context.Context, id pgtype.UUID) (WebhookDelivery, error)
+	ResolveReviewItem(ctx context.Context, iD pgtype.UUID, status string, reviewedBy *string) (int64, error)
+	// Documents whose global attributes contain the containment object, a single @> the GIN
+	// index on documents.attributes can answer
+	SearchDocumentsByAttributes(ctx context.Context, namespaceID pgtype.UUID, containment json.RawMessage) ([]SearchDocumentsByAttributesRow, error)
+	// Documents whose attributes for the tag contain the containment object, a single @> the GIN
+	// index on document_tags.attributes can answer
+	SearchDocumentsByTagAttributes(ctx context.Context, namespaceID pgtype.UUID, tagID pgtype.UUID, containment json.RawMessage) ([]SearchDocumentsByTagAttributesRow, error)
+	SetImportJobTotal(ctx context.Context, iD pgtype.UUID, totalEntries *int32) error
+	SetNamespaceReviewThreshold(ctx context.Context, name string, reviewThreshold *float64) (Namespace, error)
+	TouchImportJob(ctx context.Context, iD pgtype.UUID, bytesProcessed int64) error
 	UpdateDocument(ctx context.Context, iD pgtype.UUID, fileName string, title string, documentDate pgtype.Date, mimeType string, fileSize int64, attributes []byte, attributesMetadata []byte) (Document, error)
 	UpdateDocumentAttributes(ctx context.Context, iD pgtype.UUID, attributes []byte, attributesMetadata []byte) error
 	UpdateDocumentTagAttributes(ctx context.Context, documentID pgtype.UUID, tagID pgtype.UUID, attributes []byte, attributesMetadata []byte) error
-	UpdateTag(ctx context.Context, iD pgtype.UUID, name string, description *string, path string, parentID pgtype.UUID, color *string) (Tag, error)
+	UpdateTag(ctx context.Context, iD pgtype.UUID, name string, description *string, path string, parentID pgtype.UUID, color *string, inheritSchema bool, schemaCompatibility string) (Tag, error)
+	UpdateTaggingRule(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID, name string, enabled bool, priority int32, conditions json.RawMessage, action json.RawMessage) (TaggingRule, error)
 }
 
 var _ Querier = (*Queries)(nil)
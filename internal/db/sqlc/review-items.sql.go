@@ -0,0 +1,173 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: review-items.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getReviewItem = `-- name: GetReviewItem :one
+SELECT ri.id, ri.namespace_id, ri.document_id, ri.tag_id, ri.attributes, ri.confidence, ri.extracted_by, ri.status, ri.reviewed_by, ri.reviewed_at, ri.created_at, ri.modified_at, t.path AS tag_path
+FROM review_items ri
+JOIN tags t ON t.id = ri.tag_id
+WHERE ri.id = $1 AND ri.namespace_id = $2
+`
+
+type GetReviewItemRow struct {
+	ID          pgtype.UUID        `json:"id"`
+	NamespaceID pgtype.UUID        `json:"namespace_id"`
+	DocumentID  pgtype.UUID        `json:"document_id"`
+	TagID       pgtype.UUID        `json:"tag_id"`
+	Attributes  []byte             `json:"attributes"`
+	Confidence  float64            `json:"confidence"`
+	ExtractedBy string             `json:"extracted_by"`
+	Status      string             `json:"status"`
+	ReviewedBy  *string            `json:"reviewed_by"`
+	ReviewedAt  pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ModifiedAt  pgtype.Timestamptz `json:"modified_at"`
+	TagPath     string             `json:"tag_path"`
+}
+
+func (q *Queries) GetReviewItem(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID) (GetReviewItemRow, error) {
+	row := q.db.QueryRow(ctx, getReviewItem, iD, namespaceID)
+	var i GetReviewItemRow
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.DocumentID,
+		&i.TagID,
+		&i.Attributes,
+		&i.Confidence,
+		&i.ExtractedBy,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+		&i.TagPath,
+	)
+	return i, err
+}
+
+const listPendingReviewItems = `-- name: ListPendingReviewItems :many
+SELECT ri.id, ri.namespace_id, ri.document_id, ri.tag_id, ri.attributes, ri.confidence, ri.extracted_by, ri.status, ri.reviewed_by, ri.reviewed_at, ri.created_at, ri.modified_at, t.path AS tag_path
+FROM review_items ri
+JOIN tags t ON t.id = ri.tag_id
+WHERE ri.namespace_id = $1 AND ri.status = 'pending'
+ORDER BY ri.created_at, ri.id
+`
+
+type ListPendingReviewItemsRow struct {
+	ID          pgtype.UUID        `json:"id"`
+	NamespaceID pgtype.UUID        `json:"namespace_id"`
+	DocumentID  pgtype.UUID        `json:"document_id"`
+	TagID       pgtype.UUID        `json:"tag_id"`
+	Attributes  []byte             `json:"attributes"`
+	Confidence  float64            `json:"confidence"`
+	ExtractedBy string             `json:"extracted_by"`
+	Status      string             `json:"status"`
+	ReviewedBy  *string            `json:"reviewed_by"`
+	ReviewedAt  pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ModifiedAt  pgtype.Timestamptz `json:"modified_at"`
+	TagPath     string             `json:"tag_path"`
+}
+
+func (q *Queries) ListPendingReviewItems(ctx context.Context, namespaceID pgtype.UUID) ([]ListPendingReviewItemsRow, error) {
+	rows, err := q.db.Query(ctx, listPendingReviewItems, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPendingReviewItemsRow{}
+	for rows.Next() {
+		var i ListPendingReviewItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.DocumentID,
+			&i.TagID,
+			&i.Attributes,
+			&i.Confidence,
+			&i.ExtractedBy,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+			&i.TagPath,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const queueReviewItem = `-- name: QueueReviewItem :one
+INSERT INTO review_items (namespace_id, document_id, tag_id, attributes, confidence, extracted_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (document_id, tag_id) WHERE status = 'pending' DO UPDATE
+SET attributes = EXCLUDED.attributes,
+    confidence = EXCLUDED.confidence,
+    extracted_by = EXCLUDED.extracted_by,
+    modified_at = NOW()
+RETURNING id
+`
+
+func (q *Queries) QueueReviewItem(ctx context.Context, namespaceID pgtype.UUID, documentID pgtype.UUID, tagID pgtype.UUID, attributes []byte, confidence float64, extractedBy string) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, queueReviewItem,
+		namespaceID,
+		documentID,
+		tagID,
+		attributes,
+		confidence,
+		extractedBy,
+	)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const resolveReviewItem = `-- name: ResolveReviewItem :execrows
+UPDATE review_items
+SET status = $2, reviewed_by = $3, reviewed_at = NOW(), modified_at = NOW()
+WHERE id = $1 AND status = 'pending'
+`
+
+func (q *Queries) ResolveReviewItem(ctx context.Context, iD pgtype.UUID, status string, reviewedBy *string) (int64, error) {
+	result, err := q.db.Exec(ctx, resolveReviewItem, iD, status, reviewedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setNamespaceReviewThreshold = `-- name: SetNamespaceReviewThreshold :one
+UPDATE namespaces
+SET review_threshold = $2, modified_at = NOW()
+WHERE name = $1
+RETURNING id, name, created_at, modified_at, review_threshold
+`
+
+func (q *Queries) SetNamespaceReviewThreshold(ctx context.Context, name string, reviewThreshold *float64) (Namespace, error) {
+	row := q.db.QueryRow(ctx, setNamespaceReviewThreshold, name, reviewThreshold)
+	var i Namespace
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+		&i.ReviewThreshold,
+	)
+	return i, err
+}
@@ -0,0 +1,185 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: tagging-rules.sql
+
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTaggingRule = `-- name: CreateTaggingRule :one
+INSERT INTO tagging_rules (namespace_id, name, enabled, priority, conditions, action)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, namespace_id, name, enabled, priority, conditions, action, created_at, modified_at
+`
+
+func (q *Queries) CreateTaggingRule(ctx context.Context, namespaceID pgtype.UUID, name string, enabled bool, priority int32, conditions json.RawMessage, action json.RawMessage) (TaggingRule, error) {
+	row := q.db.QueryRow(ctx, createTaggingRule,
+		namespaceID,
+		name,
+		enabled,
+		priority,
+		conditions,
+		action,
+	)
+	var i TaggingRule
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.Name,
+		&i.Enabled,
+		&i.Priority,
+		&i.Conditions,
+		&i.Action,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+	)
+	return i, err
+}
+
+const deleteTaggingRule = `-- name: DeleteTaggingRule :execrows
+DELETE FROM tagging_rules WHERE id = $1 AND namespace_id = $2
+`
+
+func (q *Queries) DeleteTaggingRule(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTaggingRule, iD, namespaceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTaggingRule = `-- name: GetTaggingRule :one
+SELECT id, namespace_id, name, enabled, priority, conditions, action, created_at, modified_at FROM tagging_rules WHERE id = $1 AND namespace_id = $2
+`
+
+func (q *Queries) GetTaggingRule(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID) (TaggingRule, error) {
+	row := q.db.QueryRow(ctx, getTaggingRule, iD, namespaceID)
+	var i TaggingRule
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.Name,
+		&i.Enabled,
+		&i.Priority,
+		&i.Conditions,
+		&i.Action,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+	)
+	return i, err
+}
+
+const listEnabledTaggingRules = `-- name: ListEnabledTaggingRules :many
+SELECT id, namespace_id, name, enabled, priority, conditions, action, created_at, modified_at FROM tagging_rules
+WHERE namespace_id = $1 AND enabled
+ORDER BY priority, created_at
+`
+
+func (q *Queries) ListEnabledTaggingRules(ctx context.Context, namespaceID pgtype.UUID) ([]TaggingRule, error) {
+	rows, err := q.db.Query(ctx, listEnabledTaggingRules, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TaggingRule{}
+	for rows.Next() {
+		var i TaggingRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.Name,
+			&i.Enabled,
+			&i.Priority,
+			&i.Conditions,
+			&i.Action,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaggingRules = `-- name: ListTaggingRules :many
+SELECT id, namespace_id, name, enabled, priority, conditions, action, created_at, modified_at FROM tagging_rules
+WHERE namespace_id = $1
+ORDER BY priority, created_at
+`
+
+func (q *Queries) ListTaggingRules(ctx context.Context, namespaceID pgtype.UUID) ([]TaggingRule, error) {
+	rows, err := q.db.Query(ctx, listTaggingRules, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TaggingRule{}
+	for rows.Next() {
+		var i TaggingRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.Name,
+			&i.Enabled,
+			&i.Priority,
+			&i.Conditions,
+			&i.Action,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTaggingRule = `-- name: UpdateTaggingRule :one
+UPDATE tagging_rules SET
+    name = $3,
+    enabled = $4,
+    priority = $5,
+    conditions = $6,
+    action = $7,
+    modified_at = NOW()
+WHERE id = $1 AND namespace_id = $2
+RETURNING id, namespace_id, name, enabled, priority, conditions, action, created_at, modified_at
+`
+
+func (q *Queries) UpdateTaggingRule(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID, name string, enabled bool, priority int32, conditions json.RawMessage, action json.RawMessage) (TaggingRule, error) {
+	row := q.db.QueryRow(ctx, updateTaggingRule,
+		iD,
+		namespaceID,
+		name,
+		enabled,
+		priority,
+		conditions,
+		action,
+	)
+	var i TaggingRule
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.Name,
+		&i.Enabled,
+		&i.Priority,
+		&i.Conditions,
+		&i.Action,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+	)
+	return i, err
+}
@@ -12,12 +12,14 @@ import (
 )
 
 const createTag = `-- name: CreateTag :one
-INSERT INTO tags (namespace_id, name, description, path, parent_id, color)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, namespace_id, name, description, path, parent_id, color, created_at, modified_at
+INSERT INTO tags (
+    namespace_id, name, description, path, parent_id, color, inherit_schema, schema_compatibility
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, namespace_id, name, description, path, parent_id, color, created_at, modified_at, inherit_schema, schema_compatibility
 `
 
-func (q *Queries) CreateTag(ctx context.Context, namespaceID pgtype.UUID, name string, description *string, path string, parentID pgtype.UUID, color *string) (Tag, error) {
+func (q *Queries) CreateTag(ctx context.Context, namespaceID pgtype.UUID, name string, description *string, path string, parentID pgtype.UUID, color *string, inheritSchema bool, schemaCompatibility string) (Tag, error) {
 	row := q.db.QueryRow(ctx, createTag,
 		namespaceID,
 		name,
@@ -25,6 +27,8 @@ func (q *Queries) CreateTag(ctx context.Context, namespaceID pgtype.UUID, name s
 		path,
 		parentID,
 		color,
+		inheritSchema,
+		schemaCompatibility,
 	)
 	var i Tag
 	err := row.Scan(
@@ -37,6 +41,8 @@ func (q *Queries) CreateTag(ctx context.Context, namespaceID pgtype.UUID, name s
 		&i.Color,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.InheritSchema,
+		&i.SchemaCompatibility,
 	)
 	return i, err
 }
@@ -51,7 +57,7 @@ func (q *Queries) DeleteTag(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getTagByID = `-- name: GetTagByID :one
-SELECT id, namespace_id, name, description, path, parent_id, color, created_at, modified_at FROM tags WHERE id = $1
+SELECT id, namespace_id, name, description, path, parent_id, color, created_at, modified_at, inherit_schema, schema_compatibility FROM tags WHERE id = $1
 `
 
 func (q *Queries) GetTagByID(ctx context.Context, id pgtype.UUID) (Tag, error) {
@@ -67,12 +73,14 @@ func (q *Queries) GetTagByID(ctx context.Context, id pgtype.UUID) (Tag, error) {
 		&i.Color,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.InheritSchema,
+		&i.SchemaCompatibility,
 	)
 	return i, err
 }
 
 const getTagByName = `-- name: GetTagByName :one
-SELECT id, namespace_id, name, description, path, parent_id, color, created_at, modified_at FROM tags WHERE namespace_id = $1 AND name = $2
+SELECT id, namespace_id, name, description, path, parent_id, color, created_at, modified_at, inherit_schema, schema_compatibility FROM tags WHERE namespace_id = $1 AND name = $2
 `
 
 func (q *Queries) GetTagByName(ctx context.Context, namespaceID pgtype.UUID, name string) (Tag, error) {
@@ -88,12 +96,14 @@ func (q *Queries) GetTagByName(ctx context.Context, namespaceID pgtype.UUID, nam
 		&i.Color,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.InheritSchema,
+		&i.SchemaCompatibility,
 	)
 	return i, err
 }
 
 const getTagByPath = `-- name: GetTagByPath :one
-SELECT id, namespace_id, name, description, path, parent_id, color, created_at, modified_at FROM tags WHERE namespace_id = $1 AND path = $2
+SELECT id, namespace_id, name, description, path, parent_id, color, created_at, modified_at, inherit_schema, schema_compatibility FROM tags WHERE namespace_id = $1 AND path = $2
 `
 
 func (q *Queries) GetTagByPath(ctx context.Context, namespaceID pgtype.UUID, path string) (Tag, error) {
@@ -109,12 +119,14 @@ func (q *Queries) GetTagByPath(ctx context.Context, namespaceID pgtype.UUID, pat
 		&i.Color,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.InheritSchema,
+		&i.SchemaCompatibility,
 	)
 	return i, err
 }
 
 const getTagsByNamespace = `-- name: GetTagsByNamespace :many
-SELECT id, namespace_id, name, description, path, parent_id, color, created_at, modified_at FROM tags WHERE namespace_id = $1 ORDER BY path
+SELECT id, namespace_id, name, description, path, parent_id, color, created_at, modified_at, inherit_schema, schema_compatibility FROM tags WHERE namespace_id = $1 ORDER BY path
 `
 
 func (q *Queries) GetTagsByNamespace(ctx context.Context, namespaceID pgtype.UUID) ([]Tag, error) {
@@ -136,6 +148,51 @@ func (q *Queries) GetTagsByNamespace(ctx context.Context, namespaceID pgtype.UUI
 			&i.Color,
 			&i.CreatedAt,
 			&i.ModifiedAt,
+			&i.InheritSchema,
+			&i.SchemaCompatibility,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTagDescendants = `-- name: ListTagDescendants :many
+WITH RECURSIVE descendants AS (
+    SELECT child.id FROM tags child WHERE child.parent_id = $1
+    UNION ALL
+    SELECT t.id FROM tags t JOIN descendants d ON t.parent_id = d.id
+)
+SELECT tags.id, tags.namespace_id, tags.name, tags.description, tags.path, tags.parent_id, tags.color, tags.created_at, tags.modified_at, tags.inherit_schema, tags.schema_compatibility FROM tags JOIN descendants ON descendants.id = tags.id
+ORDER BY tags.path
+`
+
+// Every tag below the given one, following parent links
+func (q *Queries) ListTagDescendants(ctx context.Context, tagID pgtype.UUID) ([]Tag, error) {
+	rows, err := q.db.Query(ctx, listTagDescendants, tagID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.Name,
+			&i.Description,
+			&i.Path,
+			&i.ParentID,
+			&i.Color,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+			&i.InheritSchema,
+			&i.SchemaCompatibility,
 		); err != nil {
 			return nil, err
 		}
@@ -155,12 +212,14 @@ SET
     path = COALESCE($4, path),
     parent_id = COALESCE($5, parent_id),
     color = COALESCE($6, color),
+    inherit_schema = COALESCE($7, inherit_schema),
+    schema_compatibility = COALESCE($8, schema_compatibility),
     modified_at = NOW()
 WHERE id = $1
-RETURNING id, namespace_id, name, description, path, parent_id, color, created_at, modified_at
+RETURNING id, namespace_id, name, description, path, parent_id, color, created_at, modified_at, inherit_schema, schema_compatibility
 `
 
-func (q *Queries) UpdateTag(ctx context.Context, iD pgtype.UUID, name string, description *string, path string, parentID pgtype.UUID, color *string) (Tag, error) {
+func (q *Queries) UpdateTag(ctx context.Context, iD pgtype.UUID, name string, description *string, path string, parentID pgtype.UUID, color *string, inheritSchema bool, schemaCompatibility string) (Tag, error) {
 	row := q.db.QueryRow(ctx, updateTag,
 		iD,
 		name,
@@ -168,6 +227,8 @@ func (q *Queries) UpdateTag(ctx context.Context, iD pgtype.UUID, name string, de
 		path,
 		parentID,
 		color,
+		inheritSchema,
+		schemaCompatibility,
 	)
 	var i Tag
 	err := row.Scan(
@@ -180,6 +241,8 @@ func (q *Queries) UpdateTag(ctx context.Context, iD pgtype.UUID, name string, de
 		&i.Color,
 		&i.CreatedAt,
 		&i.ModifiedAt,
+		&i.InheritSchema,
+		&i.SchemaCompatibility,
 	)
 	return i, err
 }
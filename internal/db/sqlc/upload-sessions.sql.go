@@ -0,0 +1,176 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: upload-sessions.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const advanceUploadSession = `-- name: AdvanceUploadSession :one
+UPDATE upload_sessions SET
+    upload_offset = $1,
+    chunk_names = array_append(chunk_names, $2::text),
+    hash_state = $3,
+    expires_at = $4,
+    modified_at = NOW()
+WHERE id = $5 AND upload_offset = $6
+RETURNING id, namespace_id, file_name, mime_type, upload_length, metadata, upload_offset, chunk_names, hash_state, document_id, expires_at, created_at, modified_at
+`
+
+func (q *Queries) AdvanceUploadSession(ctx context.Context, newOffset int64, chunkName string, hashState []byte, expiresAt pgtype.Timestamptz, iD pgtype.UUID, expectedOffset int64) (UploadSession, error) {
+	row := q.db.QueryRow(ctx, advanceUploadSession,
+		newOffset,
+		chunkName,
+		hashState,
+		expiresAt,
+		iD,
+		expectedOffset,
+	)
+	var i UploadSession
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.FileName,
+		&i.MimeType,
+		&i.UploadLength,
+		&i.Metadata,
+		&i.UploadOffset,
+		&i.ChunkNames,
+		&i.HashState,
+		&i.DocumentID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+	)
+	return i, err
+}
+
+const completeUploadSession = `-- name: CompleteUploadSession :exec
+UPDATE upload_sessions SET
+    document_id = $2,
+    modified_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) CompleteUploadSession(ctx context.Context, iD pgtype.UUID, documentID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, completeUploadSession, iD, documentID)
+	return err
+}
+
+const createUploadSession = `-- name: CreateUploadSession :one
+INSERT INTO upload_sessions (
+    namespace_id,
+    file_name,
+    mime_type,
+    upload_length,
+    metadata,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, namespace_id, file_name, mime_type, upload_length, metadata, upload_offset, chunk_names, hash_state, document_id, expires_at, created_at, modified_at
+`
+
+func (q *Queries) CreateUploadSession(ctx context.Context, namespaceID pgtype.UUID, fileName string, mimeType string, uploadLength int64, metadata []byte, expiresAt pgtype.Timestamptz) (UploadSession, error) {
+	row := q.db.QueryRow(ctx, createUploadSession,
+		namespaceID,
+		fileName,
+		mimeType,
+		uploadLength,
+		metadata,
+		expiresAt,
+	)
+	var i UploadSession
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.FileName,
+		&i.MimeType,
+		&i.UploadLength,
+		&i.Metadata,
+		&i.UploadOffset,
+		&i.ChunkNames,
+		&i.HashState,
+		&i.DocumentID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+	)
+	return i, err
+}
+
+const deleteUploadSession = `-- name: DeleteUploadSession :exec
+DELETE FROM upload_sessions WHERE id = $1
+`
+
+func (q *Queries) DeleteUploadSession(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUploadSession, id)
+	return err
+}
+
+const getUploadSession = `-- name: GetUploadSession :one
+SELECT id, namespace_id, file_name, mime_type, upload_length, metadata, upload_offset, chunk_names, hash_state, document_id, expires_at, created_at, modified_at FROM upload_sessions WHERE id = $1
+`
+
+func (q *Queries) GetUploadSession(ctx context.Context, id pgtype.UUID) (UploadSession, error) {
+	row := q.db.QueryRow(ctx, getUploadSession, id)
+	var i UploadSession
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.FileName,
+		&i.MimeType,
+		&i.UploadLength,
+		&i.Metadata,
+		&i.UploadOffset,
+		&i.ChunkNames,
+		&i.HashState,
+		&i.DocumentID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+	)
+	return i, err
+}
+
+const listExpiredUploadSessions = `-- name: ListExpiredUploadSessions :many
+SELECT id, namespace_id, file_name, mime_type, upload_length, metadata, upload_offset, chunk_names, hash_state, document_id, expires_at, created_at, modified_at FROM upload_sessions WHERE expires_at < NOW() ORDER BY expires_at
+`
+
+func (q *Queries) ListExpiredUploadSessions(ctx context.Context) ([]UploadSession, error) {
+	rows, err := q.db.Query(ctx, listExpiredUploadSessions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UploadSession{}
+	for rows.Next() {
+		var i UploadSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.FileName,
+			&i.MimeType,
+			&i.UploadLength,
+			&i.Metadata,
+			&i.UploadOffset,
+			&i.ChunkNames,
+			&i.HashState,
+			&i.DocumentID,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,349 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhooks.sql
+
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimWebhookDeliveries = `-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries d SET
+    next_attempt_at = $1
+FROM webhooks w
+WHERE w.id = d.webhook_id
+  AND d.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    FOR UPDATE SKIP LOCKED
+    LIMIT $2
+  )
+RETURNING d.id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret
+`
+
+type ClaimWebhookDeliveriesRow struct {
+	ID        pgtype.UUID     `json:"id"`
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int32           `json:"attempts"`
+	Url       string          `json:"url"`
+	Secret    string          `json:"secret"`
+}
+
+// Claims due deliveries by pushing their next attempt out to lease_until, so a worker
+// that dies mid-delivery leaves them to be retried once the lease expires. SKIP LOCKED
+// lets several workers share the queue.
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, leaseUntil pgtype.Timestamptz, batchSize int32) ([]ClaimWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimWebhookDeliveries, leaseUntil, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimWebhookDeliveriesRow{}
+	for rows.Next() {
+		var i ClaimWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createWebhook = `-- name: CreateWebhook :one
+INSERT INTO webhooks (namespace_id, url, event_types, secret)
+VALUES ($1, $2, $3, $4)
+RETURNING id, namespace_id, url, event_types, secret, created_at, modified_at
+`
+
+func (q *Queries) CreateWebhook(ctx context.Context, namespaceID pgtype.UUID, url string, eventTypes []string, secret string) (Webhook, error) {
+	row := q.db.QueryRow(ctx, createWebhook,
+		namespaceID,
+		url,
+		eventTypes,
+		secret,
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+	)
+	return i, err
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = $1 AND namespace_id = $2
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, iD pgtype.UUID, namespaceID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebhook, iD, namespaceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getWebhook = `-- name: GetWebhook :one
+SELECT id, namespace_id, url, event_types, secret, created_at, modified_at FROM webhooks WHERE id = $1
+`
+
+func (q *Queries) GetWebhook(ctx context.Context, id pgtype.UUID) (Webhook, error) {
+	row := q.db.QueryRow(ctx, getWebhook, id)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.NamespaceID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.ModifiedAt,
+	)
+	return i, err
+}
+
+const getWebhookDelivery = `-- name: GetWebhookDelivery :one
+SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, response_status, last_error, delivered_at, created_at FROM webhook_deliveries WHERE id = $1
+`
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, id pgtype.UUID) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, getWebhookDelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.WebhookID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, response_status, last_error, delivered_at, created_at FROM webhook_deliveries
+WHERE webhook_id = $1
+  AND ($2::text IS NULL OR status = $2)
+ORDER BY created_at DESC, id
+LIMIT $4 OFFSET $3
+`
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, webhookID pgtype.UUID, status *string, rowOffset int32, rowLimit int32) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries,
+		webhookID,
+		status,
+		rowOffset,
+		rowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhooks = `-- name: ListWebhooks :many
+SELECT id, namespace_id, url, event_types, secret, created_at, modified_at FROM webhooks WHERE namespace_id = $1 ORDER BY created_at
+`
+
+func (q *Queries) ListWebhooks(ctx context.Context, namespaceID pgtype.UUID) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, listWebhooks, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Webhook{}
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.Url,
+			&i.EventTypes,
+			&i.Secret,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhooksForEvent = `-- name: ListWebhooksForEvent :many
+SELECT id, namespace_id, url, event_types, secret, created_at, modified_at FROM webhooks
+WHERE namespace_id = $1
+  AND (cardinality(event_types) = 0 OR $2::text = ANY(event_types))
+ORDER BY created_at
+`
+
+// Webhooks in the namespace subscribed to the event type, or to every event
+func (q *Queries) ListWebhooksForEvent(ctx context.Context, namespaceID pgtype.UUID, eventType string) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, listWebhooksForEvent, namespaceID, eventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Webhook{}
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.NamespaceID,
+			&i.Url,
+			&i.EventTypes,
+			&i.Secret,
+			&i.CreatedAt,
+			&i.ModifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markWebhookDeliveryFailed = `-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries SET
+    status = $1,
+    attempts = attempts + 1,
+    response_status = $2,
+    last_error = $3,
+    next_attempt_at = $4
+WHERE id = $5
+`
+
+// Records a failed attempt. The delivery is retried at next_attempt_at, or dead-lettered
+// when status is 'dead'.
+func (q *Queries) MarkWebhookDeliveryFailed(ctx context.Context, status string, responseStatus *int32, errorMessage *string, nextAttemptAt pgtype.Timestamptz, iD pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryFailed,
+		status,
+		responseStatus,
+		errorMessage,
+		nextAttemptAt,
+		iD,
+	)
+	return err
+}
+
+const markWebhookDeliverySucceeded = `-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries SET
+    status = 'succeeded',
+    attempts = attempts + 1,
+    response_status = $1,
+    last_error = NULL,
+    delivered_at = NOW()
+WHERE id = $2
+`
+
+func (q *Queries) MarkWebhookDeliverySucceeded(ctx context.Context, responseStatus *int32, iD pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliverySucceeded, responseStatus, iD)
+	return err
+}
+
+const queueWebhookDelivery = `-- name: QueueWebhookDelivery :exec
+INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (webhook_id, event_id) DO NOTHING
+`
+
+// Events redelivered by JetStream are only queued once per webhook
+func (q *Queries) QueueWebhookDelivery(ctx context.Context, webhookID pgtype.UUID, eventID string, eventType string, payload json.RawMessage) error {
+	_, err := q.db.Exec(ctx, queueWebhookDelivery,
+		webhookID,
+		eventID,
+		eventType,
+		payload,
+	)
+	return err
+}
+
+const requeueWebhookDelivery = `-- name: RequeueWebhookDelivery :one
+UPDATE webhook_deliveries SET
+    status = 'pending',
+    attempts = 0,
+    next_attempt_at = NOW()
+WHERE id = $1 AND status = 'dead'
+RETURNING id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, response_status, last_error, delivered_at, created_at
+`
+
+// Gives a dead-lettered delivery a fresh set of attempts
+func (q *Queries) RequeueWebhookDelivery(ctx context.Context, id pgtype.UUID) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, requeueWebhookDelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.WebhookID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,22 @@
+package events
+
+import "context"
+
+// DefaultActor is recorded for changes made without an actor in the context, i.e. through
+// the API
+const DefaultActor = "api"
+
+type actorKey struct{}
+
+// WithActor returns a context whose events are attributed to actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor attributed to changes made with ctx
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return DefaultActor
+}
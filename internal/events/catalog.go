@@ -0,0 +1,146 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+)
+
+// eventTypes maps each event type, named after its legacy subject, to its message
+var eventTypes = map[string]func() proto.Message{
+	DocumentUploaded:  func() proto.Message { return &eventsv1.DocumentUploadedEvent{} },
+	DocumentUpdated:   func() proto.Message { return &eventsv1.DocumentUpdatedEvent{} },
+	DocumentDeleted:   func() proto.Message { return &eventsv1.DocumentDeletedEvent{} },
+	AttributesUpdated: func() proto.Message { return &eventsv1.AttributesUpdatedEvent{} },
+	SchemaChanged:     func() proto.Message { return &eventsv1.SchemaChangedEvent{} },
+	TagExtracted:      func() proto.Message { return &eventsv1.TagExtractedEvent{} },
+	TagRemoved:        func() proto.Message { return &eventsv1.TagRemovedEvent{} },
+	TagCreated:        func() proto.Message { return &eventsv1.TagCreatedEvent{} },
+	TagUpdated:        func() proto.Message { return &eventsv1.TagUpdatedEvent{} },
+	TagDeleted:        func() proto.Message { return &eventsv1.TagDeletedEvent{} },
+	NamespaceCreated:  func() proto.Message { return &eventsv1.NamespaceCreatedEvent{} },
+	NamespaceDeleted:  func() proto.Message { return &eventsv1.NamespaceDeletedEvent{} },
+}
+
+// IsEventType reports whether eventType names a known event, e.g. "documents.uploaded"
+func IsEventType(eventType string) bool {
+	_, ok := eventTypes[eventType]
+	return ok
+}
+
+// Event is a decoded event and where it was published
+type Event struct {
+	// Type is the event type, e.g. "documents.uploaded"
+	Type string
+	// Namespace is the namespace the event happened in
+	Namespace string
+	// TagPath is the tag the event is about, for events published under a tag
+	TagPath string
+	// Message is the decoded event
+	Message proto.Message
+}
+
+// Context returns the event's identifying context
+func (e *Event) Context() *eventsv1.EventContext {
+	if m, ok := e.Message.(interface{ GetContext() *eventsv1.EventContext }); ok {
+		return m.GetContext()
+	}
+	return nil
+}
+
+// eventJSON is the JSON form of an event, as sent to webhooks and event stream clients
+type eventJSON struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Namespace  string          `json:"namespace"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Actor      string          `json:"actor"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// MarshalJSON renders the event as a JSON envelope. The event context is lifted into the
+// envelope; data holds the rest of the event with its protobuf field names.
+func (e *Event) MarshalJSON() ([]byte, error) {
+	eventContext := e.Context()
+
+	message := proto.Clone(e.Message)
+	reflected := message.ProtoReflect()
+	reflected.Clear(reflected.Descriptor().Fields().ByName("context"))
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(eventJSON{
+		ID:         eventContext.GetEventId(),
+		Type:       e.Type,
+		Namespace:  e.Namespace,
+		OccurredAt: eventContext.GetOccurredAt().AsTime(),
+		Actor:      eventContext.GetActor(),
+		Data:       data,
+	})
+}
+
+// DecodeEvent decodes an event published on a namespaced subject
+func DecodeEvent(subject string, data []byte) (*Event, error) {
+	event, err := parseSubject(subject)
+	if err != nil {
+		return nil, err
+	}
+	event.Message = eventTypes[event.Type]()
+	if err := proto.Unmarshal(data, event.Message); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", event.Type, err)
+	}
+	return event, nil
+}
+
+// parseSubject reverses DocumentSubject, TagSubject and NamespaceSubject
+func parseSubject(subject string) (*Event, error) {
+	tokens := strings.Split(subject, ".")
+	if len(tokens) < 4 || tokens[0] != SubjectPrefix {
+		return nil, fmt.Errorf("not a namespaced subject: %q", subject)
+	}
+
+	namespace, err := UnescapeToken(tokens[1])
+	if err != nil {
+		return nil, err
+	}
+	event := &Event{Namespace: namespace}
+
+	action := tokens[len(tokens)-1]
+	switch {
+	case tokens[2] == "documents" && len(tokens) == 4:
+		event.Type = resourceEventType("documents", action)
+	case tokens[2] == "tags" && len(tokens) == 5:
+		tagPath, err := UnescapeToken(tokens[3])
+		if err != nil {
+			return nil, err
+		}
+		event.TagPath = "/" + tagPath
+		event.Type = resourceEventType("tags", action)
+	case tokens[2] == "namespace" && len(tokens) == 4:
+		event.Type = "namespaces." + action
+	}
+	if !IsEventType(event.Type) {
+		return nil, fmt.Errorf("unknown event subject: %q", subject)
+	}
+	return event, nil
+}
+
+// resourceEventType names the event type of an action published under a resource.
+// Attribute and schema changes share a type whether they are for a tag or the document.
+func resourceEventType(resource string, action string) string {
+	switch action {
+	case "attributes_updated":
+		return AttributesUpdated
+	case "schema_changed":
+		return SchemaChanged
+	}
+	return resource + "." + action
+}
@@ -0,0 +1,92 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	data, err := proto.Marshal(&eventsv1.TagExtractedEvent{
+		DocumentId: "doc-1",
+		Namespace:  "my.ns",
+		TagPath:    "/invoices/2024",
+		Context:    &eventsv1.EventContext{EventId: "event-1"},
+	})
+	require.NoError(t, err)
+
+	event, err := DecodeEvent(TagSubject("my.ns", "/invoices/2024", "extracted"), data)
+	require.NoError(t, err)
+	assert.Equal(t, TagExtracted, event.Type)
+	assert.Equal(t, "my.ns", event.Namespace)
+	assert.Equal(t, "/invoices/2024", event.TagPath)
+	assert.Equal(t, "event-1", event.Context().GetEventId())
+	assert.Equal(t, "doc-1", event.Message.(*eventsv1.TagExtractedEvent).DocumentId)
+}
+
+func TestParseSubject(t *testing.T) {
+	tests := []struct {
+		subject   string
+		eventType string
+	}{
+		{DocumentSubject("acme", "uploaded"), DocumentUploaded},
+		{DocumentSubject("acme", "attributes_updated"), AttributesUpdated},
+		{DocumentSubject("acme", "schema_changed"), SchemaChanged},
+		{TagSubject("acme", "/a", "attributes_updated"), AttributesUpdated},
+		{TagSubject("acme", "/a", "schema_changed"), SchemaChanged},
+		{TagSubject("acme", "/a", "deleted"), TagDeleted},
+		{NamespaceSubject("acme", "created"), NamespaceCreated},
+	}
+	for _, tt := range tests {
+		event, err := parseSubject(tt.subject)
+		require.NoError(t, err, tt.subject)
+		assert.Equal(t, tt.eventType, event.Type, tt.subject)
+		assert.Equal(t, "acme", event.Namespace, tt.subject)
+	}
+
+	invalid := []string{
+		DocumentUploaded,
+		"wayfile.acme.documents",
+		"wayfile.acme.documents.exploded",
+		"wayfile.acme.tags.uploaded",
+		"wayfile.acme.tags.a.uploaded",
+		"wayfile.acme.namespace.attributes_updated",
+		"other.acme.documents.uploaded",
+	}
+	for _, subject := range invalid {
+		_, err := parseSubject(subject)
+		assert.Error(t, err, subject)
+	}
+}
+
+func TestEventMarshalJSON(t *testing.T) {
+	event := &Event{
+		Type:      DocumentUploaded,
+		Namespace: "acme",
+		Message: &eventsv1.DocumentUploadedEvent{
+			DocumentId: "doc-1",
+			Namespace:  "acme",
+			Context:    &eventsv1.EventContext{EventId: "event-1", Actor: "alice"},
+		},
+	}
+
+	data, err := event.MarshalJSON()
+	require.NoError(t, err)
+
+	var envelope map[string]any
+	require.NoError(t, json.Unmarshal(data, &envelope))
+	assert.Equal(t, "event-1", envelope["id"])
+	assert.Equal(t, DocumentUploaded, envelope["type"])
+	assert.Equal(t, "alice", envelope["actor"])
+	body := envelope["data"].(map[string]any)
+	assert.Equal(t, "doc-1", body["document_id"])
+	assert.NotContains(t, body, "context")
+
+	// The event itself is left untouched
+	assert.Equal(t, "event-1", event.Context().GetEventId())
+}
@@ -0,0 +1,174 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// consumeFetchWait is how long a fetch waits for messages before polling again
+	consumeFetchWait = 5 * time.Second
+	// consumeRetryDelay is how long a message the handler failed on waits to be redelivered
+	consumeRetryDelay = 5 * time.Second
+)
+
+// MessageHandler processes a message consumed from JetStream. Returning an error
+// redelivers the message; handlers should log and return nil for messages that can
+// never succeed.
+type MessageHandler func(ctx context.Context, subject string, data []byte) error
+
+// ConsumeOption configures Consume
+type ConsumeOption func(*consumeOptions)
+
+type consumeOptions struct {
+	concurrency   int
+	retryDelay    time.Duration
+	maxRetryDelay time.Duration
+}
+
+// WithConcurrency sets how many messages are handled at once. By default messages are
+// handled one at a time, in order.
+func WithConcurrency(n int) ConsumeOption {
+	return func(o *consumeOptions) {
+		o.concurrency = max(n, 1)
+	}
+}
+
+// WithRetryBackoff sets how long a message the handler failed on waits to be
+// redelivered. The delay starts at initial and doubles with each delivery up to maxDelay. By
+// default every retry waits 5 seconds.
+func WithRetryBackoff(initial time.Duration, maxDelay time.Duration) ConsumeOption {
+	return func(o *consumeOptions) {
+		o.retryDelay = initial
+		o.maxRetryDelay = maxDelay
+	}
+}
+
+// Consume pulls messages from a durable consumer created by Provision and hands them to
+// handler until ctx is cancelled. A message that fails on its consumer's last allowed
+// delivery is terminated and logged rather than left to expire.
+func Consume(
+	ctx context.Context,
+	js nats.JetStreamContext,
+	stream string,
+	durable string,
+	batchSize int,
+	handler MessageHandler,
+	opts ...ConsumeOption,
+) error {
+	o := consumeOptions{
+		concurrency:   1,
+		retryDelay:    consumeRetryDelay,
+		maxRetryDelay: consumeRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	sub, err := js.PullSubscribe("", durable, nats.Bind(stream, durable))
+	if err != nil {
+		return fmt.Errorf("failed to bind to consumer %s on stream %s: %w", durable, stream, err)
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+
+	info, err := sub.ConsumerInfo()
+	if err != nil {
+		return fmt.Errorf("failed to look up consumer %s on stream %s: %w", durable, stream, err)
+	}
+	maxDeliver := info.Config.MaxDeliver
+
+	// Each in-flight message holds a slot until its handler returns
+	slots := make(chan struct{}, o.concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for ctx.Err() == nil {
+		fetchCtx, cancel := context.WithTimeout(ctx, consumeFetchWait)
+		msgs, err := sub.Fetch(batchSize, nats.Context(fetchCtx))
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, nats.ErrTimeout) {
+				slog.Error("failed to fetch messages", "consumer", durable, "error", err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(consumeRetryDelay):
+				}
+			}
+			continue
+		}
+
+		for _, msg := range msgs {
+			slots <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+				handleMessage(ctx, durable, msg, handler, maxDeliver, &o)
+			}()
+		}
+	}
+	return nil
+}
+
+// handleMessage runs handler on a message and acknowledges, retries or terminates it
+func handleMessage(
+	ctx context.Context,
+	durable string,
+	msg *nats.Msg,
+	handler MessageHandler,
+	maxDeliver int,
+	o *consumeOptions,
+) {
+	err := handler(ctx, msg.Subject, msg.Data)
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			slog.Error("failed to ack message", "consumer", durable, "error", err)
+		}
+		return
+	}
+
+	var delivered uint64 = 1
+	if meta, metaErr := msg.Metadata(); metaErr == nil {
+		delivered = meta.NumDelivered
+	}
+	if maxDeliver > 0 && delivered >= uint64(maxDeliver) {
+		slog.Error(
+			"giving up on message after its last delivery",
+			"consumer", durable,
+			"subject", msg.Subject,
+			"deliveries", delivered,
+			"error", err,
+		)
+		_ = msg.Term()
+		return
+	}
+
+	slog.Error(
+		"failed to handle message",
+		"consumer", durable,
+		"subject", msg.Subject,
+		"deliveries", delivered,
+		"error", err,
+	)
+	_ = msg.NakWithDelay(retryDelay(delivered, o.retryDelay, o.maxRetryDelay))
+}
+
+// retryDelay doubles the initial delay for each delivery after the first, up to maxDelay
+func retryDelay(delivered uint64, initial time.Duration, maxDelay time.Duration) time.Duration {
+	delay := initial
+	for i := uint64(1); i < delivered && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,29 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		delivered uint64
+		want      time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{5, 160 * time.Second},
+		{6, 5 * time.Minute},
+		{100, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, retryDelay(tt.delivered, 10*time.Second, 5*time.Minute),
+			"delivery %d", tt.delivered)
+	}
+
+	// Without a backoff every retry waits the same
+	assert.Equal(t, consumeRetryDelay, retryDelay(4, consumeRetryDelay, consumeRetryDelay))
+}
@@ -0,0 +1,192 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+)
+
+// Publisher defines the interface for publishing events
+type Publisher interface {
+	DocumentUploaded(ctx context.Context, event *eventsv1.DocumentUploadedEvent) error
+	DocumentUpdated(ctx context.Context, event *eventsv1.DocumentUpdatedEvent) error
+	DocumentDeleted(ctx context.Context, event *eventsv1.DocumentDeletedEvent) error
+	AttributesUpdated(ctx context.Context, event *eventsv1.AttributesUpdatedEvent) error
+	SchemaChanged(ctx context.Context, event *eventsv1.SchemaChangedEvent) error
+	TagExtracted(ctx context.Context, event *eventsv1.TagExtractedEvent) error
+	TagRemoved(ctx context.Context, event *eventsv1.TagRemovedEvent) error
+	TagCreated(ctx context.Context, event *eventsv1.TagCreatedEvent) error
+	TagUpdated(ctx context.Context, event *eventsv1.TagUpdatedEvent) error
+	TagDeleted(ctx context.Context, event *eventsv1.TagDeletedEvent) error
+	NamespaceCreated(ctx context.Context, event *eventsv1.NamespaceCreatedEvent) error
+	NamespaceDeleted(ctx context.Context, event *eventsv1.NamespaceDeletedEvent) error
+}
+
+// Outbox implements Publisher by writing events to the event_outbox table. Bound to a
+// transaction with WithTx, events are only relayed if the transaction commits.
+type Outbox struct {
+	queries *sqlc.Queries
+	mode    SubjectMode
+}
+
+// NewOutbox creates a new Outbox writing through the given queries. mode selects the
+// subjects events are published on.
+func NewOutbox(queries *sqlc.Queries, mode SubjectMode) *Outbox {
+	return &Outbox{queries: queries, mode: mode}
+}
+
+// WithTx returns an Outbox that writes events within tx
+func (o *Outbox) WithTx(tx pgx.Tx) *Outbox {
+	return &Outbox{queries: o.queries.WithTx(tx), mode: o.mode}
+}
+
+// enqueue writes event once for each subject the outbox's mode publishes it on
+func (o *Outbox) enqueue(
+	ctx context.Context,
+	legacy string,
+	namespaced string,
+	event proto.Message,
+) error {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	for _, subject := range o.mode.subjects(legacy, namespaced) {
+		if err := o.queries.InsertOutboxEvent(ctx, subject, data); err != nil {
+			return fmt.Errorf("failed to write %s event to outbox: %w", subject, err)
+		}
+	}
+	return nil
+}
+
+// newEventContext identifies a new event made by the actor in ctx
+func newEventContext(ctx context.Context) *eventsv1.EventContext {
+	return &eventsv1.EventContext{
+		EventId:    uuid.NewString(),
+		OccurredAt: timestamppb.Now(),
+		Actor:      ActorFromContext(ctx),
+	}
+}
+
+// DocumentUploaded records a "documents.uploaded" event
+func (o *Outbox) DocumentUploaded(
+	ctx context.Context,
+	event *eventsv1.DocumentUploadedEvent,
+) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, DocumentUploaded, DocumentSubject(event.Namespace, "uploaded"), event)
+}
+
+// DocumentUpdated records a "documents.updated" event
+func (o *Outbox) DocumentUpdated(ctx context.Context, event *eventsv1.DocumentUpdatedEvent) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, DocumentUpdated, DocumentSubject(event.Namespace, "updated"), event)
+}
+
+// DocumentDeleted records a "documents.deleted" event
+func (o *Outbox) DocumentDeleted(ctx context.Context, event *eventsv1.DocumentDeletedEvent) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, DocumentDeleted, DocumentSubject(event.Namespace, "deleted"), event)
+}
+
+// AttributesUpdated records a "documents.attributes_updated" event
+func (o *Outbox) AttributesUpdated(
+	ctx context.Context,
+	event *eventsv1.AttributesUpdatedEvent,
+) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, AttributesUpdated, attributesSubject(event), event)
+}
+
+// SchemaChanged records a "schema.changed" event
+func (o *Outbox) SchemaChanged(ctx context.Context, event *eventsv1.SchemaChangedEvent) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, SchemaChanged, schemaSubject(event), event)
+}
+
+// TagExtracted records a "tags.extracted" event
+func (o *Outbox) TagExtracted(ctx context.Context, event *eventsv1.TagExtractedEvent) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(
+		ctx,
+		TagExtracted,
+		TagSubject(event.Namespace, event.TagPath, "extracted"),
+		event,
+	)
+}
+
+// TagRemoved records a "tags.removed" event
+func (o *Outbox) TagRemoved(ctx context.Context, event *eventsv1.TagRemovedEvent) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, TagRemoved, TagSubject(event.Namespace, event.TagPath, "removed"), event)
+}
+
+// TagCreated records a "tags.created" event
+func (o *Outbox) TagCreated(ctx context.Context, event *eventsv1.TagCreatedEvent) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, TagCreated, TagSubject(event.Namespace, event.TagPath, "created"), event)
+}
+
+// TagUpdated records a "tags.updated" event, published under the tag's old path so
+// subscribers to that tag see it renamed or moved
+func (o *Outbox) TagUpdated(ctx context.Context, event *eventsv1.TagUpdatedEvent) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(
+		ctx,
+		TagUpdated,
+		TagSubject(event.Namespace, event.OldTagPath, "updated"),
+		event,
+	)
+}
+
+// TagDeleted records a "tags.deleted" event
+func (o *Outbox) TagDeleted(ctx context.Context, event *eventsv1.TagDeletedEvent) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, TagDeleted, TagSubject(event.Namespace, event.TagPath, "deleted"), event)
+}
+
+// NamespaceCreated records a "namespaces.created" event
+func (o *Outbox) NamespaceCreated(
+	ctx context.Context,
+	event *eventsv1.NamespaceCreatedEvent,
+) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, NamespaceCreated, NamespaceSubject(event.Namespace, "created"), event)
+}
+
+// NamespaceDeleted records a "namespaces.deleted" event
+func (o *Outbox) NamespaceDeleted(
+	ctx context.Context,
+	event *eventsv1.NamespaceDeletedEvent,
+) error {
+	event.Context = newEventContext(ctx)
+	return o.enqueue(ctx, NamespaceDeleted, NamespaceSubject(event.Namespace, "deleted"), event)
+}
+
+// attributesSubject returns the subject for an attributes update: tag attributes are
+// published under the tag, global attributes under documents
+func attributesSubject(event *eventsv1.AttributesUpdatedEvent) string {
+	if event.TagPath == "" {
+		return DocumentSubject(event.Namespace, "attributes_updated")
+	}
+	return TagSubject(event.Namespace, event.TagPath, "attributes_updated")
+}
+
+// schemaSubject returns the subject for a schema change: tag schemas are published under
+// the tag, the document schema under documents
+func schemaSubject(event *eventsv1.SchemaChangedEvent) string {
+	if event.TagPath == "" {
+		return DocumentSubject(event.Namespace, "schema_changed")
+	}
+	return TagSubject(event.Namespace, event.TagPath, "schema_changed")
+}
+
+// Outbox must satisfy Publisher
+var _ Publisher = (*Outbox)(nil)
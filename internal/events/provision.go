@@ -0,0 +1,137 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/RynoXLI/Wayfile/internal/config"
+)
+
+// Provision creates or updates the configured streams and then their durable consumers.
+// It is safe to run on every startup.
+func Provision(
+	js nats.JetStreamManager,
+	streams []config.StreamConfig,
+	consumers []config.ConsumerConfig,
+) error {
+	for _, stream := range streams {
+		cfg, err := streamConfig(stream)
+		if err != nil {
+			return err
+		}
+		if err := ensureStream(js, cfg); err != nil {
+			return fmt.Errorf("failed to provision stream %s: %w", cfg.Name, err)
+		}
+	}
+
+	for _, consumer := range consumers {
+		cfg, err := consumerConfig(consumer)
+		if err != nil {
+			return err
+		}
+		if err := ensureConsumer(js, consumer.Stream, cfg); err != nil {
+			return fmt.Errorf(
+				"failed to provision consumer %s on stream %s: %w",
+				cfg.Durable,
+				consumer.Stream,
+				err,
+			)
+		}
+	}
+	return nil
+}
+
+// streamConfig converts a configured stream to its JetStream definition
+func streamConfig(c config.StreamConfig) (*nats.StreamConfig, error) {
+	if c.Name == "" {
+		return nil, errors.New("stream name is required")
+	}
+	if len(c.Subjects) == 0 {
+		return nil, fmt.Errorf("stream %s needs at least one subject", c.Name)
+	}
+
+	cfg := &nats.StreamConfig{
+		Name:       c.Name,
+		Subjects:   c.Subjects,
+		MaxAge:     time.Duration(c.MaxAge) * time.Second,
+		Replicas:   max(c.Replicas, 1),
+		Duplicates: time.Duration(c.DuplicateWindow) * time.Second,
+	}
+
+	switch c.Retention {
+	case "", "limits":
+		cfg.Retention = nats.LimitsPolicy
+	case "interest":
+		cfg.Retention = nats.InterestPolicy
+	case "workqueue":
+		cfg.Retention = nats.WorkQueuePolicy
+	default:
+		return nil, fmt.Errorf("stream %s: unknown retention %q", c.Name, c.Retention)
+	}
+
+	switch c.Storage {
+	case "", "file":
+		cfg.Storage = nats.FileStorage
+	case "memory":
+		cfg.Storage = nats.MemoryStorage
+	default:
+		return nil, fmt.Errorf("stream %s: unknown storage %q", c.Name, c.Storage)
+	}
+
+	return cfg, nil
+}
+
+// consumerConfig converts a configured consumer to its JetStream definition
+func consumerConfig(c config.ConsumerConfig) (*nats.ConsumerConfig, error) {
+	if c.Stream == "" || c.Durable == "" {
+		return nil, errors.New("consumer stream and durable name are required")
+	}
+
+	cfg := &nats.ConsumerConfig{
+		Durable:       c.Durable,
+		DeliverPolicy: nats.DeliverAllPolicy,
+		AckPolicy:     nats.AckExplicitPolicy,
+		AckWait:       time.Duration(c.AckWait) * time.Second,
+		MaxDeliver:    c.MaxDeliver,
+		MaxAckPending: c.MaxAckPending,
+	}
+	// A single filter must use FilterSubject to work with servers older than 2.10
+	if len(c.FilterSubjects) == 1 {
+		cfg.FilterSubject = c.FilterSubjects[0]
+	} else {
+		cfg.FilterSubjects = c.FilterSubjects
+	}
+
+	return cfg, nil
+}
+
+// ensureStream creates the stream, or updates it if it already exists
+func ensureStream(js nats.JetStreamManager, cfg *nats.StreamConfig) error {
+	_, err := js.StreamInfo(cfg.Name)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = js.AddStream(cfg)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	_, err = js.UpdateStream(cfg)
+	return err
+}
+
+// ensureConsumer creates the durable consumer, or updates it if it already exists
+func ensureConsumer(js nats.JetStreamManager, stream string, cfg *nats.ConsumerConfig) error {
+	_, err := js.ConsumerInfo(stream, cfg.Durable)
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		_, err = js.AddConsumer(stream, cfg)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	_, err = js.UpdateConsumer(stream, cfg)
+	return err
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RynoXLI/Wayfile/internal/config"
+)
+
+func TestStreamConfig(t *testing.T) {
+	cfg, err := streamConfig(config.StreamConfig{
+		Name:            "WAYFILE",
+		Subjects:        []string{"documents.>"},
+		Retention:       "workqueue",
+		MaxAge:          60,
+		Storage:         "memory",
+		DuplicateWindow: 120,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "WAYFILE", cfg.Name)
+	assert.Equal(t, nats.WorkQueuePolicy, cfg.Retention)
+	assert.Equal(t, nats.MemoryStorage, cfg.Storage)
+	assert.Equal(t, time.Minute, cfg.MaxAge)
+	assert.Equal(t, 2*time.Minute, cfg.Duplicates)
+	assert.Equal(t, 1, cfg.Replicas)
+
+	// Defaults
+	cfg, err = streamConfig(config.StreamConfig{Name: "S", Subjects: []string{"a"}, Replicas: 3})
+	require.NoError(t, err)
+	assert.Equal(t, nats.LimitsPolicy, cfg.Retention)
+	assert.Equal(t, nats.FileStorage, cfg.Storage)
+	assert.Equal(t, 3, cfg.Replicas)
+
+	invalid := []config.StreamConfig{
+		{Subjects: []string{"a"}},
+		{Name: "S"},
+		{Name: "S", Subjects: []string{"a"}, Retention: "forever"},
+		{Name: "S", Subjects: []string{"a"}, Storage: "tape"},
+	}
+	for _, c := range invalid {
+		_, err := streamConfig(c)
+		assert.Error(t, err, "%+v", c)
+	}
+}
+
+func TestConsumerConfig(t *testing.T) {
+	cfg, err := consumerConfig(config.ConsumerConfig{
+		Stream:         "WAYFILE",
+		Durable:        "extractor",
+		FilterSubjects: []string{"documents.uploaded"},
+		AckWait:        30,
+		MaxDeliver:     5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "extractor", cfg.Durable)
+	assert.Equal(t, "documents.uploaded", cfg.FilterSubject)
+	assert.Empty(t, cfg.FilterSubjects)
+	assert.Equal(t, nats.AckExplicitPolicy, cfg.AckPolicy)
+	assert.Equal(t, 30*time.Second, cfg.AckWait)
+	assert.Equal(t, 5, cfg.MaxDeliver)
+
+	cfg, err = consumerConfig(config.ConsumerConfig{
+		Stream:         "WAYFILE",
+		Durable:        "indexer",
+		FilterSubjects: []string{"documents.>", "tags.>"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, cfg.FilterSubject)
+	assert.Equal(t, []string{"documents.>", "tags.>"}, cfg.FilterSubjects)
+
+	_, err = consumerConfig(config.ConsumerConfig{Durable: "extractor"})
+	assert.Error(t, err)
+}
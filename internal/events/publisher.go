@@ -2,49 +2,164 @@
 package events
 
 import (
-	"github.com/nats-io/nats.go"
-	"google.golang.org/protobuf/proto"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
 
-	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	"github.com/nats-io/nats.go"
 )
 
-// Publisher defines the interface for publishing events
-type Publisher interface {
-	DocumentUploaded(event *eventsv1.DocumentUploadedEvent) error
-	SchemaChanged(event *eventsv1.SchemaChangedEvent) error
-	TagExtracted(event *eventsv1.TagExtractedEvent) error
+// defaultAckTimeout is how long to wait for JetStream to acknowledge a publish
+const defaultAckTimeout = 5 * time.Second
+
+// PublishFailureHandler is called when JetStream rejects a publish or doesn't acknowledge it
+// in time
+type PublishFailureHandler func(subject string, msgID string, err error)
+
+// PublisherOption configures a JetStreamPublisher
+type PublisherOption func(*JetStreamPublisher)
+
+// WithFailureHandler sets the handler called for every failed publish. By default failures
+// are logged.
+func WithFailureHandler(handler PublishFailureHandler) PublisherOption {
+	return func(p *JetStreamPublisher) {
+		p.onFailure = handler
+	}
+}
+
+// WithAckTimeout sets how long to wait for each publish to be acknowledged
+func WithAckTimeout(timeout time.Duration) PublisherOption {
+	return func(p *JetStreamPublisher) {
+		p.ackTimeout = timeout
+	}
+}
+
+// PublisherStats counts publish outcomes since the publisher was created
+type PublisherStats struct {
+	Published uint64
+	Failed    uint64
+	Pending   int
 }
 
-// JetStreamPublisher implements Publisher interface using NATS JetStream
+// JetStreamPublisher publishes encoded events to NATS JetStream without blocking on each
+// acknowledgement. Backpressure comes from the JetStream context's
+// PublishAsyncMaxPending limit.
 type JetStreamPublisher struct {
-	js nats.JetStreamContext
+	js         nats.JetStreamContext
+	onFailure  PublishFailureHandler
+	ackTimeout time.Duration
+	published  atomic.Uint64
+	failed     atomic.Uint64
 }
 
 // NewPublisher creates a new JetStreamPublisher instance
-func NewPublisher(js nats.JetStreamContext) *JetStreamPublisher {
-	return &JetStreamPublisher{js: js}
+func NewPublisher(js nats.JetStreamContext, opts ...PublisherOption) *JetStreamPublisher {
+	p := &JetStreamPublisher{
+		js: js,
+		onFailure: func(subject string, msgID string, err error) {
+			slog.Error("failed to publish event", "subject", subject, "msg_id", msgID, "error", err)
+		},
+		ackTimeout: defaultAckTimeout,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// PendingAck is an in-flight publish awaiting acknowledgement
+type PendingAck interface {
+	// Wait blocks until the publish is acknowledged, fails, or times out
+	Wait(ctx context.Context) error
+}
+
+// jetStreamAck tracks the PubAckFuture of a single publish
+type jetStreamAck struct {
+	publisher *JetStreamPublisher
+	future    nats.PubAckFuture
+	subject   string
+	msgID     string
+}
+
+// PublishAsync starts publishing an encoded event. msgID is sent as the Nats-Msg-Id header
+// so JetStream drops redelivered copies within the stream's duplicate window.
+func (p *JetStreamPublisher) PublishAsync(
+	subject string,
+	msgID string,
+	data []byte,
+) (PendingAck, error) {
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, msgID)
+
+	future, err := p.js.PublishMsgAsync(msg)
+	if err != nil {
+		p.fail(subject, msgID, err)
+		return nil, err
+	}
+	return &jetStreamAck{publisher: p, future: future, subject: subject, msgID: msgID}, nil
 }
 
-func (p *JetStreamPublisher) publish(subject string, event proto.Message) error {
-	data, err := proto.Marshal(event)
+// Publish publishes an encoded event and waits for JetStream to acknowledge it
+func (p *JetStreamPublisher) Publish(
+	ctx context.Context,
+	subject string,
+	msgID string,
+	data []byte,
+) error {
+	ack, err := p.PublishAsync(subject, msgID, data)
 	if err != nil {
 		return err
 	}
-	_, err = p.js.PublishAsync(subject, data)
+	return ack.Wait(ctx)
+}
+
+// Wait blocks until the publish is acknowledged, fails, or times out
+func (a *jetStreamAck) Wait(ctx context.Context) error {
+	timer := time.NewTimer(a.publisher.ackTimeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-a.future.Ok():
+		a.publisher.published.Add(1)
+		return nil
+	case err = <-a.future.Err():
+	case <-timer.C:
+		err = fmt.Errorf("no acknowledgement after %s", a.publisher.ackTimeout)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	a.publisher.fail(a.subject, a.msgID, err)
 	return err
 }
 
-// DocumentUploaded publishes a "document.uploaded" event to NATS JetStream
-func (p *JetStreamPublisher) DocumentUploaded(event *eventsv1.DocumentUploadedEvent) error {
-	return p.publish(DocumentUploaded, event)
+func (p *JetStreamPublisher) fail(subject string, msgID string, err error) {
+	p.failed.Add(1)
+	p.onFailure(subject, msgID, err)
 }
 
-// SchemaChanged publishes a "schema.changed" event to NATS JetStream
-func (p *JetStreamPublisher) SchemaChanged(event *eventsv1.SchemaChangedEvent) error {
-	return p.publish(SchemaChanged, event)
+// Stats returns publish counts and the number of publishes awaiting acknowledgement
+func (p *JetStreamPublisher) Stats() PublisherStats {
+	return PublisherStats{
+		Published: p.published.Load(),
+		Failed:    p.failed.Load(),
+		Pending:   p.js.PublishAsyncPending(),
+	}
 }
 
-// TagExtracted publishes a "tags.extracted" event to NATS JetStream
-func (p *JetStreamPublisher) TagExtracted(event *eventsv1.TagExtractedEvent) error {
-	return p.publish(TagExtracted, event)
+// Drain waits for every outstanding publish to be acknowledged, or for ctx to end
+func (p *JetStreamPublisher) Drain(ctx context.Context) error {
+	select {
+	case <-p.js.PublishAsyncComplete():
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf(
+			"%d publishes still awaiting acknowledgement: %w",
+			p.js.PublishAsyncPending(),
+			ctx.Err(),
+		)
+	}
 }
@@ -0,0 +1,216 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/RynoXLI/Wayfile/internal/db"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+)
+
+const (
+	// relayMaxBackoff caps how long the relay waits between attempts while JetStream is failing
+	relayMaxBackoff = time.Minute
+	// relayPruneInterval is how often delivered events past their retention are deleted
+	relayPruneInterval = time.Hour
+	// eventRetryBackoff is how long an event that failed to publish waits before its first
+	// retry. The wait doubles with each failed attempt, up to eventMaxRetryBackoff.
+	eventRetryBackoff    = 5 * time.Second
+	eventMaxRetryBackoff = time.Hour
+)
+
+// errPublishFailed reports events that failed to publish. They are retried or parked on
+// their own, so the relay keeps polling rather than backing off.
+var errPublishFailed = errors.New("failed to publish event")
+
+// Sender delivers encoded events to the message broker
+type Sender interface {
+	PublishAsync(subject string, msgID string, data []byte) (PendingAck, error)
+}
+
+// Relay moves committed events from the outbox to JetStream. Events are published in the
+// order they were written and each carries its outbox ID as a message ID, so an event that
+// is published again after a failed commit is dropped as a duplicate by JetStream. An event
+// that fails to publish is retried with backoff while the events after it go ahead, and is
+// parked once it has failed maxAttempts times.
+type Relay struct {
+	db          db.TxBeginner
+	queries     *sqlc.Queries
+	sender      Sender
+	batchSize   int32
+	maxAttempts int32
+	retention   time.Duration
+}
+
+// NewRelay creates a new Relay. Delivered events are kept for retention before being pruned.
+func NewRelay(
+	pool db.TxBeginner,
+	queries *sqlc.Queries,
+	sender Sender,
+	batchSize int32,
+	maxAttempts int32,
+	retention time.Duration,
+) *Relay {
+	return &Relay{
+		db:          pool,
+		queries:     queries,
+		sender:      sender,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		retention:   retention,
+	}
+}
+
+// Run relays events until the context is cancelled, polling the outbox every interval. A
+// batch in progress when the context is cancelled is allowed to finish.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	batchCtx := context.WithoutCancel(ctx)
+	backoff := interval
+	var lastPrune time.Time
+
+	for {
+		wait := interval
+		for ctx.Err() == nil {
+			sent, err := r.RelayBatch(batchCtx)
+			if errors.Is(err, errPublishFailed) {
+				slog.Error("failed to relay events", "error", err)
+			} else if err != nil {
+				slog.Error("failed to relay events", "error", err, "retry_in", backoff)
+				wait = backoff
+				backoff = min(backoff*2, relayMaxBackoff)
+				break
+			}
+			backoff = interval
+			if sent < int(r.batchSize) {
+				break
+			}
+		}
+
+		if time.Since(lastPrune) >= relayPruneInterval {
+			lastPrune = time.Now()
+			if err := r.prune(ctx); err != nil {
+				slog.Error("failed to prune event outbox", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// RelayBatch publishes the oldest unsent events that are due and marks those JetStream
+// acknowledged as delivered. Failures are recorded on the event, which is retried by a later
+// batch once its backoff has passed, or parked if it has no attempts left. It returns the
+// number of events sent and the first publish error.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	var sent int
+	var publishErr error
+	err := db.RunInTx(ctx, r.db, func(tx pgx.Tx) error {
+		queries := r.queries.WithTx(tx)
+		pending, err := queries.ClaimOutboxEvents(ctx, r.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim outbox events: %w", err)
+		}
+
+		// Publish the whole batch before waiting, so acknowledgements are pipelined
+		acks := make([]PendingAck, len(pending))
+		errs := make([]error, len(pending))
+		for i, event := range pending {
+			acks[i], errs[i] = r.sender.PublishAsync(
+				event.Subject,
+				outboxMsgID(event.ID),
+				event.Payload,
+			)
+		}
+
+		for i, event := range pending {
+			if errs[i] == nil {
+				errs[i] = acks[i].Wait(ctx)
+			}
+			if errs[i] != nil {
+				if publishErr == nil {
+					publishErr = fmt.Errorf("%w %d: %w", errPublishFailed, event.ID, errs[i])
+				}
+				if err := r.recordFailure(ctx, queries, event, errs[i]); err != nil {
+					return fmt.Errorf("failed to record failure of event %d: %w", event.ID, err)
+				}
+				continue
+			}
+			if err := queries.MarkOutboxEventSent(ctx, event.ID); err != nil {
+				return fmt.Errorf("failed to mark event %d sent: %w", event.ID, err)
+			}
+			sent++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sent, publishErr
+}
+
+// recordFailure schedules the retry of an event that failed to publish, or parks it if it
+// has no attempts left
+func (r *Relay) recordFailure(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	event sqlc.EventOutbox,
+	publishErr error,
+) error {
+	message := publishErr.Error()
+	if event.Attempts+1 >= r.maxAttempts {
+		slog.Error("parking undeliverable event",
+			"event_id", event.ID,
+			"subject", event.Subject,
+			"attempts", event.Attempts+1,
+			"error", publishErr,
+		)
+		return queries.ParkOutboxEvent(ctx, &message, event.ID)
+	}
+	return queries.MarkOutboxEventFailed(ctx, &message, pgtype.Timestamptz{
+		Time:  time.Now().Add(retryBackoff(event.Attempts)),
+		Valid: true,
+	}, event.ID)
+}
+
+// retryBackoff returns how long an event waits before its next attempt, given the attempts
+// it has already failed before this one
+func retryBackoff(attempts int32) time.Duration {
+	backoff := eventRetryBackoff
+	for range attempts {
+		if backoff >= eventMaxRetryBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	return min(backoff, eventMaxRetryBackoff)
+}
+
+// outboxMsgID returns the JetStream message ID of an outbox event
+func outboxMsgID(id int64) string {
+	return fmt.Sprintf("outbox-%d", id)
+}
+
+// prune deletes delivered events older than the retention period
+func (r *Relay) prune(ctx context.Context) error {
+	deleted, err := r.queries.DeleteSentOutboxEvents(ctx, pgtype.Timestamptz{
+		Time:  time.Now().Add(-r.retention),
+		Valid: true,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		slog.Info("pruned delivered events", "count", deleted)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{0, 5 * time.Second},
+		{1, 10 * time.Second},
+		{4, 80 * time.Second},
+		{9, 2560 * time.Second},
+		{10, time.Hour},
+		{1000, time.Hour},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, retryBackoff(tt.attempts), "attempts %d", tt.attempts)
+	}
+}
@@ -1,8 +1,142 @@
 package events
 
-// Event subjects
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Legacy event subjects, published in SubjectModeLegacy and SubjectModeBoth
 const (
-	DocumentUploaded = "documents.uploaded"
-	SchemaChanged    = "schema.changed"
-	TagExtracted     = "tags.extracted"
+	DocumentUploaded  = "documents.uploaded"
+	DocumentUpdated   = "documents.updated"
+	DocumentDeleted   = "documents.deleted"
+	AttributesUpdated = "documents.attributes_updated"
+	SchemaChanged     = "schema.changed"
+	TagExtracted      = "tags.extracted"
+	TagRemoved        = "tags.removed"
+	TagCreated        = "tags.created"
+	TagUpdated        = "tags.updated"
+	TagDeleted        = "tags.deleted"
+	NamespaceCreated  = "namespaces.created"
+	NamespaceDeleted  = "namespaces.deleted"
 )
+
+// SubjectPrefix is the first token of every namespaced subject
+const SubjectPrefix = "wayfile"
+
+// SubjectMode selects which subjects events are published on
+type SubjectMode string
+
+// Subject modes
+const (
+	// SubjectModeNamespaced publishes on subjects scoped to the namespace and resource,
+	// e.g. wayfile.<namespace>.documents.uploaded
+	SubjectModeNamespaced SubjectMode = "namespaced"
+	// SubjectModeLegacy publishes on the flat subjects, e.g. documents.uploaded
+	SubjectModeLegacy SubjectMode = "legacy"
+	// SubjectModeBoth publishes every event on both subjects while consumers migrate
+	SubjectModeBoth SubjectMode = "both"
+)
+
+// ParseSubjectMode validates a subject mode from configuration
+func ParseSubjectMode(s string) (SubjectMode, error) {
+	switch mode := SubjectMode(s); mode {
+	case SubjectModeNamespaced, SubjectModeLegacy, SubjectModeBoth:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown subject mode %q (want namespaced, legacy or both)", s)
+}
+
+// subjects returns the subjects an event is published on in this mode
+func (m SubjectMode) subjects(legacy string, namespaced string) []string {
+	switch m {
+	case SubjectModeLegacy:
+		return []string{legacy}
+	case SubjectModeBoth:
+		return []string{namespaced, legacy}
+	default:
+		return []string{namespaced}
+	}
+}
+
+// DocumentSubject returns the subject for a document event in a namespace, e.g.
+// wayfile.<namespace>.documents.uploaded
+func DocumentSubject(namespace string, action string) string {
+	return SubjectPrefix + "." + EscapeToken(namespace) + ".documents." + action
+}
+
+// TagSubject returns the subject for an event about a tag in a namespace, e.g.
+// wayfile.<namespace>.tags.<tag-path-token>.extracted. The whole tag path is a single
+// token, so wayfile.<namespace>.tags.<tag-path-token>.* receives every event for one tag.
+func TagSubject(namespace string, tagPath string, action string) string {
+	return SubjectPrefix + "." + EscapeToken(namespace) + ".tags." +
+		TagPathToken(tagPath) + "." + action
+}
+
+// NamespaceSubject returns the subject for an event about a namespace itself, e.g.
+// wayfile.<namespace>.namespace.created
+func NamespaceSubject(namespace string, action string) string {
+	return SubjectPrefix + "." + EscapeToken(namespace) + ".namespace." + action
+}
+
+// SchemaInvalidationSubjects returns the subjects published in a mode when a tag's effective
+// schema may change: its schema changed, or the tag moved or was deleted
+func SchemaInvalidationSubjects(mode SubjectMode) []string {
+	if mode == SubjectModeLegacy {
+		return []string{SchemaChanged, TagUpdated, TagDeleted}
+	}
+	wildcard := SubjectPrefix + ".*.tags.*."
+	return []string{wildcard + "schema_changed", wildcard + "updated", wildcard + "deleted"}
+}
+
+// TagPathToken encodes a tag path such as /invoices/2024 as a single subject token,
+// invoices%2F2024
+func TagPathToken(tagPath string) string {
+	return EscapeToken(strings.TrimPrefix(tagPath, "/"))
+}
+
+// EscapeToken encodes s so it can be used as a single subject token. Letters, digits,
+// '-' and '_' are kept; every other byte, including the '.', '*' and '>' NATS reserves,
+// is written as %XX.
+func EscapeToken(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isTokenChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// UnescapeToken decodes a subject token produced by EscapeToken
+func UnescapeToken(token string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(token))
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		if c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		if i+2 >= len(token) {
+			return "", fmt.Errorf("truncated escape in subject token %q", token)
+		}
+		v, err := strconv.ParseUint(token[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("invalid escape in subject token %q", token)
+		}
+		b.WriteByte(byte(v))
+		i += 2
+	}
+	return b.String(), nil
+}
+
+func isTokenChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '_'
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeToken(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"acme", "acme"},
+		{"Tax-Returns_2019", "Tax-Returns_2019"},
+		{"invoices/2024", "invoices%2F2024"},
+		{"a.b*c>d", "a%2Eb%2Ac%3Ed"},
+		{"two words", "two%20words"},
+		{"100%", "100%25"},
+		{"café", "caf%C3%A9"},
+	}
+	for _, tt := range tests {
+		got := EscapeToken(tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+
+		back, err := UnescapeToken(got)
+		require.NoError(t, err)
+		assert.Equal(t, tt.in, back)
+	}
+
+	for _, invalid := range []string{"%", "%2", "abc%G1"} {
+		_, err := UnescapeToken(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSubjects(t *testing.T) {
+	assert.Equal(t, "wayfile.acme.documents.uploaded", DocumentSubject("acme", "uploaded"))
+	assert.Equal(
+		t,
+		"wayfile.acme.tags.invoices%2F2024.extracted",
+		TagSubject("acme", "/invoices/2024", "extracted"),
+	)
+	assert.Equal(t, "wayfile.my%2Ens.namespace.created", NamespaceSubject("my.ns", "created"))
+}
+
+func TestSubjectMode(t *testing.T) {
+	for _, s := range []string{"namespaced", "legacy", "both"} {
+		mode, err := ParseSubjectMode(s)
+		require.NoError(t, err)
+		assert.Equal(t, SubjectMode(s), mode)
+	}
+	_, err := ParseSubjectMode("flat")
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"wayfile.a.documents.uploaded"},
+		SubjectModeNamespaced.subjects(DocumentUploaded, "wayfile.a.documents.uploaded"))
+	assert.Equal(t, []string{DocumentUploaded},
+		SubjectModeLegacy.subjects(DocumentUploaded, "wayfile.a.documents.uploaded"))
+	assert.Equal(t, []string{"wayfile.a.documents.uploaded", DocumentUploaded},
+		SubjectModeBoth.subjects(DocumentUploaded, "wayfile.a.documents.uploaded"))
+}
+
+func TestSchemaInvalidationSubjects(t *testing.T) {
+	assert.Equal(t, []string{SchemaChanged, TagUpdated, TagDeleted},
+		SchemaInvalidationSubjects(SubjectModeLegacy))
+	namespaced := []string{
+		"wayfile.*.tags.*.schema_changed",
+		"wayfile.*.tags.*.updated",
+		"wayfile.*.tags.*.deleted",
+	}
+	assert.Equal(t, namespaced, SchemaInvalidationSubjects(SubjectModeNamespaced))
+	assert.Equal(t, namespaced, SchemaInvalidationSubjects(SubjectModeBoth))
+}
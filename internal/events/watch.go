@@ -0,0 +1,184 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// followInitialBackoff is how long Follow waits before resubscribing to a subject it
+	// stopped watching; the wait doubles with each failed attempt up to followMaxBackoff
+	followInitialBackoff = time.Second
+	followMaxBackoff     = time.Minute
+)
+
+// StreamedEvent is an event read back from a JetStream stream
+type StreamedEvent struct {
+	*Event
+	// Sequence is the event's position in the stream, usable to resume after it
+	Sequence uint64
+}
+
+// Watcher follows the events published in a namespace
+type Watcher struct {
+	js     nats.JetStreamContext
+	stream string
+}
+
+// NewWatcher creates a Watcher reading from the given stream, which must capture the
+// namespaced subjects
+func NewWatcher(js nats.JetStreamContext, stream string) *Watcher {
+	return &Watcher{js: js, stream: stream}
+}
+
+// NamespaceWildcard returns the subject matching every event in a namespace
+func NamespaceWildcard(namespace string) string {
+	return SubjectPrefix + "." + EscapeToken(namespace) + ".>"
+}
+
+// Watch streams the events published in a namespace, in order, until ctx is cancelled.
+// It starts after stream sequence afterSeq, or with new events when afterSeq is 0. The
+// returned channel is closed when watching stops.
+func (w *Watcher) Watch(
+	ctx context.Context,
+	namespace string,
+	afterSeq uint64,
+) (<-chan StreamedEvent, error) {
+	return w.watch(ctx, NamespaceWildcard(namespace), afterSeq)
+}
+
+// watch streams the events published on subject after stream sequence afterSeq, or new
+// events when afterSeq is 0
+func (w *Watcher) watch(
+	ctx context.Context,
+	subject string,
+	afterSeq uint64,
+) (<-chan StreamedEvent, error) {
+	opts := []nats.SubOpt{nats.BindStream(w.stream), nats.OrderedConsumer()}
+	if afterSeq > 0 {
+		opts = append(opts, nats.StartSequence(afterSeq+1))
+	} else {
+		opts = append(opts, nats.DeliverNew())
+	}
+	sub, err := w.js.SubscribeSync(subject, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch stream %s: %w", w.stream, err)
+	}
+
+	events := make(chan StreamedEvent)
+	go func() {
+		defer close(events)
+		defer func() { _ = sub.Unsubscribe() }()
+
+		for {
+			msg, err := sub.NextMsgWithContext(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("stopped watching events", "subject", subject, "error", err)
+				}
+				return
+			}
+			meta, err := msg.Metadata()
+			if err != nil {
+				slog.Error("skipping event without metadata", "subject", msg.Subject, "error", err)
+				continue
+			}
+			event, err := DecodeEvent(msg.Subject, msg.Data)
+			if err != nil {
+				slog.Error("skipping undecodable event", "subject", msg.Subject, "error", err)
+				continue
+			}
+
+			select {
+			case events <- StreamedEvent{Event: event, Sequence: meta.Sequence.Stream}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// Follow calls notify with the subject of every new message published on any of subjects,
+// without decoding it, until ctx is cancelled. A subscription that stops is re-established
+// with backoff, after which notify is called with an empty subject, as messages may have
+// been missed in between. Only failing to subscribe at first is returned as an error.
+func (w *Watcher) Follow(
+	ctx context.Context,
+	subjects []string,
+	notify func(subject string),
+) error {
+	subs := make([]*nats.Subscription, len(subjects))
+	for i, subject := range subjects {
+		sub, err := w.subscribeNew(subject)
+		if err != nil {
+			for _, sub := range subs[:i] {
+				_ = sub.Unsubscribe()
+			}
+			return err
+		}
+		subs[i] = sub
+	}
+
+	for i, subject := range subjects {
+		go w.follow(ctx, subject, subs[i], notify)
+	}
+	return nil
+}
+
+// subscribeNew subscribes to the new messages published on subject
+func (w *Watcher) subscribeNew(subject string) (*nats.Subscription, error) {
+	sub, err := w.js.SubscribeSync(
+		subject,
+		nats.BindStream(w.stream),
+		nats.OrderedConsumer(),
+		nats.DeliverNew(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch stream %s: %w", w.stream, err)
+	}
+	return sub, nil
+}
+
+// follow hands the messages of sub to notify, resubscribing to subject whenever it stops
+func (w *Watcher) follow(
+	ctx context.Context,
+	subject string,
+	sub *nats.Subscription,
+	notify func(subject string),
+) {
+	for {
+		for {
+			msg, err := sub.NextMsgWithContext(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("stopped following events", "subject", subject, "error", err)
+				}
+				break
+			}
+			notify(msg.Subject)
+		}
+		_ = sub.Unsubscribe()
+
+		backoff := followInitialBackoff
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			var err error
+			if sub, err = w.subscribeNew(subject); err == nil {
+				break
+			}
+			slog.Error("failed to resume following events", "subject", subject, "error", err)
+			backoff = min(backoff*2, followMaxBackoff)
+		}
+		slog.Info("resumed following events", "subject", subject)
+		notify("")
+	}
+}
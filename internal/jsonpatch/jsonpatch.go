@@ -0,0 +1,325 @@
+// Package jsonpatch applies JSON Merge Patch (RFC 7396) and JSON Patch (RFC 6902)
+// documents
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Patch errors
+var (
+	// ErrInvalidPatch is returned when a patch is malformed or can't be applied to the
+	// document, e.g. because a path doesn't exist
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrTestFailed is returned when a JSON Patch test operation doesn't match
+	ErrTestFailed = errors.New("patch test failed")
+)
+
+// MergePatch applies a JSON Merge Patch to a JSON document. Object members set to null in
+// the patch are removed; any other patch value replaces the document's.
+func MergePatch(doc []byte, patch []byte) ([]byte, error) {
+	var target any
+	if len(doc) > 0 {
+		if err := json.Unmarshal(doc, &target); err != nil {
+			return nil, fmt.Errorf("invalid document: %w", err)
+		}
+	}
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergePatch(target, p))
+}
+
+// mergePatch merges patch into target, reusing target's objects
+func mergePatch(target any, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+	return t
+}
+
+// Operation is one operation of a JSON Patch
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies a JSON Patch, an array of operations, to a JSON document. The operations
+// are applied in order and the patch fails as a whole if any of them does.
+func Apply(doc []byte, patch []byte) ([]byte, error) {
+	var root any
+	if len(doc) > 0 {
+		if err := json.Unmarshal(doc, &root); err != nil {
+			return nil, fmt.Errorf("invalid document: %w", err)
+		}
+	}
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: patch must be an array of operations: %v", ErrInvalidPatch, err)
+	}
+
+	for i, op := range ops {
+		var err error
+		if root, err = applyOperation(root, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+// applyOperation applies one operation and returns the new root
+func applyOperation(root any, op Operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: %s requires a value", ErrInvalidPatch, op.Op)
+		}
+		var value any
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(root, path, value)
+		case "replace":
+			if len(path) == 0 {
+				return value, nil
+			}
+			if root, _, err = remove(root, path); err != nil {
+				return nil, err
+			}
+			return add(root, path, value)
+		default:
+			current, err := get(root, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return root, nil
+		}
+	case "remove":
+		if len(path) == 0 {
+			return nil, fmt.Errorf("%w: the document root can't be removed", ErrInvalidPatch)
+		}
+		root, _, err = remove(root, path)
+		return root, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value any
+		if op.Op == "move" {
+			if isProperPrefix(from, path) {
+				return nil, fmt.Errorf(
+					"%w: a value can't be moved into one of its children",
+					ErrInvalidPatch,
+				)
+			}
+			if len(from) == 0 {
+				// Moving the root onto itself
+				return root, nil
+			}
+			if root, value, err = remove(root, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = get(root, from); err != nil {
+				return nil, err
+			}
+			value = deepCopy(value)
+		}
+		return add(root, path, value)
+	}
+	return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path %q must start with /", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// isProperPrefix reports whether prefix points to an ancestor of path
+func isProperPrefix(prefix []string, path []string) bool {
+	return len(prefix) < len(path) && slices.Equal(prefix, path[:len(prefix)])
+}
+
+// arrayIndex parses an array index token, which must be within 0 to limit
+func arrayIndex(token string, limit int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+	if i > limit {
+		return 0, fmt.Errorf("%w: array index %d out of range", ErrInvalidPatch, i)
+	}
+	return i, nil
+}
+
+// get returns the value at path
+func get(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q not found", ErrInvalidPatch, token)
+			}
+			node = child
+		case []any:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: %q is not in an object or array", ErrInvalidPatch, token)
+		}
+	}
+	return node, nil
+}
+
+// add adds value at path, inserting it into arrays, and returns the updated node
+func add(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token, rest := path[0], path[1:]
+
+	switch n := node.(type) {
+	case map[string]any:
+		if len(rest) == 0 {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("%w: member %q not found", ErrInvalidPatch, token)
+		}
+		updated, err := add(child, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+	case []any:
+		if len(rest) == 0 {
+			if token == "-" {
+				return append(n, value), nil
+			}
+			i, err := arrayIndex(token, len(n))
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(n, i, value), nil
+		}
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := add(n[i], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	}
+	return nil, fmt.Errorf("%w: %q is not in an object or array", ErrInvalidPatch, token)
+}
+
+// remove removes the value at a non-empty path and returns the updated node and the
+// removed value
+func remove(node any, path []string) (any, any, error) {
+	token, rest := path[0], path[1:]
+
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: member %q not found", ErrInvalidPatch, token)
+		}
+		if len(rest) == 0 {
+			delete(n, token)
+			return n, child, nil
+		}
+		updated, removed, err := remove(child, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = updated
+		return n, removed, nil
+	case []any:
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) == 0 {
+			removed := n[i]
+			return slices.Delete(n, i, i+1), removed, nil
+		}
+		updated, removed, err := remove(n[i], rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[i] = updated
+		return n, removed, nil
+	}
+	return nil, nil, fmt.Errorf("%w: %q is not in an object or array", ErrInvalidPatch, token)
+}
+
+// deepCopy copies decoded JSON so a copied value can be changed independently
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for key, child := range v {
+			c[key] = deepCopy(child)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, child := range v {
+			c[i] = deepCopy(child)
+		}
+		return c
+	}
+	return value
+}
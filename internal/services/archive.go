@@ -0,0 +1,282 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+)
+
+// Archive errors
+var (
+	// ErrInvalidArchiveRequest is returned when an archive request doesn't select documents correctly
+	ErrInvalidArchiveRequest = errors.New("invalid archive request")
+)
+
+// archiveManifestName is the name of the manifest entry at the root of every archive
+const archiveManifestName = "manifest.json"
+
+// archiveDocumentsDir is the directory documents are placed under inside an archive
+const archiveDocumentsDir = "documents"
+
+// ArchiveRequest selects the documents to include in an archive. Exactly one of
+// DocumentIDs or TagPath must be set.
+type ArchiveRequest struct {
+	DocumentIDs        []string
+	TagPath            string
+	IncludeDescendants bool
+}
+
+// Archive is a resolved set of documents ready to be streamed as a ZIP
+type Archive struct {
+	Namespace string
+	Documents []sqlc.Document
+	Manifest  ArchiveManifest
+}
+
+// ArchiveManifest describes the contents of an archive
+type ArchiveManifest struct {
+	Namespace          string                 `json:"namespace"`
+	TagPath            string                 `json:"tag_path,omitempty"`
+	IncludeDescendants bool                   `json:"include_descendants,omitempty"`
+	CreatedAt          time.Time              `json:"created_at"`
+	Documents          []ArchiveManifestEntry `json:"documents"`
+}
+
+// ArchiveManifestEntry describes a single document in an archive
+type ArchiveManifestEntry struct {
+	ID               string               `json:"id"`
+	Path             string               `json:"path"`
+	FileName         string               `json:"file_name"`
+	Title            string               `json:"title"`
+	MimeType         string               `json:"mime_type"`
+	DetectedMimeType *string              `json:"detected_mime_type,omitempty"`
+	ChecksumSha256   string               `json:"checksum_sha256"`
+	FileSize         int64                `json:"file_size"`
+	DocumentDate     *string              `json:"document_date,omitempty"`
+	Attributes       json.RawMessage      `json:"attributes,omitempty"`
+	Tags             []ArchiveManifestTag `json:"tags"`
+	CreatedAt        time.Time            `json:"created_at"`
+	ModifiedAt       time.Time            `json:"modified_at"`
+}
+
+// ArchiveManifestTag describes a tag applied to an archived document
+type ArchiveManifestTag struct {
+	Path       string          `json:"path"`
+	Attributes json.RawMessage `json:"attributes,omitempty"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+}
+
+// PrepareArchive resolves the documents selected by req within the namespace and builds the
+// archive manifest. Documents outside the namespace are treated as not found.
+func (s *DocumentService) PrepareArchive(
+	ctx context.Context,
+	namespace string,
+	req ArchiveRequest,
+) (*Archive, error) {
+	if (len(req.DocumentIDs) == 0) == (req.TagPath == "") {
+		return nil, fmt.Errorf(
+			"%w: exactly one of document IDs or tag path is required",
+			ErrInvalidArchiveRequest,
+		)
+	}
+
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	var docs []sqlc.Document
+	if req.TagPath != "" {
+		tagPath := normalizeTagPath(req.TagPath)
+		if _, err := s.queries.GetTagByPath(ctx, ns.ID, tagPath); err != nil {
+			return nil, fmt.Errorf("%w at path %q", ErrTagNotFound, tagPath)
+		}
+		docs, err = s.queries.ListDocumentsByTagPath(ctx, ns.ID, tagPath, req.IncludeDescendants)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list documents for tag: %w", err)
+		}
+	} else {
+		ids := make([]pgtype.UUID, 0, len(req.DocumentIDs))
+		seen := make(map[uuid.UUID]bool, len(req.DocumentIDs))
+		for _, id := range req.DocumentIDs {
+			docUUID, err := uuid.Parse(id)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid document ID %q", ErrInvalidArchiveRequest, id)
+			}
+			if seen[docUUID] {
+				continue
+			}
+			seen[docUUID] = true
+			ids = append(ids, pgtype.UUID{Bytes: docUUID, Valid: true})
+		}
+
+		docs, err = s.queries.ListDocumentsByIDs(ctx, ns.ID, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list documents: %w", err)
+		}
+		if len(docs) != len(ids) {
+			return nil, ErrDocumentNotInNamespace
+		}
+	}
+
+	manifest, err := s.buildArchiveManifest(ctx, namespace, docs)
+	if err != nil {
+		return nil, err
+	}
+	if req.TagPath != "" {
+		manifest.TagPath = normalizeTagPath(req.TagPath)
+		manifest.IncludeDescendants = req.IncludeDescendants
+	}
+
+	return &Archive{
+		Namespace: namespace,
+		Documents: docs,
+		Manifest:  *manifest,
+	}, nil
+}
+
+// buildArchiveManifest collects metadata, tags and attributes for the archived documents
+// and assigns each a unique path inside the archive
+func (s *DocumentService) buildArchiveManifest(
+	ctx context.Context,
+	namespace string,
+	docs []sqlc.Document,
+) (*ArchiveManifest, error) {
+	ids := make([]pgtype.UUID, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	tagRows, err := s.queries.ListTagsForDocuments(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document tags: %w", err)
+	}
+	tagsByDoc := make(map[pgtype.UUID][]ArchiveManifestTag, len(docs))
+	for _, row := range tagRows {
+		tagsByDoc[row.DocumentID] = append(tagsByDoc[row.DocumentID], ArchiveManifestTag{
+			Path:       row.Path,
+			Attributes: row.Attributes,
+			Metadata:   row.AttributesMetadata,
+		})
+	}
+
+	manifest := &ArchiveManifest{
+		Namespace: namespace,
+		CreatedAt: time.Now().UTC(),
+		Documents: make([]ArchiveManifestEntry, 0, len(docs)),
+	}
+	used := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		entry := ArchiveManifestEntry{
+			ID:               doc.ID.String(),
+			Path:             uniqueArchivePath(used, doc.FileName),
+			FileName:         doc.FileName,
+			Title:            doc.Title,
+			MimeType:         doc.MimeType,
+			DetectedMimeType: doc.DetectedMimeType,
+			ChecksumSha256:   doc.ChecksumSha256,
+			FileSize:         doc.FileSize,
+			Attributes:       doc.Attributes,
+			Tags:             tagsByDoc[doc.ID],
+			CreatedAt:        doc.CreatedAt.Time,
+			ModifiedAt:       doc.ModifiedAt.Time,
+		}
+		if entry.Tags == nil {
+			entry.Tags = []ArchiveManifestTag{}
+		}
+		if doc.DocumentDate.Valid {
+			date := doc.DocumentDate.Time.Format(time.DateOnly)
+			entry.DocumentDate = &date
+		}
+		manifest.Documents = append(manifest.Documents, entry)
+	}
+
+	return manifest, nil
+}
+
+// uniqueArchivePath returns a safe path for a file inside the archive, adding a numeric
+// suffix when another document with the same name was already placed
+func uniqueArchivePath(used map[string]bool, filename string) string {
+	name := strings.NewReplacer("/", "_", "\\", "_").Replace(filename)
+	if name == "" || name == "." || name == ".." {
+		name = "document"
+	}
+
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := path.Join(archiveDocumentsDir, name)
+	for i := 2; used[candidate]; i++ {
+		candidate = path.Join(archiveDocumentsDir, fmt.Sprintf("%s (%d)%s", base, i, ext))
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// WriteArchive streams the archive as a ZIP to w, writing the manifest first and then each
+// document read directly from storage
+func (s *DocumentService) WriteArchive(ctx context.Context, w io.Writer, archive *Archive) error {
+	zw := zip.NewWriter(w)
+
+	manifest, err := json.MarshalIndent(archive.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	entry, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     archiveManifestName,
+		Method:   zip.Deflate,
+		Modified: archive.Manifest.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := entry.Write(manifest); err != nil {
+		return err
+	}
+
+	for i := range archive.Documents {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		doc := &archive.Documents[i]
+		if err := s.writeArchiveEntry(ctx, zw, doc, archive.Manifest.Documents[i].Path); err != nil {
+			return fmt.Errorf("failed to archive document %s: %w", doc.ID.String(), err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeArchiveEntry copies a single document from storage into the archive
+func (s *DocumentService) writeArchiveEntry(
+	ctx context.Context,
+	zw *zip.Writer,
+	doc *sqlc.Document,
+	name string,
+) error {
+	reader, err := s.storage.Open(ctx, doc)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	entry, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: doc.CreatedAt.Time,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, reader)
+	return err
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueArchivePath(t *testing.T) {
+	used := make(map[string]bool)
+
+	tests := []struct {
+		name     string
+		filename string
+		want     string
+	}{
+		{name: "plain filename", filename: "report.pdf", want: "documents/report.pdf"},
+		{name: "duplicate filename", filename: "report.pdf", want: "documents/report (2).pdf"},
+		{name: "second duplicate", filename: "report.pdf", want: "documents/report (3).pdf"},
+		{name: "no extension", filename: "README", want: "documents/README"},
+		{name: "path separators", filename: "../../etc/passwd", want: "documents/.._.._etc_passwd"},
+		{name: "windows separators", filename: `C:\temp\a.txt`, want: "documents/C:_temp_a.txt"},
+		{name: "parent directory", filename: "..", want: "documents/document"},
+		{name: "empty", filename: "", want: "documents/document (2)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, uniqueArchivePath(used, tt.filename))
+		})
+	}
+}
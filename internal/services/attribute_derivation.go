@@ -0,0 +1,271 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+)
+
+// computedKeyword is the property keyword holding the expression that computes an
+// attribute, e.g. "x-computed": "net + tax"
+const computedKeyword = "x-computed"
+
+// documentReference is the root of expression references to the fields of the document
+// whose attributes are written, e.g. $document.document_date
+const documentReference = "$document"
+
+// documentFieldNames are the document fields expressions can refer to
+var documentFieldNames = []string{"document_date", "file_name", "mime_type", "title"}
+
+// documentFields returns the fields of a document expressions can refer to. A document
+// without a date has no document_date.
+func documentFields(document *sqlc.Document) map[string]interface{} {
+	if document == nil {
+		return nil
+	}
+	fields := map[string]interface{}{
+		"file_name": document.FileName,
+		"mime_type": document.MimeType,
+		"title":     document.Title,
+	}
+	if document.DocumentDate.Valid {
+		fields["document_date"] = document.DocumentDate.Time.Format(time.DateOnly)
+	}
+	return fields
+}
+
+// AttributeDerivations lists the attributes a write didn't set itself
+type AttributeDerivations struct {
+	// Defaulted are the attributes set to their schema default
+	Defaulted []string
+	// Computed are the attributes computed from other attributes
+	Computed []string
+	// Coerced are the values converted to their declared type
+	Coerced []AttributeCoercion
+}
+
+// computedAttribute is an attribute computed by an expression
+type computedAttribute struct {
+	field      string
+	expression expression
+}
+
+// schemaDerivations fills in the attributes a schema declares defaults or expressions for
+type schemaDerivations struct {
+	// properties are the schema's property definitions, holding the defaults
+	properties map[string]interface{}
+	// computed are the computed attributes, each after those its expression refers to
+	computed []computedAttribute
+}
+
+// parseSchemaDerivations reads the defaults and computed attributes of a schema. Computed
+// attributes are top-level properties; their expressions must parse and may refer to other
+// computed attributes, but not in a cycle.
+func parseSchemaDerivations(schema []byte) (*schemaDerivations, error) {
+	var definition map[string]interface{}
+	if err := json.Unmarshal(schema, &definition); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSONSchema, err)
+	}
+	properties, _ := definition["properties"].(map[string]interface{})
+	derivations := &schemaDerivations{properties: properties}
+
+	expressions := make(map[string]expression)
+	for _, field := range slices.Sorted(maps.Keys(properties)) {
+		property, _ := properties[field].(map[string]interface{})
+		source, ok := property[computedKeyword]
+		if !ok {
+			continue
+		}
+		pointer := computedPointer(field)
+		if _, ok := property["default"]; ok {
+			return nil, schemaKeywordError(
+				pointer,
+				computedKeyword,
+				"computed attribute %q cannot have a default",
+				field,
+			)
+		}
+		sourceText, ok := source.(string)
+		if !ok {
+			return nil, schemaKeywordError(
+				pointer,
+				computedKeyword,
+				"%s of %q must be a string",
+				computedKeyword,
+				field,
+			)
+		}
+		expr, err := parseExpression(sourceText)
+		if err == nil {
+			err = checkDocumentReferences(expr)
+		}
+		if err != nil {
+			return nil, schemaKeywordError(
+				pointer,
+				computedKeyword,
+				"%s of %q: %v",
+				computedKeyword,
+				field,
+				err,
+			)
+		}
+		expressions[field] = expr
+	}
+
+	// Order the computed attributes so each is computed after those it refers to
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var visit func(field string, chain []string) error
+	visit = func(field string, chain []string) error {
+		switch state[field] {
+		case visiting:
+			return schemaKeywordError(
+				computedPointer(field),
+				computedKeyword,
+				"computed attributes refer to each other: %s",
+				strings.Join(append(chain, field), " -> "),
+			)
+		case visited:
+			return nil
+		}
+		state[field] = visiting
+		for _, reference := range expressions[field].references(nil) {
+			dependency, _, _ := strings.Cut(reference, ".")
+			if _, ok := expressions[dependency]; ok {
+				if err := visit(dependency, append(chain, field)); err != nil {
+					return err
+				}
+			}
+		}
+		state[field] = visited
+		derivations.computed = append(
+			derivations.computed,
+			computedAttribute{field: field, expression: expressions[field]},
+		)
+		return nil
+	}
+	for _, field := range slices.Sorted(maps.Keys(expressions)) {
+		if err := visit(field, nil); err != nil {
+			return nil, err
+		}
+	}
+	return derivations, nil
+}
+
+// checkDocumentReferences checks that the references of an expression starting with $ are
+// to document fields
+func checkDocumentReferences(expr expression) error {
+	for _, reference := range expr.references(nil) {
+		if !strings.HasPrefix(reference, "$") {
+			continue
+		}
+		root, field, _ := strings.Cut(reference, ".")
+		if root != documentReference || !slices.Contains(documentFieldNames, field) {
+			return fmt.Errorf(
+				"unknown document field %q, expected one of %s.%s",
+				reference,
+				documentReference,
+				strings.Join(documentFieldNames, ", "+documentReference+"."),
+			)
+		}
+	}
+	return nil
+}
+
+// computedPointer is the JSON pointer to the expression of a computed attribute
+func computedPointer(field string) string {
+	return "/properties/" + escapePointerToken(field) + "/" + computedKeyword
+}
+
+// apply sets missing attributes that have a default, including in nested objects, then
+// computes the computed attributes, replacing any value written to them. Expressions see
+// the attributes and, under $document, the fields of the document they're written to, see
+// documentFields. A computed attribute whose expression refers to a missing attribute or
+// field is removed.
+func (d *schemaDerivations) apply(
+	attributes map[string]interface{},
+	document map[string]interface{},
+) (*AttributeDerivations, error) {
+	derived := &AttributeDerivations{Defaulted: applyDefaults(d.properties, attributes)}
+	if len(d.computed) == 0 {
+		return derived, nil
+	}
+	scope := maps.Clone(attributes)
+	if document != nil {
+		scope[documentReference] = document
+	}
+	for _, computed := range d.computed {
+		value, err := computed.expression.eval(scope)
+		if errors.Is(err, errMissingOperand) {
+			delete(attributes, computed.field)
+			delete(scope, computed.field)
+			continue
+		}
+		if err != nil {
+			return nil, &ValidationError{
+				Err:    ErrAttributeValidationFailed,
+				detail: fmt.Sprintf("computing %q: %v", computed.field, err),
+				Violations: []ValidationViolation{{
+					Pointer: "/" + escapePointerToken(computed.field),
+					Keyword: computedKeyword,
+					Message: err.Error(),
+				}},
+			}
+		}
+		attributes[computed.field] = value
+		scope[computed.field] = value
+		derived.Computed = append(derived.Computed, computed.field)
+	}
+	return derived, nil
+}
+
+// applyDefaults sets the missing attributes of an object that have a default, recursing
+// into the nested objects present, and returns the names of those it set
+func applyDefaults(properties map[string]interface{}, object map[string]interface{}) []string {
+	var defaulted []string
+	for _, field := range slices.Sorted(maps.Keys(properties)) {
+		property, _ := properties[field].(map[string]interface{})
+		value, present := object[field]
+		if !present {
+			if defaultValue, ok := property["default"]; ok {
+				object[field] = cloneJSON(defaultValue)
+				defaulted = append(defaulted, field)
+			}
+			continue
+		}
+		nestedProperties, _ := property["properties"].(map[string]interface{})
+		if nested, ok := value.(map[string]interface{}); ok && nestedProperties != nil {
+			applyDefaults(nestedProperties, nested)
+		}
+	}
+	return defaulted
+}
+
+// cloneJSON deep-copies a decoded JSON value, so defaults aren't shared between writes
+func cloneJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			clone[key] = cloneJSON(item)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+		for i, item := range v {
+			clone[i] = cloneJSON(item)
+		}
+		return clone
+	}
+	return value
+}
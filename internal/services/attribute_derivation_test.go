@@ -0,0 +1,126 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+)
+
+func TestSchemaDerivations(t *testing.T) {
+	derivations, err := parseSchemaDerivations([]byte(`{
+		"type": "object",
+		"properties": {
+			"gross": {"type": "number", "x-computed": "total * 1"},
+			"total": {"type": "number", "x-computed": "net + tax"},
+			"net": {"type": "number"},
+			"tax": {"type": "number", "default": 0},
+			"currency": {"type": "string", "default": "EUR"},
+			"year": {"type": "integer", "x-computed": "year(document_date)"},
+			"document_date": {"type": "string", "format": "date"},
+			"vendor": {
+				"type": "object",
+				"properties": {"country": {"type": "string", "default": "NL"}}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	// Computed attributes come after those they refer to
+	var order []string
+	for _, computed := range derivations.computed {
+		order = append(order, computed.field)
+	}
+	assert.Equal(t, []string{"total", "gross", "year"}, order)
+
+	attributes := map[string]interface{}{
+		"net":    100.0,
+		"total":  1.0,
+		"year":   1999.0,
+		"vendor": map[string]interface{}{"name": "Acme"},
+	}
+	derived, err := derivations.apply(attributes, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &AttributeDerivations{
+		Defaulted: []string{"currency", "tax"},
+		Computed:  []string{"total", "gross"},
+	}, derived)
+	assert.Equal(t, map[string]interface{}{
+		"net":      100.0,
+		"tax":      0.0,
+		"currency": "EUR",
+		"total":    100.0,
+		"gross":    100.0,
+		"vendor":   map[string]interface{}{"name": "Acme", "country": "NL"},
+	}, attributes, "written values of computed attributes are replaced or removed")
+
+	_, err = derivations.apply(map[string]interface{}{"net": "100"}, nil)
+	assert.ErrorIs(t, err, ErrAttributeValidationFailed)
+}
+
+func TestSchemaDerivationsDocumentFields(t *testing.T) {
+	derivations, err := parseSchemaDerivations([]byte(`{
+		"type": "object",
+		"properties": {
+			"year": {"type": "integer", "x-computed": "year($document.document_date)"},
+			"label": {"type": "string", "x-computed": "concat($document.title, ' ', kind)"},
+			"source": {"type": "string", "x-computed": "$document.file_name"},
+			"format": {"type": "string", "x-computed": "$document.mime_type"},
+			"kind": {"type": "string"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	document := &sqlc.Document{
+		FileName:     "bill.pdf",
+		Title:        "Bill",
+		MimeType:     "application/pdf",
+		DocumentDate: pgtype.Date{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	attributes := map[string]interface{}{"kind": "utility"}
+	_, err = derivations.apply(attributes, documentFields(document))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"kind":   "utility",
+		"year":   2024.0,
+		"label":  "Bill utility",
+		"source": "bill.pdf",
+		"format": "application/pdf",
+	}, attributes)
+
+	// Attributes can't stand in for document fields
+	document.DocumentDate = pgtype.Date{}
+	attributes = map[string]interface{}{"document_date": "2020-01-01"}
+	_, err = derivations.apply(attributes, documentFields(document))
+	require.NoError(t, err)
+	assert.NotContains(t, attributes, "year", "the document has no date")
+
+	// Without a document, attributes computed from its fields are left out
+	attributes = map[string]interface{}{"kind": "utility"}
+	derived, err := derivations.apply(attributes, nil)
+	require.NoError(t, err)
+	assert.Empty(t, derived.Computed)
+	assert.Equal(t, map[string]interface{}{"kind": "utility"}, attributes)
+}
+
+func TestParseSchemaDerivationsErrors(t *testing.T) {
+	for _, schema := range []string{
+		`{"type": "object", "properties": {"a": {"type": "number", "x-computed": 5}}}`,
+		`{"type": "object", "properties": {"a": {"type": "number", "x-computed": "b +"}}}`,
+		`{"type": "object", "properties": {"a": {"type": "number", "x-computed": "b", "default": 1}}}`,
+		`{"type": "object", "properties": {
+			"a": {"type": "number", "x-computed": "b + 1"},
+			"b": {"type": "number", "x-computed": "a - 1"}
+		}}`,
+		`{"type": "object", "properties": {"a": {"type": "string", "x-computed": "$document"}}}`,
+		`{"type": "object", "properties": {"a": {"type": "number", "x-computed": "$document.size"}}}`,
+		`{"type": "object", "properties": {"a": {"type": "string", "x-computed": "$tag.path"}}}`,
+	} {
+		_, err := parseSchemaDerivations([]byte(schema))
+		assert.ErrorIs(t, err, ErrInvalidJSONSchema, schema)
+	}
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLocale is returned for a coercion locale that isn't a language tag
+var ErrInvalidLocale = errors.New("invalid locale")
+
+// CoercionOptions turn on converting attribute values to the types their schema declares
+type CoercionOptions struct {
+	// Locale is the BCP 47 language tag the values were written in, e.g. "de-DE". It
+	// decides the decimal separator of numbers and the order of numeric dates; without it
+	// ambiguous values are left as they are.
+	Locale string
+}
+
+// AttributeCoercion is an attribute value converted to the type its schema declares
+type AttributeCoercion struct {
+	// Path is the value's location, e.g. total, vendor.founded or lines[2]
+	Path string `json:"path"`
+	// From is the value as written
+	From interface{} `json:"from"`
+	// To is the value stored
+	To interface{} `json:"to"`
+}
+
+// Validate checks the options' locale
+func (o *CoercionOptions) Validate() error {
+	_, err := parseCoercionLocale(o.Locale)
+	return err
+}
+
+// coercionLocale is what coercion needs to know about a locale
+type coercionLocale struct {
+	// decimal is the decimal separator, 0 if unknown
+	decimal byte
+	// monthFirst is whether numeric dates put the month first, nil if unknown
+	monthFirst *bool
+}
+
+var (
+	localeRegex = regexp.MustCompile(`^([a-zA-Z]{2,3})(?:[-_]([a-zA-Z0-9]{2,8}))*$`)
+	// commaDecimalLanguages are the languages whose decimal separator is a comma
+	commaDecimalLanguages = []string{
+		"bg", "ca", "cs", "da", "de", "el", "es", "et", "fi", "fr", "hr", "hu", "id", "it",
+		"lt", "lv", "nb", "nl", "nn", "no", "pl", "pt", "ro", "ru", "sk", "sl", "sr", "sv",
+		"tr", "uk", "vi",
+	}
+	// monthFirstRegions are the regions writing numeric dates month first
+	monthFirstRegions = []string{"US", "PH", "FM", "MH", "PW"}
+)
+
+// parseCoercionLocale reads the parts of a language tag coercion uses
+func parseCoercionLocale(locale string) (coercionLocale, error) {
+	if locale == "" {
+		return coercionLocale{}, nil
+	}
+	if !localeRegex.MatchString(locale) {
+		return coercionLocale{}, fmt.Errorf("%w %q", ErrInvalidLocale, locale)
+	}
+	parts := strings.FieldsFunc(locale, func(r rune) bool { return r == '-' || r == '_' })
+	language := strings.ToLower(parts[0])
+
+	parsed := coercionLocale{decimal: '.'}
+	if slices.Contains(commaDecimalLanguages, language) {
+		parsed.decimal = ','
+	}
+	monthFirst := false
+	for _, part := range parts[1:] {
+		if len(part) == 2 && slices.Contains(monthFirstRegions, strings.ToUpper(part)) {
+			monthFirst = true
+		}
+	}
+	parsed.monthFirst = &monthFirst
+	return parsed, nil
+}
+
+// coerceAttributes converts the string, number and boolean values of attributes to the
+// types their properties declare, in place, and returns the conversions made. Values that
+// already have a declared type, or can't be converted, are left for validation to judge.
+func coerceAttributes(
+	properties map[string]interface{},
+	attributes map[string]interface{},
+	options *CoercionOptions,
+) ([]AttributeCoercion, error) {
+	locale, err := parseCoercionLocale(options.Locale)
+	if err != nil {
+		return nil, err
+	}
+	c := &coercion{locale: locale}
+	c.object("", properties, attributes)
+	return c.coercions, nil
+}
+
+// coercedField returns the top-level attribute a coercion was made in
+func coercedField(coercion AttributeCoercion) string {
+	field, _, _ := strings.Cut(coercion.Path, ".")
+	field, _, _ = strings.Cut(field, "[")
+	return field
+}
+
+// coercion collects the conversions made while coercing attributes
+type coercion struct {
+	locale    coercionLocale
+	coercions []AttributeCoercion
+}
+
+// object coerces the properties of an object at path
+func (c *coercion) object(
+	path string,
+	properties map[string]interface{},
+	object map[string]interface{},
+) {
+	for _, field := range slices.Sorted(maps.Keys(properties)) {
+		value, ok := object[field]
+		if !ok {
+			continue
+		}
+		property, _ := properties[field].(map[string]interface{})
+		object[field] = c.value(joinField(path, field), property, value)
+	}
+}
+
+// value coerces a value at path to its property's type and returns the result
+func (c *coercion) value(
+	path string,
+	property map[string]interface{},
+	value interface{},
+) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		nested, _ := property["properties"].(map[string]interface{})
+		c.object(path, nested, v)
+		return v
+	case []interface{}:
+		items, _ := property["items"].(map[string]interface{})
+		for i, item := range v {
+			v[i] = c.value(fmt.Sprintf("%s[%d]", path, i), items, item)
+		}
+		return v
+	case nil:
+		return nil
+	}
+
+	types := schemaTypes(property)
+	if len(types) == 0 {
+		return value
+	}
+	format, _ := property["format"].(string)
+	if slices.Contains(types, jsonType(value)) &&
+		(jsonType(value) != "string" || format == "") {
+		return value
+	}
+	if jsonType(value) == "number" && slices.Contains(types, "integer") &&
+		value.(float64) == math.Trunc(value.(float64)) {
+		return value
+	}
+
+	for _, t := range types {
+		coerced, ok := c.convert(t, format, value)
+		if !ok {
+			continue
+		}
+		if coerced != value {
+			c.coercions = append(
+				c.coercions,
+				AttributeCoercion{Path: path, From: value, To: coerced},
+			)
+		}
+		return coerced
+	}
+	return value
+}
+
+// convert converts a value to a type, and a string to a format
+func (c *coercion) convert(t string, format string, value interface{}) (interface{}, bool) {
+	switch t {
+	case "number", "integer":
+		var number float64
+		switch v := value.(type) {
+		case string:
+			parsed, ok := c.parseNumber(v)
+			if !ok {
+				return nil, false
+			}
+			number = parsed
+		case float64:
+			number = v
+		default:
+			return nil, false
+		}
+		if t == "integer" && number != math.Trunc(number) {
+			return nil, false
+		}
+		return number, true
+	case "boolean":
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "true", "yes", "y", "on", "1":
+			return true, true
+		case "false", "no", "n", "off", "0":
+			return false, true
+		}
+		return nil, false
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return c.formatString(format, v)
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		case bool:
+			return strconv.FormatBool(v), true
+		}
+	}
+	return nil, false
+}
+
+// numberRegex matches numbers with optional grouping, before separators are resolved
+var numberRegex = regexp.MustCompile(`^[+-]?[0-9][0-9.,' \x{00A0}\x{202F}]*$`)
+
+// parseNumber parses a number written with the locale's separators. Without a locale, the
+// last of a mix of '.' and ',' is the decimal separator, and a lone ',' is one unless it
+// is followed by exactly three digits, which is ambiguous.
+func (c *coercion) parseNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if !numberRegex.MatchString(s) {
+		return 0, false
+	}
+	for _, space := range []string{" ", "'", "\u00a0", "\u202f"} {
+		s = strings.ReplaceAll(s, space, "")
+	}
+
+	decimal := c.locale.decimal
+	if decimal == 0 {
+		lastDot, lastComma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+		switch {
+		case lastDot >= 0 && lastComma >= 0:
+			decimal = s[max(lastDot, lastComma)]
+		case lastComma >= 0 && strings.Count(s, ",") == 1:
+			if len(s)-lastComma-1 == 3 {
+				return 0, false
+			}
+			decimal = ','
+		case lastComma >= 0:
+			decimal = '.'
+		default:
+			decimal = '.'
+			if strings.Count(s, ".") > 1 {
+				decimal = ','
+			}
+		}
+	}
+	grouping := ","
+	if decimal == ',' {
+		grouping = "."
+	}
+	if strings.Count(s, string(decimal)) > 1 {
+		return 0, false
+	}
+	s = strings.ReplaceAll(s, grouping, "")
+	s = strings.Replace(s, string(decimal), ".", 1)
+	number, err := strconv.ParseFloat(s, 64)
+	return number, err == nil
+}
+
+// dateLayouts are the unambiguous date layouts coercion reads
+var dateLayouts = []string{
+	time.DateOnly, "2006/01/02", "2006.01.02", "20060102", "2 January 2006", "January 2, 2006",
+	"2 Jan 2006", "Jan 2, 2006", "02-Jan-2006",
+}
+
+// dateTimeLayouts are the date-time layouts coercion reads, after the dates
+var dateTimeLayouts = []string{
+	time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05Z07:00", "2006-01-02 15:04:05",
+	"2006-01-02 15:04", time.RFC1123Z, time.RFC1123,
+}
+
+// numericDateRegex matches dates of day, month and year numbers, e.g. 01/03/2024
+var numericDateRegex = regexp.MustCompile(`^(\d{1,2})([./-])(\d{1,2})[./-](\d{4})$`)
+
+// formatString normalizes a date or date-time string to its format
+func (c *coercion) formatString(format string, s string) (interface{}, bool) {
+	switch format {
+	case "date":
+		t, ok := c.parseDate(s)
+		if !ok {
+			return nil, false
+		}
+		return t.Format(time.DateOnly), true
+	case "date-time":
+		t, ok := c.parseDate(s)
+		if !ok {
+			return nil, false
+		}
+		return t.Format(time.RFC3339Nano), true
+	}
+	return s, true
+}
+
+// parseDate parses a date or date-time. Numeric dates are read day first, or month first
+// in regions writing them so; without a locale they must be unambiguous. Dates with dots
+// are always day first.
+func (c *coercion) parseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range slices.Concat(dateLayouts, dateTimeLayouts) {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	match := numericDateRegex.FindStringSubmatch(s)
+	if match == nil {
+		return time.Time{}, false
+	}
+	first, _ := strconv.Atoi(match[1])
+	second, _ := strconv.Atoi(match[3])
+	year, _ := strconv.Atoi(match[4])
+	monthFirst := false
+	switch {
+	case match[2] == ".":
+	case c.locale.monthFirst != nil:
+		monthFirst = *c.locale.monthFirst
+	case first > 12:
+	case second > 12:
+		monthFirst = true
+	case first != second:
+		return time.Time{}, false
+	}
+	day, month := first, second
+	if monthFirst {
+		day, month = second, first
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Day() != day || int(t.Month()) != month {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case nil:
+		return "null"
+	}
+	return ""
+}
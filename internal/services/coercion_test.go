@@ -0,0 +1,125 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoerceValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		property string
+		value    interface{}
+		want     interface{}
+	}{
+		{"plain number", "", `{"type": "number"}`, "12.5", 12.5},
+		{"grouped number", "", `{"type": "number"}`, "1,234.50", 1234.5},
+		{"european number", "", `{"type": "number"}`, "1.234,50", 1234.5},
+		{"repeated grouping", "", `{"type": "number"}`, "1.234.567", 1234567.0},
+		{"lone comma decimal", "", `{"type": "number"}`, "12,5", 12.5},
+		{"ambiguous comma left as is", "", `{"type": "number"}`, "1,234", "1,234"},
+		{"locale comma decimal", "de-DE", `{"type": "number"}`, "1,234", 1.234},
+		{"locale comma grouping", "en-US", `{"type": "number"}`, "1,234", 1234.0},
+		{"space grouping", "fr-FR", `{"type": "number"}`, "1 234,5", 1234.5},
+		{"apostrophe grouping", "de-CH", `{"type": "number"}`, "1'234,5", 1234.5},
+		{"negative", "", `{"type": "number"}`, "-3.5", -3.5},
+		{"integer", "", `{"type": "integer"}`, "1,000.00", 1000.0},
+		{"fractional integer left as is", "", `{"type": "integer"}`, "2.5", "2.5"},
+		{"not a number", "", `{"type": "number"}`, "12 apples", "12 apples"},
+		{"yes", "", `{"type": "boolean"}`, "Yes", true},
+		{"off", "", `{"type": "boolean"}`, "off", false},
+		{"not a boolean", "", `{"type": "boolean"}`, "maybe", "maybe"},
+		{"number to string", "", `{"type": "string"}`, 42.0, "42"},
+		{"boolean to string", "", `{"type": "string"}`, true, "true"},
+		{"iso date", "", `{"type": "string", "format": "date"}`, "2024-03-15", "2024-03-15"},
+		{"named month", "", `{"type": "string", "format": "date"}`, "15 March 2024", "2024-03-15"},
+		{"dotted date", "", `{"type": "string", "format": "date"}`, "15.03.2024", "2024-03-15"},
+		{"inferred date", "", `{"type": "string", "format": "date"}`, "03/15/2024", "2024-03-15"},
+		{"ambiguous date left as is", "", `{"type": "string", "format": "date"}`,
+			"03/04/2024", "03/04/2024"},
+		{"us date", "en-US", `{"type": "string", "format": "date"}`, "03/04/2024", "2024-03-04"},
+		{"british date", "en-GB", `{"type": "string", "format": "date"}`,
+			"03/04/2024", "2024-04-03"},
+		{"impossible date left as is", "en-GB", `{"type": "string", "format": "date"}`,
+			"31/02/2024", "31/02/2024"},
+		{"date-time", "", `{"type": "string", "format": "date-time"}`,
+			"2024-03-15 10:30:00", "2024-03-15T10:30:00Z"},
+		{"date to date-time", "", `{"type": "string", "format": "date-time"}`,
+			"2024-03-15", "2024-03-15T00:00:00Z"},
+		{"first type that fits", "", `{"type": ["boolean", "number"]}`, "1", true},
+		{"already typed", "", `{"type": ["string", "number"]}`, "12", "12"},
+		{"untyped", "", `{}`, "12", "12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			properties := parseProperties(t, `{"value": `+tt.property+`}`)
+			attributes := map[string]interface{}{"value": tt.value}
+			coercions, err := coerceAttributes(
+				properties,
+				attributes,
+				&CoercionOptions{Locale: tt.locale},
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, attributes["value"])
+			if tt.want == tt.value {
+				assert.Empty(t, coercions)
+			} else {
+				assert.Equal(t, []AttributeCoercion{
+					{Path: "value", From: tt.value, To: tt.want},
+				}, coercions)
+			}
+		})
+	}
+}
+
+func TestCoerceNestedValues(t *testing.T) {
+	properties := parseProperties(t, `{
+		"vendor": {
+			"type": "object",
+			"properties": {"founded": {"type": "string", "format": "date"}}
+		},
+		"lines": {"type": "array", "items": {"type": "number"}},
+		"notes": {"type": "string"}
+	}`)
+	attributes := map[string]interface{}{
+		"vendor": map[string]interface{}{"founded": "1 Jan 1990", "name": "Acme"},
+		"lines":  []interface{}{"1,5", 2.0, "3,25"},
+		"notes":  "1,5",
+		"extra":  "1,5",
+	}
+
+	coercions, err := coerceAttributes(properties, attributes, &CoercionOptions{Locale: "nl"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"vendor": map[string]interface{}{"founded": "1990-01-01", "name": "Acme"},
+		"lines":  []interface{}{1.5, 2.0, 3.25},
+		"notes":  "1,5",
+		"extra":  "1,5",
+	}, attributes)
+	assert.Equal(t, []AttributeCoercion{
+		{Path: "lines[0]", From: "1,5", To: 1.5},
+		{Path: "lines[2]", From: "3,25", To: 3.25},
+		{Path: "vendor.founded", From: "1 Jan 1990", To: "1990-01-01"},
+	}, coercions)
+	assert.Equal(t, "lines", coercedField(coercions[0]))
+	assert.Equal(t, "vendor", coercedField(coercions[2]))
+}
+
+func TestCoercionLocale(t *testing.T) {
+	assert.NoError(t, (&CoercionOptions{}).Validate())
+	assert.NoError(t, (&CoercionOptions{Locale: "pt_BR"}).Validate())
+	assert.ErrorIs(t, (&CoercionOptions{Locale: "german"}).Validate(), ErrInvalidLocale)
+	assert.ErrorIs(t, (&CoercionOptions{Locale: "de DE"}).Validate(), ErrInvalidLocale)
+}
+
+func parseProperties(t *testing.T, properties string) map[string]interface{} {
+	t.Helper()
+	derivations, err := parseSchemaDerivations(
+		[]byte(`{"type": "object", "properties": ` + properties + `}`),
+	)
+	require.NoError(t, err)
+	return derivations.properties
+}
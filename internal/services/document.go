@@ -4,17 +4,26 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
 	"github.com/RynoXLI/Wayfile/internal/auth"
+	"github.com/RynoXLI/Wayfile/internal/db"
 	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
 	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/jsonpatch"
 	"github.com/RynoXLI/Wayfile/internal/storage"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -28,9 +37,10 @@ var (
 // DocumentService orchestrates document operations across storage, events, and URL generation
 type DocumentService struct {
 	storage    *storage.Storage
-	publisher  events.Publisher
+	outbox     *events.Outbox
 	signer     *auth.Signer
 	baseURL    string
+	db         db.TxBeginner
 	queries    *sqlc.Queries
 	tagService *TagService
 }
@@ -43,8 +53,40 @@ const (
 	ExtractionMethodManual ExtractionMethod = "manual"
 	// ExtractionMethodAutomatic indicates the tag/attribute was automatically extracted
 	ExtractionMethodAutomatic ExtractionMethod = "automatic"
+	// ExtractionMethodDerived indicates the attribute was computed from other attributes by
+	// an expression in the tag's schema
+	ExtractionMethodDerived ExtractionMethod = "derived"
 )
 
+// AttributeUpdateMode is how an attributes update is applied to the current attributes
+type AttributeUpdateMode string
+
+const (
+	// AttributeUpdateReplace replaces the attributes with a new JSON object
+	AttributeUpdateReplace AttributeUpdateMode = "replace"
+	// AttributeUpdateMergePatch applies a JSON Merge Patch (RFC 7396) to the attributes
+	AttributeUpdateMergePatch AttributeUpdateMode = "merge_patch"
+	// AttributeUpdateJSONPatch applies a JSON Patch (RFC 6902) to the attributes
+	AttributeUpdateJSONPatch AttributeUpdateMode = "json_patch"
+)
+
+// SkipReason is why an automatic extraction left an attribute as it was
+type SkipReason string
+
+const (
+	// SkipReasonManual indicates the attribute was set manually
+	SkipReasonManual SkipReason = "manual"
+	// SkipReasonHigherConfidence indicates the attribute was extracted with a higher
+	// confidence
+	SkipReasonHigherConfidence SkipReason = "higher_confidence"
+)
+
+// SkippedAttribute is an extracted attribute that was not written over the current value
+type SkippedAttribute struct {
+	Field  string
+	Reason SkipReason
+}
+
 // AttributeExtractionInfo contains extraction info for a single attribute
 type AttributeExtractionInfo struct {
 	Method      ExtractionMethod `json:"extraction_method"`
@@ -52,6 +94,8 @@ type AttributeExtractionInfo struct {
 	ExtractedAt time.Time        `json:"extracted_at"`
 	Source      string           `json:"source"`
 	Confidence  *float64         `json:"confidence,omitempty"`
+	// Coercions are the values of the attribute converted to their declared type
+	Coercions []AttributeCoercion `json:"coercions,omitempty"`
 }
 
 // DocumentTagMetadata contains comprehensive extraction information
@@ -65,17 +109,19 @@ type DocumentTagMetadata struct {
 // NewDocumentService creates a new document service with the given dependencies
 func NewDocumentService(
 	storage *storage.Storage,
-	publisher events.Publisher,
+	outbox *events.Outbox,
 	signer *auth.Signer,
 	baseURL string,
+	pool db.TxBeginner,
 	queries *sqlc.Queries,
 	tagService *TagService,
 ) *DocumentService {
 	return &DocumentService{
 		storage:    storage,
-		publisher:  publisher,
+		outbox:     outbox,
 		signer:     signer,
 		baseURL:    baseURL,
+		db:         pool,
 		queries:    queries,
 		tagService: tagService,
 	}
@@ -135,11 +181,13 @@ func (s *DocumentService) parseExistingMetadata(
 ) DocumentTagMetadata {
 	if len(metadataBytes) > 0 {
 		var metadata DocumentTagMetadata
-		if err := json.Unmarshal(metadataBytes, &metadata); err == nil {
+		err := json.Unmarshal(metadataBytes, &metadata)
+		if err == nil && metadata.Tag.Method != "" {
 			return metadata
 		}
 	}
-	// Return default metadata if parsing fails or no metadata exists
+	// Return default metadata if parsing fails or no metadata exists, e.g. the empty
+	// global metadata of a new document
 	return DocumentTagMetadata{
 		Tag: AttributeExtractionInfo{
 			Method: ExtractionMethodManual, ExtractedBy: fallbackExtractedBy,
@@ -167,6 +215,73 @@ func (s *DocumentService) updateAttributeExtractionInfo(
 	}
 }
 
+// updateChangedAttributeInfo updates extraction info for the attributes an update added or
+// changed and removes it for those it deleted, keeping the provenance of the rest
+func (s *DocumentService) updateChangedAttributeInfo(
+	metadata *DocumentTagMetadata,
+	previous map[string]interface{},
+	updated map[string]interface{},
+	extractionMethod ExtractionMethod,
+	extractedBy string,
+) {
+	changed := make(map[string]interface{}, len(updated))
+	for fieldName, value := range updated {
+		if old, ok := previous[fieldName]; !ok || !reflect.DeepEqual(old, value) {
+			changed[fieldName] = value
+		}
+	}
+	s.updateAttributeExtractionInfo(metadata, changed, extractionMethod, extractedBy)
+	for fieldName := range metadata.Attributes {
+		if _, ok := updated[fieldName]; !ok {
+			delete(metadata.Attributes, fieldName)
+		}
+	}
+}
+
+// attributeValidationError reports attributes that couldn't be prepared for writing as an
+// invalid argument. Failed validations are returned as they are, so callers can report
+// their violations.
+func attributeValidationError(err error) error {
+	if errors.Is(err, ErrAttributeValidationFailed) {
+		return err
+	}
+	return status.Errorf(codes.InvalidArgument, "attribute validation failed: %v", err)
+}
+
+// markDerivedAttributes records that the attributes a write didn't set itself were derived
+// from the schema, as defaults or computed values, so they never take precedence over
+// extracted ones. The values it coerced are recorded with the attributes holding them.
+func markDerivedAttributes(metadata *DocumentTagMetadata, derived *AttributeDerivations) {
+	if derived == nil {
+		return
+	}
+	if metadata.Attributes == nil {
+		metadata.Attributes = make(map[string]AttributeExtractionInfo)
+	}
+	now := time.Now()
+	for _, fieldName := range derived.Defaulted {
+		metadata.Attributes[fieldName] = AttributeExtractionInfo{
+			Method: ExtractionMethodDerived, ExtractedBy: "schema",
+			ExtractedAt: now, Source: "default",
+		}
+	}
+	for _, fieldName := range derived.Computed {
+		metadata.Attributes[fieldName] = AttributeExtractionInfo{
+			Method: ExtractionMethodDerived, ExtractedBy: "schema",
+			ExtractedAt: now, Source: "computed",
+		}
+	}
+	for _, coercion := range derived.Coerced {
+		fieldName := coercedField(coercion)
+		info, ok := metadata.Attributes[fieldName]
+		if !ok {
+			continue
+		}
+		info.Coercions = append(info.Coercions, coercion)
+		metadata.Attributes[fieldName] = info
+	}
+}
+
 // marshalMetadata marshals metadata to JSON with error handling
 func (s *DocumentService) marshalMetadata(metadata *DocumentTagMetadata) ([]byte, error) {
 	metadataJSON, err := json.Marshal(metadata)
@@ -176,19 +291,26 @@ func (s *DocumentService) marshalMetadata(metadata *DocumentTagMetadata) ([]byte
 	return metadataJSON, nil
 }
 
-// createAttributeMetadata creates comprehensive extraction metadata for attributes
+// createAttributeMetadata creates comprehensive extraction metadata for attributes. The
+// confidence, if any, applies to the tag and every attribute.
 func (s *DocumentService) createAttributeMetadata(
 	attributesMap map[string]interface{},
 	extractionMethod ExtractionMethod,
 	extractedBy string,
+	confidence *float64,
 ) (*DocumentTagMetadata, error) {
 	metadata := &DocumentTagMetadata{
 		Tag: AttributeExtractionInfo{
 			Method: extractionMethod, ExtractedBy: extractedBy,
 			ExtractedAt: time.Now(), Source: string(extractionMethod),
+			Confidence: confidence,
 		},
 	}
 	s.updateAttributeExtractionInfo(metadata, attributesMap, extractionMethod, extractedBy)
+	for fieldName, info := range metadata.Attributes {
+		info.Confidence = confidence
+		metadata.Attributes[fieldName] = info
+	}
 	return metadata, nil
 }
 
@@ -198,7 +320,8 @@ type DocumentUploadResult struct {
 	DownloadURL string
 }
 
-// UploadDocument uploads a document, generates a download URL, and publishes an event
+// UploadDocument uploads a document, generates a download URL, and records an uploaded
+// event in the same transaction as the document
 func (s *DocumentService) UploadDocument(
 	ctx context.Context,
 	namespace string,
@@ -207,8 +330,37 @@ func (s *DocumentService) UploadDocument(
 	fileSize int,
 	data io.Reader,
 ) (*DocumentUploadResult, error) {
-	result, err := s.storage.Upload(ctx, namespace, filename, mimeType, fileSize, data)
+	var result *storage.UploadResult
+	err := db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		result, err = s.storage.WithTx(tx).
+			Upload(ctx, namespace, filename, mimeType, fileSize, data)
+		if err != nil {
+			return err
+		}
+
+		event := &eventsv1.DocumentUploadedEvent{
+			DocumentId: result.Document.ID.String(),
+			Namespace:  namespace,
+			Filename:   filename,
+			MimeType:   mimeType,
+		}
+		if result.Document.DetectedMimeType != nil {
+			event.DetectedMimeType = *result.Document.DetectedMimeType
+		}
+		return s.outbox.WithTx(tx).DocumentUploaded(ctx, event)
+	})
 	if err != nil {
+		// The file was stored but its record rolled back
+		if result != nil {
+			if delErr := s.storage.Discard(ctx, result); delErr != nil {
+				slog.Error(
+					"failed to delete file of rolled back upload",
+					"document_id", result.Document.ID.String(),
+					"error", delErr,
+				)
+			}
+		}
 		return nil, err
 	}
 
@@ -217,16 +369,6 @@ func (s *DocumentService) UploadDocument(
 	downloadURL := fmt.Sprintf("%s/api/v1/ns/%s/documents/%s?token=%s",
 		s.baseURL, namespace, docID, token)
 
-	event := &eventsv1.DocumentUploadedEvent{
-		DocumentId: docID,
-		Namespace:  namespace,
-		Filename:   filename,
-		MimeType:   mimeType,
-	}
-	if err := s.publisher.DocumentUploaded(event); err != nil {
-		return nil, err
-	}
-
 	return &DocumentUploadResult{
 		Document:    result.Document,
 		DownloadURL: downloadURL,
@@ -242,16 +384,62 @@ func (s *DocumentService) DownloadDocument(
 	return s.storage.Download(ctx, namespace, documentID)
 }
 
+// GetDocument retrieves a document's metadata without opening its content
+func (s *DocumentService) GetDocument(
+	ctx context.Context,
+	namespace string,
+	documentID string,
+) (*sqlc.Document, error) {
+	return s.storage.GetDocument(ctx, namespace, documentID)
+}
+
+// DownloadDocumentRange retrieves a byte range of a document previously resolved with GetDocument
+func (s *DocumentService) DownloadDocumentRange(
+	ctx context.Context,
+	doc *sqlc.Document,
+	r storage.ByteRange,
+) (io.ReadCloser, error) {
+	return s.storage.DownloadRange(ctx, doc, r)
+}
+
 // DeleteDocument removes a document from storage
 func (s *DocumentService) DeleteDocument(
 	ctx context.Context,
 	namespace string,
 	documentID string,
 ) error {
-	return s.storage.Delete(ctx, namespace, documentID)
+	doc, err := s.storage.GetDocument(ctx, namespace, documentID)
+	if err != nil {
+		return err
+	}
+
+	err = db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		if err := s.queries.WithTx(tx).DeleteDocument(ctx, doc.ID); err != nil {
+			return err
+		}
+		return s.outbox.WithTx(tx).DocumentDeleted(ctx, &eventsv1.DocumentDeletedEvent{
+			DocumentId: documentID,
+			Namespace:  namespace,
+			Filename:   doc.FileName,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// The record is gone, so a failure here only leaves an unreachable file behind
+	if err := s.storage.DeleteFile(ctx, doc); err != nil {
+		slog.Error("failed to delete document file", "document_id", documentID, "error", err)
+	}
+	return nil
 }
 
-// AddTagToDocument associates a tag with a document and validates attributes against the schema
+// AddTagToDocument associates a tag with a document and validates attributes against the
+// schema. Automatic extractions pass the extractor's confidence; manual ones pass nil.
+// Manual writes replace the tag's attributes. Unless forced, automatic writes keep the
+// attributes that were set manually or extracted with a higher confidence, and return the
+// extracted attributes skipped because of them. With coercion options, attribute values
+// are converted to the types the schema declares before validation.
 func (s *DocumentService) AddTagToDocument(
 	ctx context.Context,
 	namespace string,
@@ -260,72 +448,104 @@ func (s *DocumentService) AddTagToDocument(
 	attributesJSON *string,
 	extractionMethod ExtractionMethod,
 	extractedBy string,
-) error {
+	confidence *float64,
+	force bool,
+	coerce *CoercionOptions,
+) ([]SkippedAttribute, error) {
+	return s.addTagToDocument(
+		ctx,
+		namespace,
+		documentID,
+		tagPath,
+		attributesJSON,
+		extractionMethod,
+		extractedBy,
+		confidence,
+		force,
+		coerce,
+		nil,
+	)
+}
+
+// addTagToDocument adds a tag as AddTagToDocument does. If claim is set, it is called first
+// in the transaction that adds the tag, which is only added if claim succeeds.
+func (s *DocumentService) addTagToDocument(
+	ctx context.Context,
+	namespace string,
+	documentID string,
+	tagPath string,
+	attributesJSON *string,
+	extractionMethod ExtractionMethod,
+	extractedBy string,
+	confidence *float64,
+	force bool,
+	coerce *CoercionOptions,
+	claim func(queries *sqlc.Queries) error,
+) ([]SkippedAttribute, error) {
 	// Validate namespace
 	ns, err := s.validateNamespace(ctx, namespace)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Resolve tag by path
 	tag, err := s.resolveTagByPath(ctx, ns.ID, tagPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Parse and validate document ID
 	docPgUUID, err := s.parseAndValidateDocumentID(documentID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Verify document exists in the specified namespace
 	document, err := s.queries.GetDocumentByID(ctx, docPgUUID)
 	if err != nil {
-		return status.Errorf(codes.NotFound, "document not found: %v", err)
+		return nil, status.Errorf(codes.NotFound, "document not found: %v", err)
 	}
 	if document.NamespaceID != ns.ID {
-		return ErrDocumentNotInNamespace
+		return nil, ErrDocumentNotInNamespace
 	}
 
 	// Validate attributes if provided
 	var attributesData []byte
 	var attributesMap map[string]interface{}
+	var derived *AttributeDerivations
 	if attributesJSON != nil && *attributesJSON != "" {
 		// Parse attributes JSON to validate it's proper JSON
 		if err := json.Unmarshal([]byte(*attributesJSON), &attributesMap); err != nil {
-			return status.Errorf(codes.InvalidArgument, "invalid attributes JSON: %v", err)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid attributes JSON: %v", err)
 		}
 
-		// Validate attributes against tag's schema using TagService
-		if err := s.tagService.ValidateAttributes(ctx, tag.ID, attributesMap); err != nil {
-			return status.Errorf(codes.InvalidArgument, "attribute validation failed: %v", err)
+		// Coerce values and fill in defaults and computed attributes, then validate against
+		// the tag's schema
+		derived, err = s.tagService.PrepareAttributes(
+			ctx,
+			tag.ID,
+			&document,
+			attributesMap,
+			coerce,
+		)
+		if err != nil {
+			return nil, attributeValidationError(err)
 		}
 
-		attributesData = []byte(*attributesJSON)
+		if attributesData, err = json.Marshal(attributesMap); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal attributes: %v", err)
+		}
 	}
 
 	// Create comprehensive extraction metadata
-	metadata, err := s.createAttributeMetadata(attributesMap, extractionMethod, extractedBy)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to create metadata: %v", err)
-	}
-
-	metadataJSON, err := s.marshalMetadata(metadata)
-	if err != nil {
-		return err
-	}
-
-	// Add the document-tag association
-	err = s.queries.AddDocumentTag(ctx, docPgUUID, tag.ID, attributesData, metadataJSON)
+	metadata, err := s.createAttributeMetadata(
+		attributesMap,
+		extractionMethod,
+		extractedBy,
+		confidence,
+	)
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to add tag to document: %v", err)
-	}
-
-	// Publish tag extracted event
-	attributesStr := ""
-	if attributesJSON != nil {
-		attributesStr = *attributesJSON
+		return nil, status.Errorf(codes.Internal, "failed to create metadata: %v", err)
 	}
 
 	// Include extraction method in event metadata
@@ -333,33 +553,163 @@ func (s *DocumentService) AddTagToDocument(
 		"extraction_method": string(extractionMethod),
 		"extracted_by":      extractedBy,
 	}
+	if confidence != nil {
+		eventMetadataStruct["confidence"] = strconv.FormatFloat(*confidence, 'f', -1, 64)
+	}
 	eventMetadataBytes, err := json.Marshal(eventMetadataStruct)
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to marshal event metadata: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to marshal event metadata: %v", err)
 	}
-	event := &eventsv1.TagExtractedEvent{
-		DocumentId: documentID,
-		Namespace:  namespace,
-		TagPath:    tagPath,
-		Metadata:   string(eventMetadataBytes),
-		Attributes: attributesStr,
-	}
-	if err := s.publisher.TagExtracted(event); err != nil {
-		// Log error but don't fail the operation
-		// The tag association was successful, event publishing is secondary
-		slog.Error(
-			"failed to publish TagExtracted event",
-			"error",
-			err,
-			"document_id",
-			documentID,
-			"tag_path",
-			tagPath,
+
+	// Add the document-tag association and record the change and event together
+	var skipped []SkippedAttribute
+	err = db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		queries := s.queries.WithTx(tx)
+		if claim != nil {
+			if err := claim(queries); err != nil {
+				return err
+			}
+		}
+
+		// Lock the current attributes so the precedence rules apply to the latest ones
+		var previousMap map[string]interface{}
+		current, err := queries.LockDocumentTagAttributes(ctx, docPgUUID, tag.ID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return status.Errorf(codes.Internal, "failed to get tag attributes: %v", err)
+		}
+		tagged := err == nil
+		_ = json.Unmarshal(current.Attributes, &previousMap)
+
+		updatedMap := attributesMap
+		if tagged && extractionMethod == ExtractionMethodAutomatic && !force {
+			currentMetadata := s.parseExistingMetadata(current.AttributesMetadata, "api-user")
+			updatedMap, skipped = resolveAttributePrecedence(
+				previousMap,
+				currentMetadata,
+				attributesMap,
+				metadata,
+			)
+			attributesData = nil
+			if updatedMap != nil {
+				// Kept attributes may be the inputs of computed ones. The extracted values
+				// were coerced already, and the coercions of skipped ones don't apply.
+				var coerced []AttributeCoercion
+				if derived != nil {
+					coerced = derived.Coerced
+				}
+				derived, err = s.tagService.PrepareAttributes(
+					ctx,
+					tag.ID,
+					&document,
+					updatedMap,
+					nil,
+				)
+				if err != nil {
+					return attributeValidationError(err)
+				}
+				derived.Coerced = slices.DeleteFunc(coerced, func(c AttributeCoercion) bool {
+					return slices.ContainsFunc(skipped, func(skip SkippedAttribute) bool {
+						return skip.Field == coercedField(c)
+					})
+				})
+				if attributesData, err = json.Marshal(updatedMap); err != nil {
+					return status.Errorf(codes.Internal, "failed to marshal attributes: %v", err)
+				}
+			}
+		}
+		markDerivedAttributes(metadata, derived)
+		metadataJSON, err := s.marshalMetadata(metadata)
+		if err != nil {
+			return err
+		}
+
+		err = queries.AddDocumentTag(ctx, docPgUUID, tag.ID, attributesData, metadataJSON)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to add tag to document: %v", err)
+		}
+		err = s.recordAttributeHistory(
+			ctx,
+			queries,
+			docPgUUID,
+			tag.ID,
+			previousMap,
+			updatedMap,
+			extractionMethod,
+			extractedBy,
 		)
+		if err != nil {
+			return err
+		}
+
+		event := &eventsv1.TagExtractedEvent{
+			DocumentId: documentID,
+			Namespace:  namespace,
+			TagPath:    tagPath,
+			Metadata:   string(eventMetadataBytes),
+			Attributes: string(attributesData),
+		}
+		if err := s.outbox.WithTx(tx).TagExtracted(ctx, event); err != nil {
+			return status.Errorf(codes.Internal, "failed to record tag extracted event: %v", err)
+		}
 		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return skipped, nil
+}
 
-	return nil
+// resolveAttributePrecedence merges an automatic extraction, described by metadata, into
+// a tag's current attributes. Attributes set manually are kept, as are attributes
+// extracted with a higher confidence than the extraction's, together with their
+// provenance. Returns the merged attributes and the extracted attributes that were skipped.
+func resolveAttributePrecedence(
+	current map[string]interface{},
+	currentMetadata DocumentTagMetadata,
+	extracted map[string]interface{},
+	metadata *DocumentTagMetadata,
+) (map[string]interface{}, []SkippedAttribute) {
+	// A tag added by hand stays so
+	if currentMetadata.Tag.Method == ExtractionMethodManual {
+		metadata.Tag = currentMetadata.Tag
+	}
+
+	merged := maps.Clone(extracted)
+	var skipped []SkippedAttribute
+	for fieldName, value := range current {
+		info, ok := currentMetadata.Attributes[fieldName]
+		if !ok {
+			info = currentMetadata.Tag
+		}
+		_, isExtracted := extracted[fieldName]
+
+		var reason SkipReason
+		switch {
+		case info.Method == ExtractionMethodManual:
+			reason = SkipReasonManual
+		case isExtracted && info.Confidence != nil && metadata.Tag.Confidence != nil &&
+			*info.Confidence > *metadata.Tag.Confidence:
+			reason = SkipReasonHigherConfidence
+		default:
+			continue
+		}
+
+		if merged == nil {
+			merged = make(map[string]interface{})
+		}
+		if metadata.Attributes == nil {
+			metadata.Attributes = make(map[string]AttributeExtractionInfo)
+		}
+		merged[fieldName] = value
+		metadata.Attributes[fieldName] = info
+		if isExtracted {
+			skipped = append(skipped, SkippedAttribute{Field: fieldName, Reason: reason})
+		}
+	}
+	slices.SortFunc(skipped, func(a, b SkippedAttribute) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+	return merged, skipped
 }
 
 // ListDocumentTags retrieves all tags associated with a document
@@ -460,13 +810,19 @@ func (s *DocumentService) GetDocumentAttributes(
 	return &attributes, nil
 }
 
-// UpdateDocumentAttributes updates attributes for a document (global) or specific tag
+// UpdateDocumentAttributes updates attributes for a document (global) or specific tag.
+// The update is a JSON object replacing the attributes, or a patch applied to them,
+// depending on the mode. Only the provenance of attributes the update changes is
+// rewritten; attributes it removes lose theirs. With coercion options, the values of a
+// tag's attributes are converted to the types its schema declares before validation.
 func (s *DocumentService) UpdateDocumentAttributes(
 	ctx context.Context,
 	namespace string,
 	documentID string,
 	tagPath string,
-	attributesJSON string,
+	update string,
+	mode AttributeUpdateMode,
+	coerce *CoercionOptions,
 ) error {
 	// Validate namespace and parse document ID
 	ns, err := s.validateNamespace(ctx, namespace)
@@ -487,66 +843,293 @@ func (s *DocumentService) UpdateDocumentAttributes(
 		return ErrDocumentNotInNamespace
 	}
 
-	attributesMap, err := s.parseAndValidateAttributesJSON(attributesJSON)
-	if err != nil {
-		return err
+	// An empty tag path means the document's global attributes
+	var tag *sqlc.Tag
+	if tagPath != "" {
+		if tag, err = s.resolveTagByPath(ctx, ns.ID, tagPath); err != nil {
+			return err
+		}
 	}
 
-	if tagPath == "" {
-		// Handle document global attributes
-		metadata, err := s.createAttributeMetadata(
+	return db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		queries := s.queries.WithTx(tx)
+
+		// Lock the current attributes so concurrent patches apply one after the other
+		var currentAttributes, currentMetadata []byte
+		if tag == nil {
+			current, err := queries.LockDocumentAttributes(ctx, docPgUUID)
+			if err != nil {
+				return status.Errorf(codes.NotFound, "document not found: %v", err)
+			}
+			currentAttributes, currentMetadata = current.Attributes, current.AttributesMetadata
+		} else {
+			current, err := queries.LockDocumentTagAttributes(ctx, docPgUUID, tag.ID)
+			if err != nil {
+				return status.Errorf(
+					codes.NotFound,
+					"document-tag association not found: %v",
+					err,
+				)
+			}
+			currentAttributes, currentMetadata = current.Attributes, current.AttributesMetadata
+		}
+
+		attributesJSON, err := applyAttributeUpdate(currentAttributes, update, mode)
+		if err != nil {
+			return err
+		}
+		attributesMap, err := s.parseAndValidateAttributesJSON(string(attributesJSON))
+		if err != nil {
+			return err
+		}
+		if attributesMap == nil {
+			return status.Error(codes.InvalidArgument, "attributes must be a JSON object")
+		}
+		var derived *AttributeDerivations
+		if tag != nil {
+			derived, err = s.tagService.PrepareAttributes(
+				ctx,
+				tag.ID,
+				&document,
+				attributesMap,
+				coerce,
+			)
+			if err != nil {
+				return attributeValidationError(err)
+			}
+			if attributesJSON, err = json.Marshal(attributesMap); err != nil {
+				return status.Errorf(codes.Internal, "failed to marshal attributes: %v", err)
+			}
+		}
+
+		var previousMap map[string]interface{}
+		_ = json.Unmarshal(currentAttributes, &previousMap)
+		metadata := s.parseExistingMetadata(currentMetadata, "api-user")
+		s.updateChangedAttributeInfo(
+			&metadata,
+			previousMap,
 			attributesMap,
 			ExtractionMethodManual,
 			"api-user",
 		)
+		markDerivedAttributes(&metadata, derived)
+		metadataJSON, err := s.marshalMetadata(&metadata)
 		if err != nil {
-			return status.Errorf(codes.Internal, "failed to create metadata: %v", err)
+			return err
 		}
-		metadataJSON, err := s.marshalMetadata(metadata)
+
+		var tagID pgtype.UUID
+		if tag != nil {
+			tagID = tag.ID
+		}
+		err = s.recordAttributeHistory(
+			ctx,
+			queries,
+			docPgUUID,
+			tagID,
+			previousMap,
+			attributesMap,
+			ExtractionMethodManual,
+			"api-user",
+		)
 		if err != nil {
 			return err
 		}
-		err = s.queries.UpdateDocumentAttributes(
+
+		if tag == nil {
+			err := queries.UpdateDocumentAttributes(ctx, docPgUUID, attributesJSON, metadataJSON)
+			if err != nil {
+				return status.Errorf(
+					codes.Internal,
+					"failed to update document attributes: %v",
+					err,
+				)
+			}
+			return s.recordAttributesUpdated(
+				ctx,
+				tx,
+				namespace,
+				documentID,
+				"",
+				string(attributesJSON),
+			)
+		}
+
+		err = queries.UpdateDocumentTagAttributes(
 			ctx,
 			docPgUUID,
-			[]byte(attributesJSON),
+			tag.ID,
+			attributesJSON,
 			metadataJSON,
 		)
 		if err != nil {
-			return status.Errorf(codes.Internal, "failed to update document attributes: %v", err)
+			return status.Errorf(codes.Internal, "failed to update tag attributes: %v", err)
 		}
-		return nil
+		return s.recordAttributesUpdated(
+			ctx,
+			tx,
+			namespace,
+			documentID,
+			tag.Path,
+			string(attributesJSON),
+		)
+	})
+}
+
+// applyAttributeUpdate applies an attributes update to the current attributes and returns
+// the new attributes
+func applyAttributeUpdate(
+	current []byte,
+	update string,
+	mode AttributeUpdateMode,
+) ([]byte, error) {
+	if len(current) == 0 {
+		current = []byte("{}")
 	}
 
-	// Handle tag-specific attributes
-	tag, err := s.resolveTagByPath(ctx, ns.ID, tagPath)
+	var updated []byte
+	var err error
+	switch mode {
+	case AttributeUpdateReplace:
+		return []byte(update), nil
+	case AttributeUpdateMergePatch:
+		updated, err = jsonpatch.MergePatch(current, []byte(update))
+	case AttributeUpdateJSONPatch:
+		updated, err = jsonpatch.Apply(current, []byte(update))
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown attribute update mode %q", mode)
+	}
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "attribute patch failed: %v", err)
+	}
 	if err != nil {
-		return err
+		return nil, status.Errorf(codes.InvalidArgument, "invalid attribute patch: %v", err)
+	}
+	return updated, nil
+}
+
+// recordAttributeHistory records a history entry for every attribute that differs between
+// previous and updated. tagID is invalid for global attributes.
+func (s *DocumentService) recordAttributeHistory(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	documentID pgtype.UUID,
+	tagID pgtype.UUID,
+	previous map[string]interface{},
+	updated map[string]interface{},
+	extractionMethod ExtractionMethod,
+	extractedBy string,
+) error {
+	fields := make([]string, 0, len(previous)+len(updated))
+	for fieldName := range previous {
+		fields = append(fields, fieldName)
 	}
-	if err := s.tagService.ValidateAttributes(ctx, tag.ID, attributesMap); err != nil {
-		return status.Errorf(codes.InvalidArgument, "attribute validation failed: %v", err)
+	for fieldName := range updated {
+		if _, ok := previous[fieldName]; !ok {
+			fields = append(fields, fieldName)
+		}
 	}
+	slices.Sort(fields)
+
+	actor := events.ActorFromContext(ctx)
+	for _, fieldName := range fields {
+		oldValue, hadOld := previous[fieldName]
+		newValue, hasNew := updated[fieldName]
+		if hadOld && hasNew && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
 
-	// Get current data and update metadata
-	currentData, err := s.queries.GetDocumentTagAttributes(ctx, docPgUUID, tag.ID)
+		// A missing value is stored as NULL, unlike a JSON null
+		var oldJSON, newJSON []byte
+		if hadOld {
+			oldJSON, _ = json.Marshal(oldValue)
+		}
+		if hasNew {
+			newJSON, _ = json.Marshal(newValue)
+		}
+		err := queries.RecordAttributeChange(
+			ctx,
+			documentID,
+			tagID,
+			fieldName,
+			oldJSON,
+			newJSON,
+			actor,
+			string(extractionMethod),
+			extractedBy,
+		)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to record attribute history: %v", err)
+		}
+	}
+	return nil
+}
+
+// GetAttributeHistory lists the recorded changes to a document's attributes, newest first.
+// An empty tag path lists the global attributes' history and an empty field every field's.
+func (s *DocumentService) GetAttributeHistory(
+	ctx context.Context,
+	namespace string,
+	documentID string,
+	tagPath string,
+	field string,
+) ([]sqlc.AttributeHistory, error) {
+	// Validate namespace and parse document ID
+	ns, err := s.validateNamespace(ctx, namespace)
 	if err != nil {
-		return status.Errorf(codes.NotFound, "document-tag association not found: %v", err)
+		return nil, err
 	}
-	metadata := s.parseExistingMetadata(currentData.AttributesMetadata, "api-user")
-	s.updateAttributeExtractionInfo(&metadata, attributesMap, ExtractionMethodManual, "api-user")
-	updatedMetadataJSON, err := s.marshalMetadata(&metadata)
+	docPgUUID, err := s.parseAndValidateDocumentID(documentID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = s.queries.UpdateDocumentTagAttributes(
-		ctx,
-		docPgUUID,
-		tag.ID,
-		[]byte(attributesJSON),
-		updatedMetadataJSON,
-	)
+
+	// Verify document exists in the specified namespace
+	document, err := s.queries.GetDocumentByID(ctx, docPgUUID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "document not found: %v", err)
+	}
+	if document.NamespaceID != ns.ID {
+		return nil, ErrDocumentNotInNamespace
+	}
+
+	var tagID pgtype.UUID
+	if tagPath != "" {
+		tag, err := s.resolveTagByPath(ctx, ns.ID, tagPath)
+		if err != nil {
+			return nil, err
+		}
+		tagID = tag.ID
+	}
+	var fieldFilter *string
+	if field != "" {
+		fieldFilter = &field
+	}
+
+	history, err := s.queries.ListAttributeHistory(ctx, docPgUUID, tagID, fieldFilter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list attribute history: %v", err)
+	}
+	return history, nil
+}
+
+// recordAttributesUpdated records an attributes updated event within tx
+func (s *DocumentService) recordAttributesUpdated(
+	ctx context.Context,
+	tx pgx.Tx,
+	namespace string,
+	documentID string,
+	tagPath string,
+	attributesJSON string,
+) error {
+	err := s.outbox.WithTx(tx).AttributesUpdated(ctx, &eventsv1.AttributesUpdatedEvent{
+		DocumentId: documentID,
+		Namespace:  namespace,
+		TagPath:    tagPath,
+		Attributes: attributesJSON,
+	})
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to update tag attributes: %v", err)
+		return status.Errorf(codes.Internal, "failed to record attributes updated event: %v", err)
 	}
 	return nil
 }
@@ -585,11 +1168,37 @@ func (s *DocumentService) RemoveTagFromDocument(
 		return ErrDocumentNotInNamespace
 	}
 
-	// Remove the document-tag association
-	err = s.queries.RemoveDocumentTag(ctx, docPgUUID, tag.ID)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to remove tag from document: %v", err)
-	}
-
-	return nil
+	// Remove the document-tag association and record the removed attributes and event
+	// together
+	return db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		queries := s.queries.WithTx(tx)
+		attributes, err := queries.RemoveDocumentTag(ctx, docPgUUID, tag.ID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return status.Errorf(codes.Internal, "failed to remove tag from document: %v", err)
+		}
+		var previousMap map[string]interface{}
+		_ = json.Unmarshal(attributes, &previousMap)
+		err = s.recordAttributeHistory(
+			ctx,
+			queries,
+			docPgUUID,
+			tag.ID,
+			previousMap,
+			nil,
+			ExtractionMethodManual,
+			"api-user",
+		)
+		if err != nil {
+			return err
+		}
+		err = s.outbox.WithTx(tx).TagRemoved(ctx, &eventsv1.TagRemovedEvent{
+			DocumentId: documentID,
+			Namespace:  namespace,
+			TagPath:    tag.Path,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to record tag removed event: %v", err)
+		}
+		return nil
+	})
 }
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestApplyAttributeUpdate(t *testing.T) {
+	current := []byte(`{"total": 10, "vendor": "Acme", "notes": "paid"}`)
+
+	updated, err := applyAttributeUpdate(current, `{"total": 12}`, AttributeUpdateReplace)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"total": 12}`, string(updated))
+
+	updated, err = applyAttributeUpdate(
+		current,
+		`{"total": 12, "notes": null}`,
+		AttributeUpdateMergePatch,
+	)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"total": 12, "vendor": "Acme"}`, string(updated))
+
+	updated, err = applyAttributeUpdate(
+		current,
+		`[{"op": "test", "path": "/total", "value": 10}, {"op": "remove", "path": "/notes"}]`,
+		AttributeUpdateJSONPatch,
+	)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"total": 10, "vendor": "Acme"}`, string(updated))
+
+	// Patches apply to empty attributes as to an empty object
+	updated, err = applyAttributeUpdate(nil, `{"total": 1}`, AttributeUpdateMergePatch)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"total": 1}`, string(updated))
+
+	_, err = applyAttributeUpdate(
+		current,
+		`[{"op": "test", "path": "/total", "value": 11}]`,
+		AttributeUpdateJSONPatch,
+	)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = applyAttributeUpdate(
+		current,
+		`[{"op": "remove", "path": "/missing"}]`,
+		AttributeUpdateJSONPatch,
+	)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = applyAttributeUpdate(current, `{}`, AttributeUpdateMode("upsert"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateChangedAttributeInfo(t *testing.T) {
+	extractedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	confidence := 0.7
+	automatic := AttributeExtractionInfo{
+		Method:      ExtractionMethodAutomatic,
+		ExtractedBy: "invoice-reader",
+		ExtractedAt: extractedAt,
+		Source:      string(ExtractionMethodAutomatic),
+		Confidence:  &confidence,
+	}
+	metadata := DocumentTagMetadata{
+		Tag: automatic,
+		Attributes: map[string]AttributeExtractionInfo{
+			"total":  automatic,
+			"vendor": automatic,
+			"notes":  automatic,
+		},
+	}
+
+	s := &DocumentService{}
+	s.updateChangedAttributeInfo(
+		&metadata,
+		map[string]interface{}{"total": 10.0, "vendor": "Acme", "notes": "paid"},
+		map[string]interface{}{"total": 12.0, "vendor": "Acme", "due": "2025-02-01"},
+		ExtractionMethodManual,
+		"api-user",
+	)
+
+	// Unchanged attributes and the tag keep their provenance
+	assert.Equal(t, automatic, metadata.Tag)
+	assert.Equal(t, automatic, metadata.Attributes["vendor"])
+
+	// Changed and added attributes are attributed to the update
+	for _, field := range []string{"total", "due"} {
+		info := metadata.Attributes[field]
+		assert.Equal(t, ExtractionMethodManual, info.Method, field)
+		assert.Equal(t, "api-user", info.ExtractedBy, field)
+		assert.Nil(t, info.Confidence, field)
+	}
+
+	// Removed attributes lose theirs
+	assert.NotContains(t, metadata.Attributes, "notes")
+	assert.Len(t, metadata.Attributes, 3)
+}
+
+func TestResolveAttributePrecedence(t *testing.T) {
+	low, high := 0.5, 0.9
+	manual := AttributeExtractionInfo{Method: ExtractionMethodManual, ExtractedBy: "api-user"}
+	confident := AttributeExtractionInfo{
+		Method:      ExtractionMethodAutomatic,
+		ExtractedBy: "invoice-reader",
+		Confidence:  &high,
+	}
+	unsure := AttributeExtractionInfo{
+		Method:      ExtractionMethodAutomatic,
+		ExtractedBy: "invoice-reader",
+		Confidence:  &low,
+	}
+	current := map[string]interface{}{
+		"total":    12.0,
+		"vendor":   "Acme",
+		"due":      "2025-02-01",
+		"currency": "EUR",
+		"notes":    "call first",
+	}
+	currentMetadata := DocumentTagMetadata{
+		Tag: unsure,
+		Attributes: map[string]AttributeExtractionInfo{
+			"total":    manual,
+			"vendor":   confident,
+			"due":      unsure,
+			"currency": confident,
+			"notes":    manual,
+		},
+	}
+
+	s := &DocumentService{}
+	extracted := map[string]interface{}{"total": 10.0, "vendor": "ACME", "due": "2025-03-01"}
+	confidence := 0.7
+	metadata, err := s.createAttributeMetadata(
+		extracted,
+		ExtractionMethodAutomatic,
+		"invoice-reader",
+		&confidence,
+	)
+	require.NoError(t, err)
+
+	merged, skipped := resolveAttributePrecedence(current, currentMetadata, extracted, metadata)
+
+	// Manual and more confident values are kept, manual ones even if not extracted again
+	assert.Equal(t, map[string]interface{}{
+		"total":  12.0,
+		"vendor": "Acme",
+		"due":    "2025-03-01",
+		"notes":  "call first",
+	}, merged)
+	assert.Equal(t, []SkippedAttribute{
+		{Field: "total", Reason: SkipReasonManual},
+		{Field: "vendor", Reason: SkipReasonHigherConfidence},
+	}, skipped)
+
+	// Kept values keep their provenance
+	assert.Equal(t, manual, metadata.Attributes["total"])
+	assert.Equal(t, confident, metadata.Attributes["vendor"])
+	assert.Equal(t, manual, metadata.Attributes["notes"])
+	assert.InDelta(t, confidence, *metadata.Attributes["due"].Confidence, 1e-9)
+	assert.NotContains(t, metadata.Attributes, "currency")
+	assert.Equal(t, ExtractionMethodAutomatic, metadata.Tag.Method)
+
+	// A tag added by hand keeps its provenance
+	currentMetadata.Tag = manual
+	metadata, err = s.createAttributeMetadata(nil, ExtractionMethodAutomatic, "x", &confidence)
+	require.NoError(t, err)
+	merged, skipped = resolveAttributePrecedence(current, currentMetadata, nil, metadata)
+	assert.Equal(t, manual, metadata.Tag)
+	assert.Equal(t, map[string]interface{}{"total": 12.0, "notes": "call first"}, merged)
+	assert.Empty(t, skipped)
+}
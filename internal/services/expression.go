@@ -0,0 +1,550 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Expressions compute attributes from other attributes of the same tag and from the fields
+// of the document they're written to. The language is deliberately small: number, string
+// and boolean literals, attribute references such as net or vendor.name, document field
+// references such as $document.document_date, the arithmetic operators + - * / with the
+// usual precedence and parentheses, and the functions in expressionFunctions. + also
+// concatenates strings. An expression referring to a missing or null attribute or field
+// has no value, unless the reference is guarded by coalesce.
+
+// errMissingOperand is returned while evaluating an expression that refers to a missing
+// attribute
+var errMissingOperand = errors.New("missing operand")
+
+// expression is a parsed expression
+type expression interface {
+	eval(attributes map[string]interface{}) (interface{}, error)
+	// references appends the attribute paths the expression refers to
+	references(paths []string) []string
+}
+
+type literalExpression struct{ value interface{} }
+
+type referenceExpression struct{ path []string }
+
+type unaryExpression struct {
+	operand expression
+}
+
+type binaryExpression struct {
+	operator    byte
+	left, right expression
+}
+
+type callExpression struct {
+	name string
+	args []expression
+}
+
+// expressionFunction is a function expressions can call. Its arguments are evaluated
+// first, except by coalesce.
+type expressionFunction struct {
+	minArgs, maxArgs int
+	call             func(args []interface{}) (interface{}, error)
+}
+
+// expressionFunctions are the functions expressions can call. maxArgs -1 means any number.
+// coalesce returns its first argument with a value and is evaluated by callExpression.
+var expressionFunctions = map[string]expressionFunction{
+	"year":     {1, 1, datePart(func(t time.Time) int { return t.Year() })},
+	"month":    {1, 1, datePart(func(t time.Time) int { return int(t.Month()) })},
+	"day":      {1, 1, datePart(func(t time.Time) int { return t.Day() })},
+	"abs":      {1, 1, numberFunction(math.Abs)},
+	"round":    {1, 2, roundFunction},
+	"min":      {1, -1, extremeFunction(math.Min)},
+	"max":      {1, -1, extremeFunction(math.Max)},
+	"concat":   {1, -1, concatFunction},
+	"coalesce": {1, -1, nil},
+}
+
+// parseExpression parses an expression
+func parseExpression(source string) (expression, error) {
+	p := &expressionParser{source: source}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.token.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.token.text, p.token.pos)
+	}
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdentifier
+	tokenOperator
+)
+
+type expressionToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// expressionParser is a recursive descent parser over the tokens of an expression
+type expressionParser struct {
+	source string
+	pos    int
+	token  expressionToken
+}
+
+// next reads the next token
+func (p *expressionParser) next() error {
+	for p.pos < len(p.source) && unicode.IsSpace(rune(p.source[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos == len(p.source) {
+		p.token = expressionToken{kind: tokenEnd, pos: start}
+		return nil
+	}
+
+	c := p.source[p.pos]
+	switch {
+	case c >= '0' && c <= '9':
+		for p.pos < len(p.source) && (isDigit(p.source[p.pos]) || p.source[p.pos] == '.') {
+			p.pos++
+		}
+		p.token = expressionToken{kind: tokenNumber, text: p.source[start:p.pos], pos: start}
+	case c == '_' || c == '$' || unicode.IsLetter(rune(c)):
+		p.pos++
+		for p.pos < len(p.source) && isIdentifierPart(p.source[p.pos]) {
+			p.pos++
+		}
+		p.token = expressionToken{kind: tokenIdentifier, text: p.source[start:p.pos], pos: start}
+	case c == '"' || c == '\'':
+		p.pos++
+		var text strings.Builder
+		for p.pos < len(p.source) && p.source[p.pos] != c {
+			if p.source[p.pos] == '\\' && p.pos+1 < len(p.source) {
+				p.pos++
+			}
+			text.WriteByte(p.source[p.pos])
+			p.pos++
+		}
+		if p.pos == len(p.source) {
+			return fmt.Errorf("unterminated string at position %d", start)
+		}
+		p.pos++
+		p.token = expressionToken{kind: tokenString, text: text.String(), pos: start}
+	case strings.IndexByte("+-*/(),", c) >= 0:
+		p.pos++
+		p.token = expressionToken{kind: tokenOperator, text: string(c), pos: start}
+	default:
+		return fmt.Errorf("unexpected %q at position %d", c, start)
+	}
+	return nil
+}
+
+// parseSum parses additions and subtractions of products
+func (p *expressionParser) parseSum() (expression, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("+") || p.isOperator("-") {
+		operator := p.token.text[0]
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpression{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseProduct parses multiplications and divisions of unary expressions
+func (p *expressionParser) parseProduct() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("*") || p.isOperator("/") {
+		operator := p.token.text[0]
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpression{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses a negated or plain operand
+func (p *expressionParser) parseUnary() (expression, error) {
+	if !p.isOperator("-") {
+		return p.parseOperand()
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &unaryExpression{operand: operand}, nil
+}
+
+// parseOperand parses a literal, attribute reference, function call or parenthesized
+// expression
+func (p *expressionParser) parseOperand() (expression, error) {
+	token := p.token
+	switch token.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", token.text, token.pos)
+		}
+		return &literalExpression{value: value}, p.next()
+	case tokenString:
+		return &literalExpression{value: token.text}, p.next()
+	case tokenIdentifier:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch token.text {
+		case "true", "false":
+			return &literalExpression{value: token.text == "true"}, nil
+		}
+		if p.isOperator("(") {
+			return p.parseCall(token)
+		}
+		path := strings.Split(token.text, ".")
+		for _, part := range path {
+			if part == "" {
+				return nil, fmt.Errorf("invalid reference %q at position %d", token.text, token.pos)
+			}
+		}
+		return &referenceExpression{path: path}, nil
+	case tokenEnd:
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	if !p.isOperator("(") {
+		return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// parseCall parses the arguments of a call to the function named by token
+func (p *expressionParser) parseCall(token expressionToken) (expression, error) {
+	function, ok := expressionFunctions[token.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", token.text, token.pos)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	call := &callExpression{name: token.text}
+	for !p.isOperator(")") {
+		if len(call.args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if len(call.args) < function.minArgs ||
+		(function.maxArgs >= 0 && len(call.args) > function.maxArgs) {
+		return nil, fmt.Errorf(
+			"wrong number of arguments to %s at position %d",
+			token.text,
+			token.pos,
+		)
+	}
+	return call, nil
+}
+
+func (p *expressionParser) isOperator(operator string) bool {
+	return p.token.kind == tokenOperator && p.token.text == operator
+}
+
+func (p *expressionParser) expect(operator string) error {
+	if !p.isOperator(operator) {
+		if p.token.kind == tokenEnd {
+			return fmt.Errorf("expected %q at end of expression", operator)
+		}
+		return fmt.Errorf("expected %q at position %d", operator, p.token.pos)
+	}
+	return p.next()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierPart(c byte) bool {
+	return c == '_' || c == '.' || isDigit(c) || unicode.IsLetter(rune(c))
+}
+
+func (e *literalExpression) eval(map[string]interface{}) (interface{}, error) {
+	return e.value, nil
+}
+
+func (e *literalExpression) references(paths []string) []string {
+	return paths
+}
+
+func (e *referenceExpression) eval(attributes map[string]interface{}) (interface{}, error) {
+	var value interface{} = attributes
+	for _, part := range e.path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errMissingOperand
+		}
+		if value, ok = object[part]; !ok || value == nil {
+			return nil, errMissingOperand
+		}
+	}
+	return value, nil
+}
+
+func (e *referenceExpression) references(paths []string) []string {
+	return append(paths, strings.Join(e.path, "."))
+}
+
+func (e *unaryExpression) eval(attributes map[string]interface{}) (interface{}, error) {
+	value, err := e.operand.eval(attributes)
+	if err != nil {
+		return nil, err
+	}
+	number, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("cannot negate %s", describeValue(value))
+	}
+	return -number, nil
+}
+
+func (e *unaryExpression) references(paths []string) []string {
+	return e.operand.references(paths)
+}
+
+func (e *binaryExpression) eval(attributes map[string]interface{}) (interface{}, error) {
+	left, err := e.left.eval(attributes)
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.right.eval(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.operator == '+' {
+		leftString, leftOK := left.(string)
+		rightString, rightOK := right.(string)
+		if leftOK && rightOK {
+			return leftString + rightString, nil
+		}
+	}
+	a, leftOK := left.(float64)
+	b, rightOK := right.(float64)
+	if !leftOK || !rightOK {
+		return nil, fmt.Errorf(
+			"cannot apply %c to %s and %s",
+			e.operator,
+			describeValue(left),
+			describeValue(right),
+		)
+	}
+	var result float64
+	switch e.operator {
+	case '+':
+		result = a + b
+	case '-':
+		result = a - b
+	case '*':
+		result = a * b
+	case '/':
+		if b == 0 {
+			return nil, errors.New("division by zero")
+		}
+		result = a / b
+	}
+	return roundNoise(result), nil
+}
+
+func (e *binaryExpression) references(paths []string) []string {
+	return e.right.references(e.left.references(paths))
+}
+
+func (e *callExpression) eval(attributes map[string]interface{}) (interface{}, error) {
+	args := make([]interface{}, len(e.args))
+	for i, arg := range e.args {
+		value, err := arg.eval(attributes)
+		if errors.Is(err, errMissingOperand) && e.name == "coalesce" {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if e.name == "coalesce" {
+			return value, nil
+		}
+		args[i] = value
+	}
+	if e.name == "coalesce" {
+		return nil, errMissingOperand
+	}
+	value, err := expressionFunctions[e.name].call(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.name, err)
+	}
+	return value, nil
+}
+
+func (e *callExpression) references(paths []string) []string {
+	for _, arg := range e.args {
+		paths = arg.references(paths)
+	}
+	return paths
+}
+
+// roundNoise rounds away the representation error of decimal arithmetic, so that e.g.
+// 0.1 + 0.2 is 0.3
+func roundNoise(value float64) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'g', 15, 64), 64)
+	if err != nil {
+		return value
+	}
+	return rounded
+}
+
+// describeValue names the JSON type of a value for error messages
+func describeValue(value interface{}) string {
+	switch value.(type) {
+	case float64:
+		return "a number"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return "null"
+}
+
+// datePart returns a function extracting part of a date or date-time string
+func datePart(part func(time.Time) int) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a date, got %s", describeValue(args[0]))
+		}
+		for _, layout := range []string{time.DateOnly, time.RFC3339Nano} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return float64(part(t)), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid date %q", s)
+	}
+}
+
+// numberFunction wraps a function of one number
+func numberFunction(f func(float64) float64) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		x, ok := args[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected a number, got %s", describeValue(args[0]))
+		}
+		return f(x), nil
+	}
+}
+
+// roundFunction rounds a number half away from zero, to a number of decimal places if given
+func roundFunction(args []interface{}) (interface{}, error) {
+	x, ok := args[0].(float64)
+	if !ok {
+		return nil, fmt.Errorf("expected a number, got %s", describeValue(args[0]))
+	}
+	if len(args) == 1 {
+		return math.Round(x), nil
+	}
+	places, ok := args[1].(float64)
+	if !ok || places != math.Trunc(places) || places < 0 || places > 15 {
+		return nil, errors.New("decimal places must be an integer from 0 to 15")
+	}
+	scale := math.Pow(10, places)
+	return roundNoise(math.Round(x*scale) / scale), nil
+}
+
+// extremeFunction returns a function folding its number arguments with f
+func extremeFunction(f func(float64, float64) float64) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		var result float64
+		for i, arg := range args {
+			x, ok := arg.(float64)
+			if !ok {
+				return nil, fmt.Errorf("expected numbers, got %s", describeValue(arg))
+			}
+			if i == 0 {
+				result = x
+			} else {
+				result = f(result, x)
+			}
+		}
+		return result, nil
+	}
+}
+
+// concatFunction joins its arguments as text
+func concatFunction(args []interface{}) (interface{}, error) {
+	var text strings.Builder
+	for _, arg := range args {
+		switch value := arg.(type) {
+		case string:
+			text.WriteString(value)
+		case float64:
+			text.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+		case bool:
+			text.WriteString(strconv.FormatBool(value))
+		default:
+			return nil, fmt.Errorf("cannot concatenate %s", describeValue(arg))
+		}
+	}
+	return text.String(), nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpressionEval(t *testing.T) {
+	attributes := map[string]interface{}{
+		"net":           100.1,
+		"tax":           20.2,
+		"quantity":      3.0,
+		"document_date": "2024-03-15",
+		"issued_at":     "2023-11-02T10:00:00Z",
+		"vendor":        map[string]interface{}{"name": "Acme"},
+		"note":          nil,
+	}
+
+	tests := []struct {
+		source string
+		want   interface{}
+	}{
+		{"net + tax", 120.3},
+		{"net - tax * 2", 59.7},
+		{"(net - tax) * 2", 159.8},
+		{"-net / 2", -50.05},
+		{"year(document_date)", 2024.0},
+		{"month(issued_at)", 11.0},
+		{"day(document_date)", 15.0},
+		{"round(net / quantity, 2)", 33.37},
+		{"round(2.5)", 3.0},
+		{"abs(tax - net)", 79.9},
+		{"min(net, tax, quantity)", 3.0},
+		{"max(net, tax)", 100.1},
+		{"vendor.name + ' Ltd'", "Acme Ltd"},
+		{`concat(vendor.name, "-", year(document_date))`, "Acme-2024"},
+		{"coalesce(discount, note, 0)", 0.0},
+		{"true", true},
+	}
+	for _, tt := range tests {
+		expr, err := parseExpression(tt.source)
+		require.NoError(t, err, tt.source)
+		got, err := expr.eval(attributes)
+		require.NoError(t, err, tt.source)
+		assert.Equal(t, tt.want, got, tt.source)
+	}
+
+	// Missing and null attributes leave the expression without a value
+	for _, source := range []string{"net + discount", "year(note)", "vendor.name.first"} {
+		expr, err := parseExpression(source)
+		require.NoError(t, err, source)
+		_, err = expr.eval(attributes)
+		assert.ErrorIs(t, err, errMissingOperand, source)
+	}
+
+	// Type errors are reported
+	for _, source := range []string{
+		"net + vendor.name",
+		"-vendor",
+		"year(net)",
+		"year(vendor.name)",
+		"net / (tax - tax)",
+		"round(net, 0.5)",
+		"concat(vendor)",
+	} {
+		expr, err := parseExpression(source)
+		require.NoError(t, err, source)
+		_, err = expr.eval(attributes)
+		assert.Error(t, err, source)
+		assert.NotErrorIs(t, err, errMissingOperand, source)
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	for _, source := range []string{
+		"",
+		"net +",
+		"net tax",
+		"(net + tax",
+		"sum(net)",
+		"year(a, b)",
+		"round()",
+		"net..tax",
+		"1.2.3",
+		"'open",
+		"net % 2",
+	} {
+		_, err := parseExpression(source)
+		assert.Error(t, err, source)
+	}
+
+	expr, err := parseExpression("round(net + tax, 2) * coalesce(rate, vendor.rate)")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"net", "tax", "rate", "vendor.rate"}, expr.references(nil))
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/storage"
+)
+
+// ExtractionInput is an uploaded document handed to an extractor
+type ExtractionInput struct {
+	DocumentID string
+	Namespace  string
+	Filename   string
+	// MimeType is the detected type when the content was sniffed, otherwise the type the
+	// client declared
+	MimeType string
+	Size     int64
+	Content  io.Reader
+}
+
+// ExtractedTag is a tag an extractor found for a document, with the attributes it read
+// and how confident it is, from 0 to 1
+type ExtractedTag struct {
+	TagPath    string
+	Attributes map[string]any
+	Confidence float64
+	// ExtractedBy is recorded in the tag's provenance, e.g. the rule that matched. It
+	// defaults to the extractor's name.
+	ExtractedBy string
+}
+
+// Extractor reads uploaded documents and finds tags for them. Extractors are registered
+// with the ExtractionService and must be safe to run concurrently.
+type Extractor interface {
+	// Name identifies the extractor in provenance and logs
+	Name() string
+	// Accepts reports whether the extractor handles documents of the MIME type
+	Accepts(mimeType string) bool
+	// Extract returns the tags found in the document. An error retries the document.
+	Extract(ctx context.Context, input *ExtractionInput) ([]ExtractedTag, error)
+}
+
+// ExtractionService runs registered extractors over uploaded documents and records what
+// they find as automatic tags, or queues them for review if they aren't confident enough
+type ExtractionService struct {
+	storage         *storage.Storage
+	documentService *DocumentService
+	reviewService   *ReviewService
+
+	mu         sync.RWMutex
+	extractors []Extractor
+}
+
+// NewExtractionService creates a new extraction service with no extractors registered
+func NewExtractionService(
+	storage *storage.Storage,
+	documentService *DocumentService,
+	reviewService *ReviewService,
+) *ExtractionService {
+	return &ExtractionService{
+		storage:         storage,
+		documentService: documentService,
+		reviewService:   reviewService,
+	}
+}
+
+// Register adds an extractor that is run over every document it accepts
+func (s *ExtractionService) Register(extractor Extractor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.extractors = append(s.extractors, extractor)
+}
+
+// Extractors returns the registered extractors
+func (s *ExtractionService) Extractors() []Extractor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Extractor(nil), s.extractors...)
+}
+
+// HandleEvent runs the extractors over a document named in a documents.uploaded event. It
+// is an events.MessageHandler; an error retries the whole document, which is safe since
+// adding a tag again replaces it.
+func (s *ExtractionService) HandleEvent(ctx context.Context, subject string, data []byte) error {
+	event, err := events.DecodeEvent(subject, data)
+	if err != nil {
+		slog.Error("failed to decode event", "subject", subject, "error", err)
+		return nil
+	}
+	uploaded, ok := event.Message.(*eventsv1.DocumentUploadedEvent)
+	if !ok {
+		return nil
+	}
+
+	doc, err := s.storage.GetDocument(ctx, uploaded.Namespace, uploaded.DocumentId)
+	if errors.Is(err, storage.ErrNotFound) {
+		slog.Info(
+			"skipping extraction of deleted document",
+			"document_id", uploaded.DocumentId,
+			"namespace", uploaded.Namespace,
+		)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	mimeType := doc.MimeType
+	if doc.DetectedMimeType != nil {
+		mimeType = *doc.DetectedMimeType
+	}
+
+	var errs []error
+	for _, extractor := range s.Extractors() {
+		if !extractor.Accepts(mimeType) {
+			continue
+		}
+		if err := s.runExtractor(ctx, extractor, uploaded.Namespace, doc, mimeType); err != nil {
+			errs = append(errs, fmt.Errorf("extractor %s: %w", extractor.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runExtractor runs one extractor over a document and tags it with the results
+func (s *ExtractionService) runExtractor(
+	ctx context.Context,
+	extractor Extractor,
+	namespace string,
+	doc *sqlc.Document,
+	mimeType string,
+) error {
+	content, err := s.storage.Open(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("failed to open document: %w", err)
+	}
+	defer func() { _ = content.Close() }()
+
+	documentID := doc.ID.String()
+	tags, err := extractor.Extract(ctx, &ExtractionInput{
+		DocumentID: documentID,
+		Namespace:  namespace,
+		Filename:   doc.FileName,
+		MimeType:   mimeType,
+		Size:       doc.FileSize,
+		Content:    content,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx = events.WithActor(ctx, extractorActor(extractor.Name()))
+	for _, tag := range tags {
+		if err := s.applyExtractedTag(ctx, extractor, namespace, documentID, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyExtractedTag adds an extracted tag to a document, or queues it for review if it is
+// less confident than the namespace's threshold. Tags that can never be applied, such as
+// unknown paths or attributes the schema rejects, are logged and skipped.
+func (s *ExtractionService) applyExtractedTag(
+	ctx context.Context,
+	extractor Extractor,
+	namespace string,
+	documentID string,
+	tag ExtractedTag,
+) error {
+	if tag.Confidence < 0 || tag.Confidence > 1 {
+		slog.Warn(
+			"skipping extracted tag with confidence out of range",
+			"extractor", extractor.Name(),
+			"document_id", documentID,
+			"tag_path", tag.TagPath,
+			"confidence", tag.Confidence,
+		)
+		return nil
+	}
+
+	var attributesJSON *string
+	if len(tag.Attributes) > 0 {
+		data, err := json.Marshal(tag.Attributes)
+		if err != nil {
+			slog.Warn(
+				"skipping extracted tag with unencodable attributes",
+				"extractor", extractor.Name(),
+				"document_id", documentID,
+				"tag_path", tag.TagPath,
+				"error", err,
+			)
+			return nil
+		}
+		attributes := string(data)
+		attributesJSON = &attributes
+	}
+
+	extractedBy := tag.ExtractedBy
+	if extractedBy == "" {
+		extractedBy = extractor.Name()
+	}
+	confidence := tag.Confidence
+	queued, err := s.reviewService.QueueExtraction(
+		ctx,
+		namespace,
+		documentID,
+		tag.TagPath,
+		attributesJSON,
+		confidence,
+		extractedBy,
+	)
+	if err == nil && queued {
+		slog.Info(
+			"queued extracted tag for review",
+			"extractor", extractor.Name(),
+			"document_id", documentID,
+			"tag_path", tag.TagPath,
+			"confidence", tag.Confidence,
+		)
+		return nil
+	}
+	if err == nil {
+		var skipped []SkippedAttribute
+		skipped, err = s.documentService.AddTagToDocument(
+			ctx,
+			namespace,
+			documentID,
+			tag.TagPath,
+			attributesJSON,
+			ExtractionMethodAutomatic,
+			extractedBy,
+			&confidence,
+			false,
+			nil,
+		)
+		for _, attribute := range skipped {
+			slog.Info(
+				"kept attribute over extracted value",
+				"extractor", extractor.Name(),
+				"document_id", documentID,
+				"tag_path", tag.TagPath,
+				"field", attribute.Field,
+				"reason", attribute.Reason,
+			)
+		}
+	}
+	if err != nil && isPermanentTagError(err) {
+		slog.Warn(
+			"skipping extracted tag",
+			"extractor", extractor.Name(),
+			"document_id", documentID,
+			"tag_path", tag.TagPath,
+			"error", err,
+		)
+		return nil
+	}
+	return err
+}
+
+// isPermanentTagError reports whether adding a tag failed in a way retrying won't fix
+func isPermanentTagError(err error) bool {
+	if errors.Is(err, ErrTagNotFound) || errors.Is(err, ErrDocumentNotInNamespace) ||
+		errors.Is(err, ErrAttributeValidationFailed) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound:
+		return true
+	}
+	return false
+}
+
+// extractorActor is the actor events are attributed to for changes made by an extractor
+func extractorActor(name string) string {
+	return "extractor:" + name
+}
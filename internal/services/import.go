@@ -0,0 +1,703 @@
+package services
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/events"
+	"github.com/RynoXLI/Wayfile/internal/storage"
+)
+
+var (
+	// ErrImportNotFound is returned when an import job doesn't exist in the namespace
+	ErrImportNotFound = errors.New("import not found")
+	// ErrUnsupportedArchive is returned when an import isn't a readable ZIP or tar archive
+	ErrUnsupportedArchive = errors.New("unsupported archive format")
+	// ErrImportTooLarge is returned when an archive exceeds the configured maximum import size
+	ErrImportTooLarge = errors.New("archive exceeds maximum import size")
+)
+
+// Archive formats accepted for import
+const (
+	ArchiveFormatZip   = "zip"
+	ArchiveFormatTar   = "tar"
+	ArchiveFormatTarGz = "tar.gz"
+)
+
+// Import job statuses
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// Import entry statuses
+const (
+	ImportEntryCreated   = "created"
+	ImportEntryDuplicate = "duplicate"
+	ImportEntrySkipped   = "skipped"
+	ImportEntryFailed    = "failed"
+)
+
+const (
+	// archiveSniffLen covers the tar magic at offset 257
+	archiveSniffLen = 262
+	// importStaleAfter is how long a running import may go without progress before it is
+	// assumed abandoned and requeued
+	importStaleAfter = 5 * time.Minute
+	// importHeartbeatInterval is how often progress is reported while a large entry is copied
+	importHeartbeatInterval = 30 * time.Second
+)
+
+// invalidTagNameChars matches runs of characters that can't appear in a tag name
+var invalidTagNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// ImportService unpacks uploaded ZIP and tar archives into documents in the background,
+// mapping the archive's directories to tags
+type ImportService struct {
+	storage         *storage.Storage
+	documentService *DocumentService
+	tagService      *TagService
+	queries         *sqlc.Queries
+	maxSize         int64
+	maxEntrySize    int64
+	wake            chan struct{}
+}
+
+// NewImportService creates a new import service
+func NewImportService(
+	storage *storage.Storage,
+	documentService *DocumentService,
+	tagService *TagService,
+	queries *sqlc.Queries,
+	maxSize int64,
+	maxEntrySize int64,
+) *ImportService {
+	return &ImportService{
+		storage:         storage,
+		documentService: documentService,
+		tagService:      tagService,
+		queries:         queries,
+		maxSize:         maxSize,
+		maxEntrySize:    maxEntrySize,
+		wake:            make(chan struct{}, 1),
+	}
+}
+
+// MaxSize returns the largest archive the service accepts
+func (s *ImportService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// importStagingID returns the staging key of an import's archive
+func importStagingID(jobID string) string {
+	return "import-" + jobID
+}
+
+// importActor identifies an import as the author of the documents, tags and events it creates
+func importActor(jobID string) string {
+	return "import:" + jobID
+}
+
+// detectArchiveFormat identifies an archive from its leading bytes
+func detectArchiveFormat(head []byte) (string, error) {
+	switch {
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")), bytes.HasPrefix(head, []byte("PK\x05\x06")):
+		return ArchiveFormatZip, nil
+	case bytes.HasPrefix(head, []byte("\x1f\x8b")):
+		return ArchiveFormatTarGz, nil
+	case len(head) >= archiveSniffLen && string(head[257:262]) == "ustar":
+		return ArchiveFormatTar, nil
+	}
+	return "", fmt.Errorf("%w: expected a ZIP, tar or gzipped tar archive", ErrUnsupportedArchive)
+}
+
+// CreateImport stages an archive and queues it for import. Directories inside the archive
+// become tags under tagPath, or at the root when tagPath is empty.
+func (s *ImportService) CreateImport(
+	ctx context.Context,
+	namespace string,
+	filename string,
+	tagPath string,
+	data io.Reader,
+) (*sqlc.ImportJob, error) {
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	var rootTag *string
+	if tagPath != "" {
+		normalized := normalizeTagPath(tagPath)
+		if _, err := s.queries.GetTagByPath(ctx, ns.ID, normalized); err != nil {
+			return nil, fmt.Errorf("%w at path %q", ErrTagNotFound, normalized)
+		}
+		rootTag = &normalized
+	}
+
+	buffered := bufio.NewReader(data)
+	head, _ := buffered.Peek(archiveSniffLen)
+	format, err := detectArchiveFormat(head)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID := uuid.New()
+	stagingID := importStagingID(jobID.String())
+	discard := func() {
+		if err := s.storage.DeleteStaged(ctx, stagingID); err != nil {
+			slog.Error("failed to delete staged archive", "import_id", jobID.String(), "error", err)
+		}
+	}
+
+	counter := &countingReader{r: io.LimitReader(buffered, s.maxSize+1)}
+	if err := s.storage.StageChunk(ctx, stagingID, 0, counter); err != nil {
+		discard()
+		return nil, fmt.Errorf("failed to stage archive: %w", err)
+	}
+	if counter.n > s.maxSize {
+		discard()
+		return nil, ErrImportTooLarge
+	}
+
+	// ZIP archives are indexed, so they can be validated and counted up front
+	var totalEntries *int32
+	if format == ArchiveFormatZip {
+		reader := s.storage.OpenStagedAt(ctx, stagingID, counter.n)
+		zr, err := zip.NewReader(reader, counter.n)
+		_ = reader.Close()
+		if err != nil {
+			discard()
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedArchive, err)
+		}
+		var total int32
+		for _, f := range zr.File {
+			if !f.FileInfo().IsDir() {
+				total++
+			}
+		}
+		totalEntries = &total
+	}
+
+	job, err := s.queries.CreateImportJob(
+		ctx,
+		pgtype.UUID{Bytes: jobID, Valid: true},
+		ns.ID,
+		filename,
+		format,
+		rootTag,
+		counter.n,
+		totalEntries,
+	)
+	if err != nil {
+		discard()
+		return nil, fmt.Errorf("failed to create import: %w", err)
+	}
+
+	// Wake the worker without blocking if it's already been signalled
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return &job, nil
+}
+
+// GetImport retrieves an import job within a namespace
+func (s *ImportService) GetImport(
+	ctx context.Context,
+	namespace string,
+	importID string,
+) (*sqlc.ImportJob, error) {
+	id, err := uuid.Parse(importID)
+	if err != nil {
+		return nil, ErrImportNotFound
+	}
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	job, err := s.queries.GetImportJob(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrImportNotFound
+		}
+		return nil, err
+	}
+	if job.NamespaceID != ns.ID {
+		return nil, ErrImportNotFound
+	}
+	return &job, nil
+}
+
+// ListImportEntries retrieves the per-entry results of an import job
+func (s *ImportService) ListImportEntries(
+	ctx context.Context,
+	namespace string,
+	importID string,
+	limit int32,
+	offset int32,
+) ([]sqlc.ImportJobEntry, error) {
+	job, err := s.GetImport(ctx, namespace, importID)
+	if err != nil {
+		return nil, err
+	}
+	return s.queries.ListImportJobEntries(ctx, job.ID, limit, offset)
+}
+
+// RunWorker processes queued imports until the context is cancelled. It wakes immediately
+// when an import is created and otherwise polls every interval, requeueing abandoned jobs.
+func (s *ImportService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		requeued, err := s.queries.RequeueStaleImportJobs(ctx, pgtype.Timestamptz{
+			Time:  time.Now().Add(-importStaleAfter),
+			Valid: true,
+		})
+		if err != nil {
+			slog.Error("failed to requeue stale imports", "error", err)
+		} else if requeued > 0 {
+			slog.Info("requeued stale imports", "count", requeued)
+		}
+
+		for {
+			processed, err := s.ProcessNext(ctx)
+			if err != nil {
+				slog.Error("failed to process import", "error", err)
+				break
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// ProcessNext claims the oldest pending import and runs it to completion. It reports
+// whether a job was claimed.
+func (s *ImportService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.queries.ClaimImportJob(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim import: %w", err)
+	}
+
+	status := ImportStatusCompleted
+	var errorMessage *string
+	if runErr := s.runImport(ctx, &job); runErr != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the job is requeued once it goes stale
+			return true, ctx.Err()
+		}
+		slog.Error("import failed", "import_id", job.ID.String(), "error", runErr)
+		status = ImportStatusFailed
+		message := runErr.Error()
+		errorMessage = &message
+	}
+
+	if err := s.queries.FinishImportJob(ctx, status, errorMessage, job.ID); err != nil {
+		return true, fmt.Errorf("failed to finish import: %w", err)
+	}
+	if err := s.storage.DeleteStaged(ctx, importStagingID(job.ID.String())); err != nil {
+		slog.Error("failed to delete staged archive", "import_id", job.ID.String(), "error", err)
+	}
+	return true, nil
+}
+
+// archiveEntry is a file inside an archive, independent of the archive format
+type archiveEntry struct {
+	name string
+	size int64
+	mode fs.FileMode
+	open func() (io.ReadCloser, error)
+}
+
+// importRun holds the state of a single import job while it is processed
+type importRun struct {
+	service     *ImportService
+	job         *sqlc.ImportJob
+	namespace   string
+	namespaceID pgtype.UUID
+	// done holds entries recorded by an earlier, interrupted run of the job
+	done map[string]bool
+	// tags caches tag paths known to exist
+	tags map[string]bool
+}
+
+// runImport walks the staged archive and imports each entry
+func (s *ImportService) runImport(ctx context.Context, job *sqlc.ImportJob) error {
+	ctx = events.WithActor(ctx, importActor(job.ID.String()))
+
+	ns, err := s.queries.GetNamespaceByID(ctx, job.NamespaceID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve namespace: %w", err)
+	}
+	donePaths, err := s.queries.ListImportEntryPaths(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list processed entries: %w", err)
+	}
+
+	run := &importRun{
+		service:     s,
+		job:         job,
+		namespace:   ns.Name,
+		namespaceID: ns.ID,
+		done:        make(map[string]bool, len(donePaths)),
+		tags:        make(map[string]bool),
+	}
+	for _, p := range donePaths {
+		run.done[p] = true
+	}
+
+	stagingID := importStagingID(job.ID.String())
+	if job.Format == ArchiveFormatZip {
+		reader := s.storage.OpenStagedAt(ctx, stagingID, job.ArchiveSize)
+		defer func() { _ = reader.Close() }()
+		return run.importZip(ctx, reader)
+	}
+
+	reader := s.storage.OpenStaged(ctx, stagingID, 1)
+	defer func() { _ = reader.Close() }()
+	return run.importTar(ctx, reader, job.Format == ArchiveFormatTarGz)
+}
+
+// importZip imports every file of a ZIP archive
+func (r *importRun) importZip(ctx context.Context, reader io.ReaderAt) error {
+	zr, err := zip.NewReader(reader, r.job.ArchiveSize)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnsupportedArchive, err)
+	}
+
+	var consumed int64
+	for _, f := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		consumed += int64(f.CompressedSize64)
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		entry := archiveEntry{
+			name: f.Name,
+			size: int64(f.UncompressedSize64),
+			mode: f.Mode(),
+			open: f.Open,
+		}
+		if err := r.process(ctx, entry, consumed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importTar imports every file of a tar archive, optionally gzip-compressed
+func (r *importRun) importTar(ctx context.Context, reader io.Reader, gzipped bool) error {
+	counter := &countingReader{r: reader}
+	var src io.Reader = counter
+	if gzipped {
+		gz, err := gzip.NewReader(counter)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUnsupportedArchive, err)
+		}
+		defer func() { _ = gz.Close() }()
+		src = gz
+	}
+
+	// Tar archives aren't indexed, so the total is only known once the stream ends
+	var total int32
+	tr := tar.NewReader(src)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return r.service.queries.SetImportJobTotal(ctx, r.job.ID, &total)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUnsupportedArchive, err)
+		}
+		if hdr.Typeflag == tar.TypeDir || hdr.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+		entry := archiveEntry{
+			name: hdr.Name,
+			size: hdr.Size,
+			mode: hdr.FileInfo().Mode(),
+			open: func() (io.ReadCloser, error) { return io.NopCloser(tr), nil },
+		}
+		total++
+		if err := r.process(ctx, entry, counter.n); err != nil {
+			return err
+		}
+	}
+}
+
+// importEntryResult is the outcome of importing a single archive entry
+type importEntryResult struct {
+	status     string
+	documentID pgtype.UUID
+	tagPath    *string
+	err        error
+}
+
+// process imports one entry and records its result along with the job's progress
+func (r *importRun) process(ctx context.Context, entry archiveEntry, consumed int64) error {
+	name := cleanArchivePath(entry.name)
+	if name == "" || r.done[name] {
+		return nil
+	}
+
+	var result importEntryResult
+	switch {
+	case !entry.mode.IsRegular():
+		result = importEntryResult{
+			status: ImportEntrySkipped,
+			err:    errors.New("not a regular file"),
+		}
+	case isArchiveMetadata(name):
+		result = importEntryResult{status: ImportEntrySkipped, err: errors.New("archive metadata")}
+	case entry.size > r.service.maxEntrySize:
+		result = importEntryResult{
+			status: ImportEntryFailed,
+			err:    errors.New("entry exceeds maximum document size"),
+		}
+	default:
+		result = r.importEntry(ctx, name, entry, consumed)
+	}
+
+	var errorMessage *string
+	if result.err != nil {
+		message := result.err.Error()
+		errorMessage = &message
+	}
+	if err := r.service.queries.RecordImportEntry(
+		ctx,
+		consumed,
+		r.job.ID,
+		name,
+		result.status,
+		result.documentID,
+		result.tagPath,
+		errorMessage,
+	); err != nil {
+		return fmt.Errorf("failed to record import entry %q: %w", name, err)
+	}
+	r.done[name] = true
+	return nil
+}
+
+// importEntry uploads an entry as a document and tags it with its directory
+func (r *importRun) importEntry(
+	ctx context.Context,
+	name string,
+	entry archiveEntry,
+	consumed int64,
+) importEntryResult {
+	tagPath, err := r.ensureDirectoryTag(ctx, path.Dir(name))
+	if err != nil {
+		return importEntryResult{status: ImportEntryFailed, err: err}
+	}
+
+	rc, err := entry.open()
+	if err != nil {
+		return importEntryResult{status: ImportEntryFailed, tagPath: tagPath, err: err}
+	}
+	defer func() { _ = rc.Close() }()
+
+	mimeType := mime.TypeByExtension(path.Ext(name))
+	if mimeType == "" {
+		mimeType = defaultUploadMimeType
+	}
+
+	hash := sha256.New()
+	reader := &heartbeatReader{
+		r: io.TeeReader(rc, hash),
+		beat: func() {
+			if err := r.service.queries.TouchImportJob(ctx, r.job.ID, consumed); err != nil {
+				slog.Error("failed to report import progress", "error", err)
+			}
+		},
+		last: time.Now(),
+	}
+
+	uploaded, err := r.service.documentService.UploadDocument(
+		ctx,
+		r.namespace,
+		path.Base(name),
+		mimeType,
+		int(entry.size),
+		reader,
+	)
+	if errors.Is(err, storage.ErrDuplicateFile) {
+		result := importEntryResult{status: ImportEntryDuplicate, tagPath: tagPath}
+		checksum := hex.EncodeToString(hash.Sum(nil))
+		if existing, err := r.service.queries.GetDocumentByChecksum(
+			ctx,
+			r.namespaceID,
+			checksum,
+		); err == nil {
+			result.documentID = existing.ID
+		}
+		return result
+	}
+	if err != nil {
+		return importEntryResult{status: ImportEntryFailed, tagPath: tagPath, err: err}
+	}
+
+	result := importEntryResult{
+		status:     ImportEntryCreated,
+		documentID: uploaded.Document.ID,
+		tagPath:    tagPath,
+	}
+	if tagPath != nil {
+		if _, err := r.service.documentService.AddTagToDocument(
+			ctx,
+			r.namespace,
+			uploaded.Document.ID.String(),
+			*tagPath,
+			nil,
+			ExtractionMethodAutomatic,
+			importActor(r.job.ID.String()),
+			nil,
+			false,
+			nil,
+		); err != nil {
+			result.err = fmt.Errorf("document created but tagging failed: %w", err)
+		}
+	}
+	return result
+}
+
+// ensureDirectoryTag creates the tag hierarchy for an archive directory as needed and
+// returns its path. Files at the archive root get the import's tag, if any.
+func (r *importRun) ensureDirectoryTag(ctx context.Context, dir string) (*string, error) {
+	if dir == "." {
+		return r.job.TagPath, nil
+	}
+
+	parent := ""
+	if r.job.TagPath != nil {
+		parent = *r.job.TagPath
+	}
+	for _, segment := range strings.Split(dir, "/") {
+		name := tagNameFromDirectory(segment)
+		if name == "" {
+			return nil, fmt.Errorf(
+				"%w: directory %q can't be used as a tag",
+				ErrInvalidTagName,
+				segment,
+			)
+		}
+		tagPath := buildTagPath(parent, name)
+		if !r.tags[tagPath] {
+			var parentPath *string
+			if parent != "" {
+				parentPath = &parent
+			}
+			_, err := r.service.tagService.CreateTag(
+				ctx,
+				r.namespace,
+				name,
+				nil,
+				parentPath,
+				nil,
+				nil,
+				false,
+				SchemaCompatibilityNone,
+			)
+			if err != nil && !errors.Is(err, ErrTagAlreadyExists) {
+				return nil, fmt.Errorf("failed to create tag %q: %w", tagPath, err)
+			}
+			r.tags[tagPath] = true
+		}
+		parent = tagPath
+	}
+	return &parent, nil
+}
+
+// tagNameFromDirectory derives a valid tag name from a directory name, replacing
+// unsupported characters such as spaces with hyphens
+func tagNameFromDirectory(dir string) string {
+	name := invalidTagNameChars.ReplaceAllString(dir, "-")
+	name = strings.Trim(name, "-_")
+	if len(name) > 100 {
+		name = strings.Trim(name[:100], "-_")
+	}
+	if !tagNameRegex.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// cleanArchivePath normalizes an entry name to a relative slash-separated path,
+// resolving any ".." components so entries can't escape the archive root
+func cleanArchivePath(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	cleaned := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if cleaned == "." {
+		return ""
+	}
+	return cleaned
+}
+
+// isArchiveMetadata reports whether an entry is operating system metadata rather than
+// a user file
+func isArchiveMetadata(name string) bool {
+	base := path.Base(name)
+	return strings.HasPrefix(name, "__MACOSX/") ||
+		strings.HasPrefix(base, "._") ||
+		base == ".DS_Store" ||
+		base == "Thumbs.db" ||
+		base == "desktop.ini"
+}
+
+// heartbeatReader periodically reports progress while a large entry is read, so the
+// running job isn't mistaken for an abandoned one
+type heartbeatReader struct {
+	r    io.Reader
+	beat func()
+	last time.Time
+}
+
+func (h *heartbeatReader) Read(p []byte) (int, error) {
+	if time.Since(h.last) >= importHeartbeatInterval {
+		h.beat()
+		h.last = time.Now()
+	}
+	return h.r.Read(p)
+}
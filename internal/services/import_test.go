@@ -0,0 +1,111 @@
+package services
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectArchiveFormat(t *testing.T) {
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	_, err := zw.Create("a.txt")
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	var emptyZip bytes.Buffer
+	require.NoError(t, zip.NewWriter(&emptyZip).Close())
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "a.txt", Mode: 0o644, Size: 0}))
+	require.NoError(t, tw.Close())
+
+	var gzBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzBuf)
+	_, err = gw.Write(tarBuf.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	tests := []struct {
+		name    string
+		head    []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "zip", head: zipBuf.Bytes(), want: ArchiveFormatZip},
+		{name: "empty zip", head: emptyZip.Bytes(), want: ArchiveFormatZip},
+		{name: "tar", head: tarBuf.Bytes(), want: ArchiveFormatTar},
+		{name: "gzipped tar", head: gzBuf.Bytes(), want: ArchiveFormatTarGz},
+		{name: "plain text", head: []byte("not an archive"), wantErr: true},
+		{name: "empty", head: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectArchiveFormat(tt.head)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedArchive)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTagNameFromDirectory(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{dir: "invoices", want: "invoices"},
+		{dir: "Tax Returns 2019", want: "Tax-Returns-2019"},
+		{dir: "  padded  ", want: "padded"},
+		{dir: "_private", want: "private"},
+		{dir: "a.b,c", want: "a-b-c"},
+		{dir: "Überweisungen", want: "berweisungen"},
+		{dir: "...", want: ""},
+		{dir: "日本", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			assert.Equal(t, tt.want, tagNameFromDirectory(tt.dir))
+		})
+	}
+}
+
+func TestCleanArchivePath(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "docs/a.pdf", want: "docs/a.pdf"},
+		{name: "./docs//a.pdf", want: "docs/a.pdf"},
+		{name: "/abs/a.pdf", want: "abs/a.pdf"},
+		{name: "../../etc/passwd", want: "etc/passwd"},
+		{name: `windows\dir\a.txt`, want: "windows/dir/a.txt"},
+		{name: "./", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cleanArchivePath(tt.name))
+		})
+	}
+}
+
+func TestIsArchiveMetadata(t *testing.T) {
+	assert.True(t, isArchiveMetadata("__MACOSX/docs/._a.pdf"))
+	assert.True(t, isArchiveMetadata("docs/._a.pdf"))
+	assert.True(t, isArchiveMetadata("docs/.DS_Store"))
+	assert.True(t, isArchiveMetadata("Thumbs.db"))
+	assert.False(t, isArchiveMetadata("docs/a.pdf"))
+	assert.False(t, isArchiveMetadata(".env"))
+}
@@ -3,19 +3,33 @@ package services
 
 import (
 	"context"
+	"errors"
 
+	"github.com/jackc/pgx/v5"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	"github.com/RynoXLI/Wayfile/internal/db"
 	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/events"
 )
 
 // NamespaceService orchestrates namespace operations
 type NamespaceService struct {
+	db      db.TxBeginner
 	queries *sqlc.Queries
+	outbox  *events.Outbox
 }
 
 // NewNamespaceService creates a new namespace service
-func NewNamespaceService(queries *sqlc.Queries) *NamespaceService {
+func NewNamespaceService(
+	pool db.TxBeginner,
+	queries *sqlc.Queries,
+	outbox *events.Outbox,
+) *NamespaceService {
 	return &NamespaceService{
+		db:      pool,
 		queries: queries,
+		outbox:  outbox,
 	}
 }
 
@@ -24,7 +38,19 @@ func (s *NamespaceService) CreateNamespace(
 	ctx context.Context,
 	name string,
 ) (sqlc.Namespace, error) {
-	return s.queries.CreateNamespace(ctx, name)
+	var namespace sqlc.Namespace
+	err := db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		namespace, err = s.queries.WithTx(tx).CreateNamespace(ctx, name)
+		if err != nil {
+			return err
+		}
+		return s.outbox.WithTx(tx).NamespaceCreated(ctx, &eventsv1.NamespaceCreatedEvent{
+			NamespaceId: namespace.ID.String(),
+			Namespace:   namespace.Name,
+		})
+	})
+	return namespace, err
 }
 
 // ListNamespaces retrieves all namespaces
@@ -37,7 +63,23 @@ func (s *NamespaceService) GetNamespace(ctx context.Context, name string) (sqlc.
 	return s.queries.GetNamespaceByName(ctx, name)
 }
 
-// DeleteNamespace removes a namespace
+// DeleteNamespace removes a namespace. Deleting a namespace that doesn't exist is a no-op.
 func (s *NamespaceService) DeleteNamespace(ctx context.Context, name string) error {
-	return s.queries.DeleteNamespace(ctx, name)
+	return db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		queries := s.queries.WithTx(tx)
+		namespace, err := queries.GetNamespaceByName(ctx, name)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := queries.DeleteNamespace(ctx, name); err != nil {
+			return err
+		}
+		return s.outbox.WithTx(tx).NamespaceDeleted(ctx, &eventsv1.NamespaceDeletedEvent{
+			NamespaceId: namespace.ID.String(),
+			Namespace:   namespace.Name,
+		})
+	})
 }
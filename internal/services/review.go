@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+// Review queue errors
+var (
+	// ErrReviewItemNotFound is returned when a review item doesn't exist
+	ErrReviewItemNotFound = errors.New("review item not found")
+	// ErrReviewItemResolved is returned when a review item was already accepted or rejected
+	ErrReviewItemResolved = errors.New("review item already resolved")
+	// ErrInvalidReviewThreshold is returned when a review threshold is outside 0 to 1
+	ErrInvalidReviewThreshold = errors.New("review threshold must be between 0 and 1")
+)
+
+// Review item statuses
+const (
+	reviewStatusPending  = "pending"
+	reviewStatusAccepted = "accepted"
+	reviewStatusRejected = "rejected"
+)
+
+// ReviewService queues automatic extractions a namespace isn't confident enough in and
+// applies or discards them once reviewed
+type ReviewService struct {
+	queries         *sqlc.Queries
+	documentService *DocumentService
+	tagService      *TagService
+}
+
+// NewReviewService creates a new review queue service
+func NewReviewService(
+	queries *sqlc.Queries,
+	documentService *DocumentService,
+	tagService *TagService,
+) *ReviewService {
+	return &ReviewService{
+		queries:         queries,
+		documentService: documentService,
+		tagService:      tagService,
+	}
+}
+
+// SetReviewThreshold sets the confidence below which a namespace's automatic extractions
+// are queued for review. A nil threshold applies every extraction.
+func (s *ReviewService) SetReviewThreshold(
+	ctx context.Context,
+	namespace string,
+	threshold *float64,
+) (*float64, error) {
+	if threshold != nil && (*threshold < 0 || *threshold > 1) {
+		return nil, ErrInvalidReviewThreshold
+	}
+	ns, err := s.queries.SetNamespaceReviewThreshold(ctx, namespace, threshold)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNamespaceNotFound
+		}
+		return nil, err
+	}
+	return ns.ReviewThreshold, nil
+}
+
+// QueueExtraction queues an automatic extraction for review if its confidence is below
+// the namespace's threshold. It reports whether the extraction was queued; if not, the
+// caller applies it. Queuing the same tag for a document again replaces the pending item.
+func (s *ReviewService) QueueExtraction(
+	ctx context.Context,
+	namespace string,
+	documentID string,
+	tagPath string,
+	attributesJSON *string,
+	confidence float64,
+	extractedBy string,
+) (bool, error) {
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return false, ErrNamespaceNotFound
+	}
+	if ns.ReviewThreshold == nil || confidence >= *ns.ReviewThreshold {
+		return false, nil
+	}
+
+	tag, err := s.documentService.resolveTagByPath(ctx, ns.ID, tagPath)
+	if err != nil {
+		return false, err
+	}
+	docPgUUID, err := s.documentService.parseAndValidateDocumentID(documentID)
+	if err != nil {
+		return false, err
+	}
+
+	// Extractions the schema rejects could never be accepted as they are
+	var attributes []byte
+	if attributesJSON != nil && *attributesJSON != "" {
+		var attributesMap map[string]interface{}
+		if err := json.Unmarshal([]byte(*attributesJSON), &attributesMap); err != nil {
+			return false, status.Errorf(codes.InvalidArgument, "invalid attributes JSON: %v", err)
+		}
+		// Validate them as they would be written, with defaults and computed attributes
+		document, err := s.queries.GetDocumentByID(ctx, docPgUUID)
+		if err != nil {
+			return false, status.Errorf(codes.NotFound, "document not found: %v", err)
+		}
+		_, err = s.tagService.PrepareAttributes(ctx, tag.ID, &document, attributesMap, nil)
+		if err != nil {
+			return false, attributeValidationError(err)
+		}
+		attributes = []byte(*attributesJSON)
+	}
+
+	_, err = s.queries.QueueReviewItem(
+		ctx,
+		ns.ID,
+		docPgUUID,
+		tag.ID,
+		attributes,
+		confidence,
+		extractedBy,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to queue review item: %w", err)
+	}
+	return true, nil
+}
+
+// ListReviewItems retrieves a namespace's pending review items, oldest first
+func (s *ReviewService) ListReviewItems(
+	ctx context.Context,
+	namespace string,
+) ([]sqlc.ListPendingReviewItemsRow, error) {
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+	return s.queries.ListPendingReviewItems(ctx, ns.ID)
+}
+
+// AcceptReviewItem applies a pending extraction, with attributesJSON replacing the
+// extracted attributes if set. The tag's provenance records it as set manually by the
+// reviewer. The item is resolved in the transaction that applies the tag, so an item
+// resolved concurrently is never applied.
+func (s *ReviewService) AcceptReviewItem(
+	ctx context.Context,
+	namespace string,
+	itemID string,
+	reviewer string,
+	attributesJSON *string,
+) error {
+	item, err := s.getPendingItem(ctx, namespace, itemID)
+	if err != nil {
+		return err
+	}
+
+	if attributesJSON == nil && len(item.Attributes) > 0 {
+		attributes := string(item.Attributes)
+		attributesJSON = &attributes
+	}
+	claim := func(queries *sqlc.Queries) error {
+		return resolveReviewItem(ctx, queries, item.ID, reviewStatusAccepted, reviewer)
+	}
+	_, err = s.documentService.addTagToDocument(
+		events.WithActor(ctx, reviewerActor(reviewer)),
+		namespace,
+		item.DocumentID.String(),
+		item.TagPath,
+		attributesJSON,
+		ExtractionMethodManual,
+		reviewer,
+		nil,
+		false,
+		nil,
+		claim,
+	)
+	return err
+}
+
+// RejectReviewItem discards a pending extraction
+func (s *ReviewService) RejectReviewItem(
+	ctx context.Context,
+	namespace string,
+	itemID string,
+	reviewer string,
+) error {
+	item, err := s.getPendingItem(ctx, namespace, itemID)
+	if err != nil {
+		return err
+	}
+	return resolveReviewItem(ctx, s.queries, item.ID, reviewStatusRejected, reviewer)
+}
+
+// resolveReviewItem resolves a review item if it is still pending
+func resolveReviewItem(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	id pgtype.UUID,
+	resolution string,
+	reviewer string,
+) error {
+	rows, err := queries.ResolveReviewItem(ctx, id, resolution, &reviewer)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrReviewItemResolved
+	}
+	return nil
+}
+
+// getPendingItem retrieves a review item within a namespace that is still pending
+func (s *ReviewService) getPendingItem(
+	ctx context.Context,
+	namespace string,
+	itemID string,
+) (*sqlc.GetReviewItemRow, error) {
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, ErrReviewItemNotFound
+	}
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	item, err := s.queries.GetReviewItem(ctx, pgtype.UUID{Bytes: id, Valid: true}, ns.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewItemNotFound
+		}
+		return nil, err
+	}
+	if item.Status != reviewStatusPending {
+		return nil, ErrReviewItemResolved
+	}
+	return &item, nil
+}
+
+// reviewerActor is the actor events are attributed to for changes made by a reviewer
+func reviewerActor(reviewer string) string {
+	return "reviewer:" + reviewer
+}
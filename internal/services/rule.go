@@ -0,0 +1,767 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/storage"
+)
+
+// Tagging rule errors
+var (
+	// ErrRuleNotFound is returned when a tagging rule doesn't exist
+	ErrRuleNotFound = errors.New("tagging rule not found")
+	// ErrInvalidRule is returned when a tagging rule's definition is invalid
+	ErrInvalidRule = errors.New("invalid tagging rule")
+	// ErrRuleAlreadyExists is returned when a namespace already has a rule with the name
+	ErrRuleAlreadyExists = errors.New("tagging rule with this name already exists")
+)
+
+// maxRuleTextSize is how much of a text document text_regex searches
+const maxRuleTextSize = 1 << 20 // 1 MB
+
+// RuleConditions are the tests a document must pass for a rule to apply. Unset
+// conditions are ignored.
+type RuleConditions struct {
+	FilenameGlob  *string              `json:"filename_glob,omitempty"`
+	FilenameRegex *string              `json:"filename_regex,omitempty"`
+	MimeType      *string              `json:"mime_type,omitempty"`
+	MinSize       *int64               `json:"min_size,omitempty"`
+	MaxSize       *int64               `json:"max_size,omitempty"`
+	TextRegex     *string              `json:"text_regex,omitempty"`
+	Attributes    []AttributeCondition `json:"attributes,omitempty"`
+}
+
+// AttributeCondition tests one global attribute. With neither Equals nor Matches set the
+// attribute only has to be present.
+type AttributeCondition struct {
+	Key     string          `json:"key"`
+	Equals  json.RawMessage `json:"equals,omitempty"`
+	Matches *string         `json:"matches,omitempty"`
+}
+
+// RuleAction is what a rule does to a matching document
+type RuleAction struct {
+	TagPath    string         `json:"tag_path"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Captures maps attribute names to named capture groups of the filename or text regex
+	Captures map[string]string `json:"captures,omitempty"`
+}
+
+// RuleInput is the definition of a tagging rule being created or updated
+type RuleInput struct {
+	Name       string
+	Enabled    bool
+	Priority   int32
+	Conditions RuleConditions
+	Action     RuleAction
+}
+
+// TaggingRule is a namespace's rule for tagging uploaded documents
+type TaggingRule struct {
+	ID         pgtype.UUID
+	Name       string
+	Enabled    bool
+	Priority   int32
+	Conditions RuleConditions
+	Action     RuleAction
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
+
+// RuleMatch is the outcome of evaluating a rule against a document
+type RuleMatch struct {
+	Matched bool
+	// Unmet names the conditions the document failed
+	Unmet      []string
+	TagPath    string
+	Attributes map[string]any
+}
+
+// RuleService manages tagging rules and applies them to uploaded documents as an
+// Extractor
+type RuleService struct {
+	queries    *sqlc.Queries
+	storage    *storage.Storage
+	tagService *TagService
+}
+
+// NewRuleService creates a new tagging rule service
+func NewRuleService(
+	queries *sqlc.Queries,
+	storage *storage.Storage,
+	tagService *TagService,
+) *RuleService {
+	return &RuleService{
+		queries:    queries,
+		storage:    storage,
+		tagService: tagService,
+	}
+}
+
+// CreateRule adds a tagging rule to a namespace
+func (s *RuleService) CreateRule(
+	ctx context.Context,
+	namespace string,
+	input *RuleInput,
+) (*TaggingRule, error) {
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+	conditions, action, err := s.validateRule(ctx, ns.ID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.CreateTaggingRule(
+		ctx,
+		ns.ID,
+		input.Name,
+		input.Enabled,
+		input.Priority,
+		conditions,
+		action,
+	)
+	if err != nil {
+		return nil, ruleWriteError(err)
+	}
+	return taggingRuleFromRow(&row)
+}
+
+// GetRule retrieves a tagging rule within a namespace
+func (s *RuleService) GetRule(
+	ctx context.Context,
+	namespace string,
+	ruleID string,
+) (*TaggingRule, error) {
+	row, err := s.getRuleRow(ctx, namespace, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	return taggingRuleFromRow(row)
+}
+
+// ListRules retrieves a namespace's tagging rules in the order they run
+func (s *RuleService) ListRules(ctx context.Context, namespace string) ([]*TaggingRule, error) {
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+	rows, err := s.queries.ListTaggingRules(ctx, ns.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*TaggingRule, 0, len(rows))
+	for i := range rows {
+		rule, err := taggingRuleFromRow(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// UpdateRule replaces a tagging rule's definition
+func (s *RuleService) UpdateRule(
+	ctx context.Context,
+	namespace string,
+	ruleID string,
+	input *RuleInput,
+) (*TaggingRule, error) {
+	existing, err := s.getRuleRow(ctx, namespace, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	conditions, action, err := s.validateRule(ctx, existing.NamespaceID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.UpdateTaggingRule(
+		ctx,
+		existing.ID,
+		existing.NamespaceID,
+		input.Name,
+		input.Enabled,
+		input.Priority,
+		conditions,
+		action,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRuleNotFound
+		}
+		return nil, ruleWriteError(err)
+	}
+	return taggingRuleFromRow(&row)
+}
+
+// DeleteRule removes a tagging rule. Tags it already added are kept.
+func (s *RuleService) DeleteRule(ctx context.Context, namespace string, ruleID string) error {
+	existing, err := s.getRuleRow(ctx, namespace, ruleID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.queries.DeleteTaggingRule(ctx, existing.ID, existing.NamespaceID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrRuleNotFound
+	}
+	return nil
+}
+
+// TestRule evaluates a rule, enabled or not, against an existing document without
+// changing it
+func (s *RuleService) TestRule(
+	ctx context.Context,
+	namespace string,
+	ruleID string,
+	documentID string,
+) (*RuleMatch, error) {
+	row, err := s.getRuleRow(ctx, namespace, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := taggingRuleFromRow(row)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.storage.GetDocument(ctx, namespace, documentID)
+	if err != nil {
+		return nil, err
+	}
+	mimeType := doc.MimeType
+	if doc.DetectedMimeType != nil {
+		mimeType = *doc.DetectedMimeType
+	}
+
+	target := &ruleDocument{
+		Filename:   doc.FileName,
+		MimeType:   mimeType,
+		Size:       doc.FileSize,
+		Attributes: documentAttributes(doc.Attributes),
+	}
+	if rule.Conditions.TextRegex != nil && isTextMimeType(mimeType) {
+		content, err := s.storage.Open(ctx, doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open document: %w", err)
+		}
+		defer func() { _ = content.Close() }()
+		if target.Text, err = readRuleText(content); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.evaluate(ctx, namespace, rule, target)
+}
+
+// Name identifies rules as an extractor. Tags they add record the matching rule's ID
+// as extracted_by.
+func (s *RuleService) Name() string {
+	return "rules"
+}
+
+// Accepts reports that rules are evaluated against every document
+func (s *RuleService) Accepts(string) bool {
+	return true
+}
+
+// Extract evaluates the namespace's enabled rules against an uploaded document and
+// returns the tags of those it matches, in priority order
+func (s *RuleService) Extract(
+	ctx context.Context,
+	input *ExtractionInput,
+) ([]ExtractedTag, error) {
+	ns, err := s.queries.GetNamespaceByName(ctx, input.Namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+	rows, err := s.queries.ListEnabledTaggingRules(ctx, ns.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	docID, err := uuid.Parse(input.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := s.queries.GetDocumentByID(ctx, pgtype.UUID{Bytes: docID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	target := &ruleDocument{
+		Filename:   input.Filename,
+		MimeType:   input.MimeType,
+		Size:       input.Size,
+		Attributes: documentAttributes(doc.Attributes),
+	}
+	if isTextMimeType(input.MimeType) {
+		if target.Text, err = readRuleText(input.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	var tags []ExtractedTag
+	for i := range rows {
+		rule, err := taggingRuleFromRow(&rows[i])
+		if err != nil {
+			slog.Error(
+				"skipping unreadable tagging rule",
+				"rule_id", rows[i].ID.String(),
+				"error", err,
+			)
+			continue
+		}
+		match, err := s.evaluate(ctx, input.Namespace, rule, target)
+		if err != nil {
+			return nil, err
+		}
+		if !match.Matched {
+			continue
+		}
+		tags = append(tags, ExtractedTag{
+			TagPath:     match.TagPath,
+			Attributes:  match.Attributes,
+			Confidence:  1,
+			ExtractedBy: rule.ID.String(),
+		})
+	}
+	return tags, nil
+}
+
+// getRuleRow retrieves a rule's row within a namespace
+func (s *RuleService) getRuleRow(
+	ctx context.Context,
+	namespace string,
+	ruleID string,
+) (*sqlc.TaggingRule, error) {
+	id, err := uuid.Parse(ruleID)
+	if err != nil {
+		return nil, ErrRuleNotFound
+	}
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	row, err := s.queries.GetTaggingRule(ctx, pgtype.UUID{Bytes: id, Valid: true}, ns.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRuleNotFound
+		}
+		return nil, err
+	}
+	return &row, nil
+}
+
+// validateRule checks a rule's definition and returns its conditions and action encoded
+// for storage
+func (s *RuleService) validateRule(
+	ctx context.Context,
+	namespaceID pgtype.UUID,
+	input *RuleInput,
+) ([]byte, []byte, error) {
+	if input.Name == "" || len(input.Name) > 255 {
+		return nil, nil, fmt.Errorf("%w: name must be 1 to 255 characters", ErrInvalidRule)
+	}
+	compiled, err := compileRule(&input.Conditions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	input.Action.TagPath = normalizeTagPath(input.Action.TagPath)
+	if input.Action.TagPath == "" {
+		return nil, nil, fmt.Errorf("%w: action needs a tag path", ErrInvalidRule)
+	}
+	if _, err := s.queries.GetTagByPath(ctx, namespaceID, input.Action.TagPath); err != nil {
+		return nil, nil, fmt.Errorf(
+			"%w: tag %q does not exist",
+			ErrInvalidRule,
+			input.Action.TagPath,
+		)
+	}
+	for field, group := range input.Action.Captures {
+		if !compiled.hasGroup(group) {
+			return nil, nil, fmt.Errorf(
+				"%w: attribute %q captures unknown group %q",
+				ErrInvalidRule,
+				field,
+				group,
+			)
+		}
+	}
+
+	conditions, err := json.Marshal(input.Conditions)
+	if err != nil {
+		return nil, nil, err
+	}
+	action, err := json.Marshal(input.Action)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conditions, action, nil
+}
+
+// evaluate tests a rule against a document and works out the attributes it would set
+func (s *RuleService) evaluate(
+	ctx context.Context,
+	namespace string,
+	rule *TaggingRule,
+	doc *ruleDocument,
+) (*RuleMatch, error) {
+	compiled, err := compileRule(&rule.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	unmet, captured := compiled.match(doc)
+	match := &RuleMatch{
+		Matched: len(unmet) == 0,
+		Unmet:   unmet,
+		TagPath: rule.Action.TagPath,
+	}
+	if !match.Matched {
+		return match, nil
+	}
+
+	attributes := make(map[string]any, len(rule.Action.Attributes)+len(rule.Action.Captures))
+	for field, value := range rule.Action.Attributes {
+		attributes[field] = value
+	}
+	if len(rule.Action.Captures) > 0 {
+		types, err := s.attributeTypes(ctx, namespace, rule.Action.TagPath)
+		if err != nil {
+			return nil, err
+		}
+		for field, group := range rule.Action.Captures {
+			if value, ok := captured[group]; ok {
+				attributes[field] = convertCapture(value, types[field])
+			}
+		}
+	}
+	if len(attributes) > 0 {
+		match.Attributes = attributes
+	}
+	return match, nil
+}
+
+// attributeTypes returns the JSON types the tag's effective schema declares for its
+// attributes, including those it inherits
+func (s *RuleService) attributeTypes(
+	ctx context.Context,
+	namespace string,
+	tagPath string,
+) (map[string]string, error) {
+	tag, err := s.tagService.GetTagByPath(ctx, namespace, tagPath)
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) {
+			// Applying the tag reports it as missing
+			return nil, nil
+		}
+		return nil, err
+	}
+	if tag.EffectiveSchema == nil {
+		return nil, nil
+	}
+
+	var schema struct {
+		Properties map[string]struct {
+			Type any `json:"type"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(tag.EffectiveSchema, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema of tag %s: %w", tagPath, err)
+	}
+	types := make(map[string]string, len(schema.Properties))
+	for field, property := range schema.Properties {
+		if t, ok := property.Type.(string); ok {
+			types[field] = t
+		}
+	}
+	return types, nil
+}
+
+// ruleDocument is what rules are evaluated against
+type ruleDocument struct {
+	Filename string
+	MimeType string
+	Size     int64
+	// Text is nil for documents that aren't text
+	Text       *string
+	Attributes map[string]any
+}
+
+// compiledRule holds a rule's conditions with their regular expressions compiled
+type compiledRule struct {
+	conditions    *RuleConditions
+	filenameRegex *regexp.Regexp
+	textRegex     *regexp.Regexp
+	attributes    []*regexp.Regexp // per attribute condition, nil without matches
+}
+
+// compileRule validates a rule's conditions and compiles their regular expressions
+func compileRule(conditions *RuleConditions) (*compiledRule, error) {
+	c := &compiledRule{conditions: conditions}
+	if conditions.FilenameGlob == nil && conditions.FilenameRegex == nil &&
+		conditions.MimeType == nil && conditions.MinSize == nil && conditions.MaxSize == nil &&
+		conditions.TextRegex == nil && len(conditions.Attributes) == 0 {
+		return nil, fmt.Errorf("%w: at least one condition is required", ErrInvalidRule)
+	}
+
+	if conditions.FilenameGlob != nil {
+		if _, err := path.Match(*conditions.FilenameGlob, ""); err != nil {
+			return nil, fmt.Errorf("%w: filename_glob: %v", ErrInvalidRule, err)
+		}
+	}
+	var err error
+	if conditions.FilenameRegex != nil {
+		if c.filenameRegex, err = regexp.Compile(*conditions.FilenameRegex); err != nil {
+			return nil, fmt.Errorf("%w: filename_regex: %v", ErrInvalidRule, err)
+		}
+	}
+	if conditions.TextRegex != nil {
+		if c.textRegex, err = regexp.Compile(*conditions.TextRegex); err != nil {
+			return nil, fmt.Errorf("%w: text_regex: %v", ErrInvalidRule, err)
+		}
+	}
+	if (conditions.MinSize != nil && *conditions.MinSize < 0) ||
+		(conditions.MaxSize != nil && *conditions.MaxSize < 0) {
+		return nil, fmt.Errorf("%w: sizes must not be negative", ErrInvalidRule)
+	}
+	if conditions.MinSize != nil && conditions.MaxSize != nil &&
+		*conditions.MinSize > *conditions.MaxSize {
+		return nil, fmt.Errorf("%w: min_size is larger than max_size", ErrInvalidRule)
+	}
+
+	c.attributes = make([]*regexp.Regexp, len(conditions.Attributes))
+	for i, condition := range conditions.Attributes {
+		if condition.Key == "" {
+			return nil, fmt.Errorf("%w: attribute conditions need a key", ErrInvalidRule)
+		}
+		if len(condition.Equals) > 0 && !json.Valid(condition.Equals) {
+			return nil, fmt.Errorf(
+				"%w: attribute %q: equals must be a JSON value",
+				ErrInvalidRule,
+				condition.Key,
+			)
+		}
+		if condition.Matches != nil {
+			if c.attributes[i], err = regexp.Compile(*condition.Matches); err != nil {
+				return nil, fmt.Errorf(
+					"%w: attribute %q: %v",
+					ErrInvalidRule,
+					condition.Key,
+					err,
+				)
+			}
+		}
+	}
+	return c, nil
+}
+
+// hasGroup reports whether the filename or text regex has a named capture group
+func (c *compiledRule) hasGroup(name string) bool {
+	for _, re := range []*regexp.Regexp{c.filenameRegex, c.textRegex} {
+		if re != nil && re.SubexpIndex(name) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// match tests a document against the conditions. It returns the names of the conditions
+// the document fails and, when it passes, the text captured by named groups.
+func (c *compiledRule) match(doc *ruleDocument) ([]string, map[string]string) {
+	var unmet []string
+	captured := make(map[string]string)
+	conditions := c.conditions
+
+	if conditions.FilenameGlob != nil {
+		if ok, _ := path.Match(*conditions.FilenameGlob, doc.Filename); !ok {
+			unmet = append(unmet, "filename_glob")
+		}
+	}
+	if c.filenameRegex != nil && !captureGroups(c.filenameRegex, doc.Filename, captured) {
+		unmet = append(unmet, "filename_regex")
+	}
+	if conditions.MimeType != nil && !matchMimeType(*conditions.MimeType, doc.MimeType) {
+		unmet = append(unmet, "mime_type")
+	}
+	if conditions.MinSize != nil && doc.Size < *conditions.MinSize {
+		unmet = append(unmet, "min_size")
+	}
+	if conditions.MaxSize != nil && doc.Size > *conditions.MaxSize {
+		unmet = append(unmet, "max_size")
+	}
+	if c.textRegex != nil &&
+		(doc.Text == nil || !captureGroups(c.textRegex, *doc.Text, captured)) {
+		unmet = append(unmet, "text_regex")
+	}
+	for i, condition := range conditions.Attributes {
+		if !matchAttribute(condition, c.attributes[i], doc.Attributes) {
+			unmet = append(unmet, "attributes."+condition.Key)
+		}
+	}
+	return unmet, captured
+}
+
+// captureGroups searches s with re and records its named groups that took part in the
+// match
+func captureGroups(re *regexp.Regexp, s string, captured map[string]string) bool {
+	indexes := re.FindStringSubmatchIndex(s)
+	if indexes == nil {
+		return false
+	}
+	for i, name := range re.SubexpNames() {
+		if name != "" && indexes[2*i] >= 0 {
+			captured[name] = s[indexes[2*i]:indexes[2*i+1]]
+		}
+	}
+	return true
+}
+
+// matchMimeType matches a MIME type, ignoring parameters, against a type or a wildcard
+// such as "image/*"
+func matchMimeType(pattern string, mimeType string) bool {
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		mimeType = mediaType
+	}
+	pattern = strings.ToLower(pattern)
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(strings.ToLower(mimeType), prefix+"/")
+	}
+	return pattern == "*" || strings.EqualFold(pattern, mimeType)
+}
+
+// matchAttribute tests a global attribute against a condition
+func matchAttribute(
+	condition AttributeCondition,
+	re *regexp.Regexp,
+	attributes map[string]any,
+) bool {
+	value, ok := attributes[condition.Key]
+	if !ok {
+		return false
+	}
+	if len(condition.Equals) > 0 {
+		var want any
+		if err := json.Unmarshal(condition.Equals, &want); err != nil ||
+			!reflect.DeepEqual(value, want) {
+			return false
+		}
+	}
+	if re != nil {
+		s, ok := value.(string)
+		if !ok || !re.MatchString(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// convertCapture converts captured text to the JSON type the schema declares, leaving it
+// a string when it doesn't parse so schema validation reports the problem
+func convertCapture(value string, schemaType string) any {
+	switch schemaType {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// isTextMimeType reports whether rules can search a document's content as text
+func isTextMimeType(mimeType string) bool {
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		mimeType = mediaType
+	}
+	switch mimeType {
+	case "application/json", "application/xml":
+		return true
+	}
+	return strings.HasPrefix(mimeType, "text/")
+}
+
+// readRuleText reads the start of a text document for text_regex to search
+func readRuleText(content io.Reader) (*string, error) {
+	data, err := io.ReadAll(io.LimitReader(content, maxRuleTextSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document text: %w", err)
+	}
+	text := string(data)
+	return &text, nil
+}
+
+// documentAttributes decodes a document's global attributes, treating unset or
+// malformed attributes as none
+func documentAttributes(data []byte) map[string]any {
+	var attributes map[string]any
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &attributes)
+	}
+	return attributes
+}
+
+// taggingRuleFromRow decodes a stored tagging rule
+func taggingRuleFromRow(row *sqlc.TaggingRule) (*TaggingRule, error) {
+	rule := &TaggingRule{
+		ID:         row.ID,
+		Name:       row.Name,
+		Enabled:    row.Enabled,
+		Priority:   row.Priority,
+		CreatedAt:  row.CreatedAt.Time,
+		ModifiedAt: row.ModifiedAt.Time,
+	}
+	if err := json.Unmarshal(row.Conditions, &rule.Conditions); err != nil {
+		return nil, fmt.Errorf("failed to decode rule conditions: %w", err)
+	}
+	if err := json.Unmarshal(row.Action, &rule.Action); err != nil {
+		return nil, fmt.Errorf("failed to decode rule action: %w", err)
+	}
+	return rule, nil
+}
+
+// ruleWriteError maps a failed rule insert or update to a service error
+func ruleWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		// 23505 is unique_violation
+		return ErrRuleAlreadyExists
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(n int64) *int64 { return &n }
+
+func TestCompileRule(t *testing.T) {
+	_, err := compileRule(&RuleConditions{FilenameGlob: stringPtr("*.pdf")})
+	assert.NoError(t, err)
+
+	invalid := []RuleConditions{
+		{},
+		{FilenameGlob: stringPtr("[")},
+		{FilenameRegex: stringPtr("(")},
+		{TextRegex: stringPtr("(?P<x")},
+		{MinSize: int64Ptr(-1)},
+		{MinSize: int64Ptr(10), MaxSize: int64Ptr(5)},
+		{Attributes: []AttributeCondition{{}}},
+		{Attributes: []AttributeCondition{{Key: "k", Equals: json.RawMessage("{")}}},
+		{Attributes: []AttributeCondition{{Key: "k", Matches: stringPtr("(")}}},
+	}
+	for _, conditions := range invalid {
+		_, err := compileRule(&conditions)
+		assert.ErrorIs(t, err, ErrInvalidRule, "%+v", conditions)
+	}
+}
+
+func TestRuleMatch(t *testing.T) {
+	rule, err := compileRule(&RuleConditions{
+		FilenameGlob:  stringPtr("invoice-*.txt"),
+		FilenameRegex: stringPtr(`invoice-(?P<number>\d+)`),
+		MimeType:      stringPtr("text/*"),
+		MinSize:       int64Ptr(1),
+		MaxSize:       int64Ptr(1024),
+		TextRegex:     stringPtr(`Total: (?P<total>[\d.]+)`),
+		Attributes: []AttributeCondition{
+			{Key: "vendor", Matches: stringPtr("^Acme")},
+			{Key: "paid", Equals: json.RawMessage("false")},
+			{Key: "reviewed"},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, rule.hasGroup("number"))
+	assert.True(t, rule.hasGroup("total"))
+	assert.False(t, rule.hasGroup("missing"))
+
+	text := "Total: 12.50"
+	doc := &ruleDocument{
+		Filename: "invoice-0042.txt",
+		MimeType: "text/plain; charset=utf-8",
+		Size:     100,
+		Text:     &text,
+		Attributes: map[string]any{
+			"vendor":   "Acme Corp",
+			"paid":     false,
+			"reviewed": true,
+		},
+	}
+	unmet, captured := rule.match(doc)
+	assert.Empty(t, unmet)
+	assert.Equal(t, map[string]string{"number": "0042", "total": "12.50"}, captured)
+
+	unmet, _ = rule.match(&ruleDocument{
+		Filename:   "receipt.pdf",
+		MimeType:   "application/pdf",
+		Size:       2048,
+		Attributes: map[string]any{"vendor": "Other", "paid": true},
+	})
+	assert.Equal(t, []string{
+		"filename_glob",
+		"filename_regex",
+		"mime_type",
+		"max_size",
+		"text_regex",
+		"attributes.vendor",
+		"attributes.paid",
+		"attributes.reviewed",
+	}, unmet)
+}
+
+func TestMatchMimeType(t *testing.T) {
+	assert.True(t, matchMimeType("application/pdf", "application/pdf"))
+	assert.True(t, matchMimeType("image/*", "image/PNG"))
+	assert.True(t, matchMimeType("text/plain", "text/plain; charset=utf-8"))
+	assert.True(t, matchMimeType("*", "application/zip"))
+	assert.False(t, matchMimeType("image/*", "application/pdf"))
+	assert.False(t, matchMimeType("text/plain", "text/html"))
+}
+
+func TestConvertCapture(t *testing.T) {
+	assert.Equal(t, int64(42), convertCapture("42", "integer"))
+	assert.Equal(t, 12.5, convertCapture("12.50", "number"))
+	assert.Equal(t, true, convertCapture("true", "boolean"))
+	assert.Equal(t, "0042", convertCapture("0042", "string"))
+	assert.Equal(t, "0042", convertCapture("0042", ""))
+	// Values that don't parse are left for schema validation to reject
+	assert.Equal(t, "n/a", convertCapture("n/a", "number"))
+}
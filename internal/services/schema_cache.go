@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+// SchemaCacheStats counts compiled schema cache lookups and compilations since the cache was
+// created
+type SchemaCacheStats struct {
+	Hits        uint64
+	Misses      uint64
+	Compiles    uint64
+	CompileTime time.Duration
+	Size        int
+}
+
+// HitRate returns the fraction of lookups served from the cache, or 0 before any lookup
+func (s SchemaCacheStats) HitRate() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(lookups)
+}
+
+// schemaCacheKey identifies a compiled schema by its tag and the version of its effective
+// schema, see GetEffectiveSchemaVersion
+type schemaCacheKey struct {
+	tagID   pgtype.UUID
+	version string
+}
+
+// schemaCache holds compiled effective schemas. Any change to a tag's effective schema
+// gives it a new key, so a stale schema is never served. Changes also purge the whole
+// cache, here as they commit and on other replicas as their events arrive, which only
+// frees the schemas of versions no longer in use.
+type schemaCache struct {
+	mu      sync.RWMutex
+	schemas map[schemaCacheKey]*compiledTagSchema
+	// generation counts purges, so a schema compiled before one isn't cached after it
+	generation uint64
+
+	hits         atomic.Uint64
+	misses       atomic.Uint64
+	compiles     atomic.Uint64
+	compileNanos atomic.Int64
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{schemas: make(map[schemaCacheKey]*compiledTagSchema)}
+}
+
+// get returns the compiled schema for key, if cached, and the cache generation to pass to
+// put otherwise. A nil schema is cached for tags without one.
+func (c *schemaCache) get(key schemaCacheKey) (*compiledTagSchema, bool, uint64) {
+	c.mu.RLock()
+	schema, ok := c.schemas[key]
+	generation := c.generation
+	c.mu.RUnlock()
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return schema, ok, generation
+}
+
+// put caches a compiled schema under key, unless the cache was purged since generation
+func (c *schemaCache) put(key schemaCacheKey, schema *compiledTagSchema, generation uint64) {
+	c.mu.Lock()
+	if c.generation == generation {
+		c.schemas[key] = schema
+	}
+	c.mu.Unlock()
+}
+
+// compile compiles a schema, recording how long it took
+func (c *schemaCache) compile(schema []byte) (*compiledTagSchema, error) {
+	start := time.Now()
+	defer func() {
+		c.compiles.Add(1)
+		c.compileNanos.Add(int64(time.Since(start)))
+	}()
+	return compileSchema(schema)
+}
+
+// purge drops every cached schema
+func (c *schemaCache) purge() {
+	c.mu.Lock()
+	clear(c.schemas)
+	c.generation++
+	c.mu.Unlock()
+}
+
+func (c *schemaCache) stats() SchemaCacheStats {
+	c.mu.RLock()
+	size := len(c.schemas)
+	c.mu.RUnlock()
+	return SchemaCacheStats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Compiles:    c.compiles.Load(),
+		CompileTime: time.Duration(c.compileNanos.Load()),
+		Size:        size,
+	}
+}
+
+// SchemaCacheStats reports how well the compiled schema cache is doing
+func (s *TagService) SchemaCacheStats() SchemaCacheStats {
+	return s.schemas.stats()
+}
+
+// ReportSchemaCacheStats logs the compiled schema cache's statistics on every interval until
+// the context is cancelled
+func (s *TagService) ReportSchemaCacheStats(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := s.schemas.stats()
+			slog.Info("compiled schema cache",
+				"hit_rate", stats.HitRate(),
+				"hits", stats.Hits,
+				"misses", stats.Misses,
+				"compiles", stats.Compiles,
+				"compile_time", stats.CompileTime,
+				"size", stats.Size,
+			)
+		}
+	}
+}
+
+// FollowSchemaChanges purges the compiled schema cache whenever a tag's effective schema may
+// have changed on another replica, until ctx is cancelled. Schemas are cached by version, so
+// this only frees those no longer in use. subjects are the events to follow, see
+// events.SchemaInvalidationSubjects. Any message on them purges the cache, whatever its
+// subject layout, and so does resuming after following stopped. Changes made through this
+// service purge the cache as they commit.
+func (s *TagService) FollowSchemaChanges(
+	ctx context.Context,
+	watcher *events.Watcher,
+	subjects []string,
+) error {
+	return watcher.Follow(ctx, subjects, func(subject string) {
+		s.schemas.purge()
+		slog.Debug("purged compiled schema cache", "subject", subject)
+	})
+}
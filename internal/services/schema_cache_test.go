@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+func TestSchemaCache(t *testing.T) {
+	cache := newSchemaCache()
+	tagID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	key := schemaCacheKey{tagID: tagID, version: "1"}
+	other := schemaCacheKey{tagID: pgtype.UUID{Bytes: [16]byte{2}, Valid: true}, version: "1"}
+
+	_, ok, generation := cache.get(key)
+	assert.False(t, ok)
+	schema, err := cache.compile([]byte(`{"type": "object", "required": ["amount"]}`))
+	require.NoError(t, err)
+	cache.put(key, schema, generation)
+
+	cached, ok, _ := cache.get(key)
+	require.True(t, ok)
+	assert.Same(t, schema, cached)
+	assert.Error(t, cached.validate(map[string]interface{}{}))
+
+	// A new version of the tag's effective schema is never served the old one
+	_, ok, _ = cache.get(schemaCacheKey{tagID: tagID, version: "2"})
+	assert.False(t, ok)
+
+	// Tags without a schema are cached too
+	noSchema := schemaCacheKey{tagID: pgtype.UUID{Bytes: [16]byte{3}, Valid: true}}
+	_, _, generation = cache.get(noSchema)
+	cache.put(noSchema, nil, generation)
+	cached, ok, _ = cache.get(noSchema)
+	assert.True(t, ok)
+	assert.Nil(t, cached)
+
+	// A schema compiled before a purge isn't cached after it
+	_, _, generation = cache.get(other)
+	cache.purge()
+	cache.put(other, schema, generation)
+	_, ok, _ = cache.get(other)
+	assert.False(t, ok)
+	_, ok, _ = cache.get(key)
+	assert.False(t, ok)
+
+	stats := cache.stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(6), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Compiles)
+	assert.Positive(t, stats.CompileTime)
+	assert.Zero(t, stats.Size)
+	assert.InDelta(t, 2.0/8.0, stats.HitRate(), 1e-9)
+
+	_, err = cache.compile([]byte(`{"type": 5}`))
+	assert.Error(t, err)
+	assert.Equal(t, uint64(2), cache.stats().Compiles)
+	assert.Zero(t, SchemaCacheStats{}.HitRate())
+}
+
+func TestFollowSchemaChangesLegacy(t *testing.T) {
+	srv, err := server.NewServer(&server.Options{
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+	go srv.Start()
+	defer srv.Shutdown()
+	require.True(t, srv.ReadyForConnections(10*time.Second))
+
+	nc, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	defer nc.Close()
+	js, err := nc.JetStream()
+	require.NoError(t, err)
+	subjects := events.SchemaInvalidationSubjects(events.SubjectModeLegacy)
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     "EVENTS",
+		Subjects: subjects,
+		Storage:  nats.MemoryStorage,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tagService := &TagService{schemas: newSchemaCache()}
+	err = tagService.FollowSchemaChanges(ctx, events.NewWatcher(js, "EVENTS"), subjects)
+	require.NoError(t, err)
+
+	// Legacy subjects carry no namespace, so they purge without being decoded
+	for _, subject := range subjects {
+		key := schemaCacheKey{tagID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, version: "1"}
+		_, _, generation := tagService.schemas.get(key)
+		tagService.schemas.put(key, nil, generation)
+		require.Equal(t, 1, tagService.SchemaCacheStats().Size)
+
+		_, err = js.Publish(subject, []byte(`{"tag_id": "00000000-0000-0000-0000-000000000001"}`))
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return tagService.SchemaCacheStats().Size == 0
+		}, 5*time.Second, 10*time.Millisecond, subject)
+	}
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SchemaCompatibility is how a tag's new schema versions must relate to its latest one
+type SchemaCompatibility string
+
+const (
+	// SchemaCompatibilityNone accepts any new schema
+	SchemaCompatibilityNone SchemaCompatibility = "none"
+	// SchemaCompatibilityBackward requires attributes valid under the latest schema to stay
+	// valid under the new one, e.g. fields may become optional but not required
+	SchemaCompatibilityBackward SchemaCompatibility = "backward"
+	// SchemaCompatibilityForward requires attributes valid under the new schema to be valid
+	// under the latest one, e.g. fields may become required but not optional
+	SchemaCompatibilityForward SchemaCompatibility = "forward"
+	// SchemaCompatibilityFull requires both backward and forward compatibility
+	SchemaCompatibilityFull SchemaCompatibility = "full"
+)
+
+var (
+	// ErrInvalidSchemaCompatibility is returned for an unknown compatibility mode
+	ErrInvalidSchemaCompatibility = errors.New("invalid schema compatibility")
+	// ErrIncompatibleSchema is returned when a new schema breaks the tag's compatibility mode
+	ErrIncompatibleSchema = errors.New("schema is incompatible with the latest version")
+)
+
+// ParseSchemaCompatibility validates a compatibility mode, defaulting to none
+func ParseSchemaCompatibility(s string) (SchemaCompatibility, error) {
+	switch mode := SchemaCompatibility(s); mode {
+	case "":
+		return SchemaCompatibilityNone, nil
+	case SchemaCompatibilityNone,
+		SchemaCompatibilityBackward,
+		SchemaCompatibilityForward,
+		SchemaCompatibilityFull:
+		return mode, nil
+	}
+	return "", fmt.Errorf(
+		"%w %q (want none, backward, forward or full)",
+		ErrInvalidSchemaCompatibility,
+		s,
+	)
+}
+
+// SchemaViolation is a change between schema versions that breaks compatibility
+type SchemaViolation struct {
+	// Field is the path to the attribute, with nested properties separated by dots and
+	// array items marked with [], or empty for the schema itself
+	Field string
+	// Compatibility is the direction the change breaks, backward or forward
+	Compatibility SchemaCompatibility
+	// Message describes the change
+	Message string
+}
+
+// IncompatibleSchemaError lists why a new schema version was rejected
+type IncompatibleSchemaError struct {
+	Compatibility SchemaCompatibility
+	Violations    []SchemaViolation
+}
+
+func (e *IncompatibleSchemaError) Error() string {
+	details := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		field := violation.Field
+		if field == "" {
+			field = "schema"
+		}
+		details[i] = fmt.Sprintf("%s: %s", field, violation.Message)
+	}
+	return fmt.Sprintf(
+		"%s (%s compatibility): %s",
+		ErrIncompatibleSchema,
+		e.Compatibility,
+		strings.Join(details, "; "),
+	)
+}
+
+// Unwrap makes the error match ErrIncompatibleSchema
+func (e *IncompatibleSchemaError) Unwrap() error {
+	return ErrIncompatibleSchema
+}
+
+// checkSchemaCompatibility compares a proposed schema to the latest version and returns an
+// IncompatibleSchemaError if the change breaks the compatibility mode
+func checkSchemaCompatibility(mode SchemaCompatibility, latest, proposed []byte) error {
+	if mode == SchemaCompatibilityNone {
+		return nil
+	}
+	var oldSchema, newSchema map[string]interface{}
+	if err := json.Unmarshal(latest, &oldSchema); err != nil {
+		return fmt.Errorf("failed to parse latest schema: %w", err)
+	}
+	if err := json.Unmarshal(proposed, &newSchema); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSONSchema, err)
+	}
+
+	var violations []SchemaViolation
+	if mode == SchemaCompatibilityBackward || mode == SchemaCompatibilityFull {
+		check := compatibilityCheck{direction: SchemaCompatibilityBackward}
+		check.readable("", newSchema, oldSchema)
+		violations = append(violations, check.violations...)
+	}
+	if mode == SchemaCompatibilityForward || mode == SchemaCompatibilityFull {
+		check := compatibilityCheck{direction: SchemaCompatibilityForward}
+		check.readable("", oldSchema, newSchema)
+		violations = append(violations, check.violations...)
+	}
+	if len(violations) > 0 {
+		return &IncompatibleSchemaError{Compatibility: mode, Violations: violations}
+	}
+	return nil
+}
+
+// compatibilityCheck collects the reasons attributes valid under a writer schema may be
+// invalid under a reader schema. Checking backward, the new schema reads attributes written
+// under the old one; checking forward, the old schema reads the new one's.
+type compatibilityCheck struct {
+	direction  SchemaCompatibility
+	violations []SchemaViolation
+}
+
+// readerName and writerName name the schemas in violation messages
+func (c *compatibilityCheck) readerName() string {
+	if c.direction == SchemaCompatibilityBackward {
+		return "new"
+	}
+	return "old"
+}
+
+func (c *compatibilityCheck) writerName() string {
+	if c.direction == SchemaCompatibilityBackward {
+		return "old"
+	}
+	return "new"
+}
+
+func (c *compatibilityCheck) add(field string, format string, args ...interface{}) {
+	c.violations = append(c.violations, SchemaViolation{
+		Field:         field,
+		Compatibility: c.direction,
+		Message:       fmt.Sprintf(format, args...),
+	})
+}
+
+// lowerBounds and upperBounds are the keywords whose values a reader may not raise and lower
+var (
+	lowerBounds = []string{"minimum", "exclusiveMinimum", "minLength", "minItems"}
+	upperBounds = []string{"maximum", "exclusiveMaximum", "maxLength", "maxItems"}
+)
+
+// readable checks that values valid under the writer schema are valid under the reader
+// schema at field
+func (c *compatibilityCheck) readable(field string, reader, writer map[string]interface{}) {
+	readerTypes, writerTypes := schemaTypes(reader), schemaTypes(writer)
+	if !typesAccepted(readerTypes, writerTypes) {
+		c.add(field, "type changed from %s to %s",
+			c.describeTypes(readerTypes, writerTypes, false),
+			c.describeTypes(readerTypes, writerTypes, true),
+		)
+		// Any further differences follow from the type change
+		return
+	}
+
+	c.checkEnum(field, reader, writer)
+	for _, keyword := range lowerBounds {
+		c.checkBound(field, keyword, reader, writer, func(r, w float64) bool { return w >= r })
+	}
+	for _, keyword := range upperBounds {
+		c.checkBound(field, keyword, reader, writer, func(r, w float64) bool { return w <= r })
+	}
+	if pattern, ok := reader["pattern"]; ok && pattern != writer["pattern"] {
+		c.add(field, "pattern %v of the %s schema is not in the %s one",
+			pattern, c.readerName(), c.writerName())
+	}
+
+	c.checkProperties(field, reader, writer)
+	readerItems, _ := reader["items"].(map[string]interface{})
+	writerItems, _ := writer["items"].(map[string]interface{})
+	if readerItems != nil {
+		c.readable(field+"[]", readerItems, writerItems)
+	}
+}
+
+// checkProperties checks an object's required fields and properties
+func (c *compatibilityCheck) checkProperties(field string, reader, writer map[string]interface{}) {
+	readerRequired, _ := reader["required"].([]interface{})
+	writerRequired, _ := writer["required"].([]interface{})
+	for _, name := range readerRequired {
+		if !slices.Contains(writerRequired, name) {
+			c.add(
+				joinField(field, fmt.Sprint(name)),
+				"required by the %s schema but not the %s one",
+				c.readerName(),
+				c.writerName(),
+			)
+		}
+	}
+
+	readerProperties, _ := reader["properties"].(map[string]interface{})
+	writerProperties, _ := writer["properties"].(map[string]interface{})
+	closed := reader["additionalProperties"] == false
+	if closed && writer["additionalProperties"] != false {
+		c.add(field, "additional properties are allowed by the %s schema but not the %s one",
+			c.writerName(), c.readerName())
+	}
+	for _, name := range slices.Sorted(maps.Keys(writerProperties)) {
+		if _, ok := readerProperties[name]; !ok && closed {
+			c.add(joinField(field, name), "allowed by the %s schema but not the %s one",
+				c.writerName(), c.readerName())
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(readerProperties)) {
+		readerProperty, _ := readerProperties[name].(map[string]interface{})
+		writerProperty, ok := writerProperties[name].(map[string]interface{})
+		if !ok {
+			// Adding an optional field is compatible; values stored under it while
+			// undeclared are not checked
+			continue
+		}
+		c.readable(joinField(field, name), readerProperty, writerProperty)
+	}
+}
+
+// checkEnum checks that every value the writer allows is in the reader's enum
+func (c *compatibilityCheck) checkEnum(field string, reader, writer map[string]interface{}) {
+	readerEnum, ok := reader["enum"].([]interface{})
+	if !ok {
+		return
+	}
+	writerEnum, ok := writer["enum"].([]interface{})
+	if !ok {
+		c.add(field, "values are restricted to %s by the %s schema but not the %s one",
+			formatValues(readerEnum), c.readerName(), c.writerName())
+		return
+	}
+	var missing []interface{}
+	for _, value := range writerEnum {
+		if !slices.ContainsFunc(
+			readerEnum,
+			func(v interface{}) bool { return jsonEqual(v, value) },
+		) {
+			missing = append(missing, value)
+		}
+	}
+	if len(missing) > 0 {
+		c.add(field, "values %s are allowed by the %s schema but not the %s one",
+			formatValues(missing), c.writerName(), c.readerName())
+	}
+}
+
+// checkBound checks that the writer's bound is within the reader's
+func (c *compatibilityCheck) checkBound(
+	field string,
+	keyword string,
+	reader, writer map[string]interface{},
+	within func(readerBound, writerBound float64) bool,
+) {
+	readerBound, ok := reader[keyword].(float64)
+	if !ok {
+		return
+	}
+	writerBound, ok := writer[keyword].(float64)
+	if !ok {
+		c.add(field, "%s is %v in the %s schema but unset in the %s one",
+			keyword, readerBound, c.readerName(), c.writerName())
+		return
+	}
+	if !within(readerBound, writerBound) {
+		c.add(
+			field,
+			"%s changed from %v to %v",
+			keyword,
+			c.pick(readerBound, writerBound, false),
+			c.pick(readerBound, writerBound, true),
+		)
+	}
+}
+
+// pick returns the old or new of a reader and writer value
+func (c *compatibilityCheck) pick(reader, writer interface{}, newer bool) interface{} {
+	if newer == (c.direction == SchemaCompatibilityBackward) {
+		return reader
+	}
+	return writer
+}
+
+// describeTypes describes the old or new of a reader and writer's types
+func (c *compatibilityCheck) describeTypes(reader, writer []string, newer bool) string {
+	types, _ := c.pick(reader, writer, newer).([]string)
+	if len(types) == 0 {
+		return "any"
+	}
+	return strings.Join(types, " or ")
+}
+
+// schemaTypes returns the types a schema allows, nil if it allows any
+func schemaTypes(schema map[string]interface{}) []string {
+	switch schemaType := schema["type"].(type) {
+	case string:
+		return []string{schemaType}
+	case []interface{}:
+		types := make([]string, 0, len(schemaType))
+		for _, t := range schemaType {
+			if s, ok := t.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// typesAccepted reports whether every writer type is accepted by the reader, where numbers
+// accept integers
+func typesAccepted(reader, writer []string) bool {
+	if reader == nil {
+		return true
+	}
+	if writer == nil {
+		return false
+	}
+	for _, t := range writer {
+		if !slices.Contains(reader, t) && (t != "integer" || !slices.Contains(reader, "number")) {
+			return false
+		}
+	}
+	return true
+}
+
+// joinField appends a property name to a field path
+func joinField(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+// formatValues formats enum values as JSON
+func formatValues(values []interface{}) string {
+	formatted, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Sprint(values)
+	}
+	return string(formatted)
+}
+
+// jsonEqual reports whether two decoded JSON values are equal
+func jsonEqual(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
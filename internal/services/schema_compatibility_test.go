@@ -0,0 +1,198 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchemaCompatibility(t *testing.T) {
+	mode, err := ParseSchemaCompatibility("")
+	require.NoError(t, err)
+	assert.Equal(t, SchemaCompatibilityNone, mode)
+
+	mode, err = ParseSchemaCompatibility("full")
+	require.NoError(t, err)
+	assert.Equal(t, SchemaCompatibilityFull, mode)
+
+	_, err = ParseSchemaCompatibility("transitive")
+	assert.ErrorIs(t, err, ErrInvalidSchemaCompatibility)
+}
+
+func TestCheckSchemaCompatibility(t *testing.T) {
+	latest := `{
+		"type": "object",
+		"properties": {
+			"amount": {"type": "number", "minimum": 0},
+			"count": {"type": "integer"},
+			"status": {"type": "string", "enum": ["open", "paid"]},
+			"vendor": {"type": "string"}
+		},
+		"required": ["amount"]
+	}`
+
+	tests := []struct {
+		name     string
+		mode     SchemaCompatibility
+		proposed string
+		want     []SchemaViolation
+	}{
+		{
+			name:     "none accepts anything",
+			mode:     SchemaCompatibilityNone,
+			proposed: `{"type": "object", "properties": {"amount": {"type": "string"}}}`,
+		},
+		{
+			name: "backward allows optional fields and relaxed constraints",
+			mode: SchemaCompatibilityBackward,
+			proposed: `{
+				"type": "object",
+				"properties": {
+					"amount": {"type": "number"},
+					"count": {"type": "number"},
+					"status": {"type": "string", "enum": ["open", "paid", "void"]},
+					"vendor": {"type": "string"},
+					"notes": {"type": "string"}
+				}
+			}`,
+		},
+		{
+			name: "backward rejects type changes, new required fields and narrower values",
+			mode: SchemaCompatibilityBackward,
+			proposed: `{
+				"type": "object",
+				"properties": {
+					"amount": {"type": "string"},
+					"count": {"type": "integer", "maximum": 10},
+					"status": {"type": "string", "enum": ["open"]},
+					"vendor": {"type": "string"}
+				},
+				"required": ["amount", "vendor"]
+			}`,
+			want: []SchemaViolation{
+				{Field: "vendor", Compatibility: SchemaCompatibilityBackward,
+					Message: "required by the new schema but not the old one"},
+				{Field: "amount", Compatibility: SchemaCompatibilityBackward,
+					Message: "type changed from number to string"},
+				{Field: "count", Compatibility: SchemaCompatibilityBackward,
+					Message: "maximum is 10 in the new schema but unset in the old one"},
+				{Field: "status", Compatibility: SchemaCompatibilityBackward,
+					Message: `values ["paid"] are allowed by the old schema but not the new one`},
+			},
+		},
+		{
+			name: "forward rejects relaxed constraints",
+			mode: SchemaCompatibilityForward,
+			proposed: `{
+				"type": "object",
+				"properties": {
+					"amount": {"type": "number", "minimum": -10},
+					"count": {"type": "number"},
+					"status": {"type": "string", "enum": ["open", "paid"]},
+					"vendor": {"type": "string"}
+				},
+				"required": ["amount", "vendor"]
+			}`,
+			want: []SchemaViolation{
+				{Field: "amount", Compatibility: SchemaCompatibilityForward,
+					Message: "minimum changed from 0 to -10"},
+				{Field: "count", Compatibility: SchemaCompatibilityForward,
+					Message: "type changed from integer to number"},
+			},
+		},
+		{
+			name: "full checks both directions",
+			mode: SchemaCompatibilityFull,
+			proposed: `{
+				"type": "object",
+				"properties": {
+					"amount": {"type": "number", "minimum": 0},
+					"count": {"type": "integer"},
+					"status": {"type": "string", "enum": ["open", "paid"]},
+					"vendor": {"type": "string"}
+				}
+			}`,
+			want: []SchemaViolation{
+				{Field: "amount", Compatibility: SchemaCompatibilityForward,
+					Message: "required by the old schema but not the new one"},
+			},
+		},
+		{
+			name: "closing the schema drops undeclared fields",
+			mode: SchemaCompatibilityBackward,
+			proposed: `{
+				"type": "object",
+				"properties": {
+					"amount": {"type": "number", "minimum": 0},
+					"status": {"type": "string", "enum": ["open", "paid"]},
+					"vendor": {"type": "string"}
+				},
+				"required": ["amount"],
+				"additionalProperties": false
+			}`,
+			want: []SchemaViolation{
+				{Field: "", Compatibility: SchemaCompatibilityBackward,
+					Message: "additional properties are allowed by the old schema but not the new one"},
+				{Field: "count", Compatibility: SchemaCompatibilityBackward,
+					Message: "allowed by the old schema but not the new one"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSchemaCompatibility(tt.mode, []byte(latest), []byte(tt.proposed))
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var incompatible *IncompatibleSchemaError
+			require.ErrorAs(t, err, &incompatible)
+			assert.ErrorIs(t, err, ErrIncompatibleSchema)
+			assert.Equal(t, tt.mode, incompatible.Compatibility)
+			assert.Equal(t, tt.want, incompatible.Violations)
+		})
+	}
+}
+
+func TestCheckSchemaCompatibilityNested(t *testing.T) {
+	latest := `{
+		"type": "object",
+		"x-structured-attributes": true,
+		"properties": {
+			"vendor": {
+				"type": "object",
+				"properties": {"name": {"type": "string"}}
+			},
+			"lines": {"type": "array", "items": {"type": "number"}}
+		}
+	}`
+	proposed := `{
+		"type": "object",
+		"x-structured-attributes": true,
+		"properties": {
+			"vendor": {
+				"type": "object",
+				"properties": {"name": {"type": "string", "minLength": 2}},
+				"required": ["name"]
+			},
+			"lines": {"type": "array", "items": {"type": "string"}}
+		}
+	}`
+
+	err := checkSchemaCompatibility(SchemaCompatibilityBackward, []byte(latest), []byte(proposed))
+	var incompatible *IncompatibleSchemaError
+	require.ErrorAs(t, err, &incompatible)
+	assert.Equal(t, []SchemaViolation{
+		{Field: "lines[]", Compatibility: SchemaCompatibilityBackward,
+			Message: "type changed from number to string"},
+		{Field: "vendor.name", Compatibility: SchemaCompatibilityBackward,
+			Message: "required by the new schema but not the old one"},
+		{Field: "vendor.name", Compatibility: SchemaCompatibilityBackward,
+			Message: "minLength is 2 in the new schema but unset in the old one"},
+	}, incompatible.Violations)
+	assert.EqualError(t, err, "schema is incompatible with the latest version "+
+		"(backward compatibility): lines[]: type changed from number to string; "+
+		"vendor.name: required by the new schema but not the old one; "+
+		"vendor.name: minLength is 2 in the new schema but unset in the old one")
+}
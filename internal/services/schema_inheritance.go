@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+)
+
+// ErrSchemaInheritanceConflict is returned when a schema overrides an inherited property
+// with an incompatible one
+var ErrSchemaInheritanceConflict = errors.New("schema conflicts with an inherited schema")
+
+// effectiveSchema returns the schema a tag's attributes are validated against: the tag's own
+// schema composed with those of the ancestors it inherits from, or nil if none of them has
+// a schema. A tag inherits its parent's effective schema when it sets inherit_schema.
+func (s *TagService) effectiveSchema(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	tag sqlc.Tag,
+) ([]byte, error) {
+	chain := []sqlc.Tag{tag}
+	for current := tag; current.InheritSchema && current.ParentID.Valid; {
+		parent, err := queries.GetTagByID(ctx, current.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent tag: %w", err)
+		}
+		chain = append(chain, parent)
+		current = parent
+	}
+
+	// Compose from the root down, so descendants override their ancestors
+	var schemas [][]byte
+	for _, chainTag := range slices.Backward(chain) {
+		schema, err := queries.GetLatestSchemaByTagID(ctx, chainTag.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schema for tag: %w", err)
+		}
+		schemas = append(schemas, schema.JsonSchema)
+	}
+
+	switch len(schemas) {
+	case 0:
+		return nil, nil
+	case 1:
+		return schemas[0], nil
+	}
+	return composeSchemas(schemas)
+}
+
+// checkInheritingSchemas checks that the effective schemas of a tag and of every descendant
+// inheriting from it can be composed, e.g. after the tag's schema changed or it moved
+func (s *TagService) checkInheritingSchemas(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	tag sqlc.Tag,
+) error {
+	if _, err := s.effectiveSchema(ctx, queries, tag); err != nil {
+		return err
+	}
+	descendants, err := queries.ListTagDescendants(ctx, tag.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list descendant tags: %w", err)
+	}
+	for _, descendant := range descendants {
+		if !descendant.InheritSchema {
+			continue
+		}
+		if _, err := s.effectiveSchema(ctx, queries, descendant); err != nil {
+			return fmt.Errorf("tag %q: %w", descendant.Path, err)
+		}
+	}
+	return nil
+}
+
+// composeSchemas composes object schemas, ancestors first. Properties are merged, a
+// descendant's definition replacing its ancestor's if it has the same type, and required
+// lists are combined. Structured attributes stay allowed once a schema allows them; any
+// other keyword of a descendant replaces its ancestor's.
+func composeSchemas(schemas [][]byte) ([]byte, error) {
+	composed := make(map[string]interface{})
+	properties := make(map[string]interface{})
+	var required []interface{}
+	for _, schemaJSON := range schemas {
+		var schema map[string]interface{}
+		if err := json.Unmarshal(schemaJSON, &schema); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJSONSchema, err)
+		}
+		for keyword, value := range schema {
+			switch keyword {
+			case "properties":
+				definitions, _ := value.(map[string]interface{})
+				for name, definition := range definitions {
+					if inherited, ok := properties[name]; ok {
+						if err := checkPropertyOverride(name, inherited, definition); err != nil {
+							return nil, err
+						}
+					}
+					properties[name] = definition
+				}
+			case "required":
+				names, _ := value.([]interface{})
+				for _, name := range names {
+					if !slices.Contains(required, name) {
+						required = append(required, name)
+					}
+				}
+			case structuredAttributesKeyword:
+				if composed[keyword] != true {
+					composed[keyword] = value
+				}
+			default:
+				composed[keyword] = value
+			}
+		}
+	}
+	if len(properties) > 0 {
+		composed["properties"] = properties
+	}
+	if len(required) > 0 {
+		composed["required"] = required
+	}
+	return json.Marshal(composed)
+}
+
+// checkPropertyOverride checks that a property definition can replace an inherited one,
+// i.e. that values of both have the same type, including the type of array items
+func checkPropertyOverride(name string, inherited, definition interface{}) error {
+	inheritedType, definedType := propertyType(inherited), propertyType(definition)
+	if inheritedType != definedType {
+		return fmt.Errorf(
+			"%w: property %q is %s in an ancestor's schema but %s here",
+			ErrSchemaInheritanceConflict,
+			name,
+			inheritedType,
+			definedType,
+		)
+	}
+	return nil
+}
+
+// propertyType describes the type of a property definition, e.g. "string" or
+// "array of number"
+func propertyType(definition interface{}) string {
+	properties, _ := definition.(map[string]interface{})
+	propertyType, _ := properties["type"].(string)
+	if propertyType == "array" {
+		items, _ := properties["items"].(map[string]interface{})
+		itemType, _ := items["type"].(string)
+		return "array of " + itemType
+	}
+	return propertyType
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposeSchemas(t *testing.T) {
+	invoice := []byte(`{
+		"type": "object",
+		"properties": {
+			"total": {"type": "number"},
+			"vendor": {"type": "string"}
+		},
+		"required": ["total"],
+		"additionalProperties": false
+	}`)
+	utility := []byte(`{
+		"type": "object",
+		"properties": {
+			"total": {"type": "number", "minimum": 0},
+			"meter": {"type": "string"}
+		},
+		"required": ["meter", "total"],
+		"additionalProperties": true
+	}`)
+
+	composed, err := composeSchemas([][]byte{invoice, utility})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"total": {"type": "number", "minimum": 0},
+			"vendor": {"type": "string"},
+			"meter": {"type": "string"}
+		},
+		"required": ["total", "meter"],
+		"additionalProperties": true
+	}`, string(composed))
+
+	// Structured attributes stay allowed once an ancestor allows them
+	composed, err = composeSchemas([][]byte{
+		[]byte(`{"type": "object", "x-structured-attributes": true}`),
+		[]byte(`{"type": "object", "x-structured-attributes": false}`),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "object", "x-structured-attributes": true}`, string(composed))
+
+	// Overrides must keep the type
+	_, err = composeSchemas([][]byte{
+		invoice,
+		[]byte(`{"type": "object", "properties": {"total": {"type": "string"}}}`),
+	})
+	assert.ErrorIs(t, err, ErrSchemaInheritanceConflict)
+	assert.ErrorContains(t, err, `"total" is number in an ancestor's schema but string here`)
+
+	_, err = composeSchemas([][]byte{
+		[]byte(
+			`{"type": "object", "properties": {"ids": {"type": "array", "items": {"type": "string"}}}}`,
+		),
+		[]byte(
+			`{"type": "object", "properties": {"ids": {"type": "array", "items": {"type": "integer"}}}}`,
+		),
+	})
+	assert.ErrorIs(t, err, ErrSchemaInheritanceConflict)
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AttributeFilterOperator is how an attribute filter compares an attribute to its value
+type AttributeFilterOperator string
+
+const (
+	// AttributeFilterEquals matches attributes equal to the value
+	AttributeFilterEquals AttributeFilterOperator = "equals"
+	// AttributeFilterContains matches array attributes with the value as an element
+	AttributeFilterContains AttributeFilterOperator = "contains"
+)
+
+// AttributeFilter selects documents by the value of one attribute. Field is the attribute
+// name, or the path to a nested attribute with its parts separated by dots, e.g.
+// "vendor.name". Value is a JSON-encoded primitive.
+type AttributeFilter struct {
+	Field    string
+	Operator AttributeFilterOperator
+	Value    string
+}
+
+// DocumentMatch is a document found by an attribute search, with the attributes searched
+type DocumentMatch struct {
+	ID         pgtype.UUID
+	FileName   string
+	Title      string
+	MimeType   string
+	Attributes []byte
+	CreatedAt  pgtype.Timestamptz
+}
+
+// SearchDocuments lists the documents in a namespace whose attributes match every filter,
+// oldest first. An empty tag path searches the documents' global attributes; otherwise the
+// attributes of the documents tagged with it are searched.
+func (s *DocumentService) SearchDocuments(
+	ctx context.Context,
+	namespace string,
+	tagPath string,
+	filters []AttributeFilter,
+) ([]DocumentMatch, error) {
+	ns, err := s.validateNamespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	containment, satisfiable, err := buildContainment(filters)
+	if err != nil {
+		return nil, err
+	}
+	var tagID pgtype.UUID
+	if tagPath != "" {
+		tag, err := s.resolveTagByPath(ctx, ns.ID, tagPath)
+		if err != nil {
+			return nil, err
+		}
+		tagID = tag.ID
+	}
+
+	matches := []DocumentMatch{}
+	switch {
+	case !satisfiable:
+		// No attributes can match contradicting filters
+	case tagPath == "" && len(filters) == 0:
+		rows, err := s.queries.ListDocumentsWithAttributes(ctx, ns.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to search documents: %v", err)
+		}
+		for _, row := range rows {
+			matches = append(matches, DocumentMatch(row))
+		}
+	case tagPath == "":
+		rows, err := s.queries.SearchDocumentsByAttributes(ctx, ns.ID, containment)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to search documents: %v", err)
+		}
+		for _, row := range rows {
+			matches = append(matches, DocumentMatch(row))
+		}
+	case len(filters) == 0:
+		rows, err := s.queries.ListDocumentsWithTagAttributes(ctx, ns.ID, tagID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to search documents: %v", err)
+		}
+		for _, row := range rows {
+			matches = append(matches, DocumentMatch(row))
+		}
+	default:
+		rows, err := s.queries.SearchDocumentsByTagAttributes(ctx, ns.ID, tagID, containment)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to search documents: %v", err)
+		}
+		for _, row := range rows {
+			matches = append(matches, DocumentMatch(row))
+		}
+	}
+	return matches, nil
+}
+
+// buildContainment converts filters into one object that matching attributes contain, so a
+// single @> can use the attributes' GIN index. For example vendor.name equal to "Acme" and
+// parties containing "Globex" become {"vendor": {"name": "Acme"}, "parties": ["Globex"]}.
+// It reports false when the filters contradict each other, e.g. total equal to both 1 and 2,
+// and no attributes can match.
+func buildContainment(filters []AttributeFilter) (json.RawMessage, bool, error) {
+	containment := map[string]interface{}{}
+	satisfiable := true
+	for _, filter := range filters {
+		if filter.Field == "" {
+			return nil, false, status.Error(codes.InvalidArgument, "filter field is required")
+		}
+		path := strings.Split(filter.Field, ".")
+		if slices.Contains(path, "") {
+			return nil, false, status.Errorf(
+				codes.InvalidArgument,
+				"invalid filter field %q",
+				filter.Field,
+			)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal([]byte(filter.Value), &value); err != nil {
+			return nil, false, status.Errorf(
+				codes.InvalidArgument,
+				"invalid value for filter on %q: %v",
+				filter.Field,
+				err,
+			)
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, false, status.Errorf(
+				codes.InvalidArgument,
+				"filter on %q must compare to a primitive value",
+				filter.Field,
+			)
+		}
+
+		switch filter.Operator {
+		case AttributeFilterEquals:
+			// A primitive contains only itself
+		case AttributeFilterContains:
+			value = []interface{}{value}
+		default:
+			return nil, false, status.Errorf(
+				codes.InvalidArgument,
+				"unknown filter operator %q",
+				filter.Operator,
+			)
+		}
+
+		// Wrap the value in an object per path part below the top level, innermost first
+		for j := len(path) - 1; j > 0; j-- {
+			value = map[string]interface{}{path[j]: value}
+		}
+		if !mergeContainment(containment, path[0], value) {
+			satisfiable = false
+		}
+	}
+
+	containmentJSON, err := json.Marshal(containment)
+	if err != nil {
+		return nil, false, status.Errorf(codes.Internal, "failed to marshal filters: %v", err)
+	}
+	return containmentJSON, satisfiable, nil
+}
+
+// mergeContainment adds value to the containment object under field. Containing the merged
+// object is the same as containing each part: objects merge by field and arrays by element.
+// It reports false when value conflicts with what's there, e.g. two different primitives.
+func mergeContainment(object map[string]interface{}, field string, value interface{}) bool {
+	existing, ok := object[field]
+	if !ok {
+		object[field] = value
+		return true
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		existingObject, ok := existing.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for nestedField, nested := range v {
+			if !mergeContainment(existingObject, nestedField, nested) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		existingArray, ok := existing.([]interface{})
+		if !ok {
+			return false
+		}
+		object[field] = append(existingArray, v...)
+		return true
+	default:
+		return existing == value
+	}
+}
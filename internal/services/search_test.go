@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBuildContainment(t *testing.T) {
+	containment, satisfiable, err := buildContainment([]AttributeFilter{
+		{Field: "total", Operator: AttributeFilterEquals, Value: `12.5`},
+		{Field: "vendor.name", Operator: AttributeFilterEquals, Value: `"Acme"`},
+		{Field: "vendor.city", Operator: AttributeFilterEquals, Value: `"Springfield"`},
+		{Field: "parties", Operator: AttributeFilterContains, Value: `"Globex"`},
+		{Field: "parties", Operator: AttributeFilterContains, Value: `"Initech"`},
+		{Field: "total", Operator: AttributeFilterEquals, Value: `12.5`},
+	})
+	require.NoError(t, err)
+	assert.True(t, satisfiable)
+	assert.JSONEq(t, `{
+		"total": 12.5,
+		"vendor": {"name": "Acme", "city": "Springfield"},
+		"parties": ["Globex", "Initech"]
+	}`, string(containment))
+
+	containment, satisfiable, err = buildContainment(nil)
+	require.NoError(t, err)
+	assert.True(t, satisfiable)
+	assert.JSONEq(t, `{}`, string(containment))
+
+	contradictions := [][]AttributeFilter{
+		{
+			{Field: "total", Operator: AttributeFilterEquals, Value: `1`},
+			{Field: "total", Operator: AttributeFilterEquals, Value: `2`},
+		},
+		{
+			{Field: "vendor", Operator: AttributeFilterEquals, Value: `"Acme"`},
+			{Field: "vendor.name", Operator: AttributeFilterEquals, Value: `"Acme"`},
+		},
+		{
+			{Field: "parties", Operator: AttributeFilterEquals, Value: `"Acme"`},
+			{Field: "parties", Operator: AttributeFilterContains, Value: `"Acme"`},
+		},
+	}
+	for _, filters := range contradictions {
+		_, satisfiable, err := buildContainment(filters)
+		require.NoError(t, err)
+		assert.False(t, satisfiable, "%+v", filters)
+	}
+
+	invalid := []AttributeFilter{
+		{Field: "", Operator: AttributeFilterEquals, Value: `1`},
+		{Field: "vendor..name", Operator: AttributeFilterEquals, Value: `1`},
+		{Field: "total", Operator: AttributeFilterEquals, Value: `twelve`},
+		{Field: "vendor", Operator: AttributeFilterEquals, Value: `{"name": "Acme"}`},
+		{Field: "parties", Operator: AttributeFilterContains, Value: `["Acme"]`},
+		{Field: "total", Operator: AttributeFilterOperator("greater"), Value: `1`},
+	}
+	for _, filter := range invalid {
+		_, _, err := buildContainment([]AttributeFilter{filter})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "%+v", filter)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/santhosh-tekuri/jsonschema/v5"
 
 	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	"github.com/RynoXLI/Wayfile/internal/db"
 	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
 	"github.com/RynoXLI/Wayfile/internal/events"
 )
@@ -42,8 +43,10 @@ var (
 	// ErrInvalidJSONSchema is returned when a JSON schema is malformed
 	ErrInvalidJSONSchema = errors.New("invalid JSON schema")
 	// ErrNestedTypesNotAllowed is returned when a JSON schema contains nested objects or arrays
+	// it doesn't opt into, or nests them deeper than supported
 	ErrNestedTypesNotAllowed = errors.New(
-		"nested types not allowed in schema, only primitive types are supported",
+		"nested types not allowed in schema, only primitive types are supported " +
+			"unless the schema sets " + structuredAttributesKeyword,
 	)
 	// ErrAttributeValidationFailed is returned when attributes don't match the tag's schema
 	ErrAttributeValidationFailed = errors.New("attribute validation failed")
@@ -54,15 +57,19 @@ var (
 
 // TagService orchestrates tag operations with schema management and events
 type TagService struct {
-	queries   *sqlc.Queries
-	publisher events.Publisher
+	db      db.TxBeginner
+	queries *sqlc.Queries
+	outbox  *events.Outbox
+	schemas *schemaCache
 }
 
 // NewTagService creates a new tag service
-func NewTagService(queries *sqlc.Queries, publisher events.Publisher) *TagService {
+func NewTagService(pool db.TxBeginner, queries *sqlc.Queries, outbox *events.Outbox) *TagService {
 	return &TagService{
-		queries:   queries,
-		publisher: publisher,
+		db:      pool,
+		queries: queries,
+		outbox:  outbox,
+		schemas: newSchemaCache(),
 	}
 }
 
@@ -90,11 +97,19 @@ var (
 	metaSchemaOnce sync.Once
 	// metaSchemaCompileErr stores any error from compiling the meta-schema
 	metaSchemaCompileErr error
+	// metaSchemaDocument is the decoded meta-schema, to report what it expected of a schema
+	metaSchemaDocument interface{}
 )
 
-// metaSchemaForPrimitivesOnly defines a JSON schema that validates other schemas
-// to ensure they only contain primitive types in properties
-const metaSchemaForPrimitivesOnly = `{
+// structuredAttributesKeyword is the schema keyword that opts a schema into arrays and
+// nested objects
+const structuredAttributesKeyword = "x-structured-attributes"
+
+// metaSchemaForAttributes defines a JSON schema that validates other schemas to ensure
+// their properties only contain primitive types. Schemas that set
+// x-structured-attributes may also contain arrays of primitives and objects whose
+// properties are primitives.
+const metaSchemaForAttributes = `{
   "$schema": "https://json-schema.org/draft/2020-12/schema",
   "type": "object",
   "required": ["type"],
@@ -102,47 +117,14 @@ const metaSchemaForPrimitivesOnly = `{
     "type": {
       "const": "object"
     },
+    "x-structured-attributes": {"type": "boolean"},
     "properties": {
       "type": "object",
       "additionalProperties": {
-        "type": "object",
-        "required": ["type"],
         "anyOf": [
-          {
-            "properties": {
-              "type": {"const": "string"},
-              "minLength": {"type": "integer", "minimum": 0},
-              "maxLength": {"type": "integer", "minimum": 0},
-              "pattern": {"type": "string"},
-              "format": {"type": "string", "enum": ["date", "time", "date-time", "email", "uuid", "uri", "hostname", "ipv4", "ipv6"]},
-              "enum": {"type": "array", "items": {"type": "string"}}
-            },
-            "additionalProperties": true
-          },
-          {
-            "properties": {
-              "type": {"const": "number"},
-              "minimum": {"type": "number"},
-              "maximum": {"type": "number"},
-              "enum": {"type": "array", "items": {"type": "number"}}
-            },
-            "additionalProperties": true
-          },
-          {
-            "properties": {
-              "type": {"const": "integer"},
-              "minimum": {"type": "integer"},
-              "maximum": {"type": "integer"},
-              "enum": {"type": "array", "items": {"type": "integer"}}
-            },
-            "additionalProperties": true
-          },
-          {
-            "properties": {
-              "type": {"const": "boolean"}
-            },
-            "additionalProperties": true
-          }
+          {"$ref": "#/$defs/primitive"},
+          {"$ref": "#/$defs/array"},
+          {"$ref": "#/$defs/object"}
         ]
       }
     },
@@ -151,7 +133,88 @@ const metaSchemaForPrimitivesOnly = `{
       "items": {"type": "string"}
     }
   },
-  "additionalProperties": true
+  "if": {
+    "required": ["x-structured-attributes"],
+    "properties": {"x-structured-attributes": {"const": true}}
+  },
+  "else": {
+    "properties": {
+      "properties": {"additionalProperties": {"$ref": "#/$defs/primitive"}}
+    }
+  },
+  "additionalProperties": true,
+  "$defs": {
+    "primitive": {
+      "type": "object",
+      "required": ["type"],
+      "anyOf": [
+        {
+          "properties": {
+            "type": {"const": "string"},
+            "minLength": {"type": "integer", "minimum": 0},
+            "maxLength": {"type": "integer", "minimum": 0},
+            "pattern": {"type": "string"},
+            "format": {"type": "string", "enum": ["date", "time", "date-time", "email", "uuid", "uri", "hostname", "ipv4", "ipv6"]},
+            "enum": {"type": "array", "items": {"type": "string"}}
+          },
+          "additionalProperties": true
+        },
+        {
+          "properties": {
+            "type": {"const": "number"},
+            "minimum": {"type": "number"},
+            "maximum": {"type": "number"},
+            "enum": {"type": "array", "items": {"type": "number"}}
+          },
+          "additionalProperties": true
+        },
+        {
+          "properties": {
+            "type": {"const": "integer"},
+            "minimum": {"type": "integer"},
+            "maximum": {"type": "integer"},
+            "enum": {"type": "array", "items": {"type": "integer"}}
+          },
+          "additionalProperties": true
+        },
+        {
+          "properties": {
+            "type": {"const": "boolean"}
+          },
+          "additionalProperties": true
+        }
+      ]
+    },
+    "array": {
+      "type": "object",
+      "required": ["type", "items"],
+      "properties": {
+        "type": {"const": "array"},
+        "items": {"$ref": "#/$defs/primitive"},
+        "minItems": {"type": "integer", "minimum": 0},
+        "maxItems": {"type": "integer", "minimum": 0},
+        "uniqueItems": {"type": "boolean"}
+      },
+      "additionalProperties": true
+    },
+    "object": {
+      "type": "object",
+      "required": ["type", "properties"],
+      "properties": {
+        "type": {"const": "object"},
+        "properties": {
+          "type": "object",
+          "additionalProperties": {"$ref": "#/$defs/primitive"}
+        },
+        "required": {
+          "type": "array",
+          "items": {"type": "string"}
+        },
+        "additionalProperties": {"type": "boolean"}
+      },
+      "additionalProperties": true
+    }
+  }
 }`
 
 // getCompiledMetaSchema returns the cached compiled meta-schema, compiling it on first use
@@ -161,28 +224,31 @@ func getCompiledMetaSchema() (*jsonschema.Schema, error) {
 		compiler.Draft = jsonschema.Draft2020
 
 		err := compiler.AddResource(
-			"primitives-meta-schema",
-			strings.NewReader(metaSchemaForPrimitivesOnly),
+			"attributes-meta-schema",
+			strings.NewReader(metaSchemaForAttributes),
 		)
 		if err != nil {
 			metaSchemaCompileErr = fmt.Errorf("internal error: failed to add meta-schema: %w", err)
 			return
 		}
 
-		compiledMetaSchema, metaSchemaCompileErr = compiler.Compile("primitives-meta-schema")
+		compiledMetaSchema, metaSchemaCompileErr = compiler.Compile("attributes-meta-schema")
 		if metaSchemaCompileErr != nil {
 			metaSchemaCompileErr = fmt.Errorf(
 				"internal error: failed to compile meta-schema: %w",
 				metaSchemaCompileErr,
 			)
+			return
 		}
+		_ = json.Unmarshal([]byte(metaSchemaForAttributes), &metaSchemaDocument)
 	})
 
 	return compiledMetaSchema, metaSchemaCompileErr
 }
 
-// validateSchemaPrimitivesOnly validates that a JSON schema only contains primitive types
-func validateSchemaPrimitivesOnly(schemaJSON string) error {
+// validateAttributeSchema validates that a JSON schema only contains primitive types, or
+// arrays of primitives and objects of primitives if it opts into structured attributes
+func validateAttributeSchema(schemaJSON string) error {
 	// Parse the input schema to validate it's well-formed JSON
 	var inputSchema interface{}
 	if err := json.Unmarshal([]byte(schemaJSON), &inputSchema); err != nil {
@@ -195,12 +261,12 @@ func validateSchemaPrimitivesOnly(schemaJSON string) error {
 
 	err := compiler.AddResource("input-schema", strings.NewReader(schemaJSON))
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrInvalidJSONSchema, err)
+		return newValidationError(ErrInvalidJSONSchema, err, nil, inputSchema)
 	}
 
 	_, err = compiler.Compile("input-schema")
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrInvalidJSONSchema, err)
+		return newValidationError(ErrInvalidJSONSchema, err, nil, inputSchema)
 	}
 
 	// Get the cached compiled meta-schema
@@ -211,10 +277,12 @@ func validateSchemaPrimitivesOnly(schemaJSON string) error {
 
 	// Validate the input schema against our meta-schema
 	if err := metaSchema.Validate(inputSchema); err != nil {
-		return fmt.Errorf("%w: %v", ErrNestedTypesNotAllowed, err)
+		return newValidationError(ErrNestedTypesNotAllowed, err, metaSchemaDocument, inputSchema)
 	}
 
-	return nil
+	// Computed attributes must have valid expressions
+	_, err = parseSchemaDerivations([]byte(schemaJSON))
+	return err
 }
 
 // isPrimitiveType checks if a JSON Schema type is a primitive type
@@ -228,42 +296,128 @@ func isPrimitiveType(schemaType string) bool {
 	return primitives[schemaType]
 }
 
-// ValidateAttributes validates attribute data against a tag's schema
-func (s *TagService) ValidateAttributes(
+// PrepareAttributes applies the defaults and computed attributes of a tag's effective
+// schema to the attributes being written to a document, in place, then validates them.
+// Computed attributes may refer to the document's fields; without a document, those
+// referring to them are left out. With coercion options, values are first converted to
+// the types the schema declares.
+func (s *TagService) PrepareAttributes(
 	ctx context.Context,
 	tagID pgtype.UUID,
+	document *sqlc.Document,
 	attributes map[string]interface{},
-) error {
-	// Get the tag's latest schema
-	schema, err := s.queries.GetLatestSchemaByTagID(ctx, tagID)
+	coerce *CoercionOptions,
+) (*AttributeDerivations, error) {
+	if coerce != nil {
+		if err := coerce.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	compiled, err := s.compiledSchema(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	if compiled == nil {
+		return &AttributeDerivations{}, nil
+	}
+	if attributes == nil {
+		// A null object has nothing to derive from
+		return &AttributeDerivations{}, compiled.validate(attributes)
+	}
+	var coerced []AttributeCoercion
+	if coerce != nil {
+		if coerced, err = coerceAttributes(compiled.derivations.properties, attributes, coerce); err != nil {
+			return nil, err
+		}
+	}
+	derived, err := compiled.derivations.apply(attributes, documentFields(document))
+	if err != nil {
+		return nil, err
+	}
+	if err := compiled.validate(attributes); err != nil {
+		return nil, err
+	}
+	derived.Coerced = coerced
+	return derived, nil
+}
+
+// compiledSchema returns a tag's compiled effective schema, nil if it has none
+func (s *TagService) compiledSchema(
+	ctx context.Context,
+	tagID pgtype.UUID,
+) (*compiledTagSchema, error) {
+	version, err := s.queries.GetEffectiveSchemaVersion(ctx, tagID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	key := schemaCacheKey{tagID: tagID, version: version}
+	compiled, ok, generation := s.schemas.get(key)
+	if ok {
+		return compiled, nil
+	}
+
+	tag, err := s.queries.GetTagByID(ctx, tagID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	// Get the tag's effective schema, including the schemas it inherits
+	schema, err := s.effectiveSchema(ctx, s.queries, tag)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			// No schema exists, so no validation needed
-			return nil
+		return nil, err
+	}
+	if schema != nil {
+		if compiled, err = s.schemas.compile(schema); err != nil {
+			return nil, err
 		}
-		return fmt.Errorf("failed to get schema for tag: %w", err)
 	}
+	s.schemas.put(key, compiled, generation)
+	return compiled, nil
+}
+
+// compiledTagSchema is a tag's effective schema, compiled for validation, with its
+// defaults and computed attributes
+type compiledTagSchema struct {
+	validator   *jsonschema.Schema
+	derivations *schemaDerivations
+	// document is the decoded schema, to report what failed validations expected
+	document interface{}
+}
+
+// validate validates attributes against the schema
+func (c *compiledTagSchema) validate(attributes map[string]interface{}) error {
+	if err := c.validator.Validate(attributes); err != nil {
+		return newValidationError(ErrAttributeValidationFailed, err, c.document, attributes)
+	}
+	return nil
+}
 
-	// Compile the schema for validation
+// compileSchema compiles a tag's effective schema for validating and deriving attributes
+func compileSchema(schema []byte) (*compiledTagSchema, error) {
 	compiler := jsonschema.NewCompiler()
 	compiler.Draft = jsonschema.Draft2020
 
-	err = compiler.AddResource("tag-schema", strings.NewReader(string(schema.JsonSchema)))
+	err := compiler.AddResource("tag-schema", strings.NewReader(string(schema)))
 	if err != nil {
-		return fmt.Errorf("failed to add schema resource: %w", err)
+		return nil, fmt.Errorf("failed to add schema resource: %w", err)
 	}
 
-	compiledSchema, err := compiler.Compile("tag-schema")
+	validator, err := compiler.Compile("tag-schema")
 	if err != nil {
-		return fmt.Errorf("failed to compile schema: %w", err)
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
 	}
-
-	// Validate the attributes against the schema
-	if err := compiledSchema.Validate(attributes); err != nil {
-		return fmt.Errorf("%w: %v", ErrAttributeValidationFailed, err)
+	derivations, err := parseSchemaDerivations(schema)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil
+	var document interface{}
+	if err := json.Unmarshal(schema, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	return &compiledTagSchema{
+		validator:   validator,
+		derivations: derivations,
+		document:    document,
+	}, nil
 }
 
 // ensureColor returns the provided color or generates one if nil/empty
@@ -278,6 +432,22 @@ func (s *TagService) ensureColor(color *string, tagName string) string {
 type TagWithSchema struct {
 	Tag    sqlc.Tag
 	Schema *sqlc.AttributeSchema // nil if no schema exists
+	// EffectiveSchema is the schema composed with inherited ones, nil if there is none
+	EffectiveSchema []byte
+}
+
+// withEffectiveSchema sets the effective schema of a tag with its schema
+func (s *TagService) withEffectiveSchema(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	result *TagWithSchema,
+) (*TagWithSchema, error) {
+	effective, err := s.effectiveSchema(ctx, queries, result.Tag)
+	if err != nil {
+		return nil, err
+	}
+	result.EffectiveSchema = effective
+	return result, nil
 }
 
 // validateTagInput validates tag name, optional parent path, color, and JSON schema
@@ -326,8 +496,8 @@ func (s *TagService) validateTagInput(
 		if !json.Valid([]byte(*jsonSchema)) {
 			return fmt.Errorf("%w: provided JSON schema is not valid JSON", ErrInvalidJSONSchema)
 		}
-		// Validate that schema only contains primitive types
-		if err := validateSchemaPrimitivesOnly(*jsonSchema); err != nil {
+		// Validate that schema only contains supported types
+		if err := validateAttributeSchema(*jsonSchema); err != nil {
 			return err
 		}
 	}
@@ -423,11 +593,17 @@ func (s *TagService) CreateTag(
 	parentPath *string,
 	color *string,
 	jsonSchema *string,
+	inheritSchema bool,
+	compatibility SchemaCompatibility,
 ) (*TagWithSchema, error) {
 	// Validate input
 	if err := s.validateTagInput(name, parentPath, color, jsonSchema); err != nil {
 		return nil, err
 	}
+	compatibility, err := ParseSchemaCompatibility(string(compatibility))
+	if err != nil {
+		return nil, err
+	}
 
 	// Ensure color is set (generate if not provided)
 	finalColor := s.ensureColor(color, name)
@@ -445,43 +621,69 @@ func (s *TagService) CreateTag(
 	}
 	path := buildTagPath(resolvedParentPath, name)
 
-	// Create the tag
-	tag, err := s.queries.CreateTag(
-		ctx,
-		namespace.ID,
-		name,
-		description,
-		path,
-		parentID,
-		&finalColor,
-	)
-	if err != nil {
-		// Check for unique constraint violation on path
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			// 23505 is unique_violation
-			return nil, ErrTagAlreadyExists
+	// Create the tag, its schema and the schema event together
+	var result *TagWithSchema
+	err = db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		queries := s.queries.WithTx(tx)
+		tag, err := queries.CreateTag(
+			ctx,
+			namespace.ID,
+			name,
+			description,
+			path,
+			parentID,
+			&finalColor,
+			inheritSchema,
+			string(compatibility),
+		)
+		if err != nil {
+			// Check for unique constraint violation on path
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				// 23505 is unique_violation
+				return ErrTagAlreadyExists
+			}
+			return err
 		}
-		return nil, err
-	}
 
-	result := &TagWithSchema{Tag: tag}
+		result = &TagWithSchema{Tag: tag}
 
-	// Create schema if provided
-	if jsonSchema != nil && *jsonSchema != "" {
-		schema, err := s.queries.CreateSchema(ctx, tag.ID, []byte(*jsonSchema))
+		outbox := s.outbox.WithTx(tx)
+		err = outbox.TagCreated(ctx, &eventsv1.TagCreatedEvent{
+			TagId:     tag.ID.String(),
+			Namespace: namespace.Name,
+			TagPath:   tag.Path,
+		})
 		if err != nil {
-			return nil, err
+			return err
 		}
-		result.Schema = &schema
 
-		// Publish schema created event
-		_ = s.publisher.SchemaChanged(&eventsv1.SchemaChangedEvent{
-			Namespace:     namespace.Name,
-			TagPath:       tag.Path,
-			OldJsonSchema: "",
-			NewJsonSchema: *jsonSchema,
-		})
+		// Create schema if provided
+		if jsonSchema != nil && *jsonSchema != "" {
+			schema, err := queries.CreateSchema(ctx, tag.ID, []byte(*jsonSchema))
+			if err != nil {
+				return err
+			}
+			result.Schema = &schema
+
+			// Record schema created event
+			err = outbox.SchemaChanged(ctx, &eventsv1.SchemaChangedEvent{
+				Namespace:     namespace.Name,
+				TagPath:       tag.Path,
+				OldJsonSchema: "",
+				NewJsonSchema: *jsonSchema,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// An inherited schema must compose with the new one
+		result, err = s.withEffectiveSchema(ctx, queries, result)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -513,7 +715,7 @@ func (s *TagService) GetTagByPath(
 		result.Schema = &schema
 	}
 
-	return result, nil
+	return s.withEffectiveSchema(ctx, s.queries, result)
 }
 
 // GetTagByID retrieves a tag by its UUID within a namespace
@@ -547,7 +749,7 @@ func (s *TagService) GetTagByID(
 		result.Schema = &schema
 	}
 
-	return result, nil
+	return s.withEffectiveSchema(ctx, s.queries, result)
 }
 
 // ListTags retrieves all tags in a namespace with their schemas
@@ -575,7 +777,9 @@ func (s *TagService) ListTags(ctx context.Context, namespaceName string) ([]*Tag
 			result.Schema = &schema
 		}
 
-		results[i] = result
+		if results[i], err = s.withEffectiveSchema(ctx, s.queries, result); err != nil {
+			return nil, err
+		}
 	}
 
 	return results, nil
@@ -591,6 +795,8 @@ func (s *TagService) UpdateTag(
 	parentPath *string,
 	color *string,
 	jsonSchema *string,
+	inheritSchema *bool,
+	compatibility *SchemaCompatibility,
 ) (*TagWithSchema, error) {
 	// Get namespace by name
 	namespace, err := s.queries.GetNamespaceByName(ctx, namespaceName)
@@ -644,63 +850,132 @@ func (s *TagService) UpdateTag(
 		updateColor = &generated
 	}
 
-	// Update the tag
-	tag, err = s.queries.UpdateTag(
-		ctx,
-		tag.ID,
-		updateName,
-		description,
-		updatePath,
-		parentID,
-		updateColor,
-	)
-	if err != nil {
-		// Check for unique constraint violation on path
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			// 23505 is unique_violation
-			return nil, ErrTagAlreadyExists
+	// Keep inheriting or not unless told otherwise
+	updateInheritSchema := tag.InheritSchema
+	if inheritSchema != nil {
+		updateInheritSchema = *inheritSchema
+	}
+	updateCompatibility := SchemaCompatibility(tag.SchemaCompatibility)
+	if compatibility != nil {
+		if updateCompatibility, err = ParseSchemaCompatibility(string(*compatibility)); err != nil {
+			return nil, err
 		}
-		return nil, err
 	}
 
-	result := &TagWithSchema{Tag: tag}
+	// Update the tag, any new schema version and the schema event together
+	var result *TagWithSchema
+	err = db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		queries := s.queries.WithTx(tx)
+		updated, err := queries.UpdateTag(
+			ctx,
+			tag.ID,
+			updateName,
+			description,
+			updatePath,
+			parentID,
+			updateColor,
+			updateInheritSchema,
+			string(updateCompatibility),
+		)
+		if err != nil {
+			// Check for unique constraint violation on path
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				// 23505 is unique_violation
+				return ErrTagAlreadyExists
+			}
+			return err
+		}
 
-	// Handle schema update
-	if jsonSchema != nil && *jsonSchema != "" {
-		// Check if schema changed
-		var oldSchemaStr string
-		if oldSchema != nil {
-			oldSchemaStr = string(oldSchema.JsonSchema)
+		result = &TagWithSchema{Tag: updated}
+
+		outbox := s.outbox.WithTx(tx)
+		err = outbox.TagUpdated(ctx, &eventsv1.TagUpdatedEvent{
+			TagId:      updated.ID.String(),
+			Namespace:  namespace.Name,
+			OldTagPath: tag.Path,
+			TagPath:    updated.Path,
+		})
+		if err != nil {
+			return err
 		}
-		schemaChanged := oldSchema == nil || oldSchemaStr != *jsonSchema
 
-		if schemaChanged {
-			// Create new schema version
-			newSchema, err := s.queries.CreateSchema(ctx, tag.ID, []byte(*jsonSchema))
-			if err != nil {
-				return nil, err
-			}
-			result.Schema = &newSchema
+		if err := s.updateSchema(ctx, tx, namespace.Name, result, oldSchema, jsonSchema); err != nil {
+			return err
+		}
 
-			// Publish schema changed event
-			_ = s.publisher.SchemaChanged(&eventsv1.SchemaChangedEvent{
-				Namespace:     namespace.Name,
-				TagPath:       tag.Path,
-				OldJsonSchema: oldSchemaStr,
-				NewJsonSchema: *jsonSchema,
-			})
-		} else {
-			result.Schema = oldSchema
+		// The schema, inheritance or parent may have changed, so the tag's and inheriting
+		// descendants' schemas must still compose
+		if err := s.checkInheritingSchemas(ctx, queries, updated); err != nil {
+			return err
 		}
-	} else if oldSchema != nil {
-		// No new schema provided, keep the old one
-		result.Schema = oldSchema
+		result, err = s.withEffectiveSchema(ctx, queries, result)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// This tag's and its inheriting descendants' effective schemas may have changed, so
+	// free the compiled ones
+	s.schemas.purge()
 	return result, nil
 }
 
+// updateSchema creates a new schema version for an updated tag if its schema changed and
+// records the schema changed event within tx
+func (s *TagService) updateSchema(
+	ctx context.Context,
+	tx pgx.Tx,
+	namespace string,
+	result *TagWithSchema,
+	oldSchema *sqlc.AttributeSchema,
+	jsonSchema *string,
+) error {
+	// No new schema provided, keep the old one
+	if jsonSchema == nil || *jsonSchema == "" {
+		result.Schema = oldSchema
+		return nil
+	}
+
+	// Check if schema changed
+	var oldSchemaStr string
+	if oldSchema != nil {
+		oldSchemaStr = string(oldSchema.JsonSchema)
+	}
+	if oldSchema != nil && oldSchemaStr == *jsonSchema {
+		result.Schema = oldSchema
+		return nil
+	}
+
+	// The new version must keep the compatibility the tag asks for with the latest one
+	if oldSchema != nil {
+		err := checkSchemaCompatibility(
+			SchemaCompatibility(result.Tag.SchemaCompatibility),
+			oldSchema.JsonSchema,
+			[]byte(*jsonSchema),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create new schema version
+	newSchema, err := s.queries.WithTx(tx).CreateSchema(ctx, result.Tag.ID, []byte(*jsonSchema))
+	if err != nil {
+		return err
+	}
+	result.Schema = &newSchema
+
+	// Record schema changed event
+	return s.outbox.WithTx(tx).SchemaChanged(ctx, &eventsv1.SchemaChangedEvent{
+		Namespace:     namespace,
+		TagPath:       result.Tag.Path,
+		OldJsonSchema: oldSchemaStr,
+		NewJsonSchema: *jsonSchema,
+	})
+}
+
 // DeleteTag removes a tag
 func (s *TagService) DeleteTag(ctx context.Context, namespaceName string, tagPath string) error {
 	// Get namespace by name
@@ -715,6 +990,22 @@ func (s *TagService) DeleteTag(ctx context.Context, namespaceName string, tagPat
 		return ErrTagNotFound
 	}
 
-	// Delete the tag
-	return s.queries.DeleteTag(ctx, tag.ID)
+	// Delete the tag and record the event together
+	err = db.RunInTx(ctx, s.db, func(tx pgx.Tx) error {
+		if err := s.queries.WithTx(tx).DeleteTag(ctx, tag.ID); err != nil {
+			return err
+		}
+		return s.outbox.WithTx(tx).TagDeleted(ctx, &eventsv1.TagDeletedEvent{
+			TagId:     tag.ID.String(),
+			Namespace: namespace.Name,
+			TagPath:   tag.Path,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Drop the deleted tag's compiled schema
+	s.schemas.purge()
+	return nil
 }
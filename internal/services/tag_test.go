@@ -321,7 +321,7 @@ func stringPtr(s string) *string {
 	return &s
 }
 
-func TestValidateSchemaPrimitivesOnly(t *testing.T) {
+func TestValidateAttributeSchema(t *testing.T) {
 	tests := []struct {
 		name      string
 		schema    string
@@ -381,6 +381,35 @@ func TestValidateSchemaPrimitivesOnly(t *testing.T) {
 			schema:  `{"type": "object", "properties": {"email": {"type": "string", "format": "email"}, "created_date": {"type": "string", "format": "date"}, "doc_id": {"type": "string", "format": "uuid"}}}`,
 			wantErr: false,
 		},
+		{
+			name:    "valid structured schema with array and nested object",
+			schema:  `{"type": "object", "x-structured-attributes": true, "properties": {"parties": {"type": "array", "items": {"type": "string"}, "minItems": 1, "uniqueItems": true}, "vendor": {"type": "object", "properties": {"name": {"type": "string"}, "vat": {"type": "string", "pattern": "^[A-Z]{2}"}}, "required": ["name"]}}}`,
+			wantErr: false,
+		},
+		{
+			name:      "invalid structured schema with array of objects",
+			schema:    `{"type": "object", "x-structured-attributes": true, "properties": {"lines": {"type": "array", "items": {"type": "object", "properties": {"total": {"type": "number"}}}}}}`,
+			wantErr:   true,
+			errString: "nested types not allowed",
+		},
+		{
+			name:      "invalid structured schema with two levels of nesting",
+			schema:    `{"type": "object", "x-structured-attributes": true, "properties": {"vendor": {"type": "object", "properties": {"address": {"type": "object", "properties": {"city": {"type": "string"}}}}}}}`,
+			wantErr:   true,
+			errString: "nested types not allowed",
+		},
+		{
+			name:      "invalid structured schema with untyped array items",
+			schema:    `{"type": "object", "x-structured-attributes": true, "properties": {"tags": {"type": "array"}}}`,
+			wantErr:   true,
+			errString: "nested types not allowed",
+		},
+		{
+			name:      "invalid schema opting out of structured attributes",
+			schema:    `{"type": "object", "x-structured-attributes": false, "properties": {"tags": {"type": "array", "items": {"type": "string"}}}}`,
+			wantErr:   true,
+			errString: "nested types not allowed",
+		},
 		{
 			name:      "invalid JSON",
 			schema:    `{"invalid": json}`,
@@ -391,7 +420,7 @@ func TestValidateSchemaPrimitivesOnly(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateSchemaPrimitivesOnly(tt.schema)
+			err := validateAttributeSchema(tt.schema)
 			if tt.wantErr {
 				assert.Error(t, err)
 				if tt.errString != "" {
@@ -0,0 +1,449 @@
+// Package services contains business logic and orchestration
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/storage"
+)
+
+var (
+	// ErrUploadNotFound is returned when a resumable upload doesn't exist
+	ErrUploadNotFound = errors.New("upload not found")
+	// ErrUploadExpired is returned when a resumable upload has passed its expiry
+	ErrUploadExpired = errors.New("upload expired")
+	// ErrUploadComplete is returned when data is sent to an upload that has been finalized
+	ErrUploadComplete = errors.New("upload already complete")
+	// ErrUploadOffsetMismatch is returned when a chunk doesn't start at the current upload offset
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+	// ErrUploadTooLarge is returned when the declared upload length exceeds the configured maximum
+	ErrUploadTooLarge = errors.New("upload exceeds maximum size")
+	// ErrUploadLengthExceeded is returned when a chunk would grow the upload past its declared length
+	ErrUploadLengthExceeded = errors.New("chunk exceeds declared upload length")
+	// ErrInvalidUploadMetadata is returned when upload metadata is missing or malformed
+	ErrInvalidUploadMetadata = errors.New("invalid upload metadata")
+	// ErrUploadChecksumMismatch is returned when the stored file doesn't match the received bytes
+	ErrUploadChecksumMismatch = errors.New("upload checksum mismatch")
+)
+
+// defaultUploadMimeType is used when a resumable upload doesn't declare a file type
+const defaultUploadMimeType = "application/octet-stream"
+
+// UploadService manages resumable uploads that are staged in chunks and
+// finalized into documents through DocumentService
+type UploadService struct {
+	storage         *storage.Storage
+	documentService *DocumentService
+	queries         *sqlc.Queries
+	maxSize         int64
+	expiry          time.Duration
+}
+
+// UploadProgress describes the state of a resumable upload after an operation
+type UploadProgress struct {
+	Session *sqlc.UploadSession
+	// Document is set when the operation finalized the upload
+	Document *DocumentUploadResult
+}
+
+// NewUploadService creates a new upload service
+func NewUploadService(
+	storage *storage.Storage,
+	documentService *DocumentService,
+	queries *sqlc.Queries,
+	maxSize int64,
+	expiry time.Duration,
+) *UploadService {
+	return &UploadService{
+		storage:         storage,
+		documentService: documentService,
+		queries:         queries,
+		maxSize:         maxSize,
+		expiry:          expiry,
+	}
+}
+
+// MaxSize returns the largest upload length the service accepts
+func (s *UploadService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// marshalHash serializes the internal state of a running SHA-256 hash
+func marshalHash(h hash.Hash) ([]byte, error) {
+	marshaler, ok := h.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, errors.New("hash state cannot be marshaled")
+	}
+	return marshaler.MarshalBinary()
+}
+
+// restoreHash resumes a SHA-256 hash from previously marshaled state
+func restoreHash(state []byte) (hash.Hash, error) {
+	h := sha256.New()
+	if len(state) == 0 {
+		return h, nil
+	}
+	unmarshaler, ok := h.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return nil, errors.New("hash state cannot be restored")
+	}
+	if err := unmarshaler.UnmarshalBinary(state); err != nil {
+		return nil, fmt.Errorf("failed to restore hash state: %w", err)
+	}
+	return h, nil
+}
+
+// CreateUpload starts a new resumable upload. Zero-length uploads are finalized immediately.
+func (s *UploadService) CreateUpload(
+	ctx context.Context,
+	namespace string,
+	filename string,
+	mimeType string,
+	length int64,
+	metadata map[string]string,
+) (*UploadProgress, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("%w: filename is required", ErrInvalidUploadMetadata)
+	}
+	if len(filename) > 255 {
+		return nil, fmt.Errorf(
+			"%w: filename cannot exceed 255 characters",
+			ErrInvalidUploadMetadata,
+		)
+	}
+	// The filename becomes part of the storage path, so it must name a single file
+	if strings.ContainsAny(filename, "/\\\x00") || filename == "." || filename == ".." {
+		return nil, fmt.Errorf(
+			"%w: filename cannot contain path separators",
+			ErrInvalidUploadMetadata,
+		)
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("%w: upload length cannot be negative", ErrInvalidUploadMetadata)
+	}
+	if length > s.maxSize {
+		return nil, ErrUploadTooLarge
+	}
+	if mimeType == "" {
+		mimeType = defaultUploadMimeType
+	}
+
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUploadMetadata, err)
+	}
+
+	session, err := s.queries.CreateUploadSession(
+		ctx,
+		ns.ID,
+		filename,
+		mimeType,
+		length,
+		metadataJSON,
+		pgtype.Timestamptz{Time: time.Now().Add(s.expiry), Valid: true},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload session: %w", err)
+	}
+
+	progress := &UploadProgress{Session: &session}
+	if length == 0 {
+		doc, err := s.finalize(ctx, namespace, &session, sha256.New())
+		if err != nil {
+			return nil, err
+		}
+		progress.Document = doc
+	}
+
+	return progress, nil
+}
+
+// GetUpload retrieves a resumable upload within a namespace
+func (s *UploadService) GetUpload(
+	ctx context.Context,
+	namespace string,
+	uploadID string,
+) (*sqlc.UploadSession, error) {
+	session, err := s.lookupUpload(ctx, namespace, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if session.ExpiresAt.Time.Before(time.Now()) {
+		return nil, ErrUploadExpired
+	}
+	return session, nil
+}
+
+// lookupUpload resolves an upload by ID and verifies it belongs to the namespace
+func (s *UploadService) lookupUpload(
+	ctx context.Context,
+	namespace string,
+	uploadID string,
+) (*sqlc.UploadSession, error) {
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	id, err := uuid.Parse(uploadID)
+	if err != nil {
+		return nil, ErrUploadNotFound
+	}
+
+	session, err := s.queries.GetUploadSession(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to get upload session: %w", err)
+	}
+	if session.NamespaceID != ns.ID {
+		return nil, ErrUploadNotFound
+	}
+
+	return &session, nil
+}
+
+// AppendChunk stages the bytes of data at the given offset and advances the upload.
+// When the final byte arrives the upload is finalized into a document.
+func (s *UploadService) AppendChunk(
+	ctx context.Context,
+	namespace string,
+	uploadID string,
+	offset int64,
+	data io.Reader,
+) (*UploadProgress, error) {
+	session, err := s.GetUpload(ctx, namespace, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if session.DocumentID.Valid {
+		return nil, ErrUploadComplete
+	}
+	if offset != session.UploadOffset {
+		return nil, ErrUploadOffsetMismatch
+	}
+
+	h, err := restoreHash(session.HashState)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read at most one byte past the declared length so oversized chunks can be detected
+	remaining := session.UploadLength - session.UploadOffset
+	counter := &countingReader{r: io.LimitReader(data, remaining+1)}
+	chunk := io.TeeReader(counter, h)
+
+	// Each request stages its own copy of the chunk, so a request losing the race to
+	// advance the upload can't replace the bytes of the one that won. Chunks that aren't
+	// recorded are removed along with the rest of the staging area.
+	id := session.ID.String()
+	index := int32(len(session.ChunkNames))
+	chunkName, err := s.storage.StageChunkAttempt(ctx, id, index, chunk)
+	if err != nil {
+		// Nothing is recorded for a failed chunk; the client resumes from the last offset
+		return nil, fmt.Errorf("failed to stage chunk: %w", err)
+	}
+	if counter.n > remaining {
+		return nil, ErrUploadLengthExceeded
+	}
+
+	if counter.n > 0 {
+		state, err := marshalHash(h)
+		if err != nil {
+			return nil, err
+		}
+		advanced, err := s.queries.AdvanceUploadSession(
+			ctx,
+			session.UploadOffset+counter.n,
+			chunkName,
+			state,
+			pgtype.Timestamptz{Time: time.Now().Add(s.expiry), Valid: true},
+			session.ID,
+			session.UploadOffset,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				// Another request advanced the upload concurrently
+				return nil, ErrUploadOffsetMismatch
+			}
+			return nil, fmt.Errorf("failed to advance upload session: %w", err)
+		}
+		session = &advanced
+	}
+
+	progress := &UploadProgress{Session: session}
+	if session.UploadOffset == session.UploadLength {
+		// Also reached when a previous finalization failed and the client retries
+		// with an empty chunk at the final offset
+		doc, err := s.finalize(ctx, namespace, session, h)
+		if err != nil {
+			return nil, err
+		}
+		progress.Document = doc
+	}
+
+	return progress, nil
+}
+
+// finalize streams the staged chunks through the regular document upload flow
+func (s *UploadService) finalize(
+	ctx context.Context,
+	namespace string,
+	session *sqlc.UploadSession,
+	h hash.Hash,
+) (*DocumentUploadResult, error) {
+	id := session.ID.String()
+	checksum := hex.EncodeToString(h.Sum(nil))
+
+	// The running checksum lets duplicates be rejected without copying the file again
+	if _, err := s.queries.GetDocumentByChecksum(ctx, session.NamespaceID, checksum); err == nil {
+		s.discard(ctx, session)
+		return nil, storage.ErrDuplicateFile
+	}
+
+	reader := s.storage.OpenStagedChunks(ctx, id, session.ChunkNames)
+	defer func() { _ = reader.Close() }()
+
+	result, err := s.documentService.UploadDocument(
+		ctx,
+		namespace,
+		session.FileName,
+		session.MimeType,
+		int(session.UploadLength),
+		reader,
+	)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateFile) {
+			s.discard(ctx, session)
+		}
+		return nil, err
+	}
+
+	if result.Document.ChecksumSha256 != checksum {
+		docID := result.Document.ID.String()
+		if delErr := s.documentService.DeleteDocument(ctx, namespace, docID); delErr != nil {
+			slog.Error("failed to remove document after checksum mismatch",
+				"error", delErr,
+				"upload_id", id,
+				"document_id", docID,
+			)
+		}
+		return nil, fmt.Errorf(
+			"%w: expected %s, stored %s",
+			ErrUploadChecksumMismatch,
+			checksum,
+			result.Document.ChecksumSha256,
+		)
+	}
+
+	if err := s.queries.CompleteUploadSession(ctx, session.ID, result.Document.ID); err != nil {
+		return nil, fmt.Errorf("failed to complete upload session: %w", err)
+	}
+	session.DocumentID = result.Document.ID
+
+	if err := s.storage.DeleteStaged(ctx, id); err != nil {
+		slog.Error("failed to remove staged chunks", "error", err, "upload_id", id)
+	}
+
+	return result, nil
+}
+
+// discard removes an upload session and its staged chunks, logging any failure
+func (s *UploadService) discard(ctx context.Context, session *sqlc.UploadSession) {
+	id := session.ID.String()
+	if err := s.storage.DeleteStaged(ctx, id); err != nil {
+		slog.Error("failed to remove staged chunks", "error", err, "upload_id", id)
+	}
+	if err := s.queries.DeleteUploadSession(ctx, session.ID); err != nil {
+		slog.Error("failed to remove upload session", "error", err, "upload_id", id)
+	}
+}
+
+// TerminateUpload cancels a resumable upload and removes its staged chunks
+func (s *UploadService) TerminateUpload(
+	ctx context.Context,
+	namespace string,
+	uploadID string,
+) error {
+	session, err := s.lookupUpload(ctx, namespace, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.DeleteStaged(ctx, session.ID.String()); err != nil {
+		return fmt.Errorf("failed to remove staged chunks: %w", err)
+	}
+	if err := s.queries.DeleteUploadSession(ctx, session.ID); err != nil {
+		return fmt.Errorf("failed to remove upload session: %w", err)
+	}
+	return nil
+}
+
+// ExpireUploads removes every upload session past its expiry along with its staged chunks
+func (s *UploadService) ExpireUploads(ctx context.Context) (int, error) {
+	sessions, err := s.queries.ListExpiredUploadSessions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired uploads: %w", err)
+	}
+
+	for i := range sessions {
+		s.discard(ctx, &sessions[i])
+	}
+	return len(sessions), nil
+}
+
+// RunExpirySweeper calls ExpireUploads on every interval until the context is cancelled
+func (s *UploadService) RunExpirySweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := s.ExpireUploads(ctx)
+			if err != nil {
+				slog.Error("failed to expire uploads", "error", err)
+				continue
+			}
+			if expired > 0 {
+				slog.Info("expired abandoned uploads", "count", expired)
+			}
+		}
+	}
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// ValidationViolation is one reason a value failed validation against a schema
+type ValidationViolation struct {
+	// Pointer is the JSON pointer (RFC 6901) to the failing value, e.g. /vendor/name, or
+	// empty for the whole value
+	Pointer string
+	// Keyword is the schema keyword the value failed, e.g. type or required
+	Keyword string
+	// Expected is the keyword's value in the schema, nil if there is none to show
+	Expected interface{}
+	// Actual is the failing value, or its type for the type keyword, nil if it's missing
+	Actual interface{}
+	// Message describes the failure
+	Message string
+}
+
+// ValidationError lists why attributes or a tag's schema failed validation. It matches the
+// error of what was validated, e.g. ErrAttributeValidationFailed.
+type ValidationError struct {
+	Err        error
+	Violations []ValidationViolation
+	// detail is the failure as the validator described it
+	detail string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Err, e.detail)
+}
+
+// Unwrap makes the error match the error of what was validated
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// newValidationError describes the failed validation of an instance against a schema, both
+// decoded JSON, with a violation for each innermost cause the validator reported. The
+// schema may be nil if it isn't at hand.
+func newValidationError(target error, err error, schema, instance interface{}) error {
+	result := &ValidationError{Err: target, detail: err.Error()}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		result.Violations = []ValidationViolation{{Message: err.Error()}}
+		return result
+	}
+	for _, cause := range innermostCauses(validationErr, nil) {
+		for _, violation := range violationsOf(cause, schema, instance) {
+			if !slices.ContainsFunc(result.Violations, func(v ValidationViolation) bool {
+				return v.Pointer == violation.Pointer && v.Keyword == violation.Keyword &&
+					v.Message == violation.Message
+			}) {
+				result.Violations = append(result.Violations, violation)
+			}
+		}
+	}
+	return result
+}
+
+// schemaKeywordError describes a tag's schema rejected because of a keyword at pointer
+func schemaKeywordError(pointer string, keyword string, format string, args ...interface{}) error {
+	detail := fmt.Sprintf(format, args...)
+	return &ValidationError{
+		Err:    ErrInvalidJSONSchema,
+		detail: detail,
+		Violations: []ValidationViolation{
+			{Pointer: pointer, Keyword: keyword, Message: detail},
+		},
+	}
+}
+
+// innermostCauses collects the causes of a validation error that have none themselves. A
+// value matching none of the alternatives of anyOf or oneOf is one cause, rather than the
+// reasons it fails each of them.
+func innermostCauses(
+	err *jsonschema.ValidationError,
+	causes []*jsonschema.ValidationError,
+) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 || slices.Contains(alternativeKeywords, validationKeyword(err)) {
+		return append(causes, err)
+	}
+	for _, cause := range err.Causes {
+		causes = innermostCauses(cause, causes)
+	}
+	return causes
+}
+
+// alternativeKeywords are the keywords requiring a value to match one of several schemas
+var alternativeKeywords = []string{"anyOf", "oneOf"}
+
+// validationKeyword returns the keyword a validation error was reported for
+func validationKeyword(err *jsonschema.ValidationError) string {
+	segments := strings.Split(jsonPointer(err.KeywordLocation), "/")
+	return unescapePointerToken(segments[len(segments)-1])
+}
+
+// quotedNameRegex matches the property names the validator quotes in its messages
+var quotedNameRegex = regexp.MustCompile(`'((?:[^'\\]|\\.)*)'`)
+
+// violationsOf converts a validation error without causes into violations. Missing and
+// disallowed properties are reported at their own pointers, one violation each.
+func violationsOf(
+	err *jsonschema.ValidationError,
+	schema, instance interface{},
+) []ValidationViolation {
+	keyword := validationKeyword(err)
+	instanceLocation := jsonPointer(err.InstanceLocation)
+
+	switch keyword {
+	case "required", "additionalProperties":
+		var violations []ValidationViolation
+		for _, match := range quotedNameRegex.FindAllStringSubmatch(err.Message, -1) {
+			// Names are quoted as Go strings, but in single quotes
+			name := match[1]
+			quoted := `"` + strings.NewReplacer(`\'`, `'`, `"`, `\"`).Replace(name) + `"`
+			if unquoted, unquoteErr := strconv.Unquote(quoted); unquoteErr == nil {
+				name = unquoted
+			}
+			violation := ValidationViolation{
+				Pointer: instanceLocation + "/" + escapePointerToken(name),
+				Keyword: keyword,
+				Message: "missing property",
+			}
+			if keyword == "additionalProperties" {
+				violation.Expected = false
+				violation.Actual, _ = resolvePointer(instance, violation.Pointer)
+				violation.Message = "property not allowed"
+			}
+			violations = append(violations, violation)
+		}
+		if len(violations) > 0 {
+			return violations
+		}
+	}
+
+	violation := ValidationViolation{
+		Pointer: instanceLocation,
+		Keyword: keyword,
+		Message: err.Message,
+	}
+	if slices.Contains(alternativeKeywords, keyword) && len(err.Causes) > 0 {
+		violation.Message = "does not match any of the allowed schemas"
+	}
+	if _, fragment, ok := strings.Cut(err.AbsoluteKeywordLocation, "#"); ok && schema != nil {
+		violation.Expected, _ = resolvePointer(schema, jsonPointer(fragment))
+	}
+	actual, ok := resolvePointer(instance, instanceLocation)
+	if ok && keyword == "type" {
+		actual = jsonType(actual)
+	}
+	violation.Actual = actual
+	return []ValidationViolation{violation}
+}
+
+// jsonPointer converts a location reported by the validator, which also URL escapes its
+// tokens, to a JSON pointer
+func jsonPointer(location string) string {
+	tokens := strings.Split(location, "/")
+	for i, token := range tokens {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			tokens[i] = unescaped
+		}
+	}
+	return strings.Join(tokens, "/")
+}
+
+// resolvePointer returns the value a JSON pointer refers to in a decoded JSON value
+func resolvePointer(value interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return value, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapePointerToken(token)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			item, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			value = item
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			value = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// escapePointerToken escapes a property name for a JSON pointer
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// unescapePointerToken reverses escapePointerToken
+func unescapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
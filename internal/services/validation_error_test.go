@@ -0,0 +1,98 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeValidationError(t *testing.T) {
+	compiled, err := compileSchema([]byte(`{
+		"type": "object",
+		"x-structured-attributes": true,
+		"properties": {
+			"total": {"type": "number", "minimum": 0},
+			"status": {"type": "string", "enum": ["open", "paid"]},
+			"vendor": {
+				"type": "object",
+				"properties": {"name/short": {"type": "string"}},
+				"required": ["name/short"]
+			},
+			"lines": {"type": "array", "items": {"type": "number"}}
+		},
+		"required": ["total", "status"],
+		"additionalProperties": false
+	}`))
+	require.NoError(t, err)
+
+	err = compiled.validate(map[string]interface{}{
+		"total":  -1.0,
+		"vendor": map[string]interface{}{},
+		"lines":  []interface{}{1.0, "two"},
+		"notes":  "late",
+	})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, ErrAttributeValidationFailed)
+	assert.ElementsMatch(t, []ValidationViolation{
+		{Pointer: "/status", Keyword: "required", Message: "missing property"},
+		{Pointer: "/notes", Keyword: "additionalProperties", Expected: false, Actual: "late",
+			Message: "property not allowed"},
+		{Pointer: "/total", Keyword: "minimum", Expected: 0.0, Actual: -1.0,
+			Message: "must be >= 0 but found -1"},
+		{Pointer: "/vendor/name~1short", Keyword: "required", Message: "missing property"},
+		{Pointer: "/lines/1", Keyword: "type", Expected: "number", Actual: "string",
+			Message: "expected number, but got string"},
+	}, validationErr.Violations)
+}
+
+func TestSchemaValidationError(t *testing.T) {
+	err := validateAttributeSchema(`{
+		"type": "object",
+		"properties": {"vendor": {"type": "object"}}
+	}`)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, ErrNestedTypesNotAllowed)
+	require.Len(t, validationErr.Violations, 1)
+	assert.Equal(t, ValidationViolation{
+		Pointer: "/properties/vendor",
+		Keyword: "anyOf",
+		Expected: []interface{}{
+			map[string]interface{}{"$ref": "#/$defs/primitive"},
+			map[string]interface{}{"$ref": "#/$defs/array"},
+			map[string]interface{}{"$ref": "#/$defs/object"},
+		},
+		Actual:  map[string]interface{}{"type": "object"},
+		Message: "does not match any of the allowed schemas",
+	}, validationErr.Violations[0])
+
+	err = validateAttributeSchema(`{
+		"type": "object",
+		"properties": {"total": {"type": "number", "x-computed": "net +"}}
+	}`)
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, ErrInvalidJSONSchema)
+	require.Len(t, validationErr.Violations, 1)
+	assert.Equal(t, "/properties/total/x-computed", validationErr.Violations[0].Pointer)
+	assert.Equal(t, computedKeyword, validationErr.Violations[0].Keyword)
+}
+
+func TestResolvePointer(t *testing.T) {
+	value := map[string]interface{}{
+		"a/b": map[string]interface{}{"c~d": []interface{}{1.0, 2.0}},
+	}
+	resolved, ok := resolvePointer(value, "/a~1b/c~0d/1")
+	require.True(t, ok)
+	assert.Equal(t, 2.0, resolved)
+
+	resolved, ok = resolvePointer(value, "")
+	require.True(t, ok)
+	assert.Equal(t, value, resolved)
+
+	_, ok = resolvePointer(value, "/a~1b/c~0d/2")
+	assert.False(t, ok)
+	_, ok = resolvePointer(value, "/missing")
+	assert.False(t, ok)
+}
@@ -0,0 +1,458 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/RynoXLI/Wayfile/internal/auth"
+	"github.com/RynoXLI/Wayfile/internal/db/sqlc"
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+var (
+	// ErrWebhookNotFound is returned when a webhook doesn't exist in the namespace
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is returned when a delivery doesn't exist in the namespace
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrInvalidWebhook is returned when a webhook's URL or event types are invalid
+	ErrInvalidWebhook = errors.New("invalid webhook")
+	// ErrDeliveryNotDead is returned when retrying a delivery that isn't dead-lettered
+	ErrDeliveryNotDead = errors.New("webhook delivery is not dead-lettered")
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead"
+)
+
+// Headers sent with every webhook delivery, alongside auth.SignatureHeader
+const (
+	WebhookEventHeader    = "Wayfile-Event"
+	WebhookDeliveryHeader = "Wayfile-Delivery"
+)
+
+const (
+	// webhookBatchSize is the number of due deliveries claimed at a time
+	webhookBatchSize = 20
+	// webhookLease is how long a claimed delivery is hidden from other workers
+	webhookLease = 5 * time.Minute
+	// webhookSecretBytes is the size of generated signing secrets
+	webhookSecretBytes = 32
+	// webhookMaxErrorBody is how much of a failed response is kept in the delivery log
+	webhookMaxErrorBody = 1024
+)
+
+// WebhookService manages webhook subscriptions and delivers events to them. Events
+// consumed from JetStream are queued per matching webhook, then POSTed by a background
+// worker with exponential backoff until they succeed or are dead-lettered.
+type WebhookService struct {
+	queries        *sqlc.Queries
+	client         *http.Client
+	maxAttempts    int32
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	wake           chan struct{}
+}
+
+// NewWebhookService creates a new webhook service. Deliveries are dead-lettered after
+// maxAttempts; the wait between attempts doubles from initialBackoff up to maxBackoff.
+func NewWebhookService(
+	queries *sqlc.Queries,
+	client *http.Client,
+	maxAttempts int32,
+	initialBackoff time.Duration,
+	maxBackoff time.Duration,
+) *WebhookService {
+	return &WebhookService{
+		queries:        queries,
+		client:         client,
+		maxAttempts:    maxAttempts,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+// CreateWebhook subscribes url to events of the given types in a namespace, or to every
+// event when eventTypes is empty. A signing secret is generated when secret is empty.
+func (s *WebhookService) CreateWebhook(
+	ctx context.Context,
+	namespace string,
+	rawURL string,
+	eventTypes []string,
+	secret string,
+) (*sqlc.Webhook, error) {
+	if err := validateWebhook(rawURL, eventTypes); err != nil {
+		return nil, err
+	}
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	if secret == "" {
+		key := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		secret = base64.RawURLEncoding.EncodeToString(key)
+	}
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	webhook, err := s.queries.CreateWebhook(ctx, ns.ID, rawURL, eventTypes, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// validateWebhook checks a webhook's URL and event type filter
+func validateWebhook(rawURL string, eventTypes []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	for _, eventType := range eventTypes {
+		if !events.IsEventType(eventType) {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, eventType)
+		}
+	}
+	return nil
+}
+
+// ListWebhooks retrieves the webhooks in a namespace
+func (s *WebhookService) ListWebhooks(
+	ctx context.Context,
+	namespace string,
+) ([]sqlc.Webhook, error) {
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+	return s.queries.ListWebhooks(ctx, ns.ID)
+}
+
+// GetWebhook retrieves a webhook within a namespace
+func (s *WebhookService) GetWebhook(
+	ctx context.Context,
+	namespace string,
+	webhookID string,
+) (*sqlc.Webhook, error) {
+	id, err := uuid.Parse(webhookID)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	webhook, err := s.queries.GetWebhook(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	if webhook.NamespaceID != ns.ID {
+		return nil, ErrWebhookNotFound
+	}
+	return &webhook, nil
+}
+
+// DeleteWebhook removes a webhook along with its delivery log
+func (s *WebhookService) DeleteWebhook(
+	ctx context.Context,
+	namespace string,
+	webhookID string,
+) error {
+	webhook, err := s.GetWebhook(ctx, namespace, webhookID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.queries.DeleteWebhook(ctx, webhook.ID, webhook.NamespaceID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// ListDeliveries retrieves a webhook's delivery log, newest first, optionally limited to
+// one status
+func (s *WebhookService) ListDeliveries(
+	ctx context.Context,
+	namespace string,
+	webhookID string,
+	status *string,
+	limit int32,
+	offset int32,
+) ([]sqlc.WebhookDelivery, error) {
+	webhook, err := s.GetWebhook(ctx, namespace, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return s.queries.ListWebhookDeliveries(ctx, webhook.ID, status, offset, limit)
+}
+
+// RetryDelivery requeues a dead-lettered delivery with a fresh set of attempts
+func (s *WebhookService) RetryDelivery(
+	ctx context.Context,
+	namespace string,
+	deliveryID string,
+) (*sqlc.WebhookDelivery, error) {
+	id, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	delivery, err := s.queries.GetWebhookDelivery(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	// Deliveries are only visible through a webhook in the namespace
+	if _, err := s.GetWebhook(ctx, namespace, delivery.WebhookID.String()); err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	requeued, err := s.queries.RequeueWebhookDelivery(ctx, delivery.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeliveryNotDead
+		}
+		return nil, err
+	}
+	s.notify()
+	return &requeued, nil
+}
+
+// HandleEvent queues an event consumed from JetStream for delivery to every matching
+// webhook in its namespace. It is an events.MessageHandler.
+func (s *WebhookService) HandleEvent(ctx context.Context, subject string, data []byte) error {
+	event, err := events.DecodeEvent(subject, data)
+	if err != nil {
+		// Redelivering won't help
+		slog.Error("skipping undecodable event", "subject", subject, "error", err)
+		return nil
+	}
+
+	ns, err := s.queries.GetNamespaceByName(ctx, event.Namespace)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The namespace, and with it its webhooks, is gone
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	webhooks, err := s.queries.ListWebhooksForEvent(ctx, ns.ID, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := buildWebhookPayload(event)
+	if err != nil {
+		slog.Error("skipping event", "subject", subject, "error", err)
+		return nil
+	}
+	eventID := event.Context().GetEventId()
+	for _, webhook := range webhooks {
+		if err := s.queries.QueueWebhookDelivery(
+			ctx,
+			webhook.ID,
+			eventID,
+			event.Type,
+			body,
+		); err != nil {
+			return fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+	}
+	s.notify()
+	return nil
+}
+
+// buildWebhookPayload renders an event as the JSON body POSTed to webhooks
+func buildWebhookPayload(event *events.Event) ([]byte, error) {
+	// Receivers deduplicate on the event ID
+	if event.Context().GetEventId() == "" {
+		return nil, errors.New("event has no ID")
+	}
+	return event.MarshalJSON()
+}
+
+// notify wakes the delivery worker without blocking
+func (s *WebhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RunWorker delivers due webhook events until the context is cancelled. It wakes
+// immediately when events are queued and otherwise polls every interval for retries.
+func (s *WebhookService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			delivered, err := s.DeliverDue(ctx)
+			if err != nil {
+				slog.Error("failed to deliver webhooks", "error", err)
+				break
+			}
+			if delivered == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// DeliverDue claims a batch of due deliveries and attempts each once. It returns the
+// number of deliveries attempted.
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.queries.ClaimWebhookDeliveries(ctx, pgtype.Timestamptz{
+		Time:  time.Now().Add(webhookLease),
+		Valid: true,
+	}, webhookBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		if err := s.deliver(ctx, &delivery); err != nil {
+			return 0, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// deliver POSTs a delivery and records the outcome
+func (s *WebhookService) deliver(
+	ctx context.Context,
+	delivery *sqlc.ClaimWebhookDeliveriesRow,
+) error {
+	statusCode, sendErr := s.send(ctx, delivery)
+	if ctx.Err() != nil {
+		// Shutting down; the delivery is retried once its lease expires
+		return ctx.Err()
+	}
+	var responseStatus *int32
+	if statusCode != 0 {
+		code := int32(statusCode)
+		responseStatus = &code
+	}
+
+	if sendErr == nil {
+		if err := s.queries.MarkWebhookDeliverySucceeded(ctx, responseStatus, delivery.ID); err != nil {
+			return fmt.Errorf("failed to record webhook delivery: %w", err)
+		}
+		return nil
+	}
+
+	attempts := delivery.Attempts + 1
+	status := WebhookDeliveryPending
+	if attempts >= s.maxAttempts {
+		status = WebhookDeliveryDead
+		slog.Warn(
+			"webhook delivery dead-lettered",
+			"delivery_id", delivery.ID.String(),
+			"url", delivery.Url,
+			"attempts", attempts,
+			"error", sendErr,
+		)
+	}
+	message := sendErr.Error()
+	nextAttempt := time.Now().Add(webhookBackoff(attempts, s.initialBackoff, s.maxBackoff))
+	if err := s.queries.MarkWebhookDeliveryFailed(
+		ctx,
+		status,
+		responseStatus,
+		&message,
+		pgtype.Timestamptz{Time: nextAttempt, Valid: true},
+		delivery.ID,
+	); err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// send POSTs a signed delivery, returning the response status when there was one
+func (s *WebhookService) send(
+	ctx context.Context,
+	delivery *sqlc.ClaimWebhookDeliveriesRow,
+) (int, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		delivery.Url,
+		bytes.NewReader(delivery.Payload),
+	)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Wayfile-Webhooks/1")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(
+		auth.SignatureHeader,
+		auth.NewSigner(delivery.Secret).SignPayload(time.Now(), delivery.Payload),
+	)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxErrorBody))
+		return resp.StatusCode, fmt.Errorf("webhook responded %s: %s", resp.Status, snippet)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// webhookBackoff returns the wait after a delivery's attempts-th failed attempt: initial,
+// doubling each attempt, capped at max
+func webhookBackoff(attempts int32, initial time.Duration, maxBackoff time.Duration) time.Duration {
+	backoff := initial
+	for i := int32(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventsv1 "github.com/RynoXLI/Wayfile/gen/go/events/v1"
+	"github.com/RynoXLI/Wayfile/internal/events"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{50, time.Hour},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, webhookBackoff(tt.attempts, 30*time.Second, time.Hour))
+	}
+}
+
+func TestValidateWebhook(t *testing.T) {
+	assert.NoError(t, validateWebhook("https://example.com/hook", nil))
+	assert.NoError(t, validateWebhook("http://localhost:9000", []string{events.TagExtracted}))
+
+	invalid := []struct {
+		url        string
+		eventTypes []string
+	}{
+		{"example.com/hook", nil},
+		{"ftp://example.com", nil},
+		{"https://", nil},
+		{"https://example.com", []string{"documents.exploded"}},
+	}
+	for _, tt := range invalid {
+		assert.ErrorIs(t, validateWebhook(tt.url, tt.eventTypes), ErrInvalidWebhook, tt.url)
+	}
+}
+
+func TestBuildWebhookPayload(t *testing.T) {
+	occurredAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := &events.Event{
+		Type:      events.DocumentUploaded,
+		Namespace: "acme",
+		Message: &eventsv1.DocumentUploadedEvent{
+			DocumentId: "doc-1",
+			Namespace:  "acme",
+			Filename:   "a.txt",
+			Context: &eventsv1.EventContext{
+				EventId:    "event-1",
+				OccurredAt: timestamppb.New(occurredAt),
+				Actor:      "api",
+			},
+		},
+	}
+
+	body, err := buildWebhookPayload(event)
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "event-1", payload["id"])
+	assert.Equal(t, "documents.uploaded", payload["type"])
+	assert.Equal(t, "acme", payload["namespace"])
+	assert.Equal(t, "2026-01-02T03:04:05Z", payload["occurred_at"])
+	assert.Equal(t, "api", payload["actor"])
+
+	data := payload["data"].(map[string]any)
+	assert.Equal(t, "doc-1", data["document_id"])
+	assert.Equal(t, "a.txt", data["filename"])
+	assert.NotContains(t, data, "context")
+
+	// Events without an ID can't be deduplicated by receivers
+	_, err = buildWebhookPayload(&events.Event{
+		Type:    events.DocumentUploaded,
+		Message: &eventsv1.DocumentUploadedEvent{},
+	})
+	assert.Error(t, err)
+}
@@ -65,6 +65,37 @@ func (l *LocalStorage) Download(
 	return file, err
 }
 
+// DownloadRange retrieves length bytes of a file from local storage starting at offset
+func (l *LocalStorage) DownloadRange(
+	_ context.Context,
+	namespaceID string,
+	documentID string,
+	filename string,
+	offset int64,
+	length int64,
+) (io.ReadCloser, error) {
+	fullPath := filepath.Join(l.basePath, namespaceID, documentID, filename)
+	file, err := os.Open(fullPath)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &rangeReadCloser{Reader: io.LimitReader(file, length), Closer: file}, nil
+}
+
+// rangeReadCloser limits reads to a range while closing the underlying file
+type rangeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
 // Delete removes a file from local storage
 func (l *LocalStorage) Delete(
 	_ context.Context,
@@ -80,3 +111,12 @@ func (l *LocalStorage) Delete(
 	}
 	return err
 }
+
+// DeletePrefix removes a document folder with every file in it
+func (l *LocalStorage) DeletePrefix(
+	_ context.Context,
+	namespaceID string,
+	documentID string,
+) error {
+	return os.RemoveAll(filepath.Join(l.basePath, namespaceID, documentID))
+}
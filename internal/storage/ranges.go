@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRange is returned when a Range header is malformed and should be ignored
+var ErrInvalidRange = errors.New("invalid range")
+
+// ErrRangeNotSatisfiable is returned when none of the requested ranges overlap the content
+var ErrRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// maxRanges caps how many ranges a single request may ask for
+const maxRanges = 16
+
+// ByteRange is a contiguous span of content
+type ByteRange struct {
+	Start  int64
+	Length int64
+}
+
+// ContentRange formats the range as a Content-Range header value for content of the given size
+func (r ByteRange) ContentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.Start, r.Start+r.Length-1, size)
+}
+
+// ParseRange parses an HTTP Range header (RFC 9110 section 14.2) against content of the
+// given size. Unsatisfiable ranges are dropped; if none remain ErrRangeNotSatisfiable is
+// returned. Malformed headers, unknown units and excessive range counts yield ErrInvalidRange,
+// in which case the header should be ignored and the full content served.
+func ParseRange(header string, size int64) ([]ByteRange, error) {
+	unit, spec, ok := strings.Cut(header, "=")
+	if !ok || strings.TrimSpace(unit) != "bytes" {
+		return nil, ErrInvalidRange
+	}
+
+	specs := strings.Split(spec, ",")
+	if len(specs) > maxRanges {
+		return nil, ErrInvalidRange
+	}
+
+	ranges := make([]ByteRange, 0, len(specs))
+	parsed := 0
+	for _, s := range specs {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		parsed++
+
+		first, last, ok := strings.Cut(s, "-")
+		if !ok {
+			return nil, ErrInvalidRange
+		}
+		first, last = strings.TrimSpace(first), strings.TrimSpace(last)
+
+		if first == "" {
+			// Suffix range: the last N bytes
+			n, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || n < 0 {
+				return nil, ErrInvalidRange
+			}
+			if n == 0 || size == 0 {
+				continue
+			}
+			n = min(n, size)
+			ranges = append(ranges, ByteRange{Start: size - n, Length: n})
+			continue
+		}
+
+		start, err := strconv.ParseInt(first, 10, 64)
+		if err != nil || start < 0 {
+			return nil, ErrInvalidRange
+		}
+		end := size - 1
+		if last != "" {
+			end, err = strconv.ParseInt(last, 10, 64)
+			if err != nil || end < start {
+				return nil, ErrInvalidRange
+			}
+			end = min(end, size-1)
+		}
+		if start >= size {
+			continue
+		}
+		ranges = append(ranges, ByteRange{Start: start, Length: end - start + 1})
+	}
+
+	if parsed == 0 {
+		return nil, ErrInvalidRange
+	}
+	if len(ranges) == 0 {
+		return nil, ErrRangeNotSatisfiable
+	}
+	return ranges, nil
+}
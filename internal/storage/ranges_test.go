@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		size    int64
+		want    []ByteRange
+		wantErr error
+	}{
+		{
+			name:   "closed range",
+			header: "bytes=0-499",
+			size:   1000,
+			want:   []ByteRange{{Start: 0, Length: 500}},
+		},
+		{
+			name:   "open ended range",
+			header: "bytes=900-",
+			size:   1000,
+			want:   []ByteRange{{Start: 900, Length: 100}},
+		},
+		{
+			name:   "suffix range",
+			header: "bytes=-100",
+			size:   1000,
+			want:   []ByteRange{{Start: 900, Length: 100}},
+		},
+		{
+			name:   "suffix longer than content",
+			header: "bytes=-5000",
+			size:   1000,
+			want:   []ByteRange{{Start: 0, Length: 1000}},
+		},
+		{
+			name:   "end past content is clamped",
+			header: "bytes=990-2000",
+			size:   1000,
+			want:   []ByteRange{{Start: 990, Length: 10}},
+		},
+		{
+			name:   "multiple ranges with whitespace",
+			header: "bytes=0-9, 20-29",
+			size:   100,
+			want:   []ByteRange{{Start: 0, Length: 10}, {Start: 20, Length: 10}},
+		},
+		{
+			name:   "unsatisfiable ranges are dropped",
+			header: "bytes=0-9,5000-6000",
+			size:   100,
+			want:   []ByteRange{{Start: 0, Length: 10}},
+		},
+		{
+			name:    "start past content",
+			header:  "bytes=1000-",
+			size:    1000,
+			wantErr: ErrRangeNotSatisfiable,
+		},
+		{
+			name:    "empty content",
+			header:  "bytes=0-",
+			size:    0,
+			wantErr: ErrRangeNotSatisfiable,
+		},
+		{
+			name:    "unknown unit",
+			header:  "items=0-5",
+			size:    100,
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "end before start",
+			header:  "bytes=10-5",
+			size:    100,
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "not a number",
+			header:  "bytes=a-b",
+			size:    100,
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "no ranges",
+			header:  "bytes=",
+			size:    100,
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "too many ranges",
+			header:  "bytes=0-0,1-1,2-2,3-3,4-4,5-5,6-6,7-7,8-8,9-9,10-10,11-11,12-12,13-13,14-14,15-15,16-16",
+			size:    100,
+			wantErr: ErrInvalidRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRange(tt.header, tt.size)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("range %d: expected %v, got %v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestByteRangeContentRange(t *testing.T) {
+	r := ByteRange{Start: 10, Length: 5}
+	if got := r.ContentRange(100); got != "bytes 10-14/100" {
+		t.Errorf("unexpected Content-Range %q", got)
+	}
+}
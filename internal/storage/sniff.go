@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// sniffLen is the number of leading bytes inspected by http.DetectContentType
+const sniffLen = 512
+
+// SniffContentType detects the MIME type of a stream from its leading bytes. It returns the
+// detected type and a reader that replays the consumed bytes followed by the rest of data.
+func SniffContentType(data io.Reader) (string, io.Reader, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(data, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), data), nil
+}
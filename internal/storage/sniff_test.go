@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSniffContentType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"plain text", []byte("hello world"), "text/plain; charset=utf-8"},
+		{
+			"html",
+			[]byte("<!DOCTYPE html><html><script>alert(1)</script>"),
+			"text/html; charset=utf-8",
+		},
+		{"pdf", []byte("%PDF-1.7\n"), "application/pdf"},
+		{"png", []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR"), "image/png"},
+		{"empty", nil, "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detected, reader, err := SniffContentType(bytes.NewReader(tt.content))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, detected)
+
+			replayed, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.content), len(replayed))
+		})
+	}
+}
+
+func TestSniffContentTypePreservesLongStreams(t *testing.T) {
+	content := strings.Repeat("0123456789", 200)
+	_, reader, err := SniffContentType(strings.NewReader(content))
+	require.NoError(t, err)
+
+	replayed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, content, string(replayed))
+}
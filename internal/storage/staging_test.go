@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorage(t *testing.T) *Storage {
+	client, err := NewLocalStorage(t.TempDir(), slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+	return NewStorage(client, nil, slog.New(slog.DiscardHandler))
+}
+
+func TestStageChunkAttempt(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t)
+
+	// Two attempts at the same index keep their own bytes
+	first, err := s.StageChunkAttempt(ctx, "upload", 0, strings.NewReader("hello "))
+	require.NoError(t, err)
+	lost, err := s.StageChunkAttempt(ctx, "upload", 1, strings.NewReader("there"))
+	require.NoError(t, err)
+	won, err := s.StageChunkAttempt(ctx, "upload", 1, strings.NewReader("world"))
+	require.NoError(t, err)
+	require.NotEqual(t, lost, won)
+
+	r := s.OpenStagedChunks(ctx, "upload", []string{first, won})
+	defer func() { _ = r.Close() }()
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(content))
+}
+
+func TestDeleteStaged(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t)
+
+	first, err := s.StageChunkAttempt(ctx, "upload", 0, strings.NewReader("hello "))
+	require.NoError(t, err)
+	second, err := s.StageChunkAttempt(ctx, "upload", 1, strings.NewReader("world"))
+	require.NoError(t, err)
+
+	// Every chunk is removed, and removing them again is a no-op
+	require.NoError(t, s.DeleteStaged(ctx, "upload"))
+	require.NoError(t, s.DeleteStaged(ctx, "upload"))
+	for _, name := range []string{first, second} {
+		r := s.OpenStagedChunks(ctx, "upload", []string{name})
+		_, err := io.ReadAll(r)
+		require.ErrorIs(t, err, ErrNotFound)
+		_ = r.Close()
+	}
+}
+
+func TestOpenStagedAt(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t)
+
+	content := "0123456789abcdefghijklmnopqrstuvwxyz"
+	require.NoError(t, s.StageChunk(ctx, "upload", 0, strings.NewReader(content)))
+
+	r := s.OpenStagedAt(ctx, "upload", int64(len(content)))
+	defer func() { _ = r.Close() }()
+
+	// Sequential, random and trailing reads
+	buf := make([]byte, 4)
+	for _, tc := range []struct {
+		off  int64
+		want string
+		err  error
+	}{
+		{0, "0123", nil},
+		{4, "4567", nil},
+		{20, "klmn", nil},
+		{34, "yz", io.EOF},
+		{36, "", io.EOF},
+	} {
+		n, err := r.ReadAt(buf, tc.off)
+		require.Equal(t, tc.want, string(buf[:n]), "offset %d", tc.off)
+		require.Equal(t, tc.err, err, "offset %d", tc.off)
+	}
+}
+
+func TestOpenStagedAtReadsZip(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t)
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, name := range []string{"a.txt", "dir/b.txt"} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(strings.Repeat(name, 1000)))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	size := int64(archive.Len())
+	require.NoError(t, s.StageChunk(ctx, "archive", 0, &archive))
+
+	r := s.OpenStagedAt(ctx, "archive", size)
+	defer func() { _ = r.Close() }()
+
+	zr, err := zip.NewReader(r, size)
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, strings.Repeat(f.Name, 1000), string(data))
+		_ = rc.Close()
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -31,7 +33,20 @@ type Client interface {
 		documentID string,
 		filename string,
 	) (io.ReadCloser, error)
+	// DownloadRange retrieves length bytes of a file starting at offset. Backends should
+	// read only the requested span, e.g. by seeking locally or with a ranged GET.
+	DownloadRange(
+		ctx context.Context,
+		namespaceID string,
+		documentID string,
+		filename string,
+		offset int64,
+		length int64,
+	) (io.ReadCloser, error)
 	Delete(ctx context.Context, namespaceID string, documentID string, filename string) error
+	// DeletePrefix removes every file stored under a document, e.g. all staged chunks of an
+	// upload. Removing a prefix with no files is not an error.
+	DeletePrefix(ctx context.Context, namespaceID string, documentID string) error
 }
 
 // Storage is the backend for managing document storage
@@ -54,6 +69,15 @@ func NewStorage(
 	}
 }
 
+// WithTx returns a Storage that records document metadata within tx
+func (s *Storage) WithTx(tx pgx.Tx) *Storage {
+	return &Storage{
+		client:  s.client,
+		queries: s.queries.WithTx(tx),
+		logger:  s.logger,
+	}
+}
+
 // validateDocument checks if document exists and namespace matches
 func (s *Storage) validateDocument(
 	ctx context.Context,
@@ -98,6 +122,12 @@ func (s *Storage) Upload(ctx context.Context,
 	data io.Reader) (*UploadResult, error) {
 	docID := uuid.New()
 
+	// Sniff the content type from the leading bytes; the declared type is client-supplied
+	detectedMimeType, data, err := SniffContentType(data)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate checksum while uploading using TeeReader
 	hash := sha256.New()
 	teeReader := io.TeeReader(data, hash)
@@ -146,12 +176,13 @@ func (s *Storage) Upload(ctx context.Context,
 	// Submit metadata to postgres
 	doc, err := s.queries.CreateDocument(ctx,
 		pgDocID,
-		ns.ID,           // namespace_id
-		filename,        // file_name
-		filename,        // title
-		mimeType,        // mime_type
-		checkSum,        // checksum_sha256
-		int64(fileSize), // file_size
+		ns.ID,             // namespace_id
+		filename,          // file_name
+		filename,          // title
+		mimeType,          // mime_type
+		&detectedMimeType, // detected_mime_type
+		checkSum,          // checksum_sha256
+		int64(fileSize),   // file_size
 	)
 	if err != nil {
 		shouldCleanup = true
@@ -170,6 +201,16 @@ func (s *Storage) Upload(ctx context.Context,
 	}, nil
 }
 
+// Discard removes the stored file of an upload whose database record was rolled back
+func (s *Storage) Discard(ctx context.Context, result *UploadResult) error {
+	return s.client.Delete(
+		ctx,
+		result.NamespaceID,
+		result.Document.ID.String(),
+		result.Document.FileName,
+	)
+}
+
 // GetNamespaceID retrieves the namespace UUID by name
 func (s *Storage) GetNamespaceID(ctx context.Context, namespace string) (string, error) {
 	ns, err := s.queries.GetNamespaceByName(ctx, namespace)
@@ -199,25 +240,224 @@ func (s *Storage) Download(ctx context.Context,
 	return fileReader, doc, nil
 }
 
-// Delete removes a document from storage
-func (s *Storage) Delete(ctx context.Context,
+// GetDocument retrieves a document's metadata after checking it belongs to the namespace
+func (s *Storage) GetDocument(
+	ctx context.Context,
 	namespace string,
-	documentID string) error {
-	doc, err := s.validateDocument(ctx, namespace, documentID)
-	if err != nil {
-		return err
-	}
+	documentID string,
+) (*sqlc.Document, error) {
+	return s.validateDocument(ctx, namespace, documentID)
+}
 
+// Open retrieves the full content of a document previously resolved from the database
+func (s *Storage) Open(ctx context.Context, doc *sqlc.Document) (io.ReadCloser, error) {
 	namespaceUUID, _ := uuid.Parse(doc.NamespaceID.String())
-	err = s.client.Delete(ctx, namespaceUUID.String(), documentID, doc.FileName)
-	if err != nil {
-		return err
+	documentUUID, _ := uuid.Parse(doc.ID.String())
+	return s.client.Download(ctx, namespaceUUID.String(), documentUUID.String(), doc.FileName)
+}
+
+// DownloadRange retrieves part of a document's content. The document is expected to
+// have been resolved with GetDocument.
+func (s *Storage) DownloadRange(
+	ctx context.Context,
+	doc *sqlc.Document,
+	r ByteRange,
+) (io.ReadCloser, error) {
+	namespaceUUID, _ := uuid.Parse(doc.NamespaceID.String())
+	documentUUID, _ := uuid.Parse(doc.ID.String())
+	return s.client.DownloadRange(
+		ctx,
+		namespaceUUID.String(),
+		documentUUID.String(),
+		doc.FileName,
+		r.Start,
+		r.Length,
+	)
+}
+
+// DeleteFile removes the stored file of a document whose record has been deleted
+func (s *Storage) DeleteFile(ctx context.Context, doc *sqlc.Document) error {
+	return s.client.Delete(ctx, doc.NamespaceID.String(), doc.ID.String(), doc.FileName)
+}
+
+// stagingNamespace is the storage prefix for in-progress resumable uploads.
+// Namespace IDs are UUIDs, so this can never collide with a real namespace.
+const stagingNamespace = "_staging"
+
+// stagedChunkName returns the storage filename of the chunk at the given index
+func stagedChunkName(index int32) string {
+	return fmt.Sprintf("chunk-%08d", index)
+}
+
+// StageChunk writes one chunk of a resumable upload to the staging area.
+// Writing the same index again replaces the previous chunk.
+func (s *Storage) StageChunk(
+	ctx context.Context,
+	uploadID string,
+	index int32,
+	data io.Reader,
+) error {
+	return s.client.Upload(ctx, stagingNamespace, uploadID, stagedChunkName(index), data)
+}
+
+// StageChunkAttempt writes one chunk of a resumable upload to the staging area under a
+// name unique to this call, and returns the name. Concurrent writers of the same index
+// never replace each other's chunk; the upload records the name of the one it accepted.
+func (s *Storage) StageChunkAttempt(
+	ctx context.Context,
+	uploadID string,
+	index int32,
+	data io.Reader,
+) (string, error) {
+	name := fmt.Sprintf("%s-%s", stagedChunkName(index), uuid.NewString())
+	if err := s.client.Upload(ctx, stagingNamespace, uploadID, name, data); err != nil {
+		return "", err
 	}
-	var pgDocID pgtype.UUID
-	_ = pgDocID.Scan(documentID)
-	err = s.queries.DeleteDocument(ctx, pgDocID)
-	if err != nil {
-		return err
+	return name, nil
+}
+
+// OpenStaged returns a reader that streams the staged chunks of an upload in order
+func (s *Storage) OpenStaged(ctx context.Context, uploadID string, chunks int32) io.ReadCloser {
+	names := make([]string, chunks)
+	for i := range names {
+		names[i] = stagedChunkName(int32(i))
+	}
+	return s.OpenStagedChunks(ctx, uploadID, names)
+}
+
+// OpenStagedChunks returns a reader that streams the named staged chunks of an upload in order
+func (s *Storage) OpenStagedChunks(
+	ctx context.Context,
+	uploadID string,
+	names []string,
+) io.ReadCloser {
+	return &stagedReader{ctx: ctx, client: s.client, uploadID: uploadID, names: names}
+}
+
+// StagedReaderAt provides random access to an object staged as a single chunk, as needed to
+// read formats such as ZIP whose index is at the end
+type StagedReaderAt interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// OpenStagedAt returns random access to the first staged chunk of size bytes
+func (s *Storage) OpenStagedAt(ctx context.Context, uploadID string, size int64) StagedReaderAt {
+	return &stagedReaderAt{ctx: ctx, client: s.client, uploadID: uploadID, size: size}
+}
+
+// DeleteStaged removes every staged chunk of an upload
+func (s *Storage) DeleteStaged(ctx context.Context, uploadID string) error {
+	return s.client.DeletePrefix(ctx, stagingNamespace, uploadID)
+}
+
+// stagedReader concatenates staged chunks, opening each one only when it is reached
+type stagedReader struct {
+	ctx      context.Context
+	client   Client
+	uploadID string
+	names    []string
+	next     int
+	current  io.ReadCloser
+}
+
+func (r *stagedReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if r.next >= len(r.names) {
+				return 0, io.EOF
+			}
+			rc, err := r.client.Download(r.ctx, stagingNamespace, r.uploadID, r.names[r.next])
+			if err != nil {
+				return 0, err
+			}
+			r.current = rc
+			r.next++
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			if closeErr := r.current.Close(); closeErr != nil {
+				return n, closeErr
+			}
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (r *stagedReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+// stagedReaderAt serves ReadAt from ranged downloads. Reads continuing where the previous
+// one ended reuse the open range, so front-to-back access doesn't reopen the object per call.
+type stagedReaderAt struct {
+	ctx      context.Context
+	client   Client
+	uploadID string
+	size     int64
+
+	mu      sync.Mutex
+	current io.ReadCloser
+	pos     int64
+}
+
+func (r *stagedReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if off >= r.size {
+		return 0, io.EOF
 	}
-	return nil
+	if r.current == nil || off != r.pos {
+		if err := r.closeCurrent(); err != nil {
+			return 0, err
+		}
+		rc, err := r.client.DownloadRange(
+			r.ctx,
+			stagingNamespace,
+			r.uploadID,
+			stagedChunkName(0),
+			off,
+			r.size-off,
+		)
+		if err != nil {
+			return 0, err
+		}
+		r.current = rc
+		r.pos = off
+	}
+
+	n, err := io.ReadFull(r.current, p)
+	r.pos += int64(n)
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		_ = r.closeCurrent()
+		return n, io.EOF
+	}
+	return n, err
+}
+
+func (r *stagedReaderAt) closeCurrent() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+func (r *stagedReaderAt) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closeCurrent()
 }
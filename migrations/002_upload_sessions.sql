@@ -0,0 +1,31 @@
+-- Write your migrate up statements here
+
+-- Resumable (tus) upload sessions. Chunks are staged in storage until the
+-- final byte arrives, at which point the upload is turned into a document.
+CREATE TABLE upload_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    namespace_id UUID NOT NULL REFERENCES namespaces(id) ON DELETE CASCADE,
+
+    -- File metadata declared at creation
+    file_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    upload_length BIGINT NOT NULL,
+    metadata JSONB DEFAULT '{}'::jsonb, -- decoded Upload-Metadata pairs
+
+    -- Progress
+    upload_offset BIGINT NOT NULL DEFAULT 0,
+    chunk_names TEXT[] NOT NULL DEFAULT '{}', -- staged chunks, in order
+    hash_state BYTEA, -- marshaled SHA-256 state over all bytes received so far
+    document_id UUID REFERENCES documents(id) ON DELETE SET NULL, -- set once finalized
+
+    -- Record metadata
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_upload_sessions_expires_at ON upload_sessions(expires_at);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS upload_sessions;
@@ -0,0 +1,9 @@
+-- Write your migrate up statements here
+
+-- MIME type sniffed from the file content at upload time. The declared
+-- mime_type is client-supplied and is never trusted for inline rendering.
+ALTER TABLE documents ADD COLUMN detected_mime_type VARCHAR(100);
+
+---- create above / drop below ----
+
+ALTER TABLE documents DROP COLUMN IF EXISTS detected_mime_type;
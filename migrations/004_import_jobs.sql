@@ -0,0 +1,50 @@
+-- Write your migrate up statements here
+
+-- Archive imports. The uploaded archive is staged in storage and unpacked by a
+-- background worker into one document per entry.
+CREATE TABLE import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    namespace_id UUID NOT NULL REFERENCES namespaces(id) ON DELETE CASCADE,
+
+    -- Archive metadata
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(10) NOT NULL, -- zip, tar, tar.gz
+    tag_path VARCHAR(255), -- tag that archive directories are created under (NULL for root)
+    archive_size BIGINT NOT NULL,
+
+    -- Progress
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, running, completed, failed
+    bytes_processed BIGINT NOT NULL DEFAULT 0, -- archive bytes consumed so far
+    total_entries INT, -- known up front for ZIP, once finished for TAR
+    processed_entries INT NOT NULL DEFAULT 0,
+    created_count INT NOT NULL DEFAULT 0,
+    duplicate_count INT NOT NULL DEFAULT 0,
+    skipped_count INT NOT NULL DEFAULT 0,
+    failed_count INT NOT NULL DEFAULT 0,
+    error TEXT, -- set when the whole job failed
+
+    -- Record metadata
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_import_jobs_status ON import_jobs(status, created_at);
+
+-- Per-entry results of an import
+CREATE TABLE import_job_entries (
+    job_id UUID NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+    path TEXT NOT NULL, -- entry path inside the archive
+    status VARCHAR(20) NOT NULL, -- created, duplicate, skipped, failed
+    document_id UUID REFERENCES documents(id) ON DELETE SET NULL, -- created or existing duplicate
+    tag_path VARCHAR(255), -- tag derived from the entry's directory
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, path)
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS import_job_entries;
+DROP TABLE IF EXISTS import_jobs;
@@ -0,0 +1,29 @@
+-- Write your migrate up statements here
+
+-- Transactional outbox. Events are written in the same transaction as the
+-- state change they describe and relayed to JetStream in the background, so
+-- an event is only ever emitted for a committed change and is never lost.
+CREATE TABLE event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    subject VARCHAR(255) NOT NULL,
+    payload BYTEA NOT NULL, -- protobuf-encoded event
+
+    -- Delivery. Failed events wait out a backoff before they are retried, and are
+    -- parked once their attempts run out, so they never hold up the events after them.
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    parked_at TIMESTAMPTZ, -- set once attempts are exhausted; parked events are kept
+    sent_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The relay only ever scans events still being delivered, in order
+CREATE INDEX idx_event_outbox_unsent ON event_outbox(id)
+    WHERE sent_at IS NULL AND parked_at IS NULL;
+CREATE INDEX idx_event_outbox_sent_at ON event_outbox(sent_at) WHERE sent_at IS NOT NULL;
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS event_outbox;
@@ -0,0 +1,50 @@
+-- Write your migrate up statements here
+
+-- Webhook subscriptions. Events in the namespace whose type matches event_types
+-- (all events when empty) are POSTed to url, signed with secret.
+CREATE TABLE webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    namespace_id UUID NOT NULL REFERENCES namespaces(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+
+    -- Record metadata
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhooks_namespace_id ON webhooks(namespace_id);
+
+-- Delivery log. Each matching event is queued once per webhook and retried with
+-- exponential backoff until it succeeds or runs out of attempts, when it is
+-- dead-lettered.
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id TEXT NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL, -- body POSTed to the webhook
+
+    -- Delivery
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, succeeded, dead
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    response_status INT, -- HTTP status of the last attempt
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- JetStream may redeliver an event; it is only queued once per webhook
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at)
+    WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
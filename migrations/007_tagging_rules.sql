@@ -0,0 +1,27 @@
+-- Write your migrate up statements here
+
+-- Namespace tagging rules, evaluated by the extractor worker on every upload. A
+-- document matching all of a rule's conditions gets the rule's tag and attributes.
+CREATE TABLE tagging_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    namespace_id UUID NOT NULL REFERENCES namespaces(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    priority INT NOT NULL DEFAULT 0, -- lower runs first; later rules win on conflicts
+
+    -- Rule definition
+    conditions JSONB NOT NULL, -- filename, MIME type, size, text and attribute tests
+    action JSONB NOT NULL,     -- tag path, attributes and capture group mappings
+
+    -- Record metadata
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (namespace_id, name)
+);
+
+CREATE INDEX idx_tagging_rules_namespace ON tagging_rules(namespace_id, priority, created_at);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS tagging_rules;
@@ -0,0 +1,38 @@
+-- Write your migrate up statements here
+
+-- Automatic extractions less confident than the threshold are queued for review instead
+-- of being applied. NULL applies every extraction.
+ALTER TABLE namespaces ADD COLUMN review_threshold DOUBLE PRECISION
+    CHECK (review_threshold >= 0 AND review_threshold <= 1);
+
+-- Extractions awaiting or past human review. Resolved items are kept as an audit trail.
+CREATE TABLE review_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    namespace_id UUID NOT NULL REFERENCES namespaces(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+
+    -- Proposed extraction
+    attributes JSONB,
+    confidence DOUBLE PRECISION NOT NULL,
+    extracted_by VARCHAR(255) NOT NULL,
+
+    -- Review outcome
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, accepted, rejected
+    reviewed_by VARCHAR(255),
+    reviewed_at TIMESTAMPTZ,
+
+    -- Record metadata
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A document has at most one pending extraction per tag; extracting again replaces it
+CREATE UNIQUE INDEX idx_review_items_pending ON review_items(document_id, tag_id)
+    WHERE status = 'pending';
+CREATE INDEX idx_review_items_namespace ON review_items(namespace_id, status, created_at);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS review_items;
+ALTER TABLE namespaces DROP COLUMN IF EXISTS review_threshold;
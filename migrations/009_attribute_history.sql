@@ -0,0 +1,28 @@
+-- Write your migrate up statements here
+
+-- Every change to a document's global or tag attributes, one row per changed field, so
+-- overwritten values can be traced back to what they were and who changed them
+CREATE TABLE attribute_history (
+    id BIGSERIAL PRIMARY KEY,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    tag_id UUID REFERENCES tags(id) ON DELETE CASCADE, -- NULL for global attributes
+    field VARCHAR(255) NOT NULL,
+
+    -- Change
+    old_value JSONB, -- NULL when the field was added
+    new_value JSONB, -- NULL when the field was removed
+    schema_version BIGINT, -- version of the attributes schema at the time of the change
+
+    -- Provenance
+    actor VARCHAR(255) NOT NULL,
+    extraction_method VARCHAR(20) NOT NULL, -- manual, automatic
+    extracted_by VARCHAR(255) NOT NULL,
+
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attribute_history_document ON attribute_history(document_id, tag_id, field, id);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS attribute_history;
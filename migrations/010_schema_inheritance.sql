@@ -0,0 +1,9 @@
+-- Write your migrate up statements here
+
+-- Tags that inherit their parent's effective schema compose it with their own, so a
+-- child tag only declares the attributes it adds or narrows
+ALTER TABLE tags ADD COLUMN inherit_schema BOOLEAN NOT NULL DEFAULT FALSE;
+
+---- create above / drop below ----
+
+ALTER TABLE tags DROP COLUMN IF EXISTS inherit_schema;
@@ -0,0 +1,12 @@
+-- Write your migrate up statements here
+
+-- How a tag's new schema versions must relate to its latest one: none accepts any schema;
+-- backward requires attributes written under the latest version to stay valid, forward
+-- requires attributes written under the new version to be valid under the latest one, and
+-- full requires both
+ALTER TABLE tags ADD COLUMN schema_compatibility TEXT NOT NULL DEFAULT 'none'
+    CHECK (schema_compatibility IN ('none', 'backward', 'forward', 'full'));
+
+---- create above / drop below ----
+
+ALTER TABLE tags DROP COLUMN IF EXISTS schema_compatibility;